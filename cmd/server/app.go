@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 
+	"github.com/rei0721/go-scaffold/internal/app"
+	"github.com/rei0721/go-scaffold/internal/config"
 	"github.com/rei0721/go-scaffold/pkg/cli"
 	"github.com/rei0721/go-scaffold/types/constants"
 )
@@ -18,7 +20,7 @@ func (c *AppCommand) Description() string {
 }
 
 func (c *AppCommand) Usage() string {
-	return fmt.Sprintf("%s [--config=<name>]", constants.AppServerCommandName)
+	return fmt.Sprintf("%s [--config=<name>] [--config-format=<yaml|json|toml>]", constants.AppServerCommandName)
 }
 
 func (c *AppCommand) Flags() []cli.Flag {
@@ -32,14 +34,22 @@ func (c *AppCommand) Flags() []cli.Flag {
 			Description: "Config file path",
 			EnvVar:      "REI_CONFIG_PATH", // 支持环境变量
 		},
+		{
+			Name:        "config-format",
+			Type:        cli.FlagTypeString,
+			Required:    false,
+			Description: "Config file format (yaml, json, toml), detected from extension if empty",
+			EnvVar:      "REI_CONFIG_FORMAT",
+		},
 	}
 }
 
 func (c *AppCommand) Execute(ctx *cli.Context) error {
-	configPath := ctx.GetString("config")
-
-	// 业务逻辑...
-	runApp(configPath)
+	opts := app.Options{
+		ConfigPath:   ctx.GetString("config"),
+		ConfigFormat: config.ConfigFormat(ctx.GetString("config-format")),
+	}
 
-	return nil
+	// 信号与 --timeout 由 CLI 框架统一处理,ctx.Context() 结束时触发优雅关闭
+	return runApp(ctx.Context(), opts)
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/rei0721/go-scaffold/internal/app"
+	"github.com/rei0721/go-scaffold/internal/config"
 	"github.com/rei0721/go-scaffold/pkg/cli"
 	"github.com/rei0721/go-scaffold/types/constants"
 )
@@ -21,7 +22,7 @@ func (c *InitdbCommand) Description() string {
 }
 
 func (c *InitdbCommand) Usage() string {
-	return fmt.Sprintf("%s [--config=<path>]", constants.AppInitDBCommandName)
+	return fmt.Sprintf("%s [--config=<path>] [--config-format=<yaml|json|toml>]", constants.AppInitDBCommandName)
 }
 
 func (c *InitdbCommand) Flags() []cli.Flag {
@@ -35,16 +36,22 @@ func (c *InitdbCommand) Flags() []cli.Flag {
 			Description: "Config file path",
 			EnvVar:      "REI_CONFIG_PATH",
 		},
+		{
+			Name:        "config-format",
+			Type:        cli.FlagTypeString,
+			Required:    false,
+			Description: "Config file format (yaml, json, toml), detected from extension if empty",
+			EnvVar:      "REI_CONFIG_FORMAT",
+		},
 	}
 }
 
 func (c *InitdbCommand) Execute(ctx *cli.Context) error {
-	configPath := ctx.GetString("config")
-
 	// 创建 App 实例（initdb 模式）
 	application, err := app.New(app.Options{
-		ConfigPath: configPath,
-		Mode:       app.ModeInitDB,
+		ConfigPath:   ctx.GetString("config"),
+		ConfigFormat: config.ConfigFormat(ctx.GetString("config-format")),
+		Mode:         app.ModeInitDB,
 	})
 	if err != nil {
 		os.Stderr.WriteString("failed to initialize application: " + err.Error() + "\n")
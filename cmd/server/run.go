@@ -2,41 +2,24 @@ package main
 
 import (
 	"context"
-	"os"
-	"os/signal"
-	"syscall"
+	"fmt"
 
 	"github.com/rei0721/go-scaffold/internal/app"
 	"github.com/rei0721/go-scaffold/types/constants"
 )
 
-func runApp(configPath string) {
+// runApp 启动应用并阻塞,直到 ctx 被取消 (SIGINT/SIGTERM/--timeout) 或服务器出错
+func runApp(ctx context.Context, opts app.Options) error {
 	// 2. 初始化应用程序容器
 	// app.New() 会按照依赖顺序初始化所有组件
 	// 使用依赖注入容器模式来管理组件生命周期
-	application, err := app.New(app.Options{
-		ConfigPath: configPath,
-	})
+	application, err := app.New(opts)
 	if err != nil {
-		// 此时 logger 可能还未初始化,因此直接使用 os.Stderr 输出错误
-		// 使用 os.Stderr 而不是 os.Stdout,确保错误信息不会被重定向丢失
-		os.Stderr.WriteString("failed to initialize application: " + err.Error() + "\n")
-		// 退出码 1 表示程序异常退出,便于运维监控和部署脚本判断
-		os.Exit(1)
+		// 此时 logger 可能还未初始化,因此将错误返回给 CLI 框架输出到 stderr
+		return fmt.Errorf("failed to initialize application: %w", err)
 	}
 
-	// 3. 创建信号接收通道
-	// 缓冲区大小为 1,确保至少能接收一个信号而不被阻塞
-	// 这样可以避免在处理第一个信号时丢失后续信号
-	quit := make(chan os.Signal, 1)
-
-	// 4. 注册要监听的操作系统信号
-	// SIGINT: 用户按下 Ctrl+C 时发送的中断信号
-	// SIGTERM: 系统或容器编排工具(如 Docker/K8s)发送的终止信号
-	// 监听这些信号是实现优雅关闭的关键,确保应用能够正确清理资源
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	// 5. 在新的 goroutine 中启动 HTTP 服务器
+	// 3. 在新的 goroutine 中启动 HTTP 服务器
 	// 使用 goroutine 使得主线程可以继续执行,监听关闭信号
 	// 创建缓冲通道用于接收服务器运行时的错误
 	errChan := make(chan error, 1)
@@ -48,44 +31,43 @@ func runApp(configPath string) {
 		}
 	}()
 
-	// 6. 等待关闭信号或服务器错误
+	// 4. 等待关闭信号或服务器错误
+	// SIGINT/SIGTERM 由 CLI 框架转换为 ctx 的取消,这里无需自行注册信号
 	// 使用 select 同时监听多个通道,哪个先有数据就处理哪个
-	// 这是 Go 语言中常用的并发模式
 	select {
-	case sig := <-quit:
-		// 收到操作系统信号(用户中断或系统终止)
+	case <-ctx.Done():
+		// 收到操作系统信号(用户中断或系统终止)或 --timeout 到期
 		// 记录日志以便问题追踪和审计
-		application.Logger.Info("received shutdown signal", "signal", sig.String())
+		application.Logger.Info("received shutdown signal", "reason", ctx.Err().Error())
 	case err := <-errChan:
 		// 服务器运行时发生错误(例如端口已被占用)
 		// 记录错误日志,便于排查问题
 		application.Logger.Error("server error", "error", err)
 	}
 
-	// 7. 创建带超时的上下文
+	// 5. 创建带超时的上下文
 	// 上下文用于控制优雅关闭的最大等待时间
 	// 如果超过 shutdownTimeout(30秒)仍未完成关闭,将强制退出
-	// 这防止了因某些请求或连接无法正常关闭导致的程序挂起
-	ctx, cancel := context.WithTimeout(context.Background(), constants.AppShutdownTimeout)
+	// 注意: 不能派生自已取消的 ctx,否则关闭流程会立即超时
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), constants.AppShutdownTimeout)
 	// defer 确保在函数返回时调用 cancel,释放上下文相关资源
-	// 这是 Go 中管理资源的最佳实践
 	defer cancel()
 
-	// 8. 执行优雅关闭
+	// 6. 执行优雅关闭
 	// 优雅关闭的顺序: HTTP服务器 → 调度器 → 数据库连接 → 日志同步
 	// 这个顺序确保:
 	// - 先停止接收新请求
 	// - 等待正在执行的任务完成
 	// - 关闭数据库连接
 	// - 最后同步日志,确保所有日志都写入磁盘
-	if err := application.Shutdown(ctx); err != nil {
-		// 如果优雅关闭失败,记录错误并以非零状态退出
-		// 退出码 1 表示程序异常退出
+	if err := application.Shutdown(shutdownCtx); err != nil {
+		// 如果优雅关闭失败,记录错误并返回,由 CLI 框架以非零状态退出
 		application.Logger.Error("shutdown error", "error", err)
-		os.Exit(1)
+		return err
 	}
 
-	// 9. 记录成功退出的日志
+	// 7. 记录成功退出的日志
 	// 到达这里说明所有资源都已正确清理,程序正常退出
 	application.Logger.Info("application exited gracefully")
+	return nil
 }
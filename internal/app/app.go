@@ -104,6 +104,10 @@ type Options struct {
 	// 支持相对路径和绝对路径
 	ConfigPath string
 
+	// ConfigFormat 配置文件格式(可选)
+	// 为空时根据扩展名推断,配置文件没有扩展名(如 ConfigMap 挂载)时需要指定
+	ConfigFormat config.ConfigFormat
+
 	// Mode 启动模式
 	// 支持 ModeServer（默认）和 ModeInitDB 两种模式
 	Mode AppMode
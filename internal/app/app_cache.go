@@ -21,6 +21,9 @@ func (app *App) initCache() error {
 			DialTimeout:  time.Duration(app.Config.Redis.DialTimeout) * time.Second,
 			ReadTimeout:  time.Duration(app.Config.Redis.ReadTimeout) * time.Second,
 			WriteTimeout: time.Duration(app.Config.Redis.WriteTimeout) * time.Second,
+
+			Compression:          app.Config.Redis.Compression,
+			CompressionThreshold: app.Config.Redis.CompressionThreshold,
 		}
 
 		cacheClient, err := cache.NewRedis(cacheCfg, app.Logger)
@@ -10,7 +10,11 @@ func (app *App) initConfig(opts Options) error {
 	// 1. 初始化配置管理器并加载配置
 	// 配置是整个应用的基础,必须最先加载
 	configManager := config.NewManager()
-	if err := configManager.Load(opts.ConfigPath); err != nil {
+	load := func() error { return configManager.Load(opts.ConfigPath) }
+	if opts.ConfigFormat != "" {
+		load = func() error { return configManager.LoadFormat(opts.ConfigPath, opts.ConfigFormat) }
+	}
+	if err := load(); err != nil {
 		// 配置加载失败,应用无法启动
 		return fmt.Errorf("failed to load config: %w", err)
 	}
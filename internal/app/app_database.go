@@ -3,21 +3,13 @@ package app
 import (
 	"fmt"
 
+	"github.com/rei0721/go-scaffold/internal/config"
 	"github.com/rei0721/go-scaffold/pkg/database"
 )
 
 // initDatabase 初始化数据库连接
 func (app *App) initDatabase() error {
-	db, err := database.New(&database.Config{
-		Driver:       database.Driver(app.Config.Database.Driver),
-		Host:         app.Config.Database.Host,
-		Port:         app.Config.Database.Port,
-		User:         app.Config.Database.User,
-		Password:     app.Config.Database.Password,
-		DBName:       app.Config.Database.DBName,
-		MaxOpenConns: app.Config.Database.MaxOpenConns,
-		MaxIdleConns: app.Config.Database.MaxIdleConns,
-	})
+	db, err := database.NewWithLogger(makeDatabaseConfig(app.Config), app.Logger)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -25,3 +17,20 @@ func (app *App) initDatabase() error {
 	app.Logger.Info("database connected successfully")
 	return nil
 }
+
+// makeDatabaseConfig 从应用配置创建数据库配置
+// initDatabase 和配置热重载共用,避免重载时遗漏字段
+func makeDatabaseConfig(cfg *config.Config) *database.Config {
+	return &database.Config{
+		Driver:        database.Driver(cfg.Database.Driver),
+		Host:          cfg.Database.Host,
+		Port:          cfg.Database.Port,
+		User:          cfg.Database.User,
+		Password:      cfg.Database.Password,
+		DBName:        cfg.Database.DBName,
+		MaxOpenConns:  cfg.Database.MaxOpenConns,
+		MaxIdleConns:  cfg.Database.MaxIdleConns,
+		LogLevel:      cfg.Database.LogLevel,
+		SlowThreshold: cfg.Database.SlowThreshold,
+	}
+}
@@ -2,7 +2,6 @@ package app
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/rei0721/go-scaffold/pkg/executor"
 )
@@ -25,15 +24,7 @@ func (app *App) initExecutor() error {
 
 	// 转换配置格式
 	// internal/config.ExecutorPoolConfig -> pkg/executor.Config
-	configs := make([]executor.Config, 0, len(app.Config.Executor.Pools))
-	for _, poolCfg := range app.Config.Executor.Pools {
-		configs = append(configs, executor.Config{
-			Name:        executor.PoolName(poolCfg.Name),
-			Size:        poolCfg.Size,
-			Expiry:      time.Duration(poolCfg.Expiry) * time.Second,
-			NonBlocking: poolCfg.NonBlocking,
-		})
-	}
+	configs := makeExecutorConfigs(app.Config, app.Logger)
 
 	// 创建执行器管理器
 	mgr, err := executor.NewManager(configs)
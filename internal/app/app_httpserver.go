@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/rei0721/go-scaffold/pkg/httpserver"
@@ -17,10 +18,18 @@ func (app *App) initHTTPServer() error {
 		Port:         app.Config.Server.Port,
 		ReadTimeout:  time.Duration(app.Config.Server.ReadTimeout) * time.Second,
 		WriteTimeout: time.Duration(app.Config.Server.WriteTimeout) * time.Second,
+
+		SlowRequestThreshold: time.Duration(app.Config.Server.SlowRequestThreshold) * time.Millisecond,
+		ReusePort:            app.Config.Server.ReusePort,
 	}
 
 	// 创建 HTTP 服务器实例（不直接注入executor）
-	server, err := httpserver.New(app.Router, cfg, app.Logger)
+	// http.Server 自身的错误日志(如 TLS 握手失败)通过 StdLogger 写入统一日志
+	server, err := httpserver.New(app.Router, cfg, app.Logger,
+		httpserver.WithServerOptions(func(srv *http.Server) {
+			srv.ErrorLog = app.Logger.StdLogger("error")
+		}),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create http server: %w", err)
 	}
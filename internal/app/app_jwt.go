@@ -19,10 +19,13 @@ func initJWT(app *App) error {
 	app.Logger.Info("Initializing JWT manager...")
 
 	// 创建 JWT 配置
+	// 刷新令牌状态保存在进程内存,多实例部署时应替换为基于 Redis 的实现
 	jwtCfg := &jwt.Config{
-		Secret:    app.Config.JWT.Secret,
-		ExpiresIn: app.Config.JWT.ExpiresIn,
-		Issuer:    app.Config.JWT.Issuer,
+		Secret:           app.Config.JWT.Secret,
+		ExpiresIn:        app.Config.JWT.ExpiresIn,
+		Issuer:           app.Config.JWT.Issuer,
+		RefreshExpiresIn: app.Config.JWT.RefreshExpiresIn,
+		RefreshStore:     jwt.NewMemoryRefreshStore(),
 	}
 
 	// 创建 JWT 管理器
@@ -2,7 +2,9 @@ package app
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/rei0721/go-scaffold/internal/config"
 	"github.com/rei0721/go-scaffold/pkg/logger"
 )
 
@@ -18,6 +20,17 @@ func (app *App) initLogger() error {
 		MaxSize:       app.Config.Logger.MaxSize,       // 从配置读取日志文件最大大小
 		MaxBackups:    app.Config.Logger.MaxBackups,    // 从配置读取日志文件最大备份数
 		MaxAge:        app.Config.Logger.MaxAge,        // 从配置读取日志文件最大年龄
+		ErrorFilePath: app.Config.Logger.ErrorFilePath, // 从配置读取错误日志文件路径
+		RedactKeys:    app.Config.Logger.RedactKeys,    // 从配置读取脱敏字段
+
+		ErrorRateLimit: errorRateLimitConfig(&app.Config.Logger), // 从配置读取错误日志限流
+
+		Async:             app.Config.Logger.Async,             // 从配置读取是否异步写入
+		AsyncQueueSize:    app.Config.Logger.AsyncQueueSize,    // 从配置读取异步队列容量
+		AsyncDropWhenFull: app.Config.Logger.AsyncDropWhenFull, // 从配置读取队列满时的策略
+
+		OTelCorrelation: app.Config.Logger.OTelCorrelation, // 从配置读取是否注入追踪字段
+		StrictFields:    app.Config.Logger.StrictFields,    // 从配置读取是否校验键值对
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create logger: %w", err)
@@ -26,3 +39,15 @@ func (app *App) initLogger() error {
 	app.Logger.Info("logger initialized successfully")
 	return nil
 }
+
+// errorRateLimitConfig 将配置中的错误日志限流参数转换为 logger.RateLimitConfig
+// ErrorRateLimitMax <= 0 时返回 nil,表示不启用限流
+func errorRateLimitConfig(cfg *config.LoggerConfig) *logger.RateLimitConfig {
+	if cfg.ErrorRateLimitMax <= 0 {
+		return nil
+	}
+	return &logger.RateLimitConfig{
+		MaxPerInterval: cfg.ErrorRateLimitMax,
+		Interval:       time.Duration(cfg.ErrorRateLimitInterval) * time.Second,
+	}
+}
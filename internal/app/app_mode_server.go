@@ -54,17 +54,16 @@ func (app *App) runModeServer() (*App, error) {
 	}
 	app.Logger.Debug("config watcher started")
 
+	// Register per-section reloaders
+	// 只有变化的配置段对应的组件会被重载
+	app.registerReloaders()
+
 	// Register config change hook
-	// 当配置文件变化时自动调用
+	// 所有重载函数成功后调用
 	app.ConfigManager.RegisterHook(func(old, new *config.Config) {
-		app.Logger.Info("configuration file changed, processing updates...")
-
-		// 重载 app
-		app.reload(old, new)
-
 		// 更新应用配置引用
 		app.Config = new
-		app.Logger.Info("configuration update completed")
+		app.Logger.Info("configuration update completed", "fields", config.Diff(old, new).Paths())
 	})
 
 	app.Logger.Info("application initialized successfully")
@@ -3,23 +3,56 @@ package app
 import (
 	"fmt"
 
+	"github.com/rei0721/go-scaffold/internal/config"
 	"github.com/rei0721/go-scaffold/pkg/rbac"
 )
 
 func (a *App) initRBAC() error {
 	// 初始化 RBAC
 	var err error
-	rbacCfg := &rbac.Config{
-		DB:          a.DB.DB(),
-		ModelPath:   a.Config.RBAC.ModelPath,
-		EnableCache: a.Config.RBAC.EnableCache,
-		CacheTTL:    a.Config.RBAC.CacheTTL,
-		AutoSave:    a.Config.RBAC.AutoSave,
-		TablePrefix: a.Config.RBAC.TablePrefix,
-	}
-	a.RBAC, err = rbac.New(rbacCfg)
+	a.RBAC, err = rbac.New(a.makeRBACConfig(a.Config))
 	if err != nil {
 		return fmt.Errorf("failed to init rbac: %w", err)
 	}
 	return nil
 }
+
+// makeRBACConfig 从应用配置创建 RBAC 配置
+// initRBAC 和配置热重载共用
+func (a *App) makeRBACConfig(cfg *config.Config) *rbac.Config {
+	rc := &rbac.Config{
+		DB:          a.DB.DB(),
+		ModelPath:   cfg.RBAC.ModelPath,
+		EnableABAC:  cfg.RBAC.EnableABAC,
+		EnableCache: cfg.RBAC.EnableCache,
+		CacheTTL:    cfg.RBAC.CacheTTL,
+		AutoSave:    cfg.RBAC.AutoSave,
+		TablePrefix: cfg.RBAC.TablePrefix,
+		AuditLog:    a.logRBACAudit,
+
+		SuperAdmins:    cfg.RBAC.SuperAdmins,
+		SuperAdminRole: cfg.RBAC.SuperAdminRole,
+
+		ActionHierarchy: cfg.RBAC.ActionHierarchy,
+	}
+
+	// 只加载本实例负责的域
+	if len(cfg.RBAC.LoadDomains) > 0 {
+		rc.PolicyFilters = rbac.DomainFilters(cfg.RBAC.LoadDomains...)
+	}
+	return rc
+}
+
+// logRBACAudit 将权限变更审计事件写入应用日志
+func (a *App) logRBACAudit(event rbac.AuditEvent) {
+	a.Logger.Info("rbac policy changed",
+		"action", event.Action,
+		"subject", event.Subject,
+		"object", event.Object,
+		"act", event.Act,
+		"domain", event.Domain,
+		"condition", event.Condition,
+		"actor", event.Actor,
+		"timestamp", event.Timestamp,
+	)
+}
@@ -6,155 +6,191 @@ import (
 
 	"github.com/rei0721/go-scaffold/internal/config"
 	"github.com/rei0721/go-scaffold/pkg/cache"
-	"github.com/rei0721/go-scaffold/pkg/database"
 	"github.com/rei0721/go-scaffold/pkg/httpserver"
 	"github.com/rei0721/go-scaffold/pkg/logger"
 )
 
-// reload
-func (a *App) reload(old, new *config.Config) {
-	// 重新加载配置
-	// a.Logger.Debug("reloading configuration...")
-
-	// cache
-	// 检查 Redis 配置是否变化
-	// 使用工具方法比较新旧配置
-	if isRedisConfigChanged(old, new) {
-		a.Logger.Info("redis configuration changed, reloading cache...")
-
-		// 只有在 Cache 不为 nil 且新配置启用了 Redis 时才重载
-		if a.Cache != nil && new.Redis.Enabled {
-			// 创建新的缓存配置
-			newCacheCfg := &cache.Config{
-				Host:         new.Redis.Host,
-				Port:         new.Redis.Port,
-				Password:     new.Redis.Password,
-				DB:           new.Redis.DB,
-				PoolSize:     new.Redis.PoolSize,
-				MinIdleConns: new.Redis.MinIdleConns,
-				MaxRetries:   new.Redis.MaxRetries,
-				DialTimeout:  time.Duration(new.Redis.DialTimeout) * time.Second,
-				ReadTimeout:  time.Duration(new.Redis.ReadTimeout) * time.Second,
-				WriteTimeout: time.Duration(new.Redis.WriteTimeout) * time.Second,
-			}
-
-			// 使用超时上下文进行重载
-			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-			defer cancel()
-
-			// 原子化重载缓存配置
-			err := a.Cache.Reload(ctx, newCacheCfg)
-			if err != nil {
-				a.Logger.Error("failed to reload redis cache", "error", err)
-			} else {
-				a.Logger.Info("redis cache reloaded successfully")
-			}
-		} else if !new.Redis.Enabled {
-			a.Logger.Info("redis disabled in new config")
-		} else {
-			a.Logger.Warn("cache is nil, cannot reload redis configuration")
-		}
+// registerReloaders 为各组件注册配置段重载函数
+// 配置变更时只重载变化的配置段对应的组件,
+// 任意组件重载失败时配置回滚,已重载的组件恢复到旧配置
+func (a *App) registerReloaders() {
+	a.ConfigManager.RegisterReloader("redis", a.reloadCache)
+	a.ConfigManager.RegisterReloader("database", a.reloadDatabase)
+	a.ConfigManager.RegisterReloader("logger", a.reloadLogger)
+	a.ConfigManager.RegisterReloader("executor", a.reloadExecutor)
+	a.ConfigManager.RegisterReloader("rbac", a.reloadRBAC)
+	a.ConfigManager.RegisterReloader("server", a.reloadHTTPServer)
+}
+
+// reloadCache 重载 Redis 缓存
+func (a *App) reloadCache(new *config.Config) error {
+	a.Logger.Info("redis configuration changed, reloading cache...")
+
+	// 只有在 Cache 不为 nil 且新配置启用了 Redis 时才重载
+	if !new.Redis.Enabled {
+		a.Logger.Info("redis disabled in new config")
+		return nil
+	}
+	if a.Cache == nil {
+		a.Logger.Warn("cache is nil, cannot reload redis configuration")
+		return nil
+	}
+
+	// 创建新的缓存配置
+	newCacheCfg := &cache.Config{
+		Host:         new.Redis.Host,
+		Port:         new.Redis.Port,
+		Password:     new.Redis.Password,
+		DB:           new.Redis.DB,
+		PoolSize:     new.Redis.PoolSize,
+		MinIdleConns: new.Redis.MinIdleConns,
+		MaxRetries:   new.Redis.MaxRetries,
+		DialTimeout:  time.Duration(new.Redis.DialTimeout) * time.Second,
+		ReadTimeout:  time.Duration(new.Redis.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(new.Redis.WriteTimeout) * time.Second,
+
+		Compression:          new.Redis.Compression,
+		CompressionThreshold: new.Redis.CompressionThreshold,
+	}
+
+	// 使用超时上下文进行重载
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// 原子化重载缓存配置
+	if err := a.Cache.Reload(ctx, newCacheCfg); err != nil {
+		a.Logger.Error("failed to reload redis cache", "error", err)
+		return err
+	}
+	a.Logger.Info("redis cache reloaded successfully")
+	return nil
+}
+
+// reloadDatabase 重载数据库连接
+func (a *App) reloadDatabase(new *config.Config) error {
+	a.Logger.Info("database configuration changed, reloading database...")
+
+	// 与 initDatabase 使用相同的配置构建,保留 SQL 日志级别和慢查询阈值
+	if err := a.DB.Reload(makeDatabaseConfig(new)); err != nil {
+		a.Logger.Error("failed to reload database", "error", err)
+		return err
+	}
+	a.Logger.Info("database reloaded successfully")
+	return nil
+}
+
+// reloadLogger 重载日志配置
+func (a *App) reloadLogger(new *config.Config) error {
+	a.Logger.Info("logger configuration changed, reloading logger...")
+
+	// 创建新的日志配置
+	newLoggerCfg := &logger.Config{
+		Level:         new.Logger.Level,
+		Format:        new.Logger.Format,
+		ConsoleFormat: new.Logger.ConsoleFormat,
+		FileFormat:    new.Logger.FileFormat,
+		Output:        new.Logger.Output,
+		FilePath:      new.Logger.FilePath,
+		MaxSize:       new.Logger.MaxSize,
+		MaxBackups:    new.Logger.MaxBackups,
+		MaxAge:        new.Logger.MaxAge,
+		ErrorFilePath: new.Logger.ErrorFilePath,
+		RedactKeys:    new.Logger.RedactKeys,
+
+		ErrorRateLimit: errorRateLimitConfig(&new.Logger),
+
+		Async:             new.Logger.Async,
+		AsyncQueueSize:    new.Logger.AsyncQueueSize,
+		AsyncDropWhenFull: new.Logger.AsyncDropWhenFull,
+
+		OTelCorrelation: new.Logger.OTelCorrelation,
+		StrictFields:    new.Logger.StrictFields,
+	}
+
+	// 原子化重载日志配置
+	if err := a.Logger.Reload(newLoggerCfg); err != nil {
+		a.Logger.Error("failed to reload logger", "error", err)
+		return err
 	}
+	a.Logger.Info("logger reloaded successfully")
+	return nil
+}
+
+// reloadExecutor 重载协程池配置
+func (a *App) reloadExecutor(new *config.Config) error {
+	a.Logger.Info("executor configuration changed, reloading executor...")
 
-	if isDatabaseConfigChanged(old, new) {
-		a.Logger.Info("database configuration changed, reloading database...")
-
-		// 重新加载数据库配置
-		newDBCfg := &database.Config{
-			Driver:       database.Driver(new.Database.Driver),
-			Host:         new.Database.Host,
-			Port:         new.Database.Port,
-			User:         new.Database.User,
-			Password:     new.Database.Password,
-			DBName:       new.Database.DBName,
-			MaxOpenConns: new.Database.MaxOpenConns,
-			MaxIdleConns: new.Database.MaxIdleConns,
-		}
-
-		if err := a.DB.Reload(newDBCfg); err != nil {
-			a.Logger.Error("failed to reload database", "error", err)
-		} else {
-			a.Logger.Info("database reloaded successfully")
-		}
+	// 只有在 Executor 不为 nil 且新配置启用了执行器时才重载
+	if !new.Executor.Enabled {
+		a.Logger.Info("executor disabled in new config")
+		return nil
 	}
+	if a.Executor == nil {
+		a.Logger.Warn("executor is nil, cannot reload configuration")
+		return nil
+	}
+
+	// 转换配置格式
+	newExecutorConfigs := makeExecutorConfigs(new, a.Logger)
+
+	// 原子化重载执行器配置
+	if err := a.Executor.Reload(newExecutorConfigs); err != nil {
+		a.Logger.Error("failed to reload executor", "error", err)
+		return err
+	}
+	a.Logger.Info("executor reloaded successfully", "pools", len(newExecutorConfigs))
+	return nil
+}
+
+// reloadRBAC 重载 RBAC 模型和策略(如切换模型文件)
+func (a *App) reloadRBAC(new *config.Config) error {
+	a.Logger.Info("rbac configuration changed, reloading rbac...")
 
-	// logger
-	// 检查日志配置是否变化
-	if isLoggerConfigChanged(old, new) {
-		a.Logger.Info("logger configuration changed, reloading logger...")
-
-		// 创建新的日志配置
-		newLoggerCfg := &logger.Config{
-			Level:         new.Logger.Level,
-			Format:        new.Logger.Format,
-			ConsoleFormat: new.Logger.ConsoleFormat,
-			FileFormat:    new.Logger.FileFormat,
-			Output:        new.Logger.Output,
-			FilePath:      new.Logger.FilePath,
-			MaxSize:       new.Logger.MaxSize,
-			MaxBackups:    new.Logger.MaxBackups,
-			MaxAge:        new.Logger.MaxAge,
-		}
-
-		// 原子化重载日志配置
-		if err := a.Logger.Reload(newLoggerCfg); err != nil {
-			a.Logger.Error("failed to reload logger", "error", err)
-		} else {
-			a.Logger.Info("logger reloaded successfully")
-		}
+	if a.RBAC == nil {
+		a.Logger.Warn("rbac is nil, cannot reload configuration")
+		return nil
 	}
 
-	// executor
-	// 检查执行器配置是否变化
-	if isExecutorConfigChanged(old, new) {
-		a.Logger.Info("executor configuration changed, reloading executor...")
-
-		// 只有在 Executor 不为 nil 且新配置启用了执行器时才重载
-		if a.Executor != nil && new.Executor.Enabled {
-			// 转换配置格式
-			newExecutorConfigs := makeExecutorConfigs(new)
-
-			// 原子化重载执行器配置
-			if err := a.Executor.Reload(newExecutorConfigs); err != nil {
-				a.Logger.Error("failed to reload executor", "error", err)
-			} else {
-				a.Logger.Info("executor reloaded successfully", "pools", len(newExecutorConfigs))
-			}
-		} else if !new.Executor.Enabled {
-			a.Logger.Info("executor disabled in new config")
-		} else {
-			a.Logger.Warn("executor is nil, cannot reload configuration")
-		}
+	// 原子化替换 Enforcer,失败时保持原模型和策略
+	if err := a.RBAC.Reload(a.makeRBACConfig(new)); err != nil {
+		a.Logger.Error("failed to reload rbac", "error", err)
+		return err
 	}
+	a.Logger.Info("rbac reloaded successfully")
+	return nil
+}
+
+// reloadHTTPServer 重载 HTTP 服务器配置
+func (a *App) reloadHTTPServer(new *config.Config) error {
+	a.Logger.Info("server configuration changed, reloading HTTP server...")
+
+	// 只有在 HTTPServer 不为 nil 时才重载
+	if a.HTTPServer == nil {
+		a.Logger.Warn("HTTPServer is nil, cannot reload configuration")
+		return nil
+	}
+
+	// 创建新的服务器配置
+	newServerCfg := &httpserver.Config{
+		Host:         new.Server.Host,
+		Port:         new.Server.Port,
+		ReadTimeout:  time.Duration(new.Server.ReadTimeout) * time.Second,
+		WriteTimeout: time.Duration(new.Server.WriteTimeout) * time.Second,
+		IdleTimeout:  time.Duration(new.Server.IdleTimeout) * time.Second,
+
+		SlowRequestThreshold: time.Duration(new.Server.SlowRequestThreshold) * time.Millisecond,
+		ReusePort:            new.Server.ReusePort,
+	}
+
+	// 使用超时上下文进行重载
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	// HTTP Server
-	// 检查服务器配置是否变化
-	if isServerConfigChanged(old, new) {
-		a.Logger.Info("server configuration changed, reloading HTTP server...")
-
-		// 只有在 HTTPServer 不为 nil 时才重载
-		if a.HTTPServer != nil {
-			// 创建新的服务器配置
-			newServerCfg := &httpserver.Config{
-				Host:         new.Server.Host,
-				Port:         new.Server.Port,
-				ReadTimeout:  time.Duration(new.Server.ReadTimeout) * time.Second,
-				WriteTimeout: time.Duration(new.Server.WriteTimeout) * time.Second,
-				IdleTimeout:  time.Duration(new.Server.IdleTimeout) * time.Second,
-			}
-
-			// 使用超时上下文进行重载
-			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			defer cancel()
-
-			// 原子化重载 HTTP Server 配置
-			if err := a.HTTPServer.Reload(ctx, newServerCfg); err != nil {
-				a.Logger.Error("failed to reload HTTP server", "error", err)
-			} else {
-				a.Logger.Info("HTTP server reloaded successfully")
-			}
-		} else {
-			a.Logger.Warn("HTTPServer is nil, cannot reload configuration")
-		}
+	// 原子化重载 HTTP Server 配置
+	if err := a.HTTPServer.Reload(ctx, newServerCfg); err != nil {
+		a.Logger.Error("failed to reload HTTP server", "error", err)
+		return err
 	}
+	a.Logger.Info("HTTP server reloaded successfully")
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"strconv"
+	"time"
 )
 
 // DatabaseConfig 数据库连接配置
@@ -12,7 +13,7 @@ type DatabaseConfig struct {
 	// Driver 数据库驱动类型
 	// 可选值: postgres, mysql, sqlite
 	// 影响连接字符串格式和 SQL 方言
-	Driver string `mapstructure:"driver"`
+	Driver string `mapstructure:"driver" required:"true"`
 
 	// Host 数据库服务器地址
 	// 例如: localhost, 127.0.0.1, db.example.com
@@ -37,7 +38,7 @@ type DatabaseConfig struct {
 	// DBName 数据库名称
 	// PostgreSQL/MySQL: 数据库名
 	// SQLite: 文件路径
-	DBName string `mapstructure:"dbname"`
+	DBName string `mapstructure:"dbname" required:"true"`
 
 	// MaxOpenConns 最大打开连接数
 	// 0 表示无限制(不推荐)
@@ -48,6 +49,16 @@ type DatabaseConfig struct {
 	// 建议设置为 MaxOpenConns 的 50%-100%
 	// 保持空闲连接可以提高响应速度
 	MaxIdleConns int `mapstructure:"max_idle_conns"`
+
+	// LogLevel SQL 日志级别
+	// 可选值: silent, error, warn, info
+	// 为空时默认 warn(记录出错的 SQL 和慢查询)
+	LogLevel string `mapstructure:"log_level" default:"warn"`
+
+	// SlowThreshold 慢查询阈值
+	// 执行时间超过此值的 SQL 以 warn 级别记录
+	// 为 0 时默认 200ms
+	SlowThreshold time.Duration `mapstructure:"slow_threshold" default:"200ms"`
 }
 
 func (c *DatabaseConfig) ValidateName() string {
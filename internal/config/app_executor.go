@@ -41,6 +41,45 @@ type ExecutorPoolConfig struct {
 	// false: 池满时阻塞等待
 	// 推荐使用 true
 	NonBlocking bool `mapstructure:"non_blocking"`
+
+	// Priority 池内任务的默认优先级
+	// 数值越大越优先,0 为普通优先级
+	// 仅在阻塞模式池满排队时生效
+	Priority int `mapstructure:"priority"`
+
+	// QueueSize 非阻塞模式下的缓冲队列长度
+	// 0: 池满时立即返回错误
+	// 大于 0: 池满时先排队,队列满时才返回错误
+	QueueSize int `mapstructure:"queue_size"`
+
+	// RateLimit 提交速率限制(可选)
+	// 未配置时不限制,用于调用有频率限制的外部 API 的池
+	RateLimit *ExecutorRateLimitConfig `mapstructure:"rate_limit"`
+
+	// LongRunningThreshold 长时间运行任务的告警阈值(秒)
+	// 0: 不检测
+	// 大于 0: 任务运行超过该时长时输出一条 Warn 日志
+	LongRunningThreshold int `mapstructure:"long_running_threshold"`
+
+	// PreSpawn 创建池时预先启动的 worker 数
+	// 0: 按需创建 worker
+	// 大于 0: 启动时预热,降低第一波请求的延迟
+	PreSpawn int `mapstructure:"pre_spawn"`
+}
+
+// ExecutorRateLimitConfig 执行器池的提交速率限制
+type ExecutorRateLimitConfig struct {
+	// RPS 每秒允许提交的任务数,必须大于 0
+	RPS float64 `mapstructure:"rps"`
+
+	// Burst 允许的瞬时突发任务数
+	// 0 时取 RPS 向上取整
+	Burst int `mapstructure:"burst"`
+
+	// Wait 超出速率时是否阻塞等待
+	// false: 立即返回错误
+	// true:  阻塞直到可以提交
+	Wait bool `mapstructure:"wait"`
 }
 
 func (c *ExecutorConfig) ValidateName() string {
@@ -90,6 +129,26 @@ func (c *ExecutorConfig) Validate() error {
 		if pool.Expiry < 0 {
 			return fmt.Errorf("pool %s: expiry must be non-negative", pool.Name)
 		}
+
+		// 验证缓冲队列长度
+		if pool.QueueSize < 0 {
+			return fmt.Errorf("pool %s: queue_size must be non-negative", pool.Name)
+		}
+
+		// 验证长任务阈值
+		if pool.LongRunningThreshold < 0 {
+			return fmt.Errorf("pool %s: long_running_threshold must be non-negative", pool.Name)
+		}
+
+		// 验证速率限制
+		if rl := pool.RateLimit; rl != nil {
+			if rl.RPS <= 0 {
+				return fmt.Errorf("pool %s: rate_limit.rps must be positive", pool.Name)
+			}
+			if rl.Burst < 0 {
+				return fmt.Errorf("pool %s: rate_limit.burst must be non-negative", pool.Name)
+			}
+		}
 	}
 
 	return nil
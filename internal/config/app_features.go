@@ -0,0 +1,46 @@
+package config
+
+import "strings"
+
+// FeatureFlags 功能开关配置
+// key 为开关名,value 为是否启用
+// 开关随配置文件热重载生效,修改 config.yaml 即可开启或关闭功能,无需重新部署
+// 注意:
+//   - viper 读取配置时会把 key 转为小写,开关名不区分大小写
+//   - 只适合简单的开/关场景,按用户灰度、百分比放量等需要专门的功能开关服务
+//
+// 配置示例:
+//
+//	features:
+//	  new_checkout: true
+//	  legacy_export: false
+type FeatureFlags map[string]bool
+
+// Enabled 判断功能开关是否启用
+// 参数:
+//
+//	flag: 开关名(不区分大小写)
+//	def: 配置中没有该开关时的默认值
+//
+// 返回:
+//
+//	bool: 配置中的值,未配置时返回 def
+func (f FeatureFlags) Enabled(flag string, def bool) bool {
+	if v, ok := f[strings.ToLower(flag)]; ok {
+		return v
+	}
+	return def
+}
+
+// Clone 返回功能开关的副本
+// 为 nil 时返回 nil
+func (f FeatureFlags) Clone() FeatureFlags {
+	if f == nil {
+		return nil
+	}
+	dst := make(FeatureFlags, len(f))
+	for k, v := range f {
+		dst[k] = v
+	}
+	return dst
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 )
 
@@ -12,12 +14,12 @@ type I18nConfig struct {
 	// Default 默认语言
 	// 当请求的语言不支持时使用
 	// 例如: en, zh-CN, ja
-	Default string `mapstructure:"default"`
+	Default string `mapstructure:"default" required:"true"`
 
 	// Supported 支持的语言列表
 	// 必须包含 Default 语言
 	// 例如: ["en", "zh-CN", "ja"]
-	Supported []string `mapstructure:"supported"`
+	Supported []string `mapstructure:"supported" required:"true"`
 
 	// MessagesDir 语言文件目录
 	// 包含所有语言的翻译文件
@@ -61,9 +63,67 @@ func (c *I18nConfig) Validate() error {
 		return errors.New("default locale must be in supported list")
 	}
 
+	// 确保每个支持的语言都有翻译文件
+	if c.MessagesDir != "" {
+		if err := c.ValidateI18n(c.MessagesDir); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// ValidateI18n 检查每个支持的语言在语言文件目录中都有翻译文件
+// 为什么需要:
+//   - 在配置中加了 ja-JP 却忘了发布 ja-JP.yaml 时,运行时只会静默回退到默认语言
+//   - 在加载配置时检查,启动或热更新时直接失败并列出缺失的语言
+//
+// 参数:
+//
+//	messagesDir: 语言文件目录,文件命名为 {lang}.yaml、{lang}.yml 或 {lang}.json
+//
+// 返回:
+//
+//	error: 目录不存在或有语言缺少翻译文件时的错误
+//
+// 使用示例:
+//
+//	if err := cfg.I18n.ValidateI18n("./configs/locales"); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *I18nConfig) ValidateI18n(messagesDir string) error {
+	info, err := os.Stat(messagesDir)
+	if err != nil {
+		return fmt.Errorf("messages_dir %s is not accessible: %w", messagesDir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("messages_dir %s is not a directory", messagesDir)
+	}
+
+	var missing []string
+	for _, lang := range c.Supported {
+		if !hasLocaleFile(messagesDir, lang) {
+			missing = append(missing, lang)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing locale files in %s for supported languages: %s (expected {lang}%s)",
+			messagesDir, strings.Join(missing, ", "), strings.Join(i18nLocaleFileExts, "|"))
+	}
+
+	return nil
+}
+
+// hasLocaleFile 判断目录中是否存在指定语言的翻译文件
+func hasLocaleFile(dir, lang string) bool {
+	for _, ext := range i18nLocaleFileExts {
+		if info, err := os.Stat(filepath.Join(dir, lang+ext)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
 // overrideI18nConfig 使用环境变量覆盖国际化配置
 func overrideI18nConfig(cfg *I18nConfig) {
 	// Default
@@ -9,7 +9,7 @@ type JWTConfig struct {
 	// 生产环境必须从环境变量设置
 	// 建议使用至少32个字符的随机字符串
 	// 注意: 此字段非常敏感,必须保密
-	Secret string `mapstructure:"secret"`
+	Secret string `mapstructure:"secret" required:"true"`
 
 	// ExpiresIn 令牌有效期（秒）
 	// 默认: 3600（1小时）
@@ -17,13 +17,18 @@ type JWTConfig struct {
 	// - 安全性: 过期时间越短越安全
 	// - 用户体验: 过期时间太短需频繁登录
 	// - 业务场景: 根据业务敏感度调整
-	ExpiresIn int `mapstructure:"expiresIn"`
+	ExpiresIn int `mapstructure:"expiresIn" required:"true"`
+
+	// RefreshExpiresIn 刷新令牌有效期（秒）
+	// 默认: 604800（7天）
+	// 每次刷新都会轮换刷新令牌并重新计算有效期
+	RefreshExpiresIn int `mapstructure:"refreshExpiresIn" default:"604800"`
 
 	// Issuer 签发者
 	// 标识令牌由哪个系统签发
 	// 用于多系统环境下区分token来源
 	// 默认: "go-scaffold"
-	Issuer string `mapstructure:"issuer"`
+	Issuer string `mapstructure:"issuer" default:"go-scaffold"`
 }
 
 func (c *JWTConfig) ValidateName() string {
@@ -52,5 +57,10 @@ func (c *JWTConfig) Validate() error {
 		return errors.New("jwt expiresIn must be positive")
 	}
 
+	// 验证刷新令牌有效期,0 表示使用默认值
+	if c.RefreshExpiresIn < 0 {
+		return errors.New("jwt refreshExpiresIn must be non-negative")
+	}
+
 	return nil
 }
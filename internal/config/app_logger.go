@@ -15,7 +15,7 @@ type LoggerConfig struct {
 	// 例如:如果设置为 info,debug 日志不会输出
 	// 开发环境推荐: debug
 	// 生产环境推荐: info 或 warn
-	Level string `mapstructure:"level"`
+	Level string `mapstructure:"level" required:"true"`
 
 	// Format 默认输出格式(用于所有输出)
 	// 可选值:
@@ -24,7 +24,7 @@ type LoggerConfig struct {
 	// 如果设置了 ConsoleFormat 或 FileFormat,则此字段作为后备默认值
 	// 生产环境推荐: json(便于 ELK、Splunk 等系统分析)
 	// 开发环境推荐: console(易读)
-	Format string `mapstructure:"format"`
+	Format string `mapstructure:"format" required:"true"`
 
 	// ConsoleFormat 控制台输出专用格式(可选)
 	// 可选值: json, console
@@ -47,7 +47,7 @@ type LoggerConfig struct {
 	// - 容器/K8s 环境: stdout
 	// - 传统部署: file
 	// - 开发环境: both
-	Output string `mapstructure:"output"`
+	Output string `mapstructure:"output" required:"true"`
 
 	// FilePath 日志文件路径
 	// 仅当 Output="file" 或 Output="both" 时有效
@@ -80,6 +80,42 @@ type LoggerConfig struct {
 	// - 磁盘空间
 	// - 问题排查需求
 	MaxAge int `mapstructure:"max_age"`
+
+	// ErrorFilePath 错误日志文件路径(可选)
+	// 设置后 error 级别的日志额外写入该文件,与 output 同时生效,轮转参数与 file_path 相同
+	// 例如: logs/error.log
+	ErrorFilePath string `mapstructure:"error_file_path"`
+
+	// RedactKeys 需要脱敏的字段键(可选,不区分大小写)
+	// 匹配字段的值在输出前替换为 "***"
+	// 例如: ["password", "token", "authorization"]
+	RedactKeys []string `mapstructure:"redact_keys"`
+
+	// ErrorRateLimitMax 每个时间窗口内同一条错误日志最多输出的条数(可选)
+	// 0 表示不限流,超出部分被丢弃并在窗口结束时输出一条摘要
+	ErrorRateLimitMax int `mapstructure:"error_rate_limit_max"`
+
+	// ErrorRateLimitInterval 错误日志限流的时间窗口(秒)
+	// 0 表示使用默认值(60 秒)
+	ErrorRateLimitInterval int `mapstructure:"error_rate_limit_interval" default:"60"`
+
+	// Async 是否异步写入日志(可选)
+	// 启用后日志由后台 goroutine 批量写入,业务调用不阻塞在 I/O 上
+	Async bool `mapstructure:"async"`
+
+	// AsyncQueueSize 异步队列容量(条)
+	// 0 表示使用默认值(4096)
+	AsyncQueueSize int `mapstructure:"async_queue_size" default:"4096"`
+
+	// AsyncDropWhenFull 异步队列满时丢弃日志而不是阻塞等待
+	AsyncDropWhenFull bool `mapstructure:"async_drop_when_full"`
+
+	// OTelCorrelation 是否为 WithContext 日志注入 OpenTelemetry 的 trace_id 和 span_id
+	OTelCorrelation bool `mapstructure:"otel_correlation"`
+
+	// StrictFields 是否校验日志键值对(开发模式)
+	// 键值对数量为奇数或键不是字符串时输出 DPanic 级别的告警
+	StrictFields bool `mapstructure:"strict_fields"`
 }
 
 func (c *LoggerConfig) ValidateName() string {
@@ -111,6 +147,19 @@ func (c *LoggerConfig) Validate() error {
 		return errors.New("output must be stdout, file, or both")
 	}
 
+	// 验证错误日志限流
+	if c.ErrorRateLimitMax < 0 {
+		return errors.New("error_rate_limit_max must be non-negative")
+	}
+	if c.ErrorRateLimitInterval < 0 {
+		return errors.New("error_rate_limit_interval must be non-negative")
+	}
+
+	// 验证异步队列容量
+	if c.AsyncQueueSize < 0 {
+		return errors.New("async_queue_size must be non-negative")
+	}
+
 	return nil
 }
 
@@ -10,22 +10,43 @@ type RBACConfig struct {
 	// 如果为空，将使用pkg/rbac目录下的内置model.conf
 	ModelPath string `mapstructure:"model_path"`
 
+	// 是否使用内置ABAC模型（默认false）
+	// 启用后策略可携带条件表达式，配合 EnforceWithContext 传入请求属性
+	EnableABAC bool `mapstructure:"enable_abac"`
+
 	// 是否启用缓存（默认true）
 	// 缓存可以显著提升权限检查性能
-	EnableCache bool `mapstructure:"enable_cache"`
+	EnableCache bool `mapstructure:"enable_cache" default:"true"`
 
 	// 缓存过期时间（默认30分钟）
 	// 仅在EnableCache=true时生效
-	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+	CacheTTL time.Duration `mapstructure:"cache_ttl" default:"30m"`
 
 	// 是否自动保存策略（默认true）
 	// 设置为true时，每次策略变更都会立即持久化到数据库
 	// 设置为false时，需要手动调用SavePolicy()
-	AutoSave bool `mapstructure:"auto_save"`
+	AutoSave bool `mapstructure:"auto_save" default:"true"`
 
 	// 表名前缀（可选）
 	// 用于Casbin策略表的前缀，默认为空
 	TablePrefix string `mapstructure:"table_prefix"`
+
+	// 超级管理员列表（可选）
+	// 列出的用户在所有域中跳过权限检查
+	SuperAdmins []string `mapstructure:"super_admins"`
+
+	// 超级管理员角色（可选）
+	// 拥有该角色（含继承）的用户在对应域中跳过权限检查
+	SuperAdminRole string `mapstructure:"super_admin_role"`
+
+	// 只加载的域列表（可选）
+	// 非空时实例只加载这些域的策略和角色分配，而不是整张 casbin_rule 表
+	// 用于多租户部署中按租户拆分实例，为空时加载全部策略
+	LoadDomains []string `mapstructure:"load_domains"`
+
+	// 操作层级（可选）
+	// 键为操作，值为它蕴含的操作，例如 write 蕴含 read，"*" 表示蕴含所有操作
+	ActionHierarchy map[string][]string `mapstructure:"action_hierarchy"`
 }
 
 func (c *RBACConfig) ValidateName() string {
@@ -20,7 +20,7 @@ type RedisConfig struct {
 
 	// Port Redis 端口
 	// 默认: 6379
-	Port int `mapstructure:"port"`
+	Port int `mapstructure:"port" default:"6379"`
 
 	// Password Redis 密码
 	// 如果 Redis 未设置密码,留空
@@ -62,6 +62,16 @@ type RedisConfig struct {
 	// 向 Redis 写入命令的最大等待时间
 	// 推荐: 3 秒
 	WriteTimeout int `mapstructure:"write_timeout"`
+
+	// Compression 值压缩算法
+	// 可选值: none(默认)、gzip、snappy(需在启动时通过 cache.RegisterCompressor 注册)
+	// 只压缩超过 CompressionThreshold 的值,压缩和未压缩的值可以共存
+	Compression string `mapstructure:"compression" default:"none"`
+
+	// CompressionThreshold 压缩阈值(字节)
+	// 小于该值的数据不压缩
+	// 0 表示使用默认值 1024
+	CompressionThreshold int `mapstructure:"compression_threshold" default:"1024"`
 }
 
 func (c *RedisConfig) ValidateName() string {
@@ -103,6 +113,17 @@ func (c *RedisConfig) Validate() error {
 		return errors.New("poolSize must be non-negative")
 	}
 
+	// 验证压缩配置
+	// 算法是否已注册由 cache.Config.Validate 检查
+	switch c.Compression {
+	case "", "none", "gzip", "snappy":
+	default:
+		return errors.New("compression must be one of: none, gzip, snappy")
+	}
+	if c.CompressionThreshold < 0 {
+		return errors.New("compression_threshold must be non-negative")
+	}
+
 	return nil
 }
 
@@ -181,4 +202,9 @@ func overrideRedisConfig(cfg *RedisConfig) {
 			cfg.WriteTimeout = timeout
 		}
 	}
+
+	// Compression
+	if val := os.Getenv(EnvRedisCompression); val != "" {
+		cfg.Compression = val
+	}
 }
@@ -17,7 +17,7 @@ type ServerConfig struct {
 	// Port 监听端口
 	// 有效范围: 1-65535
 	// 常用端口: 8080, 3000, 80(需要 root)
-	Port int `mapstructure:"port"`
+	Port int `mapstructure:"port" required:"true"`
 
 	// Mode 运行模式
 	// 可选值:
@@ -28,25 +28,36 @@ type ServerConfig struct {
 	// - Gin 的日志详细程度
 	// - 性能优化级别
 	// - panic 恢复行为
-	Mode string `mapstructure:"mode"`
+	Mode string `mapstructure:"mode" required:"true"`
 
 	// ReadTimeout 读取请求的超时时间(秒)
 	// 从连接建立到读取完整请求体的最大时间
 	// 防止慢速客户端占用连接
 	// 推荐: 5-60 秒
-	ReadTimeout int `mapstructure:"read_timeout"`
+	ReadTimeout int `mapstructure:"read_timeout" required:"true"`
 
 	// WriteTimeout 写入响应的超时时间(秒)
 	// 从请求处理完成到写入完整响应的最大时间
 	// 防止慢速客户端占用连接
 	// 推荐: 10-120 秒(取决于响应大小)
-	WriteTimeout int `mapstructure:"write_timeout"`
+	WriteTimeout int `mapstructure:"write_timeout" required:"true"`
 
 	// IdleTimeout 空闲连接的超时时间(秒)
 	// 从连接建立到空闲的最大时间
 	// 防止慢速客户端占用连接
 	// 推荐: 60-300 秒
 	IdleTimeout int `mapstructure:"idle_timeout"`
+
+	// SlowRequestThreshold 慢请求阈值(毫秒)
+	// 请求处理耗时超过该值时输出 Warn 日志,包含 method、path、status 和耗时
+	// 0 表示不检测
+	// 推荐: 500-2000 毫秒
+	SlowRequestThreshold int `mapstructure:"slow_request_threshold"`
+
+	// ReusePort 是否以 SO_REUSEPORT 监听端口
+	// 启用后新版本进程可以在旧进程退出前监听同一端口,实现零停机升级
+	// 仅支持 Linux、macOS 和 BSD
+	ReusePort bool `mapstructure:"reuse_port"`
 }
 
 func (c *ServerConfig) ValidateName() string {
@@ -87,6 +98,11 @@ func (c *ServerConfig) Validate() error {
 		return errors.New("writeTimeout must be positive")
 	}
 
+	// 验证慢请求阈值
+	if c.SlowRequestThreshold < 0 {
+		return errors.New("slow_request_threshold must be non-negative")
+	}
+
 	return nil
 }
 
@@ -64,6 +64,10 @@ type Config struct {
 
 	// RBAC RBAC配置
 	RBAC RBACConfig `mapstructure:"rbac"`
+
+	// Features 功能开关
+	// 可选,通过 Manager.IsEnabled 读取,随配置热重载生效
+	Features FeatureFlags `mapstructure:"features"`
 }
 
 // Validator 定义可验证配置的接口
@@ -97,6 +97,10 @@ const (
 	// EnvRedisWriteTimeout Redis 写入超时(秒)
 	// 示例: export REDIS_WRITE_TIMEOUT=3
 	EnvRedisWriteTimeout = "REDIS_WRITE_TIMEOUT"
+
+	// EnvRedisCompression Redis 值压缩算法
+	// 示例: export REDIS_COMPRESSION=gzip
+	EnvRedisCompression = "REDIS_COMPRESSION"
 )
 
 // 服务器相关环境变量
@@ -148,6 +152,10 @@ const (
 	EnvI18nSupported = "I18N_SUPPORTED"
 )
 
+// i18nLocaleFileExts 语言文件支持的扩展名
+// 与 pkg/i18n 加载翻译文件时识别的格式一致
+var i18nLocaleFileExts = []string{".yaml", ".yml", ".json"}
+
 // JWT 相关环境变量
 const (
 	// EnvJWTSecret JWT 签名密钥
@@ -164,6 +172,20 @@ const (
 	EnvJWTIssuer = "JWT_ISSUER"
 )
 
+// 密钥相关常量
+const (
+	// EnvSecretsDir 默认文件密钥提供者的目录
+	// 实际读取 REI_APP_SECRETS_DIR
+	// 示例: export REI_APP_SECRETS_DIR=/etc/secrets
+	EnvSecretsDir = "SECRETS_DIR"
+
+	// DefaultSecretsDir 默认密钥目录(Docker Secrets 约定)
+	DefaultSecretsDir = "/run/secrets"
+
+	// SecretRefPrefix 配置中密钥引用的变量名: ${secret:path/to/key}
+	SecretRefPrefix = "secret"
+)
+
 // 其他常量
 const (
 	// EnvFilePath .env 文件路径
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ChangedField 描述一个发生变化的配置字段
+type ChangedField struct {
+	// Path 字段路径,由 mapstructure 标签以 "." 连接
+	// 例如: "database.host"、"logger.level"
+	Path string
+
+	// Old 变更前的值
+	Old any
+
+	// New 变更后的值
+	New any
+}
+
+// ChangedFields 配置变更集合
+// 由 Diff 返回,按 Config 结构体字段顺序排列
+type ChangedFields []ChangedField
+
+// Contains 判断指定路径或其子路径是否发生变化
+// 参数:
+//
+//	path: 字段路径,如 "database" 或 "database.host"
+//
+// 返回:
+//
+//	bool: path 本身或 path 下的任意字段发生变化时返回 true
+func (c ChangedFields) Contains(path string) bool {
+	prefix := path + "."
+	for _, f := range c {
+		if f.Path == path || strings.HasPrefix(f.Path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Paths 返回所有变更字段的路径
+func (c ChangedFields) Paths() []string {
+	paths := make([]string, len(c))
+	for i, f := range c {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
+// Diff 计算两份配置之间的差异
+// 基于反射逐字段比较,结构体递归展开,slice 和 map 作为整体比较
+// 为什么需要:
+//   - 钩子只收到 old 和 new,需要自行比较才能知道是否与自己相关
+//   - 只修改日志级别时不应触发数据库重连等昂贵的重载
+//
+// 注意: Old/New 包含原始值(包括密码等敏感字段),记录日志时应只输出 Path
+//
+// 参数:
+//
+//	old: 变更前的配置,nil 视为零值配置
+//	new: 变更后的配置,nil 视为零值配置
+//
+// 返回:
+//
+//	ChangedFields: 发生变化的字段,无变化时为空
+//
+// 使用示例:
+//
+//	manager.RegisterHook(func(old, new *config.Config) {
+//	    diff := config.Diff(old, new)
+//	    if diff.Contains("database") {
+//	        reloadDatabase(new)
+//	    }
+//	})
+func Diff(old, new *Config) ChangedFields {
+	if old == nil {
+		old = &Config{}
+	}
+	if new == nil {
+		new = &Config{}
+	}
+
+	var changes ChangedFields
+	diffValue("", reflect.ValueOf(*old), reflect.ValueOf(*new), &changes)
+	return changes
+}
+
+// diffValue 递归比较两个值,将差异追加到 changes
+func diffValue(path string, old, new reflect.Value, changes *ChangedFields) {
+	if old.Kind() == reflect.Struct {
+		t := old.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			diffValue(joinFieldPath(path, fieldName(field)), old.Field(i), new.Field(i), changes)
+		}
+		return
+	}
+
+	if !valuesEqual(old, new) {
+		*changes = append(*changes, ChangedField{
+			Path: path,
+			Old:  old.Interface(),
+			New:  new.Interface(),
+		})
+	}
+}
+
+// valuesEqual 比较两个值是否相等
+// nil 与空的 slice/map 视为相等,避免 copyConfig 等拷贝产生误报
+func valuesEqual(old, new reflect.Value) bool {
+	switch old.Kind() {
+	case reflect.Slice, reflect.Map:
+		if old.Len() == 0 && new.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(old.Interface(), new.Interface())
+}
+
+// fieldName 返回字段在路径中的名称
+// 优先使用 mapstructure 标签,与配置文件中的键保持一致
+func fieldName(field reflect.StructField) string {
+	if tag, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ","); tag != "" && tag != "-" {
+		return tag
+	}
+	return strings.ToLower(field.Name)
+}
+
+// joinFieldPath 拼接字段路径
+func joinFieldPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
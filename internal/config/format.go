@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnsupportedFormat 不支持的配置文件格式
+var ErrUnsupportedFormat = errors.New("unsupported config format")
+
+// ConfigFormat 配置文件格式
+// 决定 viper 使用哪种解析器读取配置文件,与文件扩展名无关
+type ConfigFormat string
+
+const (
+	// FormatYAML YAML 格式(.yaml/.yml)
+	FormatYAML ConfigFormat = "yaml"
+
+	// FormatJSON JSON 格式(.json)
+	FormatJSON ConfigFormat = "json"
+
+	// FormatTOML TOML 格式(.toml)
+	FormatTOML ConfigFormat = "toml"
+)
+
+// formatExts 扩展名到配置格式的映射
+var formatExts = map[string]ConfigFormat{
+	".yaml": FormatYAML,
+	".yml":  FormatYAML,
+	".json": FormatJSON,
+	".toml": FormatTOML,
+}
+
+// Validate 检查格式是否受支持
+// 返回:
+//
+//	error: 不是 yaml、json、toml 之一时返回包装的 ErrUnsupportedFormat
+func (f ConfigFormat) Validate() error {
+	switch f {
+	case FormatYAML, FormatJSON, FormatTOML:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q (must be yaml, json, or toml)", ErrUnsupportedFormat, string(f))
+	}
+}
+
+// DetectFormat 根据文件扩展名推断配置格式(不区分大小写)
+// 参数:
+//
+//	path: 配置文件路径
+//
+// 返回:
+//
+//	ConfigFormat: 推断出的格式
+//	error: 没有扩展名或扩展名不受支持时返回包装的 ErrUnsupportedFormat,
+//	       此时应使用 LoadFormat 显式指定格式
+//
+// 使用示例:
+//
+//	format, err := config.DetectFormat("configs/config.yml") // FormatYAML
+func DetectFormat(path string) (ConfigFormat, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if format, ok := formatExts[ext]; ok {
+		return format, nil
+	}
+	return "", fmt.Errorf("%w: cannot detect format of %s, use LoadFormat", ErrUnsupportedFormat, path)
+}
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// 同一份逻辑配置的三种格式,包含环境变量替换、列表和嵌套对象
+const (
+	formatTestYAML = `
+server:
+  port: ${CONFIG_FORMAT_TEST_PORT:8080}
+  mode: release
+  read_timeout: 10
+  write_timeout: 10
+database:
+  driver: sqlite
+  dbname: app.db
+logger:
+  level: info
+  format: json
+  output: stdout
+  redact_keys: [password, token]
+i18n:
+  default: zh-CN
+  supported: [zh-CN, en-US]
+executor:
+  enabled: true
+  pools:
+    - name: http
+      size: 50
+      rate_limit:
+        rps: 1.5
+        burst: 3
+jwt:
+  secret: ${CONFIG_FORMAT_TEST_SECRET:0123456789abcdef0123456789abcdef}
+  expiresIn: 3600
+features:
+  new_checkout: true
+`
+
+	formatTestJSON = `{
+  "server": {"port": "${CONFIG_FORMAT_TEST_PORT:8080}", "mode": "release", "read_timeout": 10, "write_timeout": 10},
+  "database": {"driver": "sqlite", "dbname": "app.db"},
+  "logger": {"level": "info", "format": "json", "output": "stdout", "redact_keys": ["password", "token"]},
+  "i18n": {"default": "zh-CN", "supported": ["zh-CN", "en-US"]},
+  "executor": {"enabled": true, "pools": [{"name": "http", "size": 50, "rate_limit": {"rps": 1.5, "burst": 3}}]},
+  "jwt": {"secret": "${CONFIG_FORMAT_TEST_SECRET:0123456789abcdef0123456789abcdef}", "expiresIn": 3600},
+  "features": {"new_checkout": true}
+}`
+
+	formatTestTOML = `
+[server]
+port = "${CONFIG_FORMAT_TEST_PORT:8080}"
+mode = "release"
+read_timeout = 10
+write_timeout = 10
+
+[database]
+driver = "sqlite"
+dbname = "app.db"
+
+[logger]
+level = "info"
+format = "json"
+output = "stdout"
+redact_keys = ["password", "token"]
+
+[i18n]
+default = "zh-CN"
+supported = ["zh-CN", "en-US"]
+
+[executor]
+enabled = true
+
+[[executor.pools]]
+name = "http"
+size = 50
+
+[executor.pools.rate_limit]
+rps = 1.5
+burst = 3
+
+[jwt]
+secret = "${CONFIG_FORMAT_TEST_SECRET:0123456789abcdef0123456789abcdef}"
+expiresIn = 3600
+
+[features]
+new_checkout = true
+`
+)
+
+// TestLoadFormat 测试 YAML、JSON、TOML 加载出相同的配置
+func TestLoadFormat(t *testing.T) {
+	t.Setenv("CONFIG_FORMAT_TEST_PORT", "9090")
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		format ConfigFormat // 为空时使用 Load 按扩展名推断
+	}{
+		{"yaml", write("config.yaml", formatTestYAML), ""},
+		{"json", write("config.json", formatTestJSON), ""},
+		{"toml", write("config.toml", formatTestTOML), ""},
+		{"yaml without extension", write("config-yaml", formatTestYAML), FormatYAML},
+		{"json without extension", write("config-json", formatTestJSON), FormatJSON},
+		{"toml without extension", write("config-toml", formatTestTOML), FormatTOML},
+	}
+
+	var want *Config
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewManager()
+			var err error
+			if tt.format == "" {
+				err = m.Load(tt.path)
+			} else {
+				err = m.LoadFormat(tt.path, tt.format)
+			}
+			if err != nil {
+				t.Fatalf("load failed: %v", err)
+			}
+
+			got := m.Get()
+			if got.Server.Port != 9090 {
+				t.Errorf("server.port = %d, want 9090 from env", got.Server.Port)
+			}
+			if len(got.Executor.Pools) != 1 || got.Executor.Pools[0].RateLimit == nil || got.Executor.Pools[0].RateLimit.RPS != 1.5 {
+				t.Errorf("executor.pools = %+v, want one pool with rate_limit", got.Executor.Pools)
+			}
+			if want == nil {
+				want = got
+				return
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("config differs from yaml:\ngot  %+v\nwant %+v", got, want)
+			}
+		})
+	}
+}
+
+// TestLoadFormat_Unsupported 测试不支持的格式和无法推断的扩展名
+func TestLoadFormat_Unsupported(t *testing.T) {
+	m := NewManager()
+
+	if err := m.Load("/etc/app/config"); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("Load without extension: err = %v, want ErrUnsupportedFormat", err)
+	}
+	if err := m.LoadFormat("config.ini", ConfigFormat("ini")); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("LoadFormat ini: err = %v, want ErrUnsupportedFormat", err)
+	}
+
+	if format, err := DetectFormat("configs/CONFIG.YML"); err != nil || format != FormatYAML {
+		t.Errorf("DetectFormat = %q, %v, want yaml", format, err)
+	}
+}
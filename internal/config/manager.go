@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -42,12 +43,25 @@ type LoggerHandler func() logger.Logger
 //   - 符合依赖倒置原则
 type Manager interface {
 	// Load 从指定路径加载配置
+	// 根据扩展名推断格式(.yaml/.yml、.json、.toml)
 	// 参数:
-	//   configPath: 配置文件路径(支持 YAML、JSON 等)
+	//   configPath: 配置文件路径
 	// 返回:
-	//   error: 加载或验证失败时的错误
+	//   error: 加载或验证失败时的错误,扩展名不受支持时返回 ErrUnsupportedFormat
 	Load(configPath string) error
 
+	// LoadFormat 以指定格式从路径加载配置
+	// 忽略文件扩展名,适用于没有扩展名的文件(如 Kubernetes ConfigMap 挂载的 /etc/app/config)
+	// 环境变量替换、密钥引用、环境变量覆盖和验证与 Load 完全相同,热重载沿用该格式
+	// 参数:
+	//   configPath: 配置文件路径
+	//   format: 配置格式,必须是 FormatYAML、FormatJSON 或 FormatTOML
+	// 返回:
+	//   error: 格式不受支持时返回 ErrUnsupportedFormat,以及加载或验证失败时的错误
+	// 使用示例:
+	//   err := manager.LoadFormat("/etc/app/config", config.FormatTOML)
+	LoadFormat(configPath string, format ConfigFormat) error
+
 	// Get 返回只读的配置快照
 	// 返回:
 	//   *Config: 当前配置的副本
@@ -55,6 +69,50 @@ type Manager interface {
 	//   这是线程安全的,可以并发调用
 	Get() *Config
 
+	// Version 返回当前配置的版本号
+	// 每次成功的 Load/Update/热重载都会使版本号单调递增
+	// 返回:
+	//   uint64: 配置版本号(未加载时为 0)
+	// 用途:
+	//   比较两次读取之间配置是否发生变化
+	Version() uint64
+
+	// IsEnabled 判断功能开关是否启用
+	// 参数:
+	//   flag: 开关名(不区分大小写)
+	// 返回:
+	//   bool: 当前配置快照中的值,未配置的开关返回 false
+	// 说明:
+	//   每次调用读取最新的原子快照,配置文件修改后经 Watch 热重载即生效
+	// 使用示例:
+	//   if manager.IsEnabled("new_checkout") {
+	//       return newCheckout(ctx)
+	//   }
+	IsEnabled(flag string) bool
+
+	// Enabled 判断功能开关是否启用,未配置时返回默认值
+	// 参数:
+	//   flag: 开关名(不区分大小写)
+	//   def: 配置中没有该开关时的默认值
+	// 返回:
+	//   bool: 开关状态
+	Enabled(flag string, def bool) bool
+
+	// Subscribe 订阅配置变更
+	// 返回:
+	//   <-chan *Config: 配置变更通道,每次变更发送新配置
+	//   func(): 取消订阅函数,调用后通道会被关闭
+	// 说明:
+	//   通道发送是非阻塞的,消费者来不及处理时只保留最新配置
+	//   适合在 select 循环中消费,是 RegisterHook 的补充
+	// 使用示例:
+	//   ch, cancel := manager.Subscribe()
+	//   defer cancel()
+	//   for cfg := range ch {
+	//       reload(cfg)
+	//   }
+	Subscribe() (<-chan *Config, func())
+
 	// Update 原子地更新配置
 	// 参数:
 	//   fn: 更新函数,接收配置副本并修改
@@ -66,6 +124,38 @@ type Manager interface {
 	//   })
 	Update(fn func(*Config)) error
 
+	// Validate 检查候选配置文件,不替换当前配置
+	// 读取、环境变量替换、密钥引用和验证与热重载的 Shadow Loading 完全相同
+	// 参数:
+	//   configPath: 候选配置文件路径
+	// 返回:
+	//   error: 配置无效时的错误,nil 表示该文件可以安全地热重载
+	// 使用示例:
+	//   if err := manager.Validate("/etc/app/config.next.yaml"); err != nil {
+	//       log.Fatal(err)
+	//   }
+	Validate(configPath string) error
+
+	// DryRunUpdate 检查 Update 的结果是否有效,不替换当前配置
+	// 参数:
+	//   fn: 更新函数,接收配置副本并修改
+	// 返回:
+	//   error: 验证失败时的错误
+	// 注意:
+	//   不调用重载函数、钩子和订阅者
+	DryRunUpdate(fn func(*Config)) error
+
+	// Schema 返回所有配置项的描述
+	// 返回:
+	//   []ConfigKey: 配置项列表,包含路径、类型、默认值、是否必填和说明
+	// 用途:
+	//   生成配置文档,检查配置文件是否覆盖了所有必填项
+	// 使用示例:
+	//   for _, key := range manager.Schema() {
+	//       fmt.Printf("%-40s %-10s %s\n", key.Path, key.Type, key.Description)
+	//   }
+	Schema() []ConfigKey
+
 	// RegisterHook 注册配置变更钩子
 	// 参数:
 	//   h: 钩子处理函数
@@ -73,6 +163,19 @@ type Manager interface {
 	//   当配置重新加载时,所有注册的钩子都会被调用
 	RegisterHook(h HookHandler)
 
+	// RegisterReloader 注册配置段重载函数
+	// 参数:
+	//   section: 配置段路径(mapstructure 标签名),如 "redis" 或 "logger.level"
+	//   reloader: 重载函数,只在该配置段发生变化时调用
+	// 说明:
+	//   Update 和热重载在切换配置之前按注册顺序调用重载函数
+	//   任意一个返回错误时,已成功的重载函数会以旧配置再次调用,配置保持不变,钩子和订阅者不会收到通知
+	// 使用示例:
+	//   manager.RegisterReloader("redis", func(new *config.Config) error {
+	//       return cache.Reload(ctx, makeCacheConfig(new))
+	//   })
+	RegisterReloader(section string, reloader ReloadHandler)
+
 	// RegisterLogger 注册日志处理器并返回日志器
 	// 参数:
 	//   h: 日志处理器函数
@@ -80,6 +183,13 @@ type Manager interface {
 	//   logger.Logger: 日志器实例
 	RegisterLogger(h LoggerHandler) logger.Logger
 
+	// SetSecretProvider 设置密钥提供者
+	// 参数:
+	//   p: 用于解析 ${secret:path/to/key} 的提供者,为 nil 时恢复默认的文件提供者
+	// 注意:
+	//   需要在 Load 之前调用
+	SetSecretProvider(p SecretProvider)
+
 	// Watch 开始监听配置文件变化
 	// 返回:
 	//   error: 启动监听失败时的错误
@@ -92,7 +202,7 @@ type Manager interface {
 // 使用 viper 进行配置管理,支持配置热重载
 // 线程安全设计:
 //   - 使用 atomic.Pointer 存储配置(无锁读取)
-//   - 使用 RWMutex 保护 hooks 列表
+//   - 使用 RWMutex 保护 hooks 和 reloaders 列表
 type manager struct {
 	// v viper 实例,用于读取配置文件
 	// viper 支持多种格式:YAML、JSON、TOML 等
@@ -107,15 +217,42 @@ type manager struct {
 	// 用于监听文件变化
 	configPath string
 
+	// configFormat 配置文件格式
+	// 热重载时使用相同的格式解析
+	configFormat ConfigFormat
+
 	// hooks 配置变更钩子列表
 	// 当配置重新加载时,按注册顺序调用
 	hooks []HookHandler
 
-	// hooksMu 保护 hooks 列表的读写锁
-	// 读锁:通知钩子时
-	// 写锁:注册新钩子时
+	// reloaders 配置段重载函数列表
+	// 切换配置之前,按注册顺序调用变化配置段对应的函数
+	reloaders []sectionReloader
+
+	// hooksMu 保护 hooks 和 reloaders 列表的读写锁
+	// 读锁:通知钩子、调用重载函数时
+	// 写锁:注册新钩子、重载函数时
 	hooksMu sync.RWMutex
 
+	// version 配置版本号
+	// 每次配置替换成功后递增
+	version atomic.Uint64
+
+	// subscribers 配置变更订阅者
+	// key 为订阅 ID,value 为容量为 1 的通道
+	subscribers map[uint64]chan *Config
+
+	// nextSubID 下一个订阅 ID
+	nextSubID uint64
+
+	// subsMu 保护 subscribers 的互斥锁
+	// 同时串行化发送,保证"最新配置优先"语义
+	subsMu sync.Mutex
+
+	// secrets 密钥提供者
+	// 为 nil 时使用 defaultSecretProvider
+	secrets SecretProvider
+
 	// loggerHandler 日志处理器
 	// 延迟获取日志器,因为日志器可能在配置管理器之后初始化
 	loggerHandler LoggerHandler
@@ -136,16 +273,27 @@ type manager struct {
 //	mgr.Load("config.yaml")
 func NewManager() Manager {
 	return &manager{
-		v:     viper.New(),            // 创建新的 viper 实例
-		hooks: make([]HookHandler, 0), // 初始化空的钩子列表
+		v:           viper.New(),                   // 创建新的 viper 实例
+		hooks:       make([]HookHandler, 0),        // 初始化空的钩子列表
+		subscribers: make(map[uint64]chan *Config), // 初始化空的订阅者列表
 	}
 }
 
 // Load 从指定路径加载配置
+// 根据扩展名推断格式后调用 LoadFormat
+func (m *manager) Load(configPath string) error {
+	format, err := DetectFormat(configPath)
+	if err != nil {
+		return err
+	}
+	return m.LoadFormat(configPath, format)
+}
+
+// LoadFormat 以指定格式从路径加载配置
 // 加载流程:
-//  1. 设置配置文件路径
+//  1. 设置配置文件路径和格式
 //  2. 读取配置文件
-//  3. 处理环境变量替换(${VAR:default})
+//  3. 处理环境变量替换(${VAR:default})和密钥引用(${secret:path})
 //  4. 反序列化到 Config 结构体
 //  5. 验证配置
 //  6. 原子存储配置
@@ -153,13 +301,19 @@ func NewManager() Manager {
 // 参数:
 //
 //	configPath: 配置文件路径
+//	format: 配置格式
 //
 // 返回:
 //
 //	error: 加载失败时的错误
-func (m *manager) Load(configPath string) error {
-	// 保存配置文件路径,用于 Watch
+func (m *manager) LoadFormat(configPath string, format ConfigFormat) error {
+	if err := format.Validate(); err != nil {
+		return err
+	}
+
+	// 保存配置文件路径和格式,用于 Watch
 	m.configPath = configPath
+	m.configFormat = format
 
 	// 1. 加载 .env 文件(如果存在)
 	// 这应该在读取 config.yaml 之前完成
@@ -168,8 +322,9 @@ func (m *manager) Load(configPath string) error {
 	LoadEnv()
 
 	// 2. 设置配置文件
-	// viper 会根据文件扩展名自动检测格式
+	// 显式指定格式,viper 不再根据扩展名推断
 	m.v.SetConfigFile(configPath)
+	m.v.SetConfigType(string(format))
 
 	// 3. 读取配置文件
 	// 这会解析文件内容到 viper 内部结构
@@ -206,6 +361,10 @@ func (m *manager) Load(configPath string) error {
 	// 8. 原子存储配置
 	// 使用 atomic.Pointer.Store 确保并发安全
 	m.config.Store(cfg)
+	m.version.Add(1)
+
+	// 9. 通知订阅者
+	m.notifySubscribers(cfg)
 
 	return nil
 }
@@ -215,35 +374,19 @@ func (m *manager) Load(configPath string) error {
 //
 //	${VAR_NAME}          - 环境变量值,如果不存在则为空字符串
 //	${VAR_NAME:default}  - 环境变量值,如果不存在则使用默认值
+//	${secret:path/key}   - 通过 SecretProvider 解析的密钥
 //
 // 示例:
 //
 //	port: ${PORT:8080}
 //	host: ${HOST:localhost}
+//	password: ${secret:db/password}
 //
 // 返回:
 //
-//	error: 处理失败时的错误
+//	error: 密钥解析失败时的错误
 func (m *manager) processEnvSubstitution() error {
-	// 编译正则表达式匹配 ${VAR:default} 格式
-	// 捕获组:
-	//   1: 变量名
-	//   2: 默认值(可选)
-	envPattern := regexp.MustCompile(`\$\{([^}:]+)(?::([^}]*))?\}`)
-
-	// 获取所有配置项
-	// 返回 map[string]any,包含所有配置的键值对
-	settings := m.v.AllSettings()
-
-	// 递归处理所有配置值
-	processed := m.processMap(settings, envPattern)
-
-	// 将处理后的值设置回 viper
-	for key, value := range processed {
-		m.v.Set(key, value)
-	}
-
-	return nil
+	return m.processEnvSubstitutionForViper(m.v)
 }
 
 // processMap 递归处理 map 中的环境变量替换
@@ -255,13 +398,18 @@ func (m *manager) processEnvSubstitution() error {
 // 返回:
 //
 //	map[string]any: 处理后的 map
-func (m *manager) processMap(data map[string]any, pattern *regexp.Regexp) map[string]any {
+//	error: 密钥解析失败时的错误,包含出错的配置键路径
+func (m *manager) processMap(data map[string]any, pattern *regexp.Regexp) (map[string]any, error) {
 	result := make(map[string]any)
 	for key, value := range data {
 		// 递归处理每个值
-		result[key] = m.processValue(value, pattern)
+		processed, err := m.processValue(value, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		result[key] = processed
 	}
-	return result
+	return result, nil
 }
 
 // processValue 处理单个值的环境变量替换
@@ -279,7 +427,8 @@ func (m *manager) processMap(data map[string]any, pattern *regexp.Regexp) map[st
 // 返回:
 //
 //	any: 处理后的值
-func (m *manager) processValue(value any, pattern *regexp.Regexp) any {
+//	error: 密钥解析失败时的错误
+func (m *manager) processValue(value any, pattern *regexp.Regexp) (any, error) {
 	switch v := value.(type) {
 	case string:
 		// 字符串类型,执行环境变量替换
@@ -293,21 +442,25 @@ func (m *manager) processValue(value any, pattern *regexp.Regexp) any {
 		// 数组,处理每个元素
 		result := make([]any, len(v))
 		for i, item := range v {
-			result[i] = m.processValue(item, pattern)
+			processed, err := m.processValue(item, pattern)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			result[i] = processed
 		}
-		return result
+		return result, nil
 
 	default:
 		// 其他类型(int、bool 等),原样返回
-		return value
+		return value, nil
 	}
 }
 
-// substituteEnv 替换字符串中的环境变量
+// substituteEnv 替换字符串中的环境变量和密钥引用
 // 处理逻辑:
 //  1. 查找所有匹配 ${VAR:default} 的部分
-//  2. 对每个匹配,尝试获取环境变量
-//  3. 如果环境变量存在,使用其值
+//  2. 变量名为 secret 时,将冒号后的部分交给 SecretProvider 解析
+//  3. 否则尝试获取环境变量,存在时使用其值
 //  4. 如果不存在,使用默认值
 //
 // 参数:
@@ -318,8 +471,10 @@ func (m *manager) processValue(value any, pattern *regexp.Regexp) any {
 // 返回:
 //
 //	string: 替换后的字符串
-func (m *manager) substituteEnv(s string, pattern *regexp.Regexp) string {
-	return pattern.ReplaceAllStringFunc(s, func(match string) string {
+//	error: 密钥解析失败时的错误(不包含密钥值)
+func (m *manager) substituteEnv(s string, pattern *regexp.Regexp) (string, error) {
+	var resolveErr error
+	result := pattern.ReplaceAllStringFunc(s, func(match string) string {
 		// 提取变量名和默认值
 		submatches := pattern.FindStringSubmatch(match)
 		if len(submatches) < 2 {
@@ -336,6 +491,19 @@ func (m *manager) substituteEnv(s string, pattern *regexp.Regexp) string {
 			defaultValue = submatches[2]
 		}
 
+		// 密钥引用: ${secret:path/to/key}
+		if envName == SecretRefPrefix {
+			if resolveErr != nil {
+				return match
+			}
+			value, err := m.secretProvider().Resolve(defaultValue)
+			if err != nil {
+				resolveErr = err
+				return match
+			}
+			return value
+		}
+
 		// 尝试获取环境变量
 		if envValue := os.Getenv(envName); envValue != "" {
 			// 环境变量存在,使用其值
@@ -344,6 +512,26 @@ func (m *manager) substituteEnv(s string, pattern *regexp.Regexp) string {
 		// 环境变量不存在,使用默认值
 		return defaultValue
 	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+	return result, nil
+}
+
+// secretProvider 返回当前的密钥提供者,未设置时使用默认的文件提供者
+func (m *manager) secretProvider() SecretProvider {
+	if m.secrets != nil {
+		return m.secrets
+	}
+	return defaultSecretProvider()
+}
+
+// SetSecretProvider 设置密钥提供者
+// 参数:
+//
+//	p: 密钥提供者,为 nil 时恢复默认的文件提供者
+func (m *manager) SetSecretProvider(p SecretProvider) {
+	m.secrets = p
 }
 
 // Get 返回只读的配置快照
@@ -359,14 +547,97 @@ func (m *manager) Get() *Config {
 	return m.config.Load()
 }
 
+// Version 返回当前配置的版本号
+// 实现:
+//
+//	使用 atomic.Uint64 无锁读取
+//
+// 返回:
+//
+//	uint64: 配置版本号
+func (m *manager) Version() uint64 {
+	return m.version.Load()
+}
+
+// IsEnabled 判断功能开关是否启用
+// 未配置的开关视为关闭
+func (m *manager) IsEnabled(flag string) bool {
+	return m.Enabled(flag, false)
+}
+
+// Enabled 判断功能开关是否启用,未配置时返回 def
+// 配置尚未加载时同样返回 def
+func (m *manager) Enabled(flag string, def bool) bool {
+	cfg := m.config.Load()
+	if cfg == nil {
+		return def
+	}
+	return cfg.Features.Enabled(flag, def)
+}
+
+// Subscribe 订阅配置变更
+// 每个订阅者持有一个容量为 1 的通道
+// 发送时如果通道已满,丢弃未消费的旧配置后写入新配置(latest-wins)
+//
+// 返回:
+//
+//	<-chan *Config: 配置变更通道
+//	func(): 取消订阅函数,可重复调用
+func (m *manager) Subscribe() (<-chan *Config, func()) {
+	ch := make(chan *Config, 1)
+
+	m.subsMu.Lock()
+	id := m.nextSubID
+	m.nextSubID++
+	m.subscribers[id] = ch
+	m.subsMu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			m.subsMu.Lock()
+			defer m.subsMu.Unlock()
+			delete(m.subscribers, id)
+			close(ch)
+		})
+	}
+	return ch, cancel
+}
+
+// notifySubscribers 向所有订阅者发送新配置
+// 参数:
+//
+//	cfg: 新配置
+//
+// 线程安全:
+//
+//	持有 subsMu 期间完成发送,避免与取消订阅并发关闭通道
+func (m *manager) notifySubscribers(cfg *Config) {
+	m.subsMu.Lock()
+	defer m.subsMu.Unlock()
+	for _, ch := range m.subscribers {
+		select {
+		case ch <- cfg:
+		default:
+			// 通道已满:丢弃旧配置,只保留最新配置
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- cfg
+		}
+	}
+}
+
 // Update 原子地更新配置
 // 更新流程:
 //  1. 获取当前配置
 //  2. 创建配置副本
 //  3. 应用更新函数
 //  4. 验证新配置
-//  5. 原子替换配置
-//  6. 通知钩子
+//  5. 调用变化配置段的重载函数
+//  6. 原子替换配置
+//  7. 通知钩子
 //
 // 参数:
 //
@@ -374,7 +645,7 @@ func (m *manager) Get() *Config {
 //
 // 返回:
 //
-//	error: 验证失败时的错误
+//	error: 验证失败时的错误,重载失败时返回包装了 ErrReloadFailed 的错误
 //
 // 线程安全:
 //
@@ -400,13 +671,21 @@ func (m *manager) Update(fn func(*Config)) error {
 		return fmt.Errorf("config validation failed: %w", err)
 	}
 
+	// 重载变化的组件
+	// 失败时组件已回滚,配置保持不变
+	if err := m.runReloaders(oldCfg, newCfg); err != nil {
+		return err
+	}
+
 	// 原子替换配置
 	// 使用 atomic.Pointer.Store 确保并发安全
 	m.config.Store(newCfg)
+	m.version.Add(1)
 
-	// 通知所有注册的钩子
+	// 通知所有注册的钩子和订阅者
 	// 让其他组件知道配置已更新
 	m.notifyHooks(oldCfg, newCfg)
+	m.notifySubscribers(newCfg)
 
 	return nil
 }
@@ -425,50 +704,32 @@ func (m *manager) Update(fn func(*Config)) error {
 //
 //	*Config: 配置副本
 func (m *manager) copyConfig(src *Config) *Config {
-	dst := &Config{
-		Server: ServerConfig{
-			Port:         src.Server.Port,
-			Mode:         src.Server.Mode,
-			ReadTimeout:  src.Server.ReadTimeout,
-			WriteTimeout: src.Server.WriteTimeout,
-		},
-		Database: DatabaseConfig{
-			Driver:       src.Database.Driver,
-			Host:         src.Database.Host,
-			Port:         src.Database.Port,
-			User:         src.Database.User,
-			Password:     src.Database.Password,
-			DBName:       src.Database.DBName,
-			MaxOpenConns: src.Database.MaxOpenConns,
-			MaxIdleConns: src.Database.MaxIdleConns,
-		},
-		Redis: RedisConfig{
-			Enabled:  src.Redis.Enabled,
-			Host:     src.Redis.Host,
-			Port:     src.Redis.Port,
-			Password: src.Redis.Password,
-			DB:       src.Redis.DB,
-			PoolSize: src.Redis.PoolSize,
-		},
-		Logger: LoggerConfig{
-			Level:         src.Logger.Level,
-			Format:        src.Logger.Format,
-			ConsoleFormat: src.Logger.ConsoleFormat,
-			FileFormat:    src.Logger.FileFormat,
-			Output:        src.Logger.Output,
-			FilePath:      src.Logger.FilePath,
-			MaxSize:       src.Logger.MaxSize,
-			MaxBackups:    src.Logger.MaxBackups,
-			MaxAge:        src.Logger.MaxAge,
-		},
-		I18n: I18nConfig{
-			Default:   src.I18n.Default,
-			Supported: make([]string, len(src.I18n.Supported)),
-		},
+	// 值类型字段直接拷贝
+	// 新增的标量字段无需在这里逐个列出,避免 Update 丢失配置
+	dst := *src
+
+	// 拷贝 slice 和 map,避免 Update 修改副本时影响当前快照
+	dst.Logger.RedactKeys = slices.Clone(src.Logger.RedactKeys)
+	dst.I18n.Supported = slices.Clone(src.I18n.Supported)
+	dst.RBAC.SuperAdmins = slices.Clone(src.RBAC.SuperAdmins)
+	dst.RBAC.LoadDomains = slices.Clone(src.RBAC.LoadDomains)
+	if src.RBAC.ActionHierarchy != nil {
+		dst.RBAC.ActionHierarchy = make(map[string][]string, len(src.RBAC.ActionHierarchy))
+		for act, implied := range src.RBAC.ActionHierarchy {
+			dst.RBAC.ActionHierarchy[act] = slices.Clone(implied)
+		}
 	}
-	// 拷贝 slice
-	copy(dst.I18n.Supported, src.I18n.Supported)
-	return dst
+	dst.Executor.Pools = slices.Clone(src.Executor.Pools)
+	for i, pool := range dst.Executor.Pools {
+		if pool.RateLimit != nil {
+			rl := *pool.RateLimit
+			dst.Executor.Pools[i].RateLimit = &rl
+		}
+	}
+
+	// 拷贝功能开关
+	dst.Features = src.Features.Clone()
+	return &dst
 }
 
 // RegisterHook 注册配置变更钩子
@@ -549,8 +810,9 @@ func (m *manager) Watch() error {
 // Shadow Loading 模式:
 //  1. 使用临时 viper 实例加载新配置
 //  2. 验证新配置
-//  3. 如果验证通过,替换当前配置
-//  4. 如果验证失败,保持当前配置不变
+//  3. 如果验证通过,调用变化配置段的重载函数
+//  4. 如果重载成功,替换当前配置
+//  5. 如果验证或重载失败,保持当前配置不变
 //
 // 好处:
 //   - 避免加载无效配置导致应用崩溃
@@ -564,52 +826,40 @@ func (m *manager) handleConfigChange(e fsnotify.Event) {
 		m.log.Info("config file changed", "file", e.Name, "op", e.Op.String())
 	}
 
-	// Shadow loading: 加载到临时配置中
-	// 使用新的 viper 实例,避免影响当前配置
-	tempViper := viper.New()
-	tempViper.SetConfigFile(m.configPath)
-
-	// 读取变更后的配置文件
-	if err := tempViper.ReadInConfig(); err != nil {
+	// Shadow loading: 使用临时 viper 实例加载并验证新配置
+	// 任意一步失败时保持当前配置不变
+	tempViper, newCfg, err := m.shadowLoad(m.configPath, m.configFormat)
+	if err != nil {
 		if m.log != nil {
-			m.log.Error("failed to read changed config", "error", err)
+			m.log.Error("failed to load changed config, keeping current config", "error", err)
 		}
 		return
 	}
 
-	// 处理环境变量替换
-	m.processEnvSubstitutionForViper(tempViper)
-
-	// 反序列化到临时配置
-	newCfg := &Config{}
-	if err := tempViper.Unmarshal(newCfg); err != nil {
-		if m.log != nil {
-			m.log.Error("failed to unmarshal changed config", "error", err)
-		}
-		return
-	}
+	// 获取旧配置用于钩子通知
+	oldCfg := m.Get()
 
-	// 验证新配置
-	// 如果验证失败,保持当前配置不变
-	if err := newCfg.Validate(); err != nil {
+	// 只重载变化的配置段
+	// 任意组件重载失败时保持当前配置不变
+	if err := m.runReloaders(oldCfg, newCfg); err != nil {
 		if m.log != nil {
-			m.log.Error("changed config validation failed, keeping current config", "error", err)
+			m.log.Error("failed to reload components, keeping current config", "error", err)
 		}
 		return
 	}
 
-	// 获取旧配置用于钩子通知
-	oldCfg := m.Get()
-
 	// 原子切换配置
 	// 从这一刻起,Get() 会返回新配置
 	m.config.Store(newCfg)
 
+	m.version.Add(1)
+
 	// 更新主 viper 实例
 	m.v = tempViper
 
-	// 通知所有钩子配置已更新
+	// 通知所有钩子和订阅者配置已更新
 	m.notifyHooks(oldCfg, newCfg)
+	m.notifySubscribers(newCfg)
 
 	if m.log != nil {
 		m.log.Info("config reloaded successfully")
@@ -620,13 +870,29 @@ func (m *manager) handleConfigChange(e fsnotify.Event) {
 // 参数:
 //
 //	v: viper 实例
-func (m *manager) processEnvSubstitutionForViper(v *viper.Viper) {
+//
+// 返回:
+//
+//	error: 密钥解析失败时的错误,此时不修改 viper 中的值
+func (m *manager) processEnvSubstitutionForViper(v *viper.Viper) error {
+	// 编译正则表达式匹配 ${VAR:default} 格式
+	// 捕获组:
+	//   1: 变量名
+	//   2: 默认值(可选),对 ${secret:path} 而言是密钥路径
 	envPattern := regexp.MustCompile(`\$\{([^}:]+)(?::([^}]*))?\}`)
+
+	// 获取所有配置项并递归处理
 	settings := v.AllSettings()
-	processed := m.processMap(settings, envPattern)
+	processed, err := m.processMap(settings, envPattern)
+	if err != nil {
+		return err
+	}
+
+	// 将处理后的值设置回 viper
 	for key, value := range processed {
 		v.Set(key, value)
 	}
+	return nil
 }
 
 // GetConfigDir 返回配置文件所在的目录
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrReloadFailed 组件重载失败
+// 配置已回滚到变更前的版本
+var ErrReloadFailed = errors.New("config reload failed")
+
+// ReloadHandler 配置段变化时调用的重载函数
+// 参数:
+//
+//	new: 新配置
+//
+// 返回:
+//
+//	error: 重载失败时的错误,返回错误会使整个配置变更回滚
+type ReloadHandler func(new *Config) error
+
+// sectionReloader 绑定到配置段的重载函数
+type sectionReloader struct {
+	// section 配置段路径,如 "redis" 或 "logger.level"
+	section string
+
+	// reload 重载函数
+	reload ReloadHandler
+}
+
+// RegisterReloader 注册配置段重载函数
+// 参数:
+//
+//	section: 配置段路径,使用 mapstructure 标签名,如 "redis"、"database"
+//	reloader: 重载函数
+//
+// 线程安全:
+//
+//	使用写锁保护 reloaders 列表
+func (m *manager) RegisterReloader(section string, reloader ReloadHandler) {
+	m.hooksMu.Lock()
+	defer m.hooksMu.Unlock()
+	m.reloaders = append(m.reloaders, sectionReloader{section: section, reload: reloader})
+}
+
+// runReloaders 按注册顺序调用变化配置段对应的重载函数
+// 流程:
+//  1. 使用 Diff 计算变化的字段
+//  2. 依次调用 section 发生变化的重载函数
+//  3. 任意一个失败时,以旧配置重新调用已成功的重载函数,把组件恢复到变更前的状态
+//
+// 参数:
+//
+//	old: 旧配置
+//	new: 新配置
+//
+// 返回:
+//
+//	error: 重载失败时返回包装了 ErrReloadFailed 的错误,调用方不应再切换配置
+func (m *manager) runReloaders(old, new *Config) error {
+	m.hooksMu.RLock()
+	defer m.hooksMu.RUnlock()
+	if len(m.reloaders) == 0 {
+		return nil
+	}
+
+	diff := Diff(old, new)
+	done := make([]sectionReloader, 0, len(m.reloaders))
+	for _, r := range m.reloaders {
+		if !diff.Contains(r.section) {
+			continue
+		}
+		if err := r.reload(new); err != nil {
+			m.rollbackReloaders(old, done)
+			return fmt.Errorf("%w: %s: %v", ErrReloadFailed, r.section, err)
+		}
+		done = append(done, r)
+	}
+	return nil
+}
+
+// rollbackReloaders 以旧配置逆序重新调用已成功的重载函数
+// 回滚是尽力而为的,失败只记录日志
+func (m *manager) rollbackReloaders(old *Config, done []sectionReloader) {
+	if old == nil {
+		return
+	}
+	for i := len(done) - 1; i >= 0; i-- {
+		if err := done[i].reload(old); err != nil && m.log != nil {
+			m.log.Error("failed to roll back config section", "section", done[i].section, "error", err)
+		}
+	}
+}
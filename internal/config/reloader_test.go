@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// TestRegisterReloader 测试热重载只调用变化配置段的重载函数,失败时回滚
+func TestRegisterReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	write := func(content string) {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	write(formatTestYAML)
+
+	m := NewManager().(*manager)
+	if err := m.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	var calls []string
+	var failLogger bool
+	m.RegisterReloader("server", func(new *Config) error {
+		calls = append(calls, "server:"+new.Server.Mode)
+		return nil
+	})
+	m.RegisterReloader("logger", func(new *Config) error {
+		calls = append(calls, "logger:"+new.Logger.Level)
+		if failLogger && new.Logger.Level == "warn" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	m.RegisterReloader("database", func(new *Config) error {
+		calls = append(calls, "database")
+		return nil
+	})
+	var hooked int
+	m.RegisterHook(func(old, new *Config) { hooked++ })
+
+	// 只修改日志级别:只调用 logger 的重载函数
+	write(strings.Replace(formatTestYAML, "level: info", "level: debug", 1))
+	m.handleConfigChange(fsnotify.Event{Name: path, Op: fsnotify.Write})
+	if got := strings.Join(calls, ","); got != "logger:debug" {
+		t.Errorf("calls = %q, want %q", got, "logger:debug")
+	}
+	if m.Get().Logger.Level != "debug" || hooked != 1 {
+		t.Errorf("config not applied: level=%q hooked=%d", m.Get().Logger.Level, hooked)
+	}
+
+	// logger 重载失败:已重载的 server 以旧配置回滚,配置保持不变
+	calls = nil
+	failLogger = true
+	version := m.Version()
+	changed := strings.Replace(formatTestYAML, "level: info", "level: warn", 1)
+	write(strings.Replace(changed, "mode: release", "mode: debug", 1))
+	m.handleConfigChange(fsnotify.Event{Name: path, Op: fsnotify.Write})
+	if got, want := strings.Join(calls, ","), "server:debug,logger:warn,server:release"; got != want {
+		t.Errorf("calls = %q, want %q", got, want)
+	}
+	if cfg := m.Get(); cfg.Logger.Level != "debug" || cfg.Server.Mode != "release" {
+		t.Errorf("config changed after failed reload: level=%q mode=%q", cfg.Logger.Level, cfg.Server.Mode)
+	}
+	if m.Version() != version || hooked != 1 {
+		t.Errorf("failed reload should not bump version or notify hooks: version=%d hooked=%d", m.Version(), hooked)
+	}
+
+	// Update 返回 ErrReloadFailed
+	err := m.Update(func(cfg *Config) { cfg.Logger.Level = "warn" })
+	if !errors.Is(err, ErrReloadFailed) {
+		t.Errorf("Update error = %v, want ErrReloadFailed", err)
+	}
+}
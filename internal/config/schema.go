@@ -0,0 +1,321 @@
+package config
+
+import (
+	"embed"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configSources 配置结构体的源码
+// 构建时嵌入,运行时解析字段注释作为配置项说明,注释即文档,无需重复维护
+//
+//go:embed config.go app_*.go
+var configSources embed.FS
+
+var (
+	// fieldDocsOnce 保证源码只解析一次
+	fieldDocsOnce sync.Once
+
+	// fieldDocs 字段注释,key 为 "结构体名.字段名"
+	fieldDocs map[string][]string
+)
+
+// durationType time.Duration 的反射类型
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ConfigKey 描述一个配置项
+// 由 Manager.Schema 返回,用于生成文档和校验配置文件是否完整
+type ConfigKey struct {
+	// Path 配置项路径,由 mapstructure 标签以 "." 连接
+	// 列表元素的字段以 "[]" 标记,例如 "executor.pools[].name"
+	Path string
+
+	// Type 配置项类型
+	// 可选值: string、int、uint、float、bool、duration、list、object,
+	// 以及 []string、map[string]bool 等复合类型
+	Type string
+
+	// Default 默认值(来自 default 标签),没有默认值时为空
+	Default string
+
+	// Required 是否必填
+	// 所属模块必须配置(ValidateRequired)且字段标记了 required 标签时为 true
+	Required bool
+
+	// Description 配置项说明,取自字段注释的第一行
+	Description string
+}
+
+// Schema 返回所有配置项的描述
+// 实现 Manager 接口
+// 按 Config 结构体的字段顺序排列,结构体递归展开
+func (m *manager) Schema() []ConfigKey {
+	var keys []ConfigKey
+	walkSchema("", reflect.TypeOf(Config{}), true, func(key ConfigKey) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// GenerateExampleConfig 生成带注释的示例配置文件(YAML)
+// 每个配置项前输出完整的字段注释,值为默认值,没有默认值时为类型的零值
+// 列表类型的结构体字段输出一个示例元素
+// 返回:
+//
+//	[]byte: YAML 内容
+//	error: 编码失败时的错误
+//
+// 使用示例:
+//
+//	data, err := config.GenerateExampleConfig()
+//	if err != nil {
+//	    return err
+//	}
+//	os.WriteFile("configs/config.example.yaml", data, 0644)
+func GenerateExampleConfig() ([]byte, error) {
+	root := exampleNode(reflect.TypeOf(Config{}), true)
+
+	var sb strings.Builder
+	enc := yaml.NewEncoder(&sb)
+	enc.SetIndent(2)
+	if err := enc.Encode(root); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return []byte(sb.String()), nil
+}
+
+// walkSchema 递归遍历结构体字段
+// 参数:
+//
+//	path: 父路径
+//	t: 结构体类型
+//	required: 父级是否必须配置
+//	fn: 对每个配置项调用
+func walkSchema(path string, t reflect.Type, required bool, fn func(key ConfigKey)) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		ft := field.Type
+		key := ConfigKey{
+			Path:     joinFieldPath(path, fieldName(field)),
+			Type:     schemaType(ft),
+			Default:  field.Tag.Get("default"),
+			Required: required && field.Tag.Get("required") == "true",
+		}
+		if docs := fieldDoc(t, field); len(docs) > 0 {
+			key.Description = docs[0]
+		}
+		fn(key)
+
+		// 顶层字段的必填性由模块的 ValidateRequired 决定
+		childRequired := required
+		if path == "" {
+			childRequired = sectionRequired(ft)
+		}
+
+		switch elem := derefType(ft); {
+		case elem.Kind() == reflect.Struct && elem != durationType:
+			walkSchema(key.Path, elem, childRequired, fn)
+		case elem.Kind() == reflect.Slice && derefType(elem.Elem()).Kind() == reflect.Struct:
+			walkSchema(key.Path+"[]", derefType(elem.Elem()), childRequired, fn)
+		}
+	}
+}
+
+// sectionRequired 判断顶层模块是否必须配置
+// 模块实现了 Validator 接口时使用 ValidateRequired 的结果,否则视为可选
+func sectionRequired(t reflect.Type) bool {
+	if v, ok := reflect.New(t).Interface().(Validator); ok {
+		return v.ValidateRequired()
+	}
+	return false
+}
+
+// derefType 去掉指针
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// schemaType 返回配置项的类型名称
+func schemaType(t reflect.Type) string {
+	t = derefType(t)
+	if t == durationType {
+		return "duration"
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "int"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "uint"
+	case reflect.Float32, reflect.Float64:
+		return "float"
+	case reflect.Struct:
+		return "object"
+	case reflect.Slice:
+		if derefType(t.Elem()).Kind() == reflect.Struct {
+			return "list"
+		}
+		return "[]" + schemaType(t.Elem())
+	case reflect.Map:
+		return "map[" + schemaType(t.Key()) + "]" + schemaType(t.Elem())
+	default:
+		return t.Kind().String()
+	}
+}
+
+// exampleNode 构建结构体对应的 YAML 映射节点
+// 参数:
+//
+//	t: 结构体类型
+//	top: 是否为顶层 Config
+func exampleNode(t reflect.Type, top bool) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: fieldName(field)}
+		keyNode.HeadComment = strings.Join(fieldDoc(t, field), "\n")
+		if field.Tag.Get("required") == "true" {
+			keyNode.LineComment = "必填"
+		}
+
+		node.Content = append(node.Content, keyNode, exampleValue(field.Type, field.Tag.Get("default")))
+	}
+
+	// 顶层模块之间空一行,便于阅读
+	if top {
+		for i := 2; i < len(node.Content); i += 2 {
+			node.Content[i].HeadComment = "\n" + node.Content[i].HeadComment
+		}
+	}
+	return node
+}
+
+// exampleValue 构建字段值对应的 YAML 节点
+func exampleValue(t reflect.Type, def string) *yaml.Node {
+	t = derefType(t)
+	if t == durationType {
+		if def == "" {
+			def = "0s"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: def}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return exampleNode(t, false)
+	case reflect.Slice:
+		seq := &yaml.Node{Kind: yaml.SequenceNode}
+		if elem := derefType(t.Elem()); elem.Kind() == reflect.Struct {
+			seq.Content = append(seq.Content, exampleNode(elem, false))
+		} else {
+			seq.Style = yaml.FlowStyle
+		}
+		return seq
+	case reflect.Map:
+		return &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+	case reflect.String:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: def}
+	case reflect.Bool:
+		if def == "" {
+			def = "false"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: def}
+	case reflect.Float32, reflect.Float64:
+		if def == "" {
+			def = "0.0"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: def}
+	default:
+		if def == "" {
+			def = "0"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: def}
+	}
+}
+
+// fieldDoc 返回字段注释
+// 去掉注释开头重复的字段名,例如 "Host HTTP服务器地址" -> "HTTP服务器地址"
+func fieldDoc(t reflect.Type, field reflect.StructField) []string {
+	fieldDocsOnce.Do(parseFieldDocs)
+
+	docs := fieldDocs[t.Name()+"."+field.Name]
+	if len(docs) == 0 {
+		return nil
+	}
+
+	out := append([]string(nil), docs...)
+	if rest, ok := strings.CutPrefix(out[0], field.Name+" "); ok {
+		out[0] = strings.TrimSpace(rest)
+	}
+	return out
+}
+
+// parseFieldDocs 解析嵌入的源码,提取结构体字段注释
+// 解析失败的文件被跳过,对应字段没有说明
+func parseFieldDocs() {
+	fieldDocs = make(map[string][]string)
+
+	entries, err := configSources.ReadDir(".")
+	if err != nil {
+		return
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		src, err := configSources.ReadFile(entry.Name())
+		if err != nil {
+			continue
+		}
+		file, err := parser.ParseFile(fset, entry.Name(), src, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			for _, f := range st.Fields.List {
+				if f.Doc == nil {
+					continue
+				}
+				lines := strings.Split(strings.TrimSpace(f.Doc.Text()), "\n")
+				for _, name := range f.Names {
+					fieldDocs[spec.Name.Name+"."+name.Name] = lines
+				}
+			}
+			return false
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// 密钥解析相关错误
+var (
+	// ErrInvalidSecretRef 密钥引用无效(为空或试图访问目录之外的路径)
+	ErrInvalidSecretRef = errors.New("invalid secret reference")
+
+	// ErrSecretNotFound 密钥不存在
+	ErrSecretNotFound = errors.New("secret not found")
+)
+
+// SecretProvider 密钥提供者
+// 用于解析配置中的 ${secret:path/to/key} 引用
+// 为什么需要:
+//   - 密钥不应写在配置文件中,也不应放在环境变量里(会出现在进程信息、崩溃转储中)
+//   - 统一接口,可接入文件、Vault 等不同的密钥存储
+//
+// 实现 Vault 等远程提供者时,Resolve 会在 Load 和热重载时同步调用,应自行控制超时
+type SecretProvider interface {
+	// Resolve 解析密钥引用
+	// 参数:
+	//   ref: 密钥引用,即 ${secret:...} 中的路径部分
+	// 返回:
+	//   string: 密钥值
+	//   error: 引用无效或读取失败时的错误
+	Resolve(ref string) (string, error)
+}
+
+// fileSecretProvider 基于文件的密钥提供者
+// 每个密钥是目录下的一个文件,文件内容即密钥值
+// 对应 Kubernetes Secret 卷挂载和 Docker Secrets(/run/secrets)的用法
+type fileSecretProvider struct {
+	// dir 密钥文件所在目录
+	dir string
+}
+
+// NewFileSecretProvider 创建基于文件的密钥提供者
+// 参数:
+//
+//	dir: 密钥文件目录,如 Kubernetes Secret 卷的挂载路径
+//
+// 返回:
+//
+//	SecretProvider: 密钥提供者
+//
+// 使用示例:
+//
+//	// /etc/secrets/db/password 文件内容为数据库密码
+//	mgr.SetSecretProvider(config.NewFileSecretProvider("/etc/secrets"))
+//	// config.yaml: password: ${secret:db/password}
+func NewFileSecretProvider(dir string) SecretProvider {
+	return &fileSecretProvider{dir: dir}
+}
+
+// Resolve 读取 dir/ref 文件内容作为密钥值
+// 只允许目录内的相对路径,拒绝绝对路径和 ".." 越界访问
+// 去除结尾的换行符(echo 或编辑器写入的文件通常带换行)
+func (p *fileSecretProvider) Resolve(ref string) (string, error) {
+	if ref == "" || !filepath.IsLocal(ref) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSecretRef, ref)
+	}
+
+	data, err := os.ReadFile(filepath.Join(p.dir, ref))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", ErrSecretNotFound, ref)
+		}
+		return "", fmt.Errorf("failed to read secret %s: %w", ref, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// defaultSecretProvider 返回默认的文件密钥提供者
+// 目录优先读取环境变量 REI_APP_SECRETS_DIR,未设置时使用 DefaultSecretsDir
+// 在解析时读取环境变量,使 .env 中的设置也能生效
+func defaultSecretProvider() SecretProvider {
+	dir := os.Getenv(EnvPrefixJoin(EnvSecretsDir))
+	if dir == "" {
+		dir = DefaultSecretsDir
+	}
+	return NewFileSecretProvider(dir)
+}
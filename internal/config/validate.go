@@ -0,0 +1,95 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Validate 检查候选配置文件,不替换当前配置
+// 与热重载使用相同的 Shadow Loading 流程:
+// 临时 viper 实例读取文件、处理环境变量替换和密钥引用、反序列化后调用 Config.Validate
+// 参数:
+//
+//	configPath: 候选配置文件路径
+//	            与已加载的文件相同时沿用加载时的格式,否则根据扩展名推断
+//
+// 返回:
+//
+//	error: 扩展名不受支持时返回 ErrUnsupportedFormat,以及读取、解析或验证失败时的错误
+func (m *manager) Validate(configPath string) error {
+	format := m.configFormat
+	if configPath != m.configPath || format == "" {
+		detected, err := DetectFormat(configPath)
+		if err != nil {
+			return err
+		}
+		format = detected
+	}
+
+	_, _, err := m.shadowLoad(configPath, format)
+	return err
+}
+
+// DryRunUpdate 检查更新后的配置是否有效,不替换当前配置
+// 参数:
+//
+//	fn: 更新函数,接收配置副本并修改,与 Update 相同
+//
+// 返回:
+//
+//	error: 配置未加载或验证失败时的错误
+//
+// 注意:
+//
+//	不调用重载函数和钩子,组件重载可能出现的错误无法提前发现
+func (m *manager) DryRunUpdate(fn func(*Config)) error {
+	oldCfg := m.Get()
+	if oldCfg == nil {
+		return fmt.Errorf("configuration not loaded")
+	}
+
+	newCfg := m.copyConfig(oldCfg)
+	fn(newCfg)
+
+	if err := newCfg.Validate(); err != nil {
+		return fmt.Errorf("config validation failed: %w", err)
+	}
+	return nil
+}
+
+// shadowLoad 使用临时 viper 实例加载并验证配置,不影响当前配置
+// 参数:
+//
+//	configPath: 配置文件路径
+//	format: 配置格式
+//
+// 返回:
+//
+//	*viper.Viper: 已加载配置的临时 viper 实例
+//	*Config: 验证通过的配置
+//	error: 读取、环境变量替换、反序列化或验证失败时的错误
+func (m *manager) shadowLoad(configPath string, format ConfigFormat) (*viper.Viper, *Config, error) {
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.SetConfigType(string(format))
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// 处理环境变量替换和密钥引用
+	if err := m.processEnvSubstitutionForViper(v); err != nil {
+		return nil, nil, fmt.Errorf("failed to process env substitution: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := v.Unmarshal(cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("config validation failed: %w", err)
+	}
+	return v, cfg, nil
+}
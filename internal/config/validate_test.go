@@ -0,0 +1,77 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestValidate 测试检查候选配置文件不会替换当前配置
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte(formatTestYAML), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	m := NewManager().(*manager)
+	if err := m.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	version := m.Version()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte(strings.Replace(formatTestYAML, "level: info", "level: debug", 1)), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := m.Validate(valid); err != nil {
+		t.Errorf("Validate(valid) = %v, want nil", err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte(strings.Replace(formatTestYAML, "level: info", "level: verbose", 1)), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := m.Validate(invalid); err == nil {
+		t.Error("Validate(invalid) = nil, want error")
+	}
+
+	if err := m.Validate(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Error("Validate(missing) = nil, want error")
+	}
+
+	if m.Get().Logger.Level != "info" || m.Version() != version {
+		t.Errorf("Validate changed current config: level=%q version=%d", m.Get().Logger.Level, m.Version())
+	}
+}
+
+// TestDryRunUpdate 测试 DryRunUpdate 只验证不替换
+func TestDryRunUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(formatTestYAML), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	m := NewManager().(*manager)
+	if err := m.Load(path); err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	var reloaded bool
+	m.RegisterReloader("logger", func(new *Config) error {
+		reloaded = true
+		return nil
+	})
+	version := m.Version()
+
+	if err := m.DryRunUpdate(func(cfg *Config) { cfg.Logger.Level = "debug" }); err != nil {
+		t.Errorf("DryRunUpdate(valid) = %v, want nil", err)
+	}
+	if err := m.DryRunUpdate(func(cfg *Config) { cfg.Logger.Level = "verbose" }); err == nil {
+		t.Error("DryRunUpdate(invalid) = nil, want error")
+	}
+
+	if m.Get().Logger.Level != "info" || m.Version() != version || reloaded {
+		t.Errorf("DryRunUpdate changed state: level=%q version=%d reloaded=%v", m.Get().Logger.Level, m.Version(), reloaded)
+	}
+}
@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"strings"
+	"errors"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rei0721/go-scaffold/pkg/jwt"
@@ -30,39 +30,36 @@ import (
 // 工作流程:
 //  1. 从请求头获取 Authorization 字段
 //  2. 验证 Bearer token 格式
-//  3. 验证 token 有效性
+//  3. 验证 token 有效性,绑定了 IP/设备的令牌与当前请求的 IP 和 X-Device-ID 比对
 //  4. 将用户信息存入上下文
 //  5. 调用下一个处理器
 func AuthMiddleware(jwtManager jwt.JWT) gin.HandlerFunc {
+	extractor := jwt.FromHeader()
+
 	return func(c *gin.Context) {
 		// 1. 从请求头获取 token
 		// 标准HTTP认证头格式: Authorization: Bearer <token>
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			// 缺少认证头,返回401未授权
-			result.Unauthorized(c, "Missing authorization header")
-			c.Abort()
-			return
-		}
-
-		// 2. 验证 Bearer 格式
-		// JWT标准要求使用 "Bearer " 前缀
-		// 使用 SplitN 限制分割次数为2,防止token中包含空格导致解析错误
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			// 格式错误,返回401未授权
-			result.Unauthorized(c, "Invalid authorization format")
+		tokenString, err := extractor.ExtractToken(c.Request)
+		if err != nil {
+			if errors.Is(err, jwt.ErrTokenNotFound) {
+				// 缺少认证头,返回401未授权
+				result.Unauthorized(c, "Missing authorization header")
+			} else {
+				// 2. Bearer 格式错误,返回401未授权
+				result.Unauthorized(c, "Invalid authorization format")
+			}
 			c.Abort()
 			return
 		}
 
 		// 3. 验证 token
-		// 提取token字符串（去除"Bearer "前缀）
-		tokenString := parts[1]
-		claims, err := jwtManager.ValidateToken(tokenString)
+		// 使用 ValidateTokenBound,否则绑定的令牌在其他 IP 或设备上也能通过
+		claims, err := jwtManager.ValidateTokenBound(tokenString, c.ClientIP(), c.GetHeader(jwt.DeviceIDHeader))
 		if err != nil {
 			// Token验证失败（无效、过期、签名错误等）
 			// 统一返回401,不泄露具体原因给客户端
+			// 具体错误附加到 c.Errors,服务端可用 errors.Is 区分 jwt.ErrTokenExpired 等
+			_ = c.Error(err)
 			result.Unauthorized(c, "Invalid or expired token")
 			c.Abort()
 			return
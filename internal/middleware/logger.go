@@ -65,7 +65,7 @@ func Logger(cfg LoggerConfig, log logger.Logger) gin.HandlerFunc {
 
 		// 记录请求详细信息
 		// 使用结构化日志,便于日志分析和监控系统解析
-		log.Info("request completed",
+		fields := []interface{}{
 			"method", c.Request.Method, // HTTP 方法(GET/POST/PUT等)
 			"path", path, // 请求路径
 			"status", c.Writer.Status(), // HTTP 响应状态码
@@ -73,6 +73,13 @@ func Logger(cfg LoggerConfig, log logger.Logger) gin.HandlerFunc {
 			"durationMs", duration.Milliseconds(), // 耗时的毫秒数,便于监控系统计算
 			"clientIP", c.ClientIP(), // 客户端 IP 地址
 			"traceId", traceID, // 追踪 ID,用于请求链路追踪
-		)
+		}
+
+		// 处理器通过 c.Error 附加的错误只写入日志,不返回给客户端
+		// 如认证中间件附加的令牌验证失败原因(过期、签名无效等)
+		if len(c.Errors) > 0 {
+			fields = append(fields, "errors", c.Errors.Errors())
+		}
+		log.Info("request completed", fields...)
 	}
 }
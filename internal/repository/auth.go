@@ -44,7 +44,7 @@ type AuthRepository interface {
 	// 用于用户注册
 	// 参数:
 	//   ctx: 上下文
-	//   tx: GORM事务对象
+	//   tx: GORM事务对象，为 nil 时使用 ctx 中的事务（见 database.DBFromContext）或默认连接
 	//   user: 要创建的用户
 	// 返回:
 	//   error: 创建失败的错误
@@ -54,7 +54,7 @@ type AuthRepository interface {
 	// 用于修改密码功能
 	// 参数:
 	//   ctx: 上下文
-	//   tx: GORM事务对象
+	//   tx: GORM事务对象，为 nil 时使用 ctx 中的事务（见 database.DBFromContext）或默认连接
 	//   userID: 用户ID
 	//   hashedPassword: 新的加密密码
 	// 返回:
@@ -65,7 +65,7 @@ type AuthRepository interface {
 	// 用于更新用户相关信息
 	// 参数:
 	//   ctx: 上下文
-	//   tx: GORM事务对象
+	//   tx: GORM事务对象，为 nil 时使用 ctx 中的事务（见 database.DBFromContext）或默认连接
 	//   user: 要更新的用户对象
 	// 返回:
 	//   error: 更新失败的错误
@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/rei0721/go-scaffold/internal/models"
+	"github.com/rei0721/go-scaffold/pkg/database"
 	"gorm.io/gorm"
 )
 
@@ -29,7 +30,7 @@ func NewAuthRepository(db *gorm.DB) AuthRepository {
 // FindUserByUsername 根据用户名查找用户
 func (r *authRepository) FindUserByUsername(ctx context.Context, username string) (*models.DBUser, error) {
 	var user models.DBUser
-	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	err := database.DBFromContext(ctx, r.db).Where("username = ?", username).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // 用户不存在，返回nil而非错误
@@ -42,7 +43,7 @@ func (r *authRepository) FindUserByUsername(ctx context.Context, username string
 // FindUserByEmail 根据邮箱查找用户
 func (r *authRepository) FindUserByEmail(ctx context.Context, email string) (*models.DBUser, error) {
 	var user models.DBUser
-	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	err := database.DBFromContext(ctx, r.db).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // 用户不存在，返回nil而非错误
@@ -55,7 +56,7 @@ func (r *authRepository) FindUserByEmail(ctx context.Context, email string) (*mo
 // FindUserByID 根据ID查找用户
 func (r *authRepository) FindUserByID(ctx context.Context, userID int64) (*models.DBUser, error) {
 	var user models.DBUser
-	err := r.db.WithContext(ctx).First(&user, userID).Error
+	err := database.DBFromContext(ctx, r.db).First(&user, userID).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil // 用户不存在，返回nil而非错误
@@ -65,14 +66,23 @@ func (r *authRepository) FindUserByID(ctx context.Context, userID int64) (*model
 	return &user, nil
 }
 
+// dbFor 返回写操作使用的数据库实例
+// 显式传入的 tx 优先，否则使用 ctx 中的事务或默认连接
+func (r *authRepository) dbFor(ctx context.Context, tx *gorm.DB) *gorm.DB {
+	if tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return database.DBFromContext(ctx, r.db)
+}
+
 // CreateUser 创建新用户（在事务中）
 func (r *authRepository) CreateUser(ctx context.Context, tx *gorm.DB, user *models.DBUser) error {
-	return tx.WithContext(ctx).Create(user).Error
+	return r.dbFor(ctx, tx).Create(user).Error
 }
 
 // UpdateUserPassword 更新用户密码（在事务中）
 func (r *authRepository) UpdateUserPassword(ctx context.Context, tx *gorm.DB, userID int64, hashedPassword string) error {
-	return tx.WithContext(ctx).
+	return r.dbFor(ctx, tx).
 		Model(&models.DBUser{}).
 		Where("id = ?", userID).
 		Update("password", hashedPassword).
@@ -81,5 +91,5 @@ func (r *authRepository) UpdateUserPassword(ctx context.Context, tx *gorm.DB, us
 
 // UpdateUser 更新用户信息（在事务中）
 func (r *authRepository) UpdateUser(ctx context.Context, tx *gorm.DB, user *models.DBUser) error {
-	return tx.WithContext(ctx).Save(user).Error
+	return r.dbFor(ctx, tx).Save(user).Error
 }
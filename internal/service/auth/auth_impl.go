@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	stdErrors "errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -10,6 +11,8 @@ import (
 	"github.com/rei0721/go-scaffold/internal/models"
 	"github.com/rei0721/go-scaffold/internal/repository"
 	"github.com/rei0721/go-scaffold/internal/service"
+	"github.com/rei0721/go-scaffold/pkg/crypto"
+	"github.com/rei0721/go-scaffold/pkg/jwt"
 	"github.com/rei0721/go-scaffold/types"
 	"github.com/rei0721/go-scaffold/types/constants"
 	"github.com/rei0721/go-scaffold/types/errors"
@@ -105,7 +108,8 @@ func (s *authService) Register(ctx context.Context, req *types.RegisterRequest)
 	if c := s.GetCache(); c != nil {
 		if exec := s.GetExecutor(); exec != nil {
 			userCopy := *user
-			_ = exec.Execute(constants.AppPoolCache, func() {
+			// 并发请求重复预热同一用户时只执行一次
+			_ = exec.ExecuteDedup(constants.AppPoolCache, fmt.Sprintf("prewarm:user:%d", userCopy.ID), func() {
 				key := fmt.Sprintf("user:%d", userCopy.ID)
 				if data, err := json.Marshal(userCopy); err == nil {
 					_ = c.Set(context.Background(), key, string(data), 1*time.Hour)
@@ -167,8 +171,9 @@ func (s *authService) Login(ctx context.Context, req *types.LoginRequest) (*type
 		return nil, errors.NewBizError(errors.ErrUserNotFound, "user not found")
 	}
 
-	// 2. 验证密码
-	if err := s.Crypto.VerifyPassword(user.Password, req.Password); err != nil {
+	// 2. 验证密码,哈希参数过时时同时计算新哈希
+	newHash, err := s.verifyAndUpgradePassword(user.Password, req.Password)
+	if err != nil {
 		if log := s.GetLogger(); log != nil {
 			log.Warn("login failed: invalid password", "username", req.Username)
 		}
@@ -183,6 +188,18 @@ func (s *authService) Login(ctx context.Context, req *types.LoginRequest) (*type
 		return nil, errors.NewBizError(errors.ErrUnauthorized, "user is inactive")
 	}
 
+	// 3.1 透明升级密码哈希
+	// 写入失败不影响登录,下次登录时重试
+	if newHash != "" {
+		if err := s.Repo.UpdateUserPassword(ctx, nil, user.ID, newHash); err != nil {
+			if log := s.GetLogger(); log != nil {
+				log.Warn("failed to upgrade password hash", "userId", user.ID, "error", err)
+			}
+		} else if log := s.GetLogger(); log != nil {
+			log.Info("password hash upgraded", "userId", user.ID)
+		}
+	}
+
 	// 4. 记录登录成功
 	if log := s.GetLogger(); log != nil {
 		log.Info("user logged in successfully", "userId", user.ID, "username", user.Username)
@@ -208,7 +225,8 @@ func (s *authService) Login(ctx context.Context, req *types.LoginRequest) (*type
 	if c := s.GetCache(); c != nil {
 		if exec := s.GetExecutor(); exec != nil {
 			userCopy := *user
-			_ = exec.Execute(constants.AppPoolCache, func() {
+			// 并发请求重复预热同一用户时只执行一次
+			_ = exec.ExecuteDedup(constants.AppPoolCache, fmt.Sprintf("prewarm:user:%d", userCopy.ID), func() {
 				key := fmt.Sprintf("user:%d", userCopy.ID)
 				if data, err := json.Marshal(userCopy); err == nil {
 					_ = c.Set(context.Background(), key, string(data), 1*time.Hour)
@@ -217,27 +235,30 @@ func (s *authService) Login(ctx context.Context, req *types.LoginRequest) (*type
 		}
 	}
 
-	// 7. 生成访问令牌
-	var token string
-	var expiresIn int
+	// 7. 生成访问令牌和刷新令牌
+	// 有效期由 jwt 配置决定,expiresIn 从签发的令牌读取
+	var token, refreshToken string
+	expiresIn := jwt.DefaultExpiresIn
 
 	if jwtManager := s.GetJWT(); jwtManager != nil {
 		var err error
 		token, err = jwtManager.GenerateToken(user.ID, user.Username)
+		if err == nil {
+			refreshToken, err = jwtManager.GenerateRefreshToken(user.ID, user.Username)
+		}
 		if err != nil {
 			if log := s.GetLogger(); log != nil {
 				log.Error("failed to generate JWT token", "error", err, "userId", user.ID)
 			}
 			return nil, errors.NewBizError(errors.ErrInternalServer, "failed to generate token").WithCause(err)
 		}
-		expiresIn = 3600 // 默认 1 小时，应该从配置读取
+		expiresIn = tokenExpiresIn(jwtManager, token)
 	} else {
 		// 降级处理
 		if log := s.GetLogger(); log != nil {
 			log.Warn("JWT manager not injected, using placeholder token")
 		}
 		token = "placeholder-jwt-token"
-		expiresIn = 3600
 	}
 
 	// 8. 缓存 token（可选，用于 token 黑名单等功能）
@@ -254,9 +275,10 @@ func (s *authService) Login(ctx context.Context, req *types.LoginRequest) (*type
 
 	// 9. 返回登录响应
 	return &types.LoginResponse{
-		Token:     token,
-		ExpiresIn: expiresIn,
-		User:      *toUserResponse(user),
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    expiresIn,
+		User:         *toUserResponse(user),
 	}, nil
 }
 
@@ -345,37 +367,56 @@ func (s *authService) RefreshToken(ctx context.Context, req *types.RefreshTokenR
 		return nil, errors.NewBizError(errors.ErrInternalServer, "JWT manager not available")
 	}
 
-	// 2. 验证并提取 token 信息
-	claims, err := jwtManager.ValidateToken(req.RefreshToken)
+	// 2. 轮换 refresh token（refresh token rotation）
+	// 旧 refresh token 随即作废,被重放时整个令牌家族会被撤销
+	accessToken, newRefreshToken, err := jwtManager.RotateRefreshToken(req.RefreshToken)
 	if err != nil {
-		if log := s.GetLogger(); log != nil {
-			log.Warn("refresh token validation failed", "error", err)
+		// 过期是会话正常结束,客户端需要重新登录;
+		// 格式错误、签名无效和重放可能是攻击,记录警告
+		if stdErrors.Is(err, jwt.ErrTokenExpired) {
+			if log := s.GetLogger(); log != nil {
+				log.Info("refresh token expired")
+			}
+			return nil, errors.NewBizError(errors.ErrTokenExpired, "refresh token expired").WithCause(err)
 		}
-		return nil, errors.NewBizError(errors.ErrUnauthorized, "invalid refresh token").WithCause(err)
-	}
-
-	// 3. 生成新的 access token
-	accessToken, err := jwtManager.GenerateToken(claims.UserID, claims.Username)
-	if err != nil {
 		if log := s.GetLogger(); log != nil {
-			log.Error("failed to generate new access token", "error", err, "userId", claims.UserID)
+			if stdErrors.Is(err, jwt.ErrRefreshTokenReused) {
+				log.Warn("refresh token reuse detected, token family revoked", "error", err)
+			} else {
+				log.Warn("refresh token validation failed", "error", err)
+			}
 		}
-		return nil, errors.NewBizError(errors.ErrInternalServer, "failed to generate token").WithCause(err)
+		return nil, errors.NewBizError(errors.ErrUnauthorized, "invalid refresh token").WithCause(err)
 	}
 
-	// 4. 可选：生成新的 refresh token（refresh token rotation）
-	// 这里暂时不实现，使用原 refresh token
-	// newRefreshToken, err := jwtManager.GenerateRefreshToken(claims.UserID, claims.Username)
-
-	// 5. 返回新的 token 响应
+	// 3. 返回新的 token 响应
 	return &types.TokenResponse{
 		AccessToken:  accessToken,
-		RefreshToken: req.RefreshToken, // 保持原 refresh token
-		ExpiresIn:    3600,             // 应该从配置读取
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    tokenExpiresIn(jwtManager, accessToken),
 		TokenType:    "Bearer",
 	}, nil
 }
 
+// verifyAndUpgradePassword 验证密码并在哈希过时时返回新哈希
+// 加密器未实现 crypto.Rehasher 时只验证密码,新哈希始终为空
+func (s *authService) verifyAndUpgradePassword(hashedPassword, password string) (string, error) {
+	if r, ok := s.Crypto.(crypto.Rehasher); ok {
+		return r.VerifyAndUpgrade(hashedPassword, password)
+	}
+	return "", s.Crypto.VerifyPassword(hashedPassword, password)
+}
+
+// tokenExpiresIn 返回访问令牌的剩余有效期(秒),向上取整
+// 登录和刷新都从签发的令牌读取,响应中的 expiresIn 与令牌的 exp 声明始终一致
+func tokenExpiresIn(j jwt.JWT, token string) int {
+	d, err := j.TimeUntilExpiry(token)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return int((d + time.Second - 1) / time.Second)
+}
+
 // toUserResponse 将 User 模型转换为 UserResponse
 func toUserResponse(user *models.DBUser) *types.UserResponse {
 	return &types.UserResponse{
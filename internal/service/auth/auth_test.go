@@ -12,6 +12,7 @@ import (
 	"github.com/rei0721/go-scaffold/pkg/crypto"
 	"github.com/rei0721/go-scaffold/pkg/dbtx"
 	jwtpkg "github.com/rei0721/go-scaffold/pkg/jwt"
+	"github.com/rei0721/go-scaffold/pkg/logger"
 	"github.com/rei0721/go-scaffold/pkg/rbac"
 	"github.com/rei0721/go-scaffold/types"
 	appErrors "github.com/rei0721/go-scaffold/types/errors"
@@ -105,6 +106,10 @@ func (m stubTxManager) WithTxOptions(ctx context.Context, opts *dbtx.TxOptions,
 	return fn(nil)
 }
 
+func (m stubTxManager) SavepointSupported() bool {
+	return false
+}
+
 func (m stubTxManager) GetDB() *gorm.DB {
 	return nil
 }
@@ -132,14 +137,50 @@ func (j stubJWT) GenerateToken(userID int64, username string) (string, error) {
 	return "token:" + username, nil
 }
 
+func (j stubJWT) GenerateTokenWithOptions(userID int64, username string, opts ...jwtpkg.TokenOption) (string, error) {
+	return j.GenerateToken(userID, username)
+}
+
 func (j stubJWT) ValidateToken(tokenString string) (*jwtpkg.Claims, error) {
 	return nil, stdErrors.New("not implemented")
 }
 
+func (j stubJWT) ValidateTokenCached(tokenString string) (*jwtpkg.Claims, error) {
+	return j.ValidateToken(tokenString)
+}
+
+func (j stubJWT) ValidateTokenBound(tokenString, ip, deviceID string) (*jwtpkg.Claims, error) {
+	return nil, stdErrors.New("not implemented")
+}
+
 func (j stubJWT) RefreshToken(tokenString string) (string, error) {
 	return "", stdErrors.New("not implemented")
 }
 
+func (j stubJWT) GenerateRefreshToken(userID int64, username string) (string, error) {
+	return "refresh:" + username, nil
+}
+
+func (j stubJWT) RotateRefreshToken(oldRefresh string) (string, string, error) {
+	if oldRefresh == "expired" {
+		return "", "", jwtpkg.ErrTokenExpired
+	}
+	return "", "", jwtpkg.ErrTokenMalformed
+}
+
+func (j stubJWT) TimeUntilExpiry(tokenString string) (time.Duration, error) {
+	// exp 声明精确到秒,签发后剩余有效期略小于配置值
+	return time.Hour - 300*time.Millisecond, nil
+}
+
+func (j stubJWT) ShouldRefresh(tokenString string, threshold time.Duration) (bool, error) {
+	return false, nil
+}
+
+func (j stubJWT) Introspect(tokenString string) (*jwtpkg.Introspection, error) {
+	return nil, stdErrors.New("not implemented")
+}
+
 type stubRBAC struct {
 	mu       sync.Mutex
 	assigned []string
@@ -149,6 +190,17 @@ var _ rbac.RBAC = (*stubRBAC)(nil)
 
 func (s *stubRBAC) Enforce(sub, obj, act string) (bool, error)                { return false, nil }
 func (s *stubRBAC) EnforceWithDomain(sub, dom, obj, act string) (bool, error) { return false, nil }
+func (s *stubRBAC) IsSuperAdmin(sub, dom string) (bool, error)                { return false, nil }
+func (s *stubRBAC) EnforceP(sub rbac.Subject, obj rbac.Object, act rbac.Action) (bool, error) {
+	return false, nil
+}
+func (s *stubRBAC) EnforcePermission(p rbac.Permission) (bool, error) { return false, nil }
+func (s *stubRBAC) Explain(sub, dom, obj, act string) (bool, []string, string, error) {
+	return false, nil, "", nil
+}
+func (s *stubRBAC) EnforceWithContext(sub, obj, act string, attrs map[string]interface{}) (bool, error) {
+	return false, nil
+}
 
 func (s *stubRBAC) AddRoleForUser(user, role string) error {
 	return s.AddRoleForUserInDomain(user, role, "")
@@ -165,9 +217,16 @@ func (s *stubRBAC) DeleteRoleForUserInDomain(user, role, domain string) error
 func (s *stubRBAC) GetRolesForUser(user string) ([]string, error)                 { return nil, nil }
 func (s *stubRBAC) GetRolesForUserInDomain(user, domain string) ([]string, error) { return nil, nil }
 func (s *stubRBAC) GetUsersForRole(role string) ([]string, error)                 { return nil, nil }
+func (s *stubRBAC) GetImplicitUsersForRole(role string) ([]string, error)         { return nil, nil }
+func (s *stubRBAC) GetImplicitUsersForRoleInDomain(role, domain string) ([]string, error) {
+	return nil, nil
+}
+func (s *stubRBAC) GetRoleTree() (map[string][]string, error)                      { return nil, nil }
+func (s *stubRBAC) GetRoleTreeInDomain(domain string) (map[string][]string, error) { return nil, nil }
 
 func (s *stubRBAC) AddPolicy(sub, obj, act string) error                               { return nil }
 func (s *stubRBAC) AddPolicyWithDomain(sub, domain, obj, act string) error             { return nil }
+func (s *stubRBAC) AddConditionalPolicy(sub, domain, obj, act, cond string) error      { return nil }
 func (s *stubRBAC) RemovePolicy(sub, obj, act string) error                            { return nil }
 func (s *stubRBAC) RemovePolicyWithDomain(sub, domain, obj, act string) error          { return nil }
 func (s *stubRBAC) GetPolicy() [][]string                                              { return nil }
@@ -175,9 +234,32 @@ func (s *stubRBAC) GetFilteredPolicy(fieldIndex int, fieldValues ...string) [][]
 func (s *stubRBAC) AddPolicies(rules [][]string) error                                 { return nil }
 func (s *stubRBAC) RemovePolicies(rules [][]string) error                              { return nil }
 func (s *stubRBAC) LoadPolicy() error                                                  { return nil }
+func (s *stubRBAC) LoadFilteredPolicy(filter rbac.Filter) error                        { return nil }
+func (s *stubRBAC) LoadIncrementalFilteredPolicy(filter rbac.Filter) error             { return nil }
+func (s *stubRBAC) IsFiltered() bool                                                   { return false }
 func (s *stubRBAC) SavePolicy() error                                                  { return nil }
 func (s *stubRBAC) ClearCache() error                                                  { return nil }
 func (s *stubRBAC) Close() error                                                       { return nil }
+func (s *stubRBAC) Reload(cfg *rbac.Config) error                                      { return nil }
+
+func (s *stubRBAC) AddRoleForUserCtx(ctx context.Context, user, role string) error { return nil }
+func (s *stubRBAC) AddRoleForUserInDomainCtx(ctx context.Context, user, role, domain string) error {
+	return nil
+}
+func (s *stubRBAC) DeleteRoleForUserCtx(ctx context.Context, user, role string) error { return nil }
+func (s *stubRBAC) DeleteRoleForUserInDomainCtx(ctx context.Context, user, role, domain string) error {
+	return nil
+}
+func (s *stubRBAC) AddPolicyCtx(ctx context.Context, sub, obj, act string) error { return nil }
+func (s *stubRBAC) AddPolicyWithDomainCtx(ctx context.Context, sub, domain, obj, act string) error {
+	return nil
+}
+func (s *stubRBAC) RemovePolicyCtx(ctx context.Context, sub, obj, act string) error { return nil }
+func (s *stubRBAC) RemovePolicyWithDomainCtx(ctx context.Context, sub, domain, obj, act string) error {
+	return nil
+}
+func (s *stubRBAC) AddPoliciesCtx(ctx context.Context, rules [][]string) error    { return nil }
+func (s *stubRBAC) RemovePoliciesCtx(ctx context.Context, rules [][]string) error { return nil }
 
 func TestAuthService_Register_UsernameDuplicate(t *testing.T) {
 	repo := newStubAuthRepo()
@@ -255,9 +337,36 @@ func TestAuthService_Login_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if resp == nil || resp.Token == "" || resp.User.Username != "alice" {
+	if resp == nil || resp.Token == "" || resp.RefreshToken == "" || resp.User.Username != "alice" {
 		t.Fatalf("unexpected response: %#v", resp)
 	}
+	// expiresIn 从签发的令牌读取,不足一秒的部分向上取整
+	if resp.ExpiresIn != 3600 {
+		t.Errorf("ExpiresIn = %d, want 3600", resp.ExpiresIn)
+	}
+}
+
+func TestAuthService_Login_UpgradesPasswordHash(t *testing.T) {
+	legacy, _ := crypto.NewBcrypt(crypto.WithBcryptCost(crypto.MinBcryptCost))
+	hash, _ := legacy.HashPassword("password123")
+
+	repo := newStubAuthRepo()
+	_ = repo.CreateUser(context.Background(), nil, &models.DBUser{Username: "alice", Password: hash, Status: 1})
+
+	current, _ := crypto.NewBcrypt(crypto.WithBcryptCost(crypto.MinBcryptCost + 1))
+	svc := NewAuthService(repo)
+	impl := svc.(*authService)
+	impl.SetCrypto(current)
+	impl.SetJWT(stubJWT{})
+
+	if _, err := svc.Login(context.Background(), &types.LoginRequest{Username: "alice", Password: "password123"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	user, _ := repo.FindUserByUsername(context.Background(), "alice")
+	if user.Password == hash || current.(crypto.Rehasher).NeedsRehash(user.Password) {
+		t.Fatalf("expected password hash to be upgraded, got %q", user.Password)
+	}
 }
 
 func TestAuthService_Login_InvalidPassword(t *testing.T) {
@@ -268,6 +377,8 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	impl := svc.(*authService)
 	impl.SetCrypto(stubCrypto{})
 	impl.SetJWT(stubJWT{})
+	log, sink := logger.NewTestLogger()
+	impl.SetLogger(log)
 
 	_, err := svc.Login(context.Background(), &types.LoginRequest{Username: "alice", Password: "wrong"})
 	if err == nil {
@@ -277,4 +388,32 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 	if !stdErrors.As(err, &biz) || biz.Code != appErrors.ErrUnauthorized {
 		t.Fatalf("expected unauthorized BizError, got %T %v", err, err)
 	}
+	if !sink.Contains(logger.LevelWarn, "login failed: invalid password") {
+		t.Fatalf("expected warn log for invalid password, got %+v", sink.Entries())
+	}
+}
+
+func TestAuthService_RefreshToken_DistinguishesExpired(t *testing.T) {
+	svc := NewAuthService(newStubAuthRepo())
+	impl := svc.(*authService)
+	impl.SetJWT(stubJWT{})
+	log, sink := logger.NewTestLogger()
+	impl.SetLogger(log)
+
+	var biz *appErrors.BizError
+	_, err := svc.RefreshToken(context.Background(), &types.RefreshTokenRequest{RefreshToken: "expired"})
+	if !stdErrors.As(err, &biz) || biz.Code != appErrors.ErrTokenExpired {
+		t.Fatalf("expected token expired BizError, got %T %v", err, err)
+	}
+	if sink.Contains(logger.LevelWarn, "refresh token validation failed") {
+		t.Fatalf("expired refresh token should not log a warning, got %+v", sink.Entries())
+	}
+
+	_, err = svc.RefreshToken(context.Background(), &types.RefreshTokenRequest{RefreshToken: "garbage"})
+	if !stdErrors.As(err, &biz) || biz.Code != appErrors.ErrUnauthorized {
+		t.Fatalf("expected unauthorized BizError, got %T %v", err, err)
+	}
+	if !sink.Contains(logger.LevelWarn, "refresh token validation failed") {
+		t.Fatalf("expected warn log for malformed refresh token, got %+v", sink.Entries())
+	}
 }
@@ -0,0 +1,236 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// BreakerState 熔断器状态
+type BreakerState int
+
+const (
+	// BreakerClosed 关闭状态:正常访问 Redis
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen 打开状态:所有操作直接返回 ErrCircuitOpen
+	BreakerOpen
+
+	// BreakerHalfOpen 半开状态:放行一个探测请求,成功则关闭,失败则重新打开
+	BreakerHalfOpen
+)
+
+// String 返回状态名称
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig 熔断器配置
+// 在 Window 时间窗口内连续失败 FailureThreshold 次后打开熔断器,
+// 经过 OpenTimeout 后进入半开状态探测 Redis 是否恢复
+type BreakerConfig struct {
+	// FailureThreshold 触发熔断的连续失败次数
+	// 为 0 时使用 DefaultBreakerFailureThreshold
+	FailureThreshold int
+
+	// Window 统计连续失败的时间窗口
+	// 距离窗口内第一次失败超过此时间后重新计数
+	// 为 0 时使用 DefaultBreakerWindow
+	Window time.Duration
+
+	// OpenTimeout 熔断器打开后进入半开状态前的等待时间
+	// 为 0 时使用 DefaultBreakerOpenTimeout
+	OpenTimeout time.Duration
+}
+
+// circuitBreaker 熔断器实现
+// 以 go-redis Hook 的形式包裹所有命令,对 Cache 的每个方法都生效
+// 只有连接类错误(网络错误、超时、连接池耗尽等)计为失败,
+// redis.Nil、WRONGTYPE 等 Redis 服务端返回的错误说明 Redis 可用,不计为失败
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	// config 熔断器配置(已填充默认值)
+	config BreakerConfig
+
+	// state 当前状态
+	state BreakerState
+
+	// failures 当前窗口内的连续失败次数
+	failures int
+
+	// windowStart 当前窗口内第一次失败的时间
+	windowStart time.Time
+
+	// openedAt 熔断器打开的时间
+	openedAt time.Time
+
+	// probing 半开状态下是否已有探测请求在执行
+	probing bool
+
+	// now 当前时间函数,便于测试
+	now func() time.Time
+}
+
+// newCircuitBreaker 创建熔断器
+// 参数:
+//
+//	cfg: 熔断器配置,零值字段使用默认值
+func newCircuitBreaker(cfg BreakerConfig) *circuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultBreakerWindow
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	return &circuitBreaker{
+		config: cfg,
+		now:    time.Now,
+	}
+}
+
+// State 返回当前状态
+// 打开状态超过 OpenTimeout 后报告为半开
+func (b *circuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.config.OpenTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// allow 判断是否放行请求
+// 返回:
+//
+//	error: 熔断器打开时返回 ErrCircuitOpen
+func (b *circuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.config.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		// 冷却结束,进入半开状态,放行一个探测请求
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record 记录请求结果
+// 参数:
+//
+//	err: 请求返回的错误
+func (b *circuitBreaker) record(err error) {
+	failed := isBreakerFailure(err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerHalfOpen:
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.reset()
+		}
+	case BreakerClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		now := b.now()
+		if b.failures == 0 || now.Sub(b.windowStart) > b.config.Window {
+			b.failures = 0
+			b.windowStart = now
+		}
+		b.failures++
+		if b.failures >= b.config.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+// open 打开熔断器(调用方持有锁)
+func (b *circuitBreaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = b.now()
+	b.failures = 0
+}
+
+// reset 关闭熔断器并清空计数(调用方持有锁)
+func (b *circuitBreaker) reset() {
+	b.state = BreakerClosed
+	b.failures = 0
+}
+
+// isBreakerFailure 判断错误是否表示 Redis 不可用
+// Redis 服务端返回的错误(包括 redis.Nil)和调用方主动取消不计为失败
+func isBreakerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var redisErr redis.Error
+	return !errors.As(err, &redisErr)
+}
+
+// DialHook 实现 redis.Hook 接口
+func (b *circuitBreaker) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook 实现 redis.Hook 接口
+// 熔断器打开时直接返回 ErrCircuitOpen,不访问 Redis
+func (b *circuitBreaker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := b.allow(); err != nil {
+			return err
+		}
+		err := next(ctx, cmd)
+		b.record(err)
+		return err
+	}
+}
+
+// ProcessPipelineHook 实现 redis.Hook 接口
+// 整个 pipeline 视为一次请求
+func (b *circuitBreaker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := b.allow(); err != nil {
+			return err
+		}
+		err := next(ctx, cmds)
+		b.record(err)
+		return err
+	}
+}
@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeClock 可手动推进的时钟
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// serverError 模拟 Redis 服务端返回的错误,如 WRONGTYPE
+type serverError string
+
+func (e serverError) Error() string { return string(e) }
+
+func (serverError) RedisError() {}
+
+// errConnRefused 模拟连接类错误
+var errConnRefused = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+// newTestBreaker 创建使用 fakeClock 的熔断器
+func newTestBreaker(cfg BreakerConfig) (*circuitBreaker, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1700000000, 0)}
+	b := newCircuitBreaker(cfg)
+	b.now = clock.now
+	return b, clock
+}
+
+// TestCircuitBreakerTransitions 测试 closed -> open -> half-open -> closed/open
+func TestCircuitBreakerTransitions(t *testing.T) {
+	b, clock := newTestBreaker(BreakerConfig{FailureThreshold: 3, Window: time.Minute, OpenTimeout: 10 * time.Second})
+
+	// 连续失败达到阈值前保持关闭
+	for i := 0; i < 2; i++ {
+		if err := b.allow(); err != nil {
+			t.Fatalf("allow() in closed state error = %v", err)
+		}
+		b.record(errConnRefused)
+	}
+	if s := b.State(); s != BreakerClosed {
+		t.Fatalf("state after 2 failures = %v, want closed", s)
+	}
+
+	// 第 3 次失败打开熔断器,冷却期间拒绝所有请求
+	b.record(errConnRefused)
+	if s := b.State(); s != BreakerOpen {
+		t.Fatalf("state after 3 failures = %v, want open", s)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() in open state error = %v, want ErrCircuitOpen", err)
+	}
+
+	// 冷却结束后报告为半开,放行一个探测请求;探测失败重新打开
+	clock.advance(10 * time.Second)
+	if s := b.State(); s != BreakerHalfOpen {
+		t.Fatalf("state after OpenTimeout = %v, want half-open", s)
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() probe error = %v", err)
+	}
+	b.record(errConnRefused)
+	if s := b.State(); s != BreakerOpen {
+		t.Fatalf("state after failed probe = %v, want open", s)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() after failed probe error = %v, want ErrCircuitOpen", err)
+	}
+
+	// 再次冷却后探测成功,关闭熔断器并清空计数
+	clock.advance(10 * time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow() probe error = %v", err)
+	}
+	b.record(nil)
+	if s := b.State(); s != BreakerClosed {
+		t.Fatalf("state after successful probe = %v, want closed", s)
+	}
+	b.record(errConnRefused)
+	if s := b.State(); s != BreakerClosed {
+		t.Errorf("state after 1 failure = %v, want closed", s)
+	}
+}
+
+// TestCircuitBreakerSingleProbe 测试半开状态只放行一个探测请求
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	b, clock := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Second})
+	b.record(errConnRefused)
+	clock.advance(time.Second)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("first allow() error = %v", err)
+	}
+	// 探测请求完成前,其他请求被拒绝
+	for i := 0; i < 3; i++ {
+		if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+			t.Errorf("concurrent allow() error = %v, want ErrCircuitOpen", err)
+		}
+	}
+	b.record(nil)
+	if err := b.allow(); err != nil {
+		t.Errorf("allow() after successful probe error = %v", err)
+	}
+}
+
+// TestCircuitBreakerWindow 测试失败计数的时间窗口和成功重置
+func TestCircuitBreakerWindow(t *testing.T) {
+	b, clock := newTestBreaker(BreakerConfig{FailureThreshold: 3, Window: 10 * time.Second})
+
+	// 窗口过期后重新计数
+	b.record(errConnRefused)
+	b.record(errConnRefused)
+	clock.advance(11 * time.Second)
+	b.record(errConnRefused)
+	if s := b.State(); s != BreakerClosed {
+		t.Fatalf("state after failures across windows = %v, want closed", s)
+	}
+	b.record(errConnRefused)
+	b.record(errConnRefused)
+	if s := b.State(); s != BreakerOpen {
+		t.Fatalf("state after 3 failures within window = %v, want open", s)
+	}
+
+	// 成功请求打断连续失败
+	b, _ = newTestBreaker(BreakerConfig{FailureThreshold: 3, Window: 10 * time.Second})
+	b.record(errConnRefused)
+	b.record(errConnRefused)
+	b.record(nil)
+	b.record(errConnRefused)
+	b.record(errConnRefused)
+	if s := b.State(); s != BreakerClosed {
+		t.Errorf("state after interrupted failures = %v, want closed", s)
+	}
+}
+
+// TestIsBreakerFailure 测试哪些错误计为失败
+func TestIsBreakerFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"redis.Nil", redis.Nil, false},
+		{"wrapped redis.Nil", fmt.Errorf("get: %w", redis.Nil), false},
+		{"server error", serverError("WRONGTYPE Operation against a key holding the wrong kind of value"), false},
+		{"canceled", context.Canceled, false},
+		{"connection refused", errConnRefused, true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"pool timeout", redis.ErrPoolTimeout, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBreakerFailure(tt.err); got != tt.want {
+				t.Errorf("isBreakerFailure(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	// Redis 服务端错误不会打开熔断器
+	b, _ := newTestBreaker(BreakerConfig{FailureThreshold: 1})
+	b.record(redis.Nil)
+	b.record(serverError("WRONGTYPE"))
+	if s := b.State(); s != BreakerClosed {
+		t.Errorf("state after server errors = %v, want closed", s)
+	}
+}
+
+// TestCircuitBreakerProcessHook 测试 Hook 在熔断打开时不访问 Redis
+func TestCircuitBreakerProcessHook(t *testing.T) {
+	b, _ := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+
+	calls := 0
+	process := b.ProcessHook(func(context.Context, redis.Cmder) error {
+		calls++
+		return errConnRefused
+	})
+	pipeline := b.ProcessPipelineHook(func(context.Context, []redis.Cmder) error {
+		calls++
+		return nil
+	})
+	cmd := redis.NewStatusCmd(context.Background(), "ping")
+
+	if err := process(context.Background(), cmd); !errors.Is(err, errConnRefused) {
+		t.Fatalf("process() error = %v, want connection error", err)
+	}
+	if err := process(context.Background(), cmd); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("process() when open error = %v, want ErrCircuitOpen", err)
+	}
+	if err := pipeline(context.Background(), []redis.Cmder{cmd}); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("pipeline() when open error = %v, want ErrCircuitOpen", err)
+	}
+	if calls != 1 {
+		t.Errorf("next called %d times, want 1", calls)
+	}
+}
+
+// TestNewCircuitBreakerDefaults 测试零值配置使用默认值
+func TestNewCircuitBreakerDefaults(t *testing.T) {
+	b := newCircuitBreaker(BreakerConfig{})
+	want := BreakerConfig{
+		FailureThreshold: DefaultBreakerFailureThreshold,
+		Window:           DefaultBreakerWindow,
+		OpenTimeout:      DefaultBreakerOpenTimeout,
+	}
+	if b.config != want {
+		t.Errorf("config = %+v, want %+v", b.config, want)
+	}
+	if s := BreakerState(99).String(); s != "unknown" {
+		t.Errorf("BreakerState(99).String() = %q, want unknown", s)
+	}
+}
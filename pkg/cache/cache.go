@@ -32,14 +32,32 @@ type Cache interface {
 	//   key: 缓存键名
 	// 返回:
 	//   string: 键对应的值
-	//   error: 如果键不存在,返回 ErrKeyNotFound;其他错误返回具体错误信息
+	//   error: 如果键不存在,返回包装 ErrKeyNotFound 的错误;其他错误返回具体错误信息
 	// 使用示例:
 	//   value, err := cache.Get(ctx, "user:123")
-	//   if err == cache.ErrKeyNotFound {
+	//   if errors.Is(err, cache.ErrKeyNotFound) {
 	//       // 键不存在,从数据库加载
 	//   }
 	Get(ctx context.Context, key string) (string, error)
 
+	// GetWithTTL 在一次往返中获取键的值和剩余生存时间
+	// 参数:
+	//   ctx: 上下文
+	//   key: 缓存键名
+	// 返回:
+	//   string: 键对应的值
+	//   time.Duration: 剩余时间,-1 表示键没有设置过期时间
+	//   error: 如果键不存在,返回包装 ErrKeyNotFound 的错误
+	// 为什么不用 Get + TTL:
+	//   - 两次调用之间键可能过期,得到"有值但 TTL 为 -2"的矛盾结果
+	//   - GetWithTTL 在同一事务中执行,值和 TTL 一致,且只有一次网络往返
+	// 使用示例:
+	//   value, ttl, err := cache.GetWithTTL(ctx, "config:feature")
+	//   if err == nil && ttl > 0 && ttl < time.Minute {
+	//       // 快过期了,异步提前刷新
+	//   }
+	GetWithTTL(ctx context.Context, key string) (string, time.Duration, error)
+
 	// Set 设置键值对
 	// 参数:
 	//   ctx: 上下文
@@ -183,6 +201,220 @@ type Cache interface {
 	//   count, err := cache.IncrBy(ctx, "points:user123", 10)
 	IncrBy(ctx context.Context, key string, value int64) (int64, error)
 
+	// IncrWithExpiry 原子地增加键的整数值,并在键新建时设置过期时间
+	// 参数:
+	//   ctx: 上下文
+	//   key: 键名
+	//   delta: 要增加的数量,可以是负数
+	//   ttl: 键新建时设置的过期时间,至少 1 毫秒
+	// 返回:
+	//   int64: 增加后的值
+	//   error: ttl 不足 1 毫秒时返回 ErrInvalidExpiration,其他为操作失败时的错误
+	// 说明:
+	//   - 基于 ScriptIncrWithExpiry 在 Redis 中一次执行,不存在 Incr 与 Expire 之间的竞态
+	//   - 已有过期时间的键只自增,不会刷新过期时间,窗口长度固定
+	//   - 键存在但没有过期时间(如由 Incr 创建后进程崩溃)时同样补设过期时间
+	// 使用场景:
+	//   - 固定窗口限流
+	//   - 按时间段统计的配额
+	// 使用示例:
+	//   // 每个用户每分钟最多 100 次请求
+	//   window := time.Now().Unix() / 60
+	//   n, err := cache.IncrWithExpiry(ctx, fmt.Sprintf("ratelimit:%d:%d", userID, window), 1, time.Minute)
+	//   if err == nil && n > 100 {
+	//       // 超出限额
+	//   }
+	IncrWithExpiry(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+
+	// SetNX 仅当键不存在时设置键值对
+	// 参数:
+	//   ctx: 上下文
+	//   key: 缓存键名
+	//   value: 要缓存的值
+	//   expiration: 过期时间,0 表示永不过期
+	// 返回:
+	//   bool: 是否设置成功,键已存在时返回 false
+	//   error: 操作失败时的错误
+	// 使用场景:
+	//   - 分布式锁的加锁
+	//   - 幂等键(同一请求只处理一次)
+	// 使用示例:
+	//   ok, err := cache.SetNX(ctx, "lock:order:123", token, 10*time.Second)
+	//   if err == nil && !ok {
+	//       // 已被其他实例持有
+	//   }
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+
+	// SetXX 仅当键已存在时设置键值对
+	// 参数:
+	//   ctx: 上下文
+	//   key: 缓存键名
+	//   value: 要缓存的值
+	//   expiration: 过期时间,0 表示永不过期
+	// 返回:
+	//   bool: 是否设置成功,键不存在时返回 false
+	//   error: 操作失败时的错误
+	// 使用场景:
+	//   - 只刷新已缓存的数据,不为已删除的数据重新建立缓存
+	// 使用示例:
+	//   ok, err := cache.SetXX(ctx, "user:123", user, time.Hour)
+	SetXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
+
+	// CompareAndSwap 仅当键的当前值等于 old 时替换为 new
+	// 比较和替换在 Redis 中通过 Lua 脚本原子执行,键的剩余过期时间保持不变
+	// 参数:
+	//   ctx: 上下文
+	//   key: 缓存键名
+	//   old: 期望的当前值
+	//   new: 新值
+	// 返回:
+	//   bool: 是否替换成功,键不存在或值不等于 old 时返回 false
+	//   error: 操作失败时的错误
+	// 使用场景:
+	//   - 乐观并发控制(读取-修改-条件写回)
+	//   - 分布式锁的续期和持有者校验
+	// 使用示例:
+	//   ok, err := cache.CompareAndSwap(ctx, "config:version", "v1", "v2")
+	//   if err == nil && !ok {
+	//       // 值已被其他实例修改,重新读取后重试
+	//   }
+	CompareAndSwap(ctx context.Context, key string, old, new string) (bool, error)
+
+	// UpdateField 更新缓存对象的单个字段,不覆盖其他字段
+	// 对象可以是 JSON 字符串(Set 写入的序列化结果)或 Redis 哈希
+	// 参数:
+	//   ctx: 上下文
+	//   key: 缓存键名
+	//   fieldPath: 字段路径,用 "." 分隔嵌套字段,如 "profile.address.city";
+	//     哈希的第一段是哈希字段,其余路径在该字段存储的 JSON 中修改
+	//   value: 新值,JSON 对象中写入其 JSON 编码;哈希的单级字段中字符串原样写入
+	// 返回:
+	//   error: 更新失败时的错误:
+	//     - 键不存在时返回包装 ErrKeyNotFound 的错误
+	//     - 值或中间字段不是 JSON 对象时返回包装 ErrNotJSONObject 的错误
+	//     - 并发修改导致多次写回失败时返回包装 ErrUpdateConflict 的错误
+	// 说明:
+	//   - 写回是原子的(比较后替换),不会覆盖其他客户端同时做的修改,
+	//     避免客户端"读取-修改-写回"丢失更新
+	//   - 中间字段不存在时自动创建,键的剩余过期时间保持不变
+	//   - 未修改的数字原样保留,Snowflake ID 等大整数不会损失精度
+	// 使用示例:
+	//   err := cache.UpdateField(ctx, "user:123", "profile.nickname", "alice")
+	UpdateField(ctx context.Context, key, fieldPath string, value interface{}) error
+
+	// Transaction 在 MULTI/EXEC 事务中原子地修改多个键
+	// 用于不变式跨越多个键的操作,如在两个计数器之间转移数值
+	// 参数:
+	//   ctx: 上下文
+	//   fn: 事务函数,通过 tx 读取和排队写入;返回错误时放弃事务,错误原样返回
+	// 返回:
+	//   error: fn 的错误、执行失败的错误,
+	//     监视的键连续被修改达到 TransactionMaxRetries 次时返回包装 ErrUpdateConflict 的错误
+	// 重试语义:
+	//   - tx.Get 读取的键和 tx.Watch 的键被监视(乐观锁),EXEC 前被其他客户端修改时整个 fn 重新执行
+	//   - fn 可能被调用多次,不应有事务以外的副作用
+	//   - 排队的命令要么全部执行,要么都不执行;但 Redis 不回滚,
+	//     某条命令执行出错(如对非数字值 INCRBY)时其他命令仍然生效,返回该命令的错误
+	// 与 Eval 的区别:
+	//   Lua 脚本适合在服务端完成判断的小段逻辑,Transaction 适合在 Go 代码中根据读取结果决定写入
+	// 使用示例:
+	//   err := cache.Transaction(ctx, func(tx cache.CacheTx) error {
+	//       v, err := tx.Get("balance:a")
+	//       if err != nil {
+	//           return err
+	//       }
+	//       if n, _ := strconv.ParseInt(v, 10, 64); n < amount {
+	//           return ErrInsufficientBalance
+	//       }
+	//       tx.IncrBy("balance:a", -amount)
+	//       tx.IncrBy("balance:b", amount)
+	//       return nil
+	//   })
+	Transaction(ctx context.Context, fn func(tx CacheTx) error) error
+
+	// Eval 执行 Lua 脚本
+	// 脚本在 Redis 中原子执行,执行期间不会穿插其他客户端的命令,
+	// 用于限流、排行榜、锁释放等需要"读取-判断-写入"的复合操作
+	// 参数:
+	//   ctx: 上下文
+	//   script: Lua 脚本源码
+	//   keys: 脚本访问的键,在脚本中通过 KEYS[i] 读取(集群模式下必须声明)
+	//   args: 脚本参数,在脚本中通过 ARGV[i] 读取
+	// 返回:
+	//   interface{}: 脚本返回值,整数为 int64,字符串为 string,表为 []interface{},
+	//     脚本返回 nil 或 false 时为 nil
+	//   error: 执行失败时的错误
+	// 注意:
+	//   - 每次都发送完整脚本,重复执行的脚本应使用 Script.Run(EVALSHA)
+	//   - 脚本直接操作 Redis 中的原始值,不经过值压缩
+	// 使用示例:
+	//   n, err := cache.Eval(ctx, `return redis.call("INCR", KEYS[1])`, []string{"counter"})
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+
+	// EvalSha 按 SHA1 执行服务器已缓存的 Lua 脚本
+	// 只发送 40 字节的摘要而非完整脚本,节省带宽和服务器解析开销
+	// 参数:
+	//   ctx: 上下文
+	//   sha1: 脚本的 SHA1(ScriptLoad 或 Script.Hash 的返回值)
+	//   keys: 脚本访问的键
+	//   args: 脚本参数
+	// 返回:
+	//   interface{}: 同 Eval
+	//   error: 执行失败时的错误,服务器未缓存该脚本时返回 ErrScriptNotFound
+	// 注意:
+	//   脚本缓存在 Redis 重启、SCRIPT FLUSH 或故障转移后会丢失,
+	//   收到 ErrScriptNotFound 时应回退到 Eval,Script.Run 会自动处理
+	EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) (interface{}, error)
+
+	// ScriptLoad 将脚本加载到服务器的脚本缓存,不执行
+	// 参数:
+	//   ctx: 上下文
+	//   script: Lua 脚本源码
+	// 返回:
+	//   string: 脚本的 SHA1,用于 EvalSha
+	//   error: 加载失败(如脚本语法错误)时的错误
+	ScriptLoad(ctx context.Context, script string) (string, error)
+
+	// Publish 向频道发布消息
+	// 用于多实例之间同步事件,如本地缓存失效通知
+	// 参数:
+	//   ctx: 上下文
+	//   channel: 频道名称
+	//   message: 消息内容
+	// 返回:
+	//   error: 发布失败时的错误
+	// 注意:
+	//   - 发布订阅不持久化,发布时没有订阅者的消息会被丢弃
+	//   - 消息不经过值压缩
+	// 使用示例:
+	//   // 更新用户后通知所有实例清除本地缓存
+	//   err := cache.Publish(ctx, "cache:invalidate", "user:123")
+	Publish(ctx context.Context, channel, message string) error
+
+	// Subscribe 订阅频道
+	// 参数:
+	//   ctx: 上下文,结束时自动退订并关闭消息通道
+	//   channel: 频道名称
+	// 返回:
+	//   <-chan string: 消息通道,退订后关闭
+	//   func(): 退订函数,可重复调用
+	//   error: 订阅失败时的错误
+	// 注意:
+	//   - 返回前已等待 Redis 确认订阅,之后发布的消息都能收到
+	//   - 连接断开时自动重连并重新订阅,断开期间的消息会丢失
+	//   - Close 或 Reload 关闭底层连接后通道也会关闭,Reload 后需重新订阅
+	//   - 应及时消费消息,缓冲(DefaultSubscribeBufferSize)满后会阻塞接收
+	// 使用示例:
+	//   msgs, cancel, err := cache.Subscribe(ctx, "cache:invalidate")
+	//   if err != nil {
+	//       return err
+	//   }
+	//   defer cancel()
+	//   for key := range msgs {
+	//       localCache.Delete(key)
+	//   }
+	Subscribe(ctx context.Context, channel string) (<-chan string, func(), error)
+
 	// Ping 测试与缓存服务器的连接
 	// 参数:
 	//   ctx: 上下文
@@ -229,4 +461,16 @@ type Cache interface {
 	//       log.Error("failed to reload cache", "error", err)
 	//   }
 	Reload(ctx context.Context, config *Config) error
+
+	// BreakerState 返回熔断器当前状态
+	// 返回:
+	//   BreakerState: 未启用熔断器时始终为 BreakerClosed
+	// 使用场景:
+	//   - 健康检查中报告缓存降级状态
+	//   - 监控告警
+	// 使用示例:
+	//   if cache.BreakerState() == cache.BreakerOpen {
+	//       log.Warn("cache circuit open, serving from database")
+	//   }
+	BreakerState() BreakerState
 }
@@ -0,0 +1,415 @@
+package cache
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Compressor 值压缩算法
+// 内置 gzip,其他算法(如 snappy)可通过 RegisterCompressor 注册
+type Compressor interface {
+	// Compress 压缩数据
+	Compress(src []byte) ([]byte, error)
+
+	// Decompress 解压数据
+	Decompress(src []byte) ([]byte, error)
+}
+
+var (
+	// compressorsMu 保护 compressors
+	compressorsMu sync.RWMutex
+
+	// compressors 已注册的压缩算法
+	compressors = map[string]Compressor{
+		CompressionGzip: &gzipCompressor{},
+	}
+)
+
+// RegisterCompressor 注册压缩算法
+// 为什么需要:
+//   - snappy 等算法需要第三方依赖,不适合作为本包的强制依赖
+//   - 需要的应用在启动时注册一次即可通过 Config.Compression 使用
+//
+// 参数:
+//
+//	name: 算法名称,写入值的标记中,不能为空、不能为 "none"、不能包含 ":"
+//	c: 算法实现
+//
+// 返回:
+//
+//	error: 参数无效时返回 ErrInvalidCompressor
+//
+// 使用示例:
+//
+//	// 基于 github.com/golang/snappy
+//	type snappyCompressor struct{}
+//
+//	func (snappyCompressor) Compress(src []byte) ([]byte, error) {
+//	    return snappy.Encode(nil, src), nil
+//	}
+//	func (snappyCompressor) Decompress(src []byte) ([]byte, error) {
+//	    return snappy.Decode(nil, src)
+//	}
+//
+//	cache.RegisterCompressor(cache.CompressionSnappy, snappyCompressor{})
+func RegisterCompressor(name string, c Compressor) error {
+	if name == "" || name == CompressionNone || strings.Contains(name, compressionNameSep) || c == nil {
+		return fmt.Errorf(ErrMsgInvalidCompressor, ErrInvalidCompressor, name)
+	}
+
+	compressorsMu.Lock()
+	defer compressorsMu.Unlock()
+	compressors[name] = c
+	return nil
+}
+
+// lookupCompressor 查找已注册的压缩算法
+// "" 和 "none" 返回 nil, nil,表示不压缩
+func lookupCompressor(name string) (Compressor, error) {
+	if name == "" || name == CompressionNone {
+		return nil, nil
+	}
+
+	compressorsMu.RLock()
+	c, ok := compressors[name]
+	compressorsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf(ErrMsgUnsupportedCompression, ErrUnsupportedCompression, name)
+	}
+	return c, nil
+}
+
+// compressionSettings 压缩设置
+// 整体替换以支持 Reload 时无锁读取
+type compressionSettings struct {
+	// name 算法名称,不压缩时为空
+	name string
+
+	// compressor 算法实现,不压缩时为 nil
+	compressor Compressor
+
+	// threshold 压缩阈值(字节),小于该值的数据不压缩
+	threshold int
+}
+
+// compressedCache 值压缩装饰器
+// 只覆盖读写值的方法,其他方法直接委托给内部 Cache
+type compressedCache struct {
+	Cache
+
+	settings atomic.Pointer[compressionSettings]
+}
+
+// NewCompressedCache 创建值压缩装饰器
+// Set/MSet 时对不小于阈值的 string 和 []byte 值压缩,并加上标记前缀
+// Get/GetWithTTL/MGet 时根据标记前缀解压,没有标记的值原样返回
+// 因此压缩和未压缩的值可以共存,开启或关闭压缩都不需要清空缓存
+//
+// 注意:
+//   - 只有 string 和 []byte 类型的值会被压缩,数字等其他类型原样写入
+//   - Incr 等直接操作数值的命令不受影响
+//   - 读取到使用未注册算法压缩的值时返回 ErrUnsupportedCompression
+//
+// 参数:
+//
+//	inner: 被装饰的缓存
+//	algorithm: 压缩算法,"" 或 "none" 表示只解压不压缩
+//	threshold: 压缩阈值(字节),0 使用 DefaultCompressionThreshold
+//
+// 返回:
+//
+//	Cache: 带压缩能力的缓存
+//	error: 算法未注册或阈值为负数时的错误
+//
+// 使用示例:
+//
+//	compressed, err := cache.NewCompressedCache(redisCache, cache.CompressionGzip, 4096)
+//	if err != nil {
+//	    return err
+//	}
+//	err = compressed.Set(ctx, "report:2024", largeJSON, time.Hour)
+func NewCompressedCache(inner Cache, algorithm string, threshold int) (Cache, error) {
+	settings, err := newCompressionSettings(algorithm, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compressedCache{Cache: inner}
+	c.settings.Store(settings)
+	return c, nil
+}
+
+// newCompressionSettings 根据算法名称和阈值创建压缩设置
+func newCompressionSettings(algorithm string, threshold int) (*compressionSettings, error) {
+	if threshold < 0 {
+		return nil, fmt.Errorf("compression threshold cannot be negative: %d", threshold)
+	}
+	if threshold == 0 {
+		threshold = DefaultCompressionThreshold
+	}
+
+	compressor, err := lookupCompressor(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	name := algorithm
+	if compressor == nil {
+		name = ""
+	}
+	return &compressionSettings{name: name, compressor: compressor, threshold: threshold}, nil
+}
+
+// Get 获取键的值,压缩过的值自动解压
+// 实现 Cache 接口
+func (c *compressedCache) Get(ctx context.Context, key string) (string, error) {
+	value, err := c.Cache.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	return decodeValue(key, value)
+}
+
+// GetWithTTL 获取键的值和剩余过期时间,压缩过的值自动解压
+// 实现 Cache 接口
+func (c *compressedCache) GetWithTTL(ctx context.Context, key string) (string, time.Duration, error) {
+	value, ttl, err := c.Cache.GetWithTTL(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+	value, err = decodeValue(key, value)
+	if err != nil {
+		return "", 0, err
+	}
+	return value, ttl, nil
+}
+
+// Set 设置键值对,达到阈值的值自动压缩
+// 实现 Cache 接口
+func (c *compressedCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	encoded, err := c.encodeValue(key, value)
+	if err != nil {
+		return err
+	}
+	return c.Cache.Set(ctx, key, encoded, expiration)
+}
+
+// MGet 批量获取键的值,压缩过的值自动解压
+// 实现 Cache 接口
+func (c *compressedCache) MGet(ctx context.Context, keys ...string) ([]interface{}, error) {
+	values, err := c.Cache.MGet(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		decoded, err := decodeValue(keys[i], s)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = decoded
+	}
+	return values, nil
+}
+
+// MSet 批量设置键值对,达到阈值的值自动压缩
+// 实现 Cache 接口
+// 参数不是键值对交替排列时原样交给内部 Cache 处理
+func (c *compressedCache) MSet(ctx context.Context, pairs ...interface{}) error {
+	if len(pairs)%2 != 0 {
+		return c.Cache.MSet(ctx, pairs...)
+	}
+
+	encoded := make([]interface{}, len(pairs))
+	for i := 0; i < len(pairs); i += 2 {
+		key, _ := pairs[i].(string)
+		value, err := c.encodeValue(key, pairs[i+1])
+		if err != nil {
+			return err
+		}
+		encoded[i], encoded[i+1] = pairs[i], value
+	}
+	return c.Cache.MSet(ctx, encoded...)
+}
+
+// SetNX 仅当键不存在时设置键值对,达到阈值的值自动压缩
+// 实现 Cache 接口
+func (c *compressedCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	encoded, err := c.encodeValue(key, value)
+	if err != nil {
+		return false, err
+	}
+	return c.Cache.SetNX(ctx, key, encoded, expiration)
+}
+
+// SetXX 仅当键已存在时设置键值对,达到阈值的值自动压缩
+// 实现 Cache 接口
+func (c *compressedCache) SetXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	encoded, err := c.encodeValue(key, value)
+	if err != nil {
+		return false, err
+	}
+	return c.Cache.SetXX(ctx, key, encoded, expiration)
+}
+
+// CompareAndSwap 仅当键解压后的值等于 old 时替换为 new
+// 实现 Cache 接口
+// 存储的是编码后的值,压缩设置变化后相同原值的编码可能不同,
+// 因此先读取存储值解码比较,再以存储值作为期望值执行内部 CompareAndSwap,
+// 读取之后值被其他实例修改时内部比较失败,仍然保持原子性
+func (c *compressedCache) CompareAndSwap(ctx context.Context, key string, old, new string) (bool, error) {
+	stored, err := c.Cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, ErrKeyNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	current, err := decodeValue(key, stored)
+	if err != nil {
+		return false, err
+	}
+	if current != old {
+		return false, nil
+	}
+
+	encoded, err := c.encodeValue(key, new)
+	if err != nil {
+		return false, err
+	}
+	return c.Cache.CompareAndSwap(ctx, key, stored, encoded.(string))
+}
+
+// Reload 重载配置
+// 实现 Cache 接口
+// 内部 Cache 重载成功后再切换压缩设置
+func (c *compressedCache) Reload(ctx context.Context, config *Config) error {
+	settings, err := newCompressionSettings(config.Compression, config.CompressionThreshold)
+	if err != nil {
+		return fmt.Errorf(ErrMsgReloadFailed, err)
+	}
+	if err := c.Cache.Reload(ctx, config); err != nil {
+		return err
+	}
+	c.settings.Store(settings)
+	return nil
+}
+
+// encodeValue 按当前设置编码值
+// 返回值格式:
+//   - 压缩: compressionMagic + 算法名称 + ":" + 压缩后的数据
+//   - 不压缩: 原值
+//
+// 原值恰好以 compressionMagic 开头时使用 "none" 标记,避免读取时被误判为压缩数据
+func (c *compressedCache) encodeValue(key string, value interface{}) (interface{}, error) {
+	var raw []byte
+	switch v := value.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return value, nil
+	}
+
+	s := c.settings.Load()
+	if s.compressor == nil || len(raw) < s.threshold {
+		if bytes.HasPrefix(raw, []byte(compressionMagic)) {
+			return compressionMagic + CompressionNone + compressionNameSep + string(raw), nil
+		}
+		return value, nil
+	}
+
+	compressed, err := s.compressor.Compress(raw)
+	if err != nil {
+		return nil, fmt.Errorf(ErrMsgCompressFailed, key, err)
+	}
+	return compressionMagic + s.name + compressionNameSep + string(compressed), nil
+}
+
+// decodeValue 解码由 encodeValue 写入的值
+// 没有标记前缀的值原样返回
+func decodeValue(key, value string) (string, error) {
+	rest, ok := strings.CutPrefix(value, compressionMagic)
+	if !ok {
+		return value, nil
+	}
+
+	name, payload, ok := strings.Cut(rest, compressionNameSep)
+	if !ok {
+		return "", fmt.Errorf(ErrMsgDecompressFailed, key, ErrUnsupportedCompression)
+	}
+	if name == CompressionNone {
+		return payload, nil
+	}
+
+	compressor, err := lookupCompressor(name)
+	if err != nil {
+		return "", fmt.Errorf(ErrMsgDecompressFailed, key, err)
+	}
+	raw, err := compressor.Decompress([]byte(payload))
+	if err != nil {
+		return "", fmt.Errorf(ErrMsgDecompressFailed, key, err)
+	}
+	return string(raw), nil
+}
+
+// gzipCompressor 基于标准库的 gzip 实现
+// 使用 BestSpeed 级别,缓存场景下压缩速度比压缩率更重要
+type gzipCompressor struct {
+	writers sync.Pool
+	readers sync.Pool
+}
+
+// Compress 实现 Compressor 接口
+func (g *gzipCompressor) Compress(src []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, ok := g.writers.Get().(*gzip.Writer)
+	if ok {
+		w.Reset(&buf)
+	} else {
+		w, _ = gzip.NewWriterLevel(&buf, gzip.BestSpeed)
+	}
+	defer g.writers.Put(w)
+
+	if _, err := w.Write(src); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress 实现 Compressor 接口
+func (g *gzipCompressor) Decompress(src []byte) ([]byte, error) {
+	var (
+		r   *gzip.Reader
+		err error
+	)
+	if pooled, ok := g.readers.Get().(*gzip.Reader); ok {
+		r = pooled
+		err = r.Reset(bytes.NewReader(src))
+	} else {
+		r, err = gzip.NewReader(bytes.NewReader(src))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer g.readers.Put(r)
+
+	return io.ReadAll(r)
+}
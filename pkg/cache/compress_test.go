@@ -0,0 +1,247 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memCache 基于 map 的 Cache 桩实现,只实现压缩装饰器用到的方法
+type memCache struct {
+	Cache
+	data map[string]string
+}
+
+func newMemCache() *memCache {
+	return &memCache{data: make(map[string]string)}
+}
+
+func (m *memCache) Get(_ context.Context, key string) (string, error) {
+	v, ok := m.data[key]
+	if !ok {
+		return "", fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
+	}
+	return v, nil
+}
+
+func (m *memCache) GetWithTTL(ctx context.Context, key string) (string, time.Duration, error) {
+	v, err := m.Get(ctx, key)
+	return v, time.Minute, err
+}
+
+func (m *memCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	switch v := value.(type) {
+	case []byte:
+		m.data[key] = string(v)
+	default:
+		m.data[key] = fmt.Sprint(v)
+	}
+	return nil
+}
+
+func (m *memCache) MGet(_ context.Context, keys ...string) ([]interface{}, error) {
+	values := make([]interface{}, len(keys))
+	for i, k := range keys {
+		if v, ok := m.data[k]; ok {
+			values[i] = v
+		}
+	}
+	return values, nil
+}
+
+func (m *memCache) MSet(ctx context.Context, pairs ...interface{}) error {
+	for i := 0; i < len(pairs); i += 2 {
+		_ = m.Set(ctx, pairs[i].(string), pairs[i+1], 0)
+	}
+	return nil
+}
+
+func (m *memCache) Reload(context.Context, *Config) error {
+	return nil
+}
+
+func (m *memCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	if _, ok := m.data[key]; ok {
+		return false, nil
+	}
+	return true, m.Set(ctx, key, value, expiration)
+}
+
+func (m *memCache) SetXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	if _, ok := m.data[key]; !ok {
+		return false, nil
+	}
+	return true, m.Set(ctx, key, value, expiration)
+}
+
+func (m *memCache) CompareAndSwap(_ context.Context, key string, old, new string) (bool, error) {
+	if v, ok := m.data[key]; !ok || v != old {
+		return false, nil
+	}
+	m.data[key] = new
+	return true, nil
+}
+
+// largePayload 生成可压缩的 JSON 数据
+func largePayload(n int) string {
+	var b strings.Builder
+	b.WriteString("[")
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":%d,"username":"user%d","email":"user%d@example.com","status":"active"}`, i, i, i)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func TestCompressedCache_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	inner := newMemCache()
+	c, err := NewCompressedCache(inner, CompressionGzip, 64)
+	if err != nil {
+		t.Fatalf("NewCompressedCache: %v", err)
+	}
+
+	large := largePayload(50)
+	small := "hello"
+	magic := compressionMagic + "looks compressed"
+
+	if err := c.Set(ctx, "large", large, 0); err != nil {
+		t.Fatalf("Set large: %v", err)
+	}
+	if err := c.MSet(ctx, "small", small, "magic", magic, "bytes", []byte(large)); err != nil {
+		t.Fatalf("MSet: %v", err)
+	}
+
+	if got := inner.data["large"]; !strings.HasPrefix(got, compressionMagic+CompressionGzip) || len(got) >= len(large) {
+		t.Fatalf("large value not compressed: stored %d bytes", len(got))
+	}
+	if got := inner.data["small"]; got != small {
+		t.Fatalf("small value should be stored as-is, got %q", got)
+	}
+
+	for key, want := range map[string]string{"large": large, "small": small, "magic": magic, "bytes": large} {
+		got, err := c.Get(ctx, key)
+		if err != nil || got != want {
+			t.Fatalf("Get(%s) = %d bytes, %v; want %d bytes", key, len(got), err, len(want))
+		}
+	}
+
+	values, err := c.MGet(ctx, "large", "missing", "small")
+	if err != nil {
+		t.Fatalf("MGet: %v", err)
+	}
+	if values[0] != large || values[1] != nil || values[2] != small {
+		t.Fatalf("MGet returned unexpected values")
+	}
+
+	// 关闭压缩后仍能读取之前压缩写入的值
+	if err := c.Reload(ctx, &Config{Compression: CompressionNone}); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got, err := c.Get(ctx, "large"); err != nil || got != large {
+		t.Fatalf("Get after disabling compression failed: %v", err)
+	}
+	if err := c.Set(ctx, "plain", large, 0); err != nil {
+		t.Fatalf("Set plain: %v", err)
+	}
+	if inner.data["plain"] != large {
+		t.Fatalf("value should not be compressed after disabling compression")
+	}
+}
+
+func TestCompressedCache_Conditional(t *testing.T) {
+	ctx := context.Background()
+	inner := newMemCache()
+	c, err := NewCompressedCache(inner, CompressionGzip, 64)
+	if err != nil {
+		t.Fatalf("NewCompressedCache: %v", err)
+	}
+
+	large := largePayload(50)
+	if ok, err := c.SetXX(ctx, "key", large, 0); err != nil || ok {
+		t.Fatalf("SetXX on missing key = %v, %v; want false", ok, err)
+	}
+	if ok, err := c.SetNX(ctx, "key", large, 0); err != nil || !ok {
+		t.Fatalf("SetNX on missing key = %v, %v; want true", ok, err)
+	}
+	if !strings.HasPrefix(inner.data["key"], compressionMagic+CompressionGzip) {
+		t.Fatalf("SetNX value not compressed")
+	}
+	if ok, err := c.SetNX(ctx, "key", "other", 0); err != nil || ok {
+		t.Fatalf("SetNX on existing key = %v, %v; want false", ok, err)
+	}
+
+	// 比较的是解压后的值
+	if ok, err := c.CompareAndSwap(ctx, "key", "stale", "v2"); err != nil || ok {
+		t.Fatalf("CompareAndSwap with stale value = %v, %v; want false", ok, err)
+	}
+	if ok, err := c.CompareAndSwap(ctx, "key", large, "v2"); err != nil || !ok {
+		t.Fatalf("CompareAndSwap with current value = %v, %v; want true", ok, err)
+	}
+	if got, _ := c.Get(ctx, "key"); got != "v2" {
+		t.Fatalf("Get after CompareAndSwap = %q, want v2", got)
+	}
+	if ok, err := c.CompareAndSwap(ctx, "missing", "", "v"); err != nil || ok {
+		t.Fatalf("CompareAndSwap on missing key = %v, %v; want false", ok, err)
+	}
+
+	if ok, err := c.SetXX(ctx, "key", "v3", 0); err != nil || !ok {
+		t.Fatalf("SetXX on existing key = %v, %v; want true", ok, err)
+	}
+}
+
+func TestCompressedCache_UnsupportedCompression(t *testing.T) {
+	if _, err := NewCompressedCache(newMemCache(), "lz4", 0); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Fatalf("expected ErrUnsupportedCompression, got %v", err)
+	}
+
+	inner := newMemCache()
+	inner.data["key"] = compressionMagic + "lz4" + compressionNameSep + "payload"
+	c, err := NewCompressedCache(inner, CompressionNone, 0)
+	if err != nil {
+		t.Fatalf("NewCompressedCache: %v", err)
+	}
+	if _, err := c.Get(context.Background(), "key"); !errors.Is(err, ErrUnsupportedCompression) {
+		t.Fatalf("expected ErrUnsupportedCompression, got %v", err)
+	}
+
+	if err := RegisterCompressor("bad:name", &gzipCompressor{}); !errors.Is(err, ErrInvalidCompressor) {
+		t.Fatalf("expected ErrInvalidCompressor, got %v", err)
+	}
+}
+
+// BenchmarkCompressedCache 对比不同算法和数据大小下的写入+读取开销
+// stored_bytes 指标为实际写入缓存的字节数,用于衡量内存节省
+func BenchmarkCompressedCache(b *testing.B) {
+	ctx := context.Background()
+	for _, size := range []int{1, 10, 100, 1000} {
+		payload := largePayload(size)
+		for _, algorithm := range []string{CompressionNone, CompressionGzip} {
+			b.Run(fmt.Sprintf("%s/%dB", algorithm, len(payload)), func(b *testing.B) {
+				inner := newMemCache()
+				c, err := NewCompressedCache(inner, algorithm, DefaultCompressionThreshold)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				b.ReportAllocs()
+				b.SetBytes(int64(len(payload)))
+				for i := 0; i < b.N; i++ {
+					if err := c.Set(ctx, "key", payload, 0); err != nil {
+						b.Fatal(err)
+					}
+					if _, err := c.Get(ctx, "key"); err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(inner.data["key"])), "stored_bytes")
+			})
+		}
+	}
+}
@@ -62,6 +62,22 @@ type Config struct {
 	// 向 Redis 写入命令的最大等待时间
 	// 通常设置得比 ReadTimeout 短一些
 	WriteTimeout time.Duration
+
+	// CircuitBreaker 熔断器配置(可选)
+	// 为 nil 时不启用熔断
+	// 启用后,Redis 连续失败达到阈值时所有操作直接返回 ErrCircuitOpen,
+	// 避免 Redis 宕机时每次调用都阻塞在连接超时上
+	CircuitBreaker *BreakerConfig
+
+	// Compression 值压缩算法
+	// 可选值: "" 或 "none"(不压缩)、"gzip"、"snappy"(需先通过 RegisterCompressor 注册)
+	// 无论是否开启,读取时都会解压带压缩标记的值,开启或关闭压缩都不需要清空缓存
+	Compression string
+
+	// CompressionThreshold 压缩阈值(字节)
+	// 小于该值的数据不压缩,避免小数据的压缩开销
+	// 0 使用 DefaultCompressionThreshold
+	CompressionThreshold int
 }
 
 // DefaultConfig 返回默认配置
@@ -108,6 +124,7 @@ func DefaultConfig() *Config {
 //   - PoolSize 必须大于 0
 //   - MinIdleConns 不能大于 PoolSize
 //   - 超时时间必须大于 0
+//   - Compression 必须是已注册的算法,CompressionThreshold 不能为负数
 func (c *Config) Validate() error {
 	// 验证 Host
 	if c.Host == "" {
@@ -149,5 +166,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("redis write timeout must be greater than 0")
 	}
 
+	// 验证压缩配置
+	if _, err := newCompressionSettings(c.Compression, c.CompressionThreshold); err != nil {
+		return err
+	}
+
 	return nil
 }
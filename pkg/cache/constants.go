@@ -1,5 +1,7 @@
 package cache
 
+import "time"
+
 // 默认配置常量
 // 这些值是经过生产环境验证的合理默认值
 const (
@@ -44,6 +46,65 @@ const (
 	DefaultWriteTimeout = 3
 )
 
+// 字段更新常量
+const (
+	// FieldPathSep UpdateField 字段路径的分隔符
+	// 例如: "profile.address.city"
+	FieldPathSep = "."
+
+	// UpdateFieldMaxRetries UpdateField 因并发修改写回失败时的最大尝试次数
+	UpdateFieldMaxRetries = 10
+
+	// TransactionMaxRetries Transaction 因被监视的键被修改而放弃时的最大尝试次数
+	TransactionMaxRetries = 10
+)
+
+// 发布订阅常量
+const (
+	// DefaultSubscribeBufferSize Subscribe 返回的通道缓冲大小(条)
+	// 消费速度跟不上时消息在缓冲中等待,缓冲满后阻塞接收
+	DefaultSubscribeBufferSize = 100
+)
+
+// 熔断器默认配置常量
+// BreakerConfig 字段为零值时使用
+const (
+	// DefaultBreakerFailureThreshold 默认触发熔断的连续失败次数
+	DefaultBreakerFailureThreshold = 5
+
+	// DefaultBreakerWindow 默认统计连续失败的时间窗口
+	DefaultBreakerWindow = 10 * time.Second
+
+	// DefaultBreakerOpenTimeout 默认熔断打开后进入半开状态前的等待时间
+	DefaultBreakerOpenTimeout = 30 * time.Second
+)
+
+// 值压缩常量
+// 用于 Config.Compression 和 NewCompressedCache
+const (
+	// CompressionNone 不压缩
+	// 仍会解压已经压缩过的值,便于关闭压缩时平滑过渡
+	CompressionNone = "none"
+
+	// CompressionGzip gzip 压缩(标准库实现,内置)
+	CompressionGzip = "gzip"
+
+	// CompressionSnappy snappy 压缩
+	// 需要先通过 RegisterCompressor 注册实现
+	CompressionSnappy = "snappy"
+
+	// DefaultCompressionThreshold 默认压缩阈值(字节)
+	// 小于该值的数据压缩收益很小,反而增加 CPU 开销
+	DefaultCompressionThreshold = 1024
+
+	// compressionMagic 压缩值的标记前缀
+	// 以 NUL 字节开头,正常的文本和 JSON 不会以此开头
+	compressionMagic = "\x00cz:"
+
+	// compressionNameSep 标记中算法名称与数据之间的分隔符
+	compressionNameSep = ":"
+)
+
 // 日志消息常量
 // 避免在代码中使用魔法字符串,便于统一管理和修改
 const (
@@ -85,7 +146,8 @@ const (
 	ErrMsgNilValue = "redis: nil"
 
 	// ErrMsgKeyNotFound 键不存在的错误消息
-	ErrMsgKeyNotFound = "cache key not found: %s"
+	// 使用 fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
+	ErrMsgKeyNotFound = "%w: %s"
 
 	// ErrMsgConnectionFailed 连接失败的错误消息
 	ErrMsgConnectionFailed = "failed to connect to redis: %w"
@@ -99,6 +161,51 @@ const (
 
 	// ErrMsgReloadFailed 重载失败的错误消息
 	ErrMsgReloadFailed = "failed to reload redis: %w"
+
+	// ErrMsgUnsupportedCompression 压缩算法未注册的错误消息
+	// 使用 fmt.Errorf(ErrMsgUnsupportedCompression, ErrUnsupportedCompression, name)
+	ErrMsgUnsupportedCompression = "%w: %s"
+
+	// ErrMsgInvalidCompressor 注册压缩算法参数无效的错误消息
+	// 使用 fmt.Errorf(ErrMsgInvalidCompressor, ErrInvalidCompressor, name)
+	ErrMsgInvalidCompressor = "%w: %q"
+
+	// ErrMsgCompressFailed 压缩失败的错误消息
+	ErrMsgCompressFailed = "failed to compress value of key %s: %w"
+
+	// ErrMsgDecompressFailed 解压失败的错误消息
+	ErrMsgDecompressFailed = "failed to decompress value of key %s: %w"
+
+	// ErrMsgScriptNotFound 脚本未缓存的错误消息
+	// 使用 fmt.Errorf(ErrMsgScriptNotFound, ErrScriptNotFound, sha1)
+	ErrMsgScriptNotFound = "%w: %s"
+
+	// ErrMsgInvalidExpiration 过期时间无效的错误消息
+	// 使用 fmt.Errorf(ErrMsgInvalidExpiration, ErrInvalidExpiration, ttl)
+	ErrMsgInvalidExpiration = "%w: %v must be at least 1ms"
+
+	// ErrMsgUnexpectedScriptResult 脚本返回值类型不符合预期的错误消息
+	// 使用 fmt.Errorf(ErrMsgUnexpectedScriptResult, op, result)
+	ErrMsgUnexpectedScriptResult = "redis %s returned unexpected result %T"
+
+	// ErrMsgInvalidFieldPath 字段路径无效的错误消息
+	// 使用 fmt.Errorf(ErrMsgInvalidFieldPath, ErrInvalidFieldPath, fieldPath)
+	ErrMsgInvalidFieldPath = "%w: %q"
+
+	// ErrMsgNotJSONObject 缓存值不是 JSON 对象的错误消息
+	// 使用 fmt.Errorf(ErrMsgNotJSONObject, ErrNotJSONObject, key, fieldPath)
+	ErrMsgNotJSONObject = "%w: key %s, field %s"
+
+	// ErrMsgUpdateConflict 并发修改的错误消息
+	// 使用 fmt.Errorf(ErrMsgUpdateConflict, ErrUpdateConflict, key)
+	ErrMsgUpdateConflict = "%w: %s"
+
+	// ErrMsgTxConflict 事务冲突的错误消息
+	// 使用 fmt.Errorf(ErrMsgTxConflict, ErrUpdateConflict, TransactionMaxRetries)
+	ErrMsgTxConflict = "%w: transaction aborted after %d attempts"
+
+	// ErrMsgEncodeFieldFailed 字段值编码失败的错误消息
+	ErrMsgEncodeFieldFailed = "failed to encode cache field %s: %w"
 )
 
 // 键前缀常量
@@ -55,6 +55,28 @@
 //	// 增加指定值
 //	points, err := cache.IncrBy(ctx, "user:points", 10)
 //
+//	// 固定窗口计数器,键新建时设置过期时间(原子执行)
+//	n, err := cache.IncrWithExpiry(ctx, "rate:user:1", 1, time.Minute)
+//
+//	// 条件写入,返回是否生效
+//	ok, err := cache.SetNX(ctx, "lock:order:123", token, 10*time.Second)
+//	ok, err = cache.CompareAndSwap(ctx, "config:version", "v1", "v2")
+//
+//	// 原子更新 JSON 对象的单个字段,支持嵌套路径
+//	err = cache.UpdateField(ctx, "user:123", "profile.nickname", "alice")
+//
+//	// 多键事务,读取的键被其他客户端修改时自动重试
+//	err = cache.Transaction(ctx, func(tx cache.CacheTx) error {
+//	    tx.IncrBy("balance:a", -10)
+//	    tx.IncrBy("balance:b", 10)
+//	    return nil
+//	})
+//
+// Lua 脚本(原子执行,Script.Run 优先 EVALSHA,未缓存时回退 EVAL):
+//
+//	n, err := cache.ScriptIncrWithExpiry.Run(ctx, c, []string{"rate:user:1"}, 1, 60000)
+//	v, err := cache.ScriptCompareAndDelete.Run(ctx, c, []string{"lock:order:123"}, token)
+//
 // 批量操作:
 //
 //	// 批量获取
@@ -71,6 +93,19 @@
 //	}
 //	err = cache.Reload(ctx, newConfig)
 //
+// 发布订阅(跨实例缓存失效):
+//
+//	msgs, cancel, err := cache.Subscribe(ctx, "cache:invalidate")
+//	defer cancel()
+//	go func() {
+//	    for key := range msgs {
+//	        localCache.Delete(key)
+//	    }
+//	}()
+//
+//	// 其他实例更新用户后
+//	err = cache.Publish(ctx, "cache:invalidate", "user:123")
+//
 // # 使用场景
 //
 // 1. 数据缓存:
@@ -93,6 +128,10 @@
 //   - 保证操作原子性
 //   - 使用 SET NX 实现
 //
+// 5. 多实例协调:
+//   - 通过 Publish/Subscribe 广播本地缓存失效事件
+//   - 消息不持久化,订阅断开期间的消息会丢失
+//
 // # 最佳实践
 //
 // 1. 键命名:
@@ -108,12 +147,14 @@
 // 3. 错误处理:
 //   - 区分键不存在和其他错误
 //   - 缓存失败不应该导致服务不可用
-//   - 实现降级策略
+//   - 实现降级策略,可通过 Config.CircuitBreaker 启用熔断,
+//     Redis 不可用时操作直接返回 ErrCircuitOpen
 //
 // 4. 性能优化:
 //   - 使用批量操作
 //   - 合理设置连接池大小
-//   - 避免存储过大的值
+//   - 避免存储过大的值,大值可通过 Config.Compression 开启压缩
+//   - 压缩值带有标记前缀,压缩和未压缩的值可以共存,读取时自动解压
 //
 // # 线程安全
 //
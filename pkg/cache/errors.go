@@ -0,0 +1,44 @@
+package cache
+
+import "errors"
+
+// 预定义错误（Sentinel Errors）
+// 可使用 errors.Is() 判断
+var (
+	// ErrCircuitOpen 熔断器处于打开状态
+	// Redis 连续失败后,操作会直接返回此错误而不访问 Redis
+	// 调用方应降级到数据库等数据源
+	ErrCircuitOpen = errors.New("cache circuit breaker is open")
+
+	// ErrKeyNotFound 键不存在
+	// Get、GetWithTTL、Expire 等操作在键不存在时返回的错误会包装此错误
+	ErrKeyNotFound = errors.New("cache key not found")
+
+	// ErrUnsupportedCompression 压缩算法未注册
+	// 配置了未注册的算法,或读取到使用未注册算法压缩的值时返回
+	ErrUnsupportedCompression = errors.New("unsupported cache compression")
+
+	// ErrInvalidCompressor 注册的压缩算法名称或实现无效
+	ErrInvalidCompressor = errors.New("invalid cache compressor")
+
+	// ErrScriptNotFound 服务器脚本缓存中没有该脚本
+	// EvalSha 收到 NOSCRIPT 错误时返回,调用方应改用 Eval 发送完整脚本
+	ErrScriptNotFound = errors.New("cache script not found")
+
+	// ErrInvalidExpiration 过期时间无效
+	// IncrWithExpiry 的 ttl 不足 1 毫秒时返回,PEXPIRE 0 会直接删除键
+	ErrInvalidExpiration = errors.New("invalid cache expiration")
+
+	// ErrInvalidFieldPath 字段路径无效
+	// UpdateField 的路径为空或包含空段(如 "a..b")时返回
+	ErrInvalidFieldPath = errors.New("invalid cache field path")
+
+	// ErrNotJSONObject 缓存值不是 JSON 对象
+	// UpdateField 的目标值不是 JSON 对象,或路径中间的字段不是对象时返回
+	ErrNotJSONObject = errors.New("cache value is not a JSON object")
+
+	// ErrUpdateConflict 并发修改导致更新失败
+	// UpdateField 写回时值连续被其他客户端修改,达到 UpdateFieldMaxRetries 次后返回;
+	// Transaction 监视的键连续被修改,达到 TransactionMaxRetries 次后同样返回
+	ErrUpdateConflict = errors.New("cache update conflict")
+)
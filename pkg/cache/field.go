@@ -0,0 +1,258 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// updateHashFieldScript UpdateField 更新哈希字段使用的 Lua 脚本
+// TYPE 检查和 HSET 在脚本中原子执行,键在两次往返之间被删除或改为其他类型时返回 -1
+//
+//	KEYS[1]: 哈希键
+//	ARGV[1]: 字段名
+//	ARGV[2]: 新值
+//	ARGV[3]: 写入条件,"set" 无条件写入,"cas" 当前值等于 ARGV[4] 时写入,"new" 字段不存在时写入
+//	ARGV[4]: 期望的当前值(仅 "cas")
+//
+// 返回: 1 表示已写入,0 表示字段已被修改,-1 表示键不再是哈希
+var updateHashFieldScript = redis.NewScript(`
+if redis.call("TYPE", KEYS[1])["ok"] ~= "hash" then
+	return -1
+end
+if ARGV[3] ~= "set" then
+	local current = redis.call("HGET", KEYS[1], ARGV[1])
+	if ARGV[3] == "cas" and current ~= ARGV[4] then
+		return 0
+	end
+	if ARGV[3] == "new" and current then
+		return 0
+	end
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
+// UpdateField 更新缓存对象的单个字段
+// 实现 Cache 接口
+// 流程:
+//  1. TYPE 判断存储方式,键不存在时返回 ErrKeyNotFound
+//  2. 字符串(JSON 对象):在客户端修改字段,通过 compareAndSwapScript 原子写回并保留过期时间
+//  3. 哈希:单级路径直接 HSET;多级路径的第一段是哈希字段,其余路径在该字段的 JSON 中修改,
+//     通过 updateHashFieldScript 比较后写回
+//  4. 写回时值已被其他客户端修改则重新读取,最多尝试 UpdateFieldMaxRetries 次
+//
+// 为什么不在 Lua 中用 cjson 修改 JSON:
+// Lua 的数字是双精度浮点数,cjson 重新编码会损坏超过 2^53 的整数(如 Snowflake ID)
+func (r *redisCache) UpdateField(ctx context.Context, key, fieldPath string, value interface{}) error {
+	path, raw, err := prepareFieldUpdate(fieldPath, value)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	return retryFieldUpdate(key, func() (bool, error) {
+		typ, err := client.Type(ctx, key).Result()
+		if err != nil {
+			return false, fmt.Errorf(ErrMsgOperationFailed, "type", err)
+		}
+
+		switch typ {
+		case "none":
+			return false, fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
+		case "string":
+			return updateStringField(ctx, client, key, fieldPath, path, raw)
+		case "hash":
+			return updateHashField(ctx, client, key, fieldPath, path, value, raw)
+		default:
+			return false, fmt.Errorf(ErrMsgNotJSONObject, ErrNotJSONObject, key, fieldPath)
+		}
+	})
+}
+
+// updateStringField 修改以 JSON 字符串存储的对象的字段
+// 返回 false 表示值在读取后被修改或键已删除,需要重试
+func updateStringField(ctx context.Context, client *redis.Client, key, fieldPath string, path []string, raw json.RawMessage) (bool, error) {
+	current, err := client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf(ErrMsgOperationFailed, "get", err)
+	}
+
+	updated, err := setJSONField(key, fieldPath, current, path, raw)
+	if err != nil {
+		return false, err
+	}
+
+	swapped, err := compareAndSwapScript.Run(ctx, client, []string{key}, current, updated).Int()
+	if err != nil {
+		return false, fmt.Errorf(ErrMsgOperationFailed, "update field", err)
+	}
+	return swapped == 1, nil
+}
+
+// updateHashField 修改以哈希存储的对象的字段
+// 返回 false 表示字段在读取后被修改或键不再是哈希,需要重试
+func updateHashField(ctx context.Context, client *redis.Client, key, fieldPath string, path []string, value interface{}, raw json.RawMessage) (bool, error) {
+	field := path[0]
+	args := []interface{}{field, hashFieldValue(value, raw), "set"}
+
+	if len(path) > 1 {
+		current, err := client.HGet(ctx, key, field).Result()
+		mode := "cas"
+		if errors.Is(err, redis.Nil) {
+			current, mode = "{}", "new"
+		} else if err != nil {
+			return false, fmt.Errorf(ErrMsgOperationFailed, "hget", err)
+		}
+
+		updated, err := setJSONField(key, fieldPath, current, path[1:], raw)
+		if err != nil {
+			return false, err
+		}
+		args = []interface{}{field, updated, mode, current}
+	}
+
+	n, err := updateHashFieldScript.Run(ctx, client, []string{key}, args...).Int()
+	if err != nil {
+		return false, fmt.Errorf(ErrMsgOperationFailed, "update field", err)
+	}
+	return n == 1, nil
+}
+
+// UpdateField 更新缓存对象的单个字段,压缩过的值解压后修改再压缩写回
+// 实现 Cache 接口
+// 未压缩的值、哈希和不存在的键交给内部 Cache 处理;
+// 压缩的值无法在服务端修改,读取后通过内部 CompareAndSwap 原子写回
+func (c *compressedCache) UpdateField(ctx context.Context, key, fieldPath string, value interface{}) error {
+	path, raw, err := prepareFieldUpdate(fieldPath, value)
+	if err != nil {
+		return err
+	}
+
+	return retryFieldUpdate(key, func() (bool, error) {
+		stored, err := c.Cache.Get(ctx, key)
+		if err != nil || !strings.HasPrefix(stored, compressionMagic) {
+			return true, c.Cache.UpdateField(ctx, key, fieldPath, value)
+		}
+
+		current, err := decodeValue(key, stored)
+		if err != nil {
+			return false, err
+		}
+		updated, err := setJSONField(key, fieldPath, current, path, raw)
+		if err != nil {
+			return false, err
+		}
+		encoded, err := c.encodeValue(key, updated)
+		if err != nil {
+			return false, err
+		}
+		return c.Cache.CompareAndSwap(ctx, key, stored, encoded.(string))
+	})
+}
+
+// prepareFieldUpdate 解析字段路径并将新值编码为 JSON
+func prepareFieldUpdate(fieldPath string, value interface{}) ([]string, json.RawMessage, error) {
+	path := strings.Split(fieldPath, FieldPathSep)
+	for _, segment := range path {
+		if segment == "" {
+			return nil, nil, fmt.Errorf(ErrMsgInvalidFieldPath, ErrInvalidFieldPath, fieldPath)
+		}
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf(ErrMsgEncodeFieldFailed, fieldPath, err)
+	}
+	return path, raw, nil
+}
+
+// retryFieldUpdate 重复执行字段更新,直到写回成功、出错或达到重试上限
+// attempt 返回 false 表示写回时值已被其他客户端修改
+func retryFieldUpdate(key string, attempt func() (bool, error)) error {
+	for i := 0; i < UpdateFieldMaxRetries; i++ {
+		done, err := attempt()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+	return fmt.Errorf(ErrMsgUpdateConflict, ErrUpdateConflict, key)
+}
+
+// setJSONField 修改 JSON 对象中 path 指向的字段
+// 中间路径不存在或为 null 时创建空对象
+// 使用 json.Number 解码,未修改的数字原样保留,不会损失精度
+// 参数:
+//
+//	key: 缓存键名,用于错误信息
+//	fieldPath: 原始字段路径,用于错误信息
+//	doc: JSON 文本
+//	path: 拆分后的字段路径
+//	value: 字段的新值(JSON 编码)
+//
+// 返回:
+//
+//	string: 修改后的 JSON 文本
+//	error: doc 不是 JSON 对象或中间路径不是对象时返回包装 ErrNotJSONObject 的错误
+func setJSONField(key, fieldPath, doc string, path []string, value json.RawMessage) (string, error) {
+	var root interface{}
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&root); err != nil {
+		return "", fmt.Errorf(ErrMsgNotJSONObject, ErrNotJSONObject, key, fieldPath)
+	}
+
+	obj, ok := root.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf(ErrMsgNotJSONObject, ErrNotJSONObject, key, fieldPath)
+	}
+
+	current := obj
+	for _, segment := range path[:len(path)-1] {
+		next, exists := current[segment]
+		if !exists || next == nil {
+			child := make(map[string]interface{})
+			current[segment] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf(ErrMsgNotJSONObject, ErrNotJSONObject, key, fieldPath)
+		}
+		current = child
+	}
+	current[path[len(path)-1]] = value
+
+	out, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf(ErrMsgEncodeFieldFailed, fieldPath, err)
+	}
+	return string(out), nil
+}
+
+// hashFieldValue 返回写入哈希字段的值
+// 字符串和字节切片原样写入,与 HSET 的习惯一致;其他类型写入 JSON 编码
+func hashFieldValue(value interface{}, raw json.RawMessage) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return string(raw)
+	}
+}
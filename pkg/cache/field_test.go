@@ -0,0 +1,111 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// UpdateField memCache 的字段更新,值按 JSON 对象处理
+func (m *memCache) UpdateField(_ context.Context, key, fieldPath string, value interface{}) error {
+	path, raw, err := prepareFieldUpdate(fieldPath, value)
+	if err != nil {
+		return err
+	}
+	current, ok := m.data[key]
+	if !ok {
+		return fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
+	}
+	updated, err := setJSONField(key, fieldPath, current, path, raw)
+	if err != nil {
+		return err
+	}
+	m.data[key] = updated
+	return nil
+}
+
+// TestSetJSONField 测试嵌套路径、精度保留和错误情况
+func TestSetJSONField(t *testing.T) {
+	doc := `{"id":1790000000000000123,"profile":{"name":"alice","tags":[]},"status":null}`
+
+	tests := []struct {
+		name  string
+		path  string
+		value interface{}
+		want  string
+		err   error
+	}{
+		{"top level", "name", "bob", `{"id":1790000000000000123,"name":"bob","profile":{"name":"alice","tags":[]},"status":null}`, nil},
+		{"nested", "profile.name", "carol", `{"id":1790000000000000123,"profile":{"name":"carol","tags":[]},"status":null}`, nil},
+		{"create missing", "profile.address.city", "Paris", `{"id":1790000000000000123,"profile":{"address":{"city":"Paris"},"name":"alice","tags":[]},"status":null}`, nil},
+		{"replace null", "status.code", 2, `{"id":1790000000000000123,"profile":{"name":"alice","tags":[]},"status":{"code":2}}`, nil},
+		{"through non-object", "profile.name.first", "x", "", ErrNotJSONObject},
+		{"empty segment", "profile..name", "x", "", ErrInvalidFieldPath},
+		{"empty path", "", "x", "", ErrInvalidFieldPath},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, raw, err := prepareFieldUpdate(tt.path, tt.value)
+			if err == nil {
+				var got string
+				got, err = setJSONField("user:1", tt.path, doc, path, raw)
+				if err == nil && got != tt.want {
+					t.Errorf("setJSONField() = %s, want %s", got, tt.want)
+				}
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+
+	path, raw, _ := prepareFieldUpdate("name", "x")
+	if _, err := setJSONField("list", "name", `[1,2]`, path, raw); !errors.Is(err, ErrNotJSONObject) {
+		t.Errorf("array document error = %v, want ErrNotJSONObject", err)
+	}
+}
+
+// TestCompressedCache_UpdateField 测试压缩值解压后修改,未压缩值交给内部 Cache
+func TestCompressedCache_UpdateField(t *testing.T) {
+	ctx := context.Background()
+	inner := newMemCache()
+	c, err := NewCompressedCache(inner, CompressionGzip, 64)
+	if err != nil {
+		t.Fatalf("NewCompressedCache: %v", err)
+	}
+
+	large := fmt.Sprintf(`{"id":1790000000000000123,"bio":%q}`, strings.Repeat("gopher ", 50))
+	_ = c.Set(ctx, "large", large, 0)
+	_ = c.Set(ctx, "small", `{"id":1}`, 0)
+
+	if err := c.UpdateField(ctx, "large", "profile.nickname", "al"); err != nil {
+		t.Fatalf("UpdateField(large): %v", err)
+	}
+	if !strings.HasPrefix(inner.data["large"], compressionMagic+CompressionGzip) {
+		t.Fatalf("updated large value should stay compressed")
+	}
+	got, _ := c.Get(ctx, "large")
+	var user struct {
+		ID      json.Number `json:"id"`
+		Profile struct {
+			Nickname string `json:"nickname"`
+		} `json:"profile"`
+	}
+	if err := json.Unmarshal([]byte(got), &user); err != nil || user.ID != "1790000000000000123" || user.Profile.Nickname != "al" {
+		t.Fatalf("Get after UpdateField = %s, %v", got, err)
+	}
+
+	if err := c.UpdateField(ctx, "small", "status", 2); err != nil {
+		t.Fatalf("UpdateField(small): %v", err)
+	}
+	if got := inner.data["small"]; got != `{"id":1,"status":2}` {
+		t.Fatalf("small value = %s", got)
+	}
+
+	if err := c.UpdateField(ctx, "missing", "status", 2); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("UpdateField(missing) error = %v, want ErrKeyNotFound", err)
+	}
+}
@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Publish 向频道发布消息
+// 实现 Cache 接口
+func (r *redisCache) Publish(ctx context.Context, channel, message string) error {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 PUBLISH 命令
+	if err := client.Publish(ctx, channel, message).Err(); err != nil {
+		return fmt.Errorf(ErrMsgOperationFailed, "publish", err)
+	}
+
+	return nil
+}
+
+// Subscribe 订阅频道
+// 实现 Cache 接口
+// 设计要点:
+//   - 先等待 Redis 确认订阅再返回,返回后发布的消息不会丢失
+//   - 后台 goroutine 将 go-redis 的消息转发到返回的通道,只传递消息内容
+//   - cancel、ctx 结束或底层连接关闭(Close/Reload)时退订并关闭通道
+func (r *redisCache) Subscribe(ctx context.Context, channel string) (<-chan string, func(), error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 SUBSCRIBE 命令并等待确认
+	ps := client.Subscribe(ctx, channel)
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, nil, fmt.Errorf(ErrMsgOperationFailed, "subscribe", err)
+	}
+
+	// Channel 在 PubSub 关闭时关闭,连接断开时自动重连并重新订阅
+	msgs := ps.Channel(redis.WithChannelSize(DefaultSubscribeBufferSize))
+	out := make(chan string, DefaultSubscribeBufferSize)
+	done := make(chan struct{})
+
+	var once sync.Once
+	var id uint64
+	cancel := func() {
+		once.Do(func() {
+			r.removeSubscription(id)
+			close(done)
+			_ = ps.Close()
+		})
+	}
+	id = r.addSubscription(cancel)
+
+	go func() {
+		defer close(out)
+		defer cancel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case msg, ok := <-msgs:
+				if !ok {
+					return
+				}
+				select {
+				case out <- msg.Payload:
+				case <-ctx.Done():
+					return
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	return out, cancel, nil
+}
+
+// addSubscription 登记订阅的退订函数,返回用于注销的 id
+// Close 和 Reload 关闭底层连接时通过 closeSubscriptions 统一退订
+func (r *redisCache) addSubscription(cancel func()) uint64 {
+	r.subsMu.Lock()
+	defer r.subsMu.Unlock()
+
+	if r.subs == nil {
+		r.subs = make(map[uint64]func())
+	}
+	r.nextSubID++
+	r.subs[r.nextSubID] = cancel
+	return r.nextSubID
+}
+
+// removeSubscription 注销订阅
+func (r *redisCache) removeSubscription(id uint64) {
+	r.subsMu.Lock()
+	delete(r.subs, id)
+	r.subsMu.Unlock()
+}
+
+// closeSubscriptions 退订所有订阅,关闭对应的消息通道
+func (r *redisCache) closeSubscriptions() {
+	r.subsMu.Lock()
+	cancels := make([]func(), 0, len(r.subs))
+	for _, cancel := range r.subs {
+		cancels = append(cancels, cancel)
+	}
+	r.subsMu.Unlock()
+
+	// 在锁外调用,cancel 会通过 removeSubscription 重新获取锁
+	for _, cancel := range cancels {
+		cancel()
+	}
+}
@@ -10,6 +10,17 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// compareAndSwapScript CompareAndSwap 使用的 Lua 脚本
+// GET 和 SET 在脚本中原子执行,KEEPTTL 保留键的剩余过期时间(需要 Redis 6.0+)
+// 键不存在时 GET 返回 false,不会与任何字符串相等
+var compareAndSwapScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "KEEPTTL")
+	return 1
+end
+return 0
+`)
+
 // redisCache Redis 缓存实现
 // 封装 go-redis/v9 客户端,实现 Cache 接口
 // 为什么封装 Redis客户端?
@@ -37,6 +48,17 @@ type redisCache struct {
 	// logger 日志记录器(可选)
 	// 用于记录连接、操作等日志
 	logger Logger
+
+	// breaker 熔断器(可选)
+	// 以 Hook 形式挂载到 client 上,Reload 后挂载到新 client 继续生效
+	// 为 nil 时不启用熔断
+	breaker *circuitBreaker
+
+	// subs 活跃订阅的退订函数,Close 和 Reload 时统一退订
+	// subsMu 保护 subs 和 nextSubID,与 mu 分开避免退订时与 client 替换互相等待
+	subsMu    sync.Mutex
+	subs      map[uint64]func()
+	nextSubID uint64
 }
 
 // Logger 日志接口
@@ -129,12 +151,36 @@ func NewRedis(config *Config, logger Logger) (Cache, error) {
 		logger.Info(MsgCacheConnected)
 	}
 
-	// 4. 返回实例
-	return &redisCache{
-		client: client,
-		config: config,
-		logger: logger,
-	}, nil
+	// 4. 挂载熔断器(如果启用)
+	// 在连接测试之后挂载,启动时的连接失败直接返回错误而不计入熔断
+	var breaker *circuitBreaker
+	if config.CircuitBreaker != nil {
+		breaker = newCircuitBreaker(*config.CircuitBreaker)
+		client.AddHook(breaker)
+	}
+
+	// 5. 挂载值压缩装饰器
+	// 未开启压缩时也挂载,保证关闭压缩后仍能读取之前压缩写入的值
+	// 配置已通过验证,这里不会失败
+	return NewCompressedCache(&redisCache{
+		client:  client,
+		config:  config,
+		logger:  logger,
+		breaker: breaker,
+	}, config.Compression, config.CompressionThreshold)
+}
+
+// BreakerState 返回熔断器当前状态
+// 实现 Cache 接口
+func (r *redisCache) BreakerState() BreakerState {
+	r.mu.RLock()
+	breaker := r.breaker
+	r.mu.RUnlock()
+
+	if breaker == nil {
+		return BreakerClosed
+	}
+	return breaker.State()
 }
 
 // Get 获取键的值
@@ -151,7 +197,7 @@ func (r *redisCache) Get(ctx context.Context, key string) (string, error) {
 		// 检查是否是键不存在错误
 		if errors.Is(err, redis.Nil) {
 			// redis.Nil 表示键不存在,这是预期的情况,不是错误
-			return "", fmt.Errorf(ErrMsgKeyNotFound, key)
+			return "", fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
 		}
 		// 其他错误
 		return "", fmt.Errorf(ErrMsgOperationFailed, "get", err)
@@ -160,6 +206,32 @@ func (r *redisCache) Get(ctx context.Context, key string) (string, error) {
 	return result, nil
 }
 
+// GetWithTTL 获取键的值和剩余生存时间
+// 实现 Cache 接口
+// 使用 MULTI/EXEC 事务管道在一次往返中执行 GET 和 TTL,
+// 两条命令之间不会插入其他客户端的命令,避免键在两次调用之间过期
+func (r *redisCache) GetWithTTL(ctx context.Context, key string) (string, time.Duration, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	var getCmd *redis.StringCmd
+	var ttlCmd *redis.DurationCmd
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(ctx, key)
+		ttlCmd = pipe.TTL(ctx, key)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", 0, fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
+		}
+		return "", 0, fmt.Errorf(ErrMsgOperationFailed, "get with ttl", err)
+	}
+
+	return getCmd.Val(), ttlCmd.Val(), nil
+}
+
 // Set 设置键值对
 // 实现 Cache 接口
 func (r *redisCache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
@@ -277,7 +349,7 @@ func (r *redisCache) Expire(ctx context.Context, key string, expiration time.Dur
 
 	// ok 为 false 表示键不存在
 	if !ok {
-		return fmt.Errorf(ErrMsgKeyNotFound, key)
+		return fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
 	}
 
 	return nil
@@ -347,6 +419,75 @@ func (r *redisCache) IncrBy(ctx context.Context, key string, value int64) (int64
 	return result, nil
 }
 
+// IncrWithExpiry 原子增加指定值,键新建时设置过期时间
+// 实现 Cache 接口
+func (r *redisCache) IncrWithExpiry(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	// PEXPIRE 以毫秒为单位,不足 1 毫秒会变成 PEXPIRE 0 直接删除键
+	if ttl < time.Millisecond {
+		return 0, fmt.Errorf(ErrMsgInvalidExpiration, ErrInvalidExpiration, ttl)
+	}
+
+	// 执行 INCRBY,仅在键没有过期时间时 PEXPIRE
+	result, err := ScriptIncrWithExpiry.Run(ctx, r, []string{key}, delta, ttl.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+
+	n, ok := result.(int64)
+	if !ok {
+		return 0, fmt.Errorf(ErrMsgUnexpectedScriptResult, "incr with expiry", result)
+	}
+	return n, nil
+}
+
+// SetNX 仅当键不存在时设置键值对
+// 实现 Cache 接口
+func (r *redisCache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 SET key value NX [PX expiration]
+	ok, err := client.SetNX(ctx, key, value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf(ErrMsgOperationFailed, "setnx", err)
+	}
+
+	return ok, nil
+}
+
+// SetXX 仅当键已存在时设置键值对
+// 实现 Cache 接口
+func (r *redisCache) SetXX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 SET key value XX [PX expiration]
+	ok, err := client.SetXX(ctx, key, value, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf(ErrMsgOperationFailed, "setxx", err)
+	}
+
+	return ok, nil
+}
+
+// CompareAndSwap 仅当键的当前值等于 old 时替换为 new
+// 实现 Cache 接口
+func (r *redisCache) CompareAndSwap(ctx context.Context, key string, old, new string) (bool, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// Run 先尝试 EVALSHA,脚本未缓存时自动回退到 EVAL
+	swapped, err := compareAndSwapScript.Run(ctx, client, []string{key}, old, new).Int()
+	if err != nil {
+		return false, fmt.Errorf(ErrMsgOperationFailed, "compare and swap", err)
+	}
+
+	return swapped == 1, nil
+}
+
 // Ping 测试连接
 // 实现 Cache 接口
 func (r *redisCache) Ping(ctx context.Context) error {
@@ -384,6 +525,9 @@ func (r *redisCache) Close() error {
 		r.logger.Info(MsgCacheClosing)
 	}
 
+	// 先退订,订阅连接不在普通连接池中
+	r.closeSubscriptions()
+
 	// 关闭 Redis 客户端
 	err := r.client.Close()
 	if err != nil {
@@ -444,14 +588,22 @@ func (r *redisCache) Reload(ctx context.Context, newConfig *Config) error {
 
 	// 4. 原子替换(使用写锁)
 	// 这一步很快,不会阻塞太久
+	// 新连接已验证可用,熔断器重新开始计数
 	r.mu.Lock()
 	oldClient := r.client
 	r.client = newClient
 	r.config = newConfig
+	r.breaker = nil
+	if newConfig.CircuitBreaker != nil {
+		r.breaker = newCircuitBreaker(*newConfig.CircuitBreaker)
+		newClient.AddHook(r.breaker)
+	}
 	r.mu.Unlock()
 
 	// 5. 关闭旧连接
 	// 在锁外执行,避免阻塞
+	// 订阅绑定在旧连接上,一并退订,调用方需重新订阅
+	r.closeSubscriptions()
 	if oldClient != nil {
 		oldClient.Close()
 	}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 预置 Lua 脚本
+// 脚本在 Redis 中原子执行,用于单条命令无法表达的复合操作
+var (
+	// ScriptIncrWithExpiry 原子地自增并在首次创建时设置过期时间
+	// 用于固定窗口限流:窗口内第一次请求创建计数器并设置窗口长度,
+	// 之后的请求只自增,不会刷新过期时间
+	// 键没有过期时间(如由 INCR 创建后进程崩溃)时同样补设,避免计数器永不过期
+	//   KEYS[1]: 计数器键
+	//   ARGV[1]: 增量
+	//   ARGV[2]: 过期时间(毫秒)
+	// 返回: 自增后的值 (int64)
+	ScriptIncrWithExpiry = NewScript(`
+local n = redis.call("INCRBY", KEYS[1], ARGV[1])
+if redis.call("PTTL", KEYS[1]) == -1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return n
+`)
+
+	// ScriptCompareAndDelete 仅当键的值等于期望值时删除
+	// 用于释放分布式锁:只有持有者(值为持有者的随机令牌)才能删除锁,
+	// 避免锁过期后被其他实例获取时误删
+	//   KEYS[1]: 键
+	//   ARGV[1]: 期望的当前值
+	// 返回: 1 表示已删除,0 表示键不存在或值不相等 (int64)
+	ScriptCompareAndDelete = NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// Script 可复用的 Lua 脚本
+// 创建时计算脚本的 SHA1,Run 优先通过 EVALSHA 只发送摘要,
+// 服务器未缓存该脚本时(首次执行、SCRIPT FLUSH 或重启后)自动回退到 EVAL
+// 与 go-redis 的 redis.Script 行为一致,但基于 Cache 接口,可以配合任意实现使用
+//
+// 使用示例:
+//
+//	var releaseLock = cache.ScriptCompareAndDelete
+//	n, err := releaseLock.Run(ctx, c, []string{"lock:order:1"}, token)
+type Script struct {
+	// src 脚本源码
+	src string
+
+	// hash 脚本源码的 SHA1(十六进制小写)
+	hash string
+}
+
+// NewScript 创建脚本
+// 参数:
+//
+//	src: Lua 脚本源码,通过 KEYS 和 ARGV 访问参数
+//
+// 返回:
+//
+//	*Script: 脚本实例,可以在多个 goroutine 间共享
+func NewScript(src string) *Script {
+	sum := sha1.Sum([]byte(src))
+	return &Script{src: src, hash: hex.EncodeToString(sum[:])}
+}
+
+// Source 返回脚本源码
+func (s *Script) Source() string {
+	return s.src
+}
+
+// Hash 返回脚本的 SHA1,即 EVALSHA 使用的摘要
+func (s *Script) Hash() string {
+	return s.hash
+}
+
+// Load 将脚本预加载到 Redis 的脚本缓存
+// 可选:Run 在脚本未缓存时会自动回退到 EVAL,预加载只是避免首次执行多一次往返
+// 参数:
+//
+//	ctx: 上下文
+//	c: 缓存实例
+//
+// 返回:
+//
+//	error: 加载失败时的错误
+func (s *Script) Load(ctx context.Context, c Cache) error {
+	_, err := c.ScriptLoad(ctx, s.src)
+	return err
+}
+
+// Run 执行脚本
+// 先通过 EVALSHA 执行,返回 ErrScriptNotFound 时使用 EVAL 发送完整脚本,
+// EVAL 同时会将脚本加入服务器缓存,之后的调用只需发送摘要
+// 参数:
+//
+//	ctx: 上下文
+//	c: 缓存实例
+//	keys: 脚本访问的键 (KEYS)
+//	args: 脚本参数 (ARGV)
+//
+// 返回:
+//
+//	interface{}: 脚本返回值,类型见 Cache.Eval
+//	error: 执行失败时的错误
+func (s *Script) Run(ctx context.Context, c Cache, keys []string, args ...interface{}) (interface{}, error) {
+	result, err := c.EvalSha(ctx, s.hash, keys, args...)
+	if errors.Is(err, ErrScriptNotFound) {
+		return c.Eval(ctx, s.src, keys, args...)
+	}
+	return result, err
+}
+
+// Eval 执行 Lua 脚本
+// 实现 Cache 接口
+func (r *redisCache) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 EVAL 命令
+	result, err := client.Eval(ctx, script, keys, args...).Result()
+	return scriptResult("eval", result, err)
+}
+
+// EvalSha 按 SHA1 执行服务器已缓存的 Lua 脚本
+// 实现 Cache 接口
+func (r *redisCache) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) (interface{}, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 EVALSHA 命令
+	result, err := client.EvalSha(ctx, sha1, keys, args...).Result()
+	if redis.HasErrorPrefix(err, "NOSCRIPT") {
+		return nil, fmt.Errorf(ErrMsgScriptNotFound, ErrScriptNotFound, sha1)
+	}
+	return scriptResult("evalsha", result, err)
+}
+
+// ScriptLoad 将脚本加载到服务器缓存
+// 实现 Cache 接口
+func (r *redisCache) ScriptLoad(ctx context.Context, script string) (string, error) {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	// 执行 SCRIPT LOAD 命令
+	sha, err := client.ScriptLoad(ctx, script).Result()
+	if err != nil {
+		return "", fmt.Errorf(ErrMsgOperationFailed, "script load", err)
+	}
+
+	return sha, nil
+}
+
+// scriptResult 处理脚本执行结果
+// 脚本返回 nil 或 false 时 go-redis 返回 redis.Nil,这里转换为 nil 结果而非错误
+func scriptResult(op string, result interface{}, err error) (interface{}, error) {
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf(ErrMsgOperationFailed, op, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// scriptCache 记录脚本调用的 Cache 桩实现
+type scriptCache struct {
+	Cache
+	loaded map[string]bool
+	calls  []string
+}
+
+func (s *scriptCache) EvalSha(_ context.Context, sha1 string, _ []string, _ ...interface{}) (interface{}, error) {
+	s.calls = append(s.calls, "evalsha")
+	if !s.loaded[sha1] {
+		return nil, fmt.Errorf(ErrMsgScriptNotFound, ErrScriptNotFound, sha1)
+	}
+	return int64(1), nil
+}
+
+func (s *scriptCache) Eval(_ context.Context, script string, _ []string, _ ...interface{}) (interface{}, error) {
+	s.calls = append(s.calls, "eval")
+	s.loaded[NewScript(script).Hash()] = true
+	return int64(1), nil
+}
+
+// TestScriptRun_FallbackToEval 测试脚本未缓存时回退到 EVAL,之后使用 EVALSHA
+func TestScriptRun_FallbackToEval(t *testing.T) {
+	c := &scriptCache{loaded: make(map[string]bool)}
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := ScriptCompareAndDelete.Run(ctx, c, []string{"lock:a"}, "token"); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	}
+
+	want := []string{"evalsha", "eval", "evalsha"}
+	if fmt.Sprint(c.calls) != fmt.Sprint(want) {
+		t.Errorf("calls = %v, want %v", c.calls, want)
+	}
+	if h := ScriptCompareAndDelete.Hash(); len(h) != 40 {
+		t.Errorf("unexpected sha1 %q", h)
+	}
+}
+
+// TestIncrWithExpiry_InvalidTTL 测试过期时间不足 1 毫秒时不访问 Redis
+func TestIncrWithExpiry_InvalidTTL(t *testing.T) {
+	r := &redisCache{}
+	for _, ttl := range []time.Duration{0, -time.Second, time.Microsecond} {
+		if _, err := r.IncrWithExpiry(context.Background(), "ratelimit:1", 1, ttl); !errors.Is(err, ErrInvalidExpiration) {
+			t.Errorf("IncrWithExpiry(ttl=%v) error = %v, want ErrInvalidExpiration", ttl, err)
+		}
+	}
+}
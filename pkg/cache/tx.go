@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheTx 事务中可用的操作
+// 读取立即执行,并自动 WATCH 读取的键;写入先排队,fn 返回 nil 后通过 MULTI/EXEC 一次性原子执行
+//
+// 为什么写入不返回结果:
+// 排队的命令在 EXEC 时才执行,fn 中无法拿到结果;需要根据当前值计算新值时,先 Get 再写入
+type CacheTx interface {
+	// Watch 监视键,EXEC 前这些键被其他客户端修改时事务放弃并重试
+	// 只写不读的键需要参与冲突检测时调用;Get 会自动监视读取的键
+	// 参数:
+	//   keys: 要监视的键
+	// 返回:
+	//   error: WATCH 失败时的错误
+	Watch(keys ...string) error
+
+	// Get 读取键的值并监视该键
+	// 参数:
+	//   key: 缓存键名
+	// 返回:
+	//   string: 键对应的值
+	//   error: 键不存在时返回包装 ErrKeyNotFound 的错误
+	Get(key string) (string, error)
+
+	// Set 排队设置键值对,expiration 为 0 表示永不过期
+	Set(key string, value interface{}, expiration time.Duration)
+
+	// Delete 排队删除键
+	Delete(keys ...string)
+
+	// IncrBy 排队将键的值增加指定数值,value 为负数时减少
+	IncrBy(key string, value int64)
+
+	// Expire 排队设置键的过期时间
+	Expire(key string, expiration time.Duration)
+}
+
+// redisTx CacheTx 的 Redis 实现
+// 读取和 WATCH 在 redis.Tx 持有的连接上立即执行,写入记录在 queued 中等待 EXEC
+type redisTx struct {
+	ctx    context.Context
+	tx     *redis.Tx
+	queued []func(pipe redis.Pipeliner)
+}
+
+// Watch 实现 CacheTx 接口
+func (t *redisTx) Watch(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if err := t.tx.Watch(t.ctx, keys...).Err(); err != nil {
+		return fmt.Errorf(ErrMsgOperationFailed, "watch", err)
+	}
+	return nil
+}
+
+// Get 实现 CacheTx 接口
+func (t *redisTx) Get(key string) (string, error) {
+	if err := t.Watch(key); err != nil {
+		return "", err
+	}
+	value, err := t.tx.Get(t.ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", fmt.Errorf(ErrMsgKeyNotFound, ErrKeyNotFound, key)
+	}
+	if err != nil {
+		return "", fmt.Errorf(ErrMsgOperationFailed, "get", err)
+	}
+	return value, nil
+}
+
+// Set 实现 CacheTx 接口
+func (t *redisTx) Set(key string, value interface{}, expiration time.Duration) {
+	t.queued = append(t.queued, func(pipe redis.Pipeliner) {
+		pipe.Set(t.ctx, key, value, expiration)
+	})
+}
+
+// Delete 实现 CacheTx 接口
+func (t *redisTx) Delete(keys ...string) {
+	if len(keys) == 0 {
+		return
+	}
+	t.queued = append(t.queued, func(pipe redis.Pipeliner) {
+		pipe.Del(t.ctx, keys...)
+	})
+}
+
+// IncrBy 实现 CacheTx 接口
+func (t *redisTx) IncrBy(key string, value int64) {
+	t.queued = append(t.queued, func(pipe redis.Pipeliner) {
+		pipe.IncrBy(t.ctx, key, value)
+	})
+}
+
+// Expire 实现 CacheTx 接口
+func (t *redisTx) Expire(key string, expiration time.Duration) {
+	t.queued = append(t.queued, func(pipe redis.Pipeliner) {
+		pipe.Expire(t.ctx, key, expiration)
+	})
+}
+
+// Transaction 在 MULTI/EXEC 事务中原子地修改多个键
+// 实现 Cache 接口
+// 流程:
+//  1. 在专用连接上调用 fn,Get 立即执行并 WATCH 读取的键,写入排队
+//  2. fn 返回 nil 时通过 TxPipelined 发送 MULTI、排队的命令和 EXEC
+//  3. 被监视的键在 EXEC 前被修改时 EXEC 返回 redis.TxFailedErr,重新执行 fn,
+//     最多尝试 TransactionMaxRetries 次
+func (r *redisCache) Transaction(ctx context.Context, fn func(tx CacheTx) error) error {
+	r.mu.RLock()
+	client := r.client
+	r.mu.RUnlock()
+
+	for i := 0; i < TransactionMaxRetries; i++ {
+		var fnErr error
+		err := client.Watch(ctx, func(tx *redis.Tx) error {
+			t := &redisTx{ctx: ctx, tx: tx}
+			if fnErr = fn(t); fnErr != nil {
+				return fnErr
+			}
+			if len(t.queued) == 0 {
+				return nil
+			}
+			_, err := tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				for _, q := range t.queued {
+					q(pipe)
+				}
+				return nil
+			})
+			return err
+		})
+		switch {
+		case err == nil:
+			return nil
+		case fnErr != nil:
+			// fn 返回的错误原样返回,事务已放弃,不重试
+			return fnErr
+		case errors.Is(err, redis.TxFailedErr):
+			continue
+		default:
+			return fmt.Errorf(ErrMsgOperationFailed, "transaction", err)
+		}
+	}
+	return fmt.Errorf(ErrMsgTxConflict, ErrUpdateConflict, TransactionMaxRetries)
+}
+
+// compressedTx 值压缩装饰器的事务
+// Get 解压读取的值,Set 按压缩设置编码写入的值
+type compressedTx struct {
+	CacheTx
+
+	c *compressedCache
+
+	// err 编码失败的错误,fn 返回后检查,非 nil 时放弃事务
+	err error
+}
+
+// Get 读取键的值,压缩过的值自动解压
+// 实现 CacheTx 接口
+func (t *compressedTx) Get(key string) (string, error) {
+	value, err := t.CacheTx.Get(key)
+	if err != nil {
+		return "", err
+	}
+	return decodeValue(key, value)
+}
+
+// Set 排队设置键值对,达到阈值的值自动压缩
+// 实现 CacheTx 接口
+func (t *compressedTx) Set(key string, value interface{}, expiration time.Duration) {
+	encoded, err := t.c.encodeValue(key, value)
+	if err != nil {
+		if t.err == nil {
+			t.err = err
+		}
+		return
+	}
+	t.CacheTx.Set(key, encoded, expiration)
+}
+
+// Transaction 在事务中原子地修改多个键,读写的值自动解压和压缩
+// 实现 Cache 接口
+// 编码失败时放弃事务并返回编码错误
+func (c *compressedCache) Transaction(ctx context.Context, fn func(tx CacheTx) error) error {
+	return c.Cache.Transaction(ctx, func(tx CacheTx) error {
+		t := &compressedTx{CacheTx: tx, c: c}
+		if err := fn(t); err != nil {
+			return err
+		}
+		return t.err
+	})
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memTx memCache 的事务,写入记录在 queued 中,fn 返回 nil 后一次性应用
+type memTx struct {
+	m      *memCache
+	queued []func()
+}
+
+func (t *memTx) Watch(...string) error { return nil }
+
+func (t *memTx) Get(key string) (string, error) {
+	return t.m.Get(context.Background(), key)
+}
+
+func (t *memTx) Set(key string, value interface{}, expiration time.Duration) {
+	t.queued = append(t.queued, func() { _ = t.m.Set(context.Background(), key, value, expiration) })
+}
+
+func (t *memTx) Delete(keys ...string) {
+	t.queued = append(t.queued, func() {
+		for _, k := range keys {
+			delete(t.m.data, k)
+		}
+	})
+}
+
+func (t *memTx) IncrBy(key string, value int64) {
+	t.queued = append(t.queued, func() {
+		n, _ := strconv.ParseInt(t.m.data[key], 10, 64)
+		t.m.data[key] = strconv.FormatInt(n+value, 10)
+	})
+}
+
+func (t *memTx) Expire(string, time.Duration) {}
+
+// Transaction memCache 的事务,fn 返回错误时不应用任何写入
+func (m *memCache) Transaction(_ context.Context, fn func(tx CacheTx) error) error {
+	tx := &memTx{m: m}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	for _, q := range tx.queued {
+		q()
+	}
+	return nil
+}
+
+// failingCompressor 总是压缩失败的压缩器
+type failingCompressor struct{}
+
+func (failingCompressor) Compress([]byte) ([]byte, error)   { return nil, errors.New("boom") }
+func (failingCompressor) Decompress([]byte) ([]byte, error) { return nil, errors.New("boom") }
+
+// TestCompressedCache_Transaction 测试事务中的读写经过压缩和解压
+func TestCompressedCache_Transaction(t *testing.T) {
+	ctx := context.Background()
+	inner := newMemCache()
+	c, err := NewCompressedCache(inner, CompressionGzip, 64)
+	if err != nil {
+		t.Fatalf("NewCompressedCache: %v", err)
+	}
+
+	large := largePayload(50)
+	_ = c.Set(ctx, "src", large, 0)
+	inner.data["a"], inner.data["b"] = "10", "0"
+
+	err = c.Transaction(ctx, func(tx CacheTx) error {
+		v, err := tx.Get("src")
+		if err != nil {
+			return err
+		}
+		if v != large {
+			t.Errorf("tx.Get returned compressed value")
+		}
+		tx.Set("dst", v, 0)
+		tx.Delete("src")
+		tx.IncrBy("a", -3)
+		tx.IncrBy("b", 3)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	if !strings.HasPrefix(inner.data["dst"], compressionMagic+CompressionGzip) {
+		t.Error("tx.Set value not compressed")
+	}
+	if got, _ := c.Get(ctx, "dst"); got != large {
+		t.Error("Get after Transaction returned wrong value")
+	}
+	if _, ok := inner.data["src"]; ok || inner.data["a"] != "7" || inner.data["b"] != "3" {
+		t.Errorf("writes not applied: data=%v", inner.data)
+	}
+
+	// fn 返回错误:放弃事务,错误原样返回
+	errAbort := errors.New("insufficient balance")
+	err = c.Transaction(ctx, func(tx CacheTx) error {
+		tx.IncrBy("a", -100)
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) || inner.data["a"] != "7" {
+		t.Errorf("aborted Transaction = %v, a=%s", err, inner.data["a"])
+	}
+
+	// 编码失败:放弃事务
+	if err := RegisterCompressor("failing", failingCompressor{}); err != nil {
+		t.Fatalf("RegisterCompressor: %v", err)
+	}
+	failing, _ := NewCompressedCache(inner, "failing", 1)
+	err = failing.Transaction(ctx, func(tx CacheTx) error {
+		tx.Set("x", "value", 0)
+		tx.IncrBy("a", 1)
+		return nil
+	})
+	if err == nil || inner.data["a"] != "7" {
+		t.Errorf("Transaction with encode failure = %v, a=%s; want error and no writes", err, inner.data["a"])
+	}
+}
@@ -1,11 +1,16 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/signal"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
 )
 
 // app CLI 应用实现
@@ -74,11 +79,24 @@ func (a *app) AddCommand(cmd Command) error {
 
 // Run 执行 CLI
 func (a *app) Run(args []string) error {
-	return a.RunWithIO(args, os.Stdin, os.Stdout, os.Stderr)
+	return a.RunContext(context.Background(), args)
+}
+
+// RunContext 执行 CLI，并将 SIGINT/SIGTERM 转换为命令上下文的取消
+func (a *app) RunContext(ctx context.Context, args []string) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	return a.run(ctx, args, os.Stdin, os.Stdout, os.Stderr)
 }
 
 // RunWithIO 执行 CLI，使用自定义 I/O
 func (a *app) RunWithIO(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	return a.run(context.Background(), args, stdin, stdout, stderr)
+}
+
+// run 解析参数并在 ctx 下执行对应命令
+func (a *app) run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
 	// 没有参数或只有 help 选项，显示帮助
 	if len(args) == 0 || args[0] == "--help" || args[0] == "-h" {
 		a.printHelp(stdout)
@@ -104,23 +122,49 @@ func (a *app) RunWithIO(args []string, stdin io.Reader, stdout, stderr io.Writer
 	}
 
 	// 解析命令选项
-	parser := newFlagParser(cmdName, cmd.Flags())
+	flags, builtinTimeout := withTimeoutFlag(cmd.Flags())
+	parser := newFlagParser(cmdName, flags)
 	remainingArgs, err := parser.parse(args[1:])
 	if err != nil {
 		return err
 	}
 
+	// 应用 --timeout
+	var timeout time.Duration
+	if builtinTimeout {
+		if timeout, err = parseTimeout(parser.getValues()); err != nil {
+			return err
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	// 创建执行上下文
-	ctx := &Context{
+	cmdCtx := &Context{
 		Args:   remainingArgs,
 		Flags:  parser.getValues(),
 		Stdin:  stdin,
 		Stdout: stdout,
 		Stderr: stderr,
+		Ctx:    ctx,
 	}
 
 	// 执行命令
-	if err := cmd.Execute(ctx); err != nil {
+	if err := cmd.Execute(cmdCtx); err != nil {
+		// 上下文已结束时,优先报告取消/超时而不是命令自身的错误
+		switch {
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			return &CommandError{
+				Command: cmdName,
+				Message: fmt.Sprintf("%s after %s", ErrMsgTimeout, timeout),
+				Cause:   err,
+			}
+		case errors.Is(ctx.Err(), context.Canceled):
+			return &CancelledError{}
+		}
 		return &CommandError{
 			Command: cmdName,
 			Message: "execution failed",
@@ -131,6 +175,40 @@ func (a *app) RunWithIO(args []string, stdin io.Reader, stdout, stderr io.Writer
 	return nil
 }
 
+// withTimeoutFlag 为命令追加内置的 --timeout 选项
+// 如果命令自己定义了同名选项,则保留命令的定义且不启用内置超时 (返回 false)
+func withTimeoutFlag(flags []Flag) ([]Flag, bool) {
+	for _, f := range flags {
+		if f.Name == DefaultTimeoutFlag {
+			return flags, false
+		}
+	}
+
+	result := make([]Flag, 0, len(flags)+1)
+	result = append(result, flags...)
+	return append(result, Flag{
+		Name:        DefaultTimeoutFlag,
+		Type:        FlagTypeString,
+		Description: "Maximum execution time (e.g. 30s, 5m)",
+	}), true
+}
+
+// parseTimeout 从解析后的选项中读取 --timeout
+func parseTimeout(values map[string]interface{}) (time.Duration, error) {
+	s, ok := values[DefaultTimeoutFlag].(string)
+	if !ok || s == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(s)
+	if err != nil || timeout < 0 {
+		return 0, &UsageError{
+			Message: fmt.Sprintf("%s: --%s=%s", ErrMsgInvalidFlagValue, DefaultTimeoutFlag, s),
+		}
+	}
+	return timeout, nil
+}
+
 // printHelp 打印帮助信息
 func (a *app) printHelp(w io.Writer) {
 	fmt.Fprintf(w, "%s", a.name)
@@ -171,6 +249,7 @@ func (a *app) printHelp(w io.Writer) {
 	fmt.Fprintln(w, "\nFlags:")
 	fmt.Fprintln(w, "  -h, --help       Show help information")
 	fmt.Fprintln(w, "  -v, --version    Show version information")
+	fmt.Fprintln(w, "      --timeout    Maximum command execution time (e.g. 30s, 5m)")
 
 	fmt.Fprintf(w, "\nRun '%s [command] --help' for more information on a command.\n", a.name)
 }
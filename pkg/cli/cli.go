@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"context"
 	"io"
 )
 
@@ -52,6 +53,9 @@ type App interface {
 	AddCommand(cmd Command) error
 	// Run 执行 CLI，解析参数并路由到对应命令
 	Run(args []string) error
+	// RunContext 执行 CLI，命令上下文在收到 SIGINT/SIGTERM、
+	// ctx 被取消或 --timeout 到期时取消
+	RunContext(ctx context.Context, args []string) error
 	// RunWithIO 执行 CLI，使用自定义 I/O (用于测试)
 	RunWithIO(args []string, stdin io.Reader, stdout, stderr io.Writer) error
 }
@@ -82,6 +86,18 @@ type Context struct {
 	Stdout io.Writer
 	// Stderr 标准错误输出
 	Stderr io.Writer
+	// Ctx 命令执行的上下文
+	// 收到 SIGINT/SIGTERM 或 --timeout 到期时被取消,长时间运行的命令应监听 Ctx.Done()
+	Ctx context.Context
+}
+
+// Context 返回命令执行的 context.Context
+// 未设置时返回 context.Background()
+func (c *Context) Context() context.Context {
+	if c.Ctx == nil {
+		return context.Background()
+	}
+	return c.Ctx
 }
 
 // GetString 获取字符串类型的选项值
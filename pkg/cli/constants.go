@@ -29,6 +29,8 @@ const (
 	ErrMsgCancelled = "operation cancelled"
 	// ErrMsgInvalidFlagValue 无效的选项值
 	ErrMsgInvalidFlagValue = "invalid flag value"
+	// ErrMsgTimeout 命令执行超时
+	ErrMsgTimeout = "command timed out"
 )
 
 // 默认值
@@ -37,4 +39,7 @@ const (
 	DefaultHelpFlag = "help"
 	// DefaultVersionFlag version 选项名
 	DefaultVersionFlag = "version"
+	// DefaultTimeoutFlag 内置的超时选项名
+	// 取值为 time.ParseDuration 格式 (如 "30s"、"5m"),为空或 0 表示不限时
+	DefaultTimeoutFlag = "timeout"
 )
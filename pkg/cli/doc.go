@@ -11,6 +11,7 @@ binding, and testability-first design.
   - Standard error codes following Unix conventions
   - Automatic help generation
   - Environment variable fallback for flags
+  - Built-in SIGINT/SIGTERM cancellation and --timeout via Context.Context()
 
 # Usage
 
@@ -102,6 +103,21 @@ Commands are testable using custom I/O:
 	    }
 	}
 
+# Cancellation
+
+Run and RunContext cancel the command context on SIGINT/SIGTERM, and every
+command accepts a built-in --timeout flag (e.g. --timeout=30s). Long-running
+commands should watch ctx.Context().Done():
+
+	func (c *ServeCommand) Execute(ctx *cli.Context) error {
+	    <-ctx.Context().Done()
+	    return shutdown()
+	}
+
+If the command returns an error after a signal, Run returns a CancelledError
+(exit code 130); after a timeout it returns a CommandError wrapping
+context.DeadlineExceeded.
+
 # Error Handling
 
 The package defines standard error types with exit codes:
@@ -57,18 +57,53 @@ func NewBcrypt(opts ...Option) (Crypto, error) {
 // HashPassword 实现 Crypto 接口
 // 使用 bcrypt 算法加密密码
 func (b *bcryptCrypto) HashPassword(password string) (string, error) {
+	return b.hashBytes([]byte(password), 0)
+}
+
+// HashPasswordWithCost 实现 CostHasher 接口
+// 使用指定成本加密密码,实例配置中的成本保持不变
+func (b *bcryptCrypto) HashPasswordWithCost(password string, cost int) (string, error) {
+	if cost < MinBcryptCost || cost > MaxBcryptCost {
+		return "", fmt.Errorf(ErrMsgInvalidBcryptCost, ErrInvalidConfig, MinBcryptCost, MaxBcryptCost, cost)
+	}
+	return b.hashBytes([]byte(password), cost)
+}
+
+// HashPasswordSecure 实现 SecureHasher 接口
+func (b *bcryptCrypto) HashPasswordSecure(password *SecureBytes) (hash string, err error) {
+	err = password.Use(func(pw []byte) error {
+		hash, err = b.hashBytes(pw, 0)
+		return err
+	})
+	return hash, err
+}
+
+// hashBytes 使用 bcrypt 算法加密字节形式的密码
+// cost 为 0 时使用配置中的成本
+func (b *bcryptCrypto) hashBytes(password []byte, cost int) (string, error) {
 	// 读取当前配置
 	b.mu.RLock()
 	config := b.config
 	b.mu.RUnlock()
 
 	// 验证密码长度
-	if err := b.validatePassword(password); err != nil {
+	if err := b.validatePassword(len(password)); err != nil {
 		return "", err
 	}
 
+	// 验证密码强度
+	if config.MinStrength > StrengthVeryWeak {
+		if score := EstimateStrength(bytesView(password)).Score; score < config.MinStrength {
+			return "", fmt.Errorf(ErrMsgPasswordTooWeak, ErrPasswordTooWeak, score, config.MinStrength)
+		}
+	}
+
+	if cost == 0 {
+		cost = config.BcryptCost
+	}
+
 	// 使用 bcrypt 加密
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), config.BcryptCost)
+	hashedPassword, err := bcrypt.GenerateFromPassword(password, cost)
 	if err != nil {
 		return "", fmt.Errorf(ErrMsgHashingFailed, err)
 	}
@@ -79,13 +114,25 @@ func (b *bcryptCrypto) HashPassword(password string) (string, error) {
 // VerifyPassword 实现 Crypto 接口
 // 使用 bcrypt 算法验证密码
 func (b *bcryptCrypto) VerifyPassword(hashedPassword, password string) error {
+	return b.verifyBytes(hashedPassword, []byte(password))
+}
+
+// VerifyPasswordSecure 实现 SecureHasher 接口
+func (b *bcryptCrypto) VerifyPasswordSecure(hashedPassword string, password *SecureBytes) error {
+	return password.Use(func(pw []byte) error {
+		return b.verifyBytes(hashedPassword, pw)
+	})
+}
+
+// verifyBytes 使用 bcrypt 算法验证字节形式的密码
+func (b *bcryptCrypto) verifyBytes(hashedPassword string, password []byte) error {
 	// 验证密码长度（可选，bcrypt 会自动验证）
-	if err := b.validatePassword(password); err != nil {
+	if err := b.validatePassword(len(password)); err != nil {
 		return err
 	}
 
 	// 使用 bcrypt 验证
-	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), password)
 	if err != nil {
 		// bcrypt.ErrMismatchedHashAndPassword 表示密码不匹配
 		if err == bcrypt.ErrMismatchedHashAndPassword {
@@ -97,6 +144,12 @@ func (b *bcryptCrypto) VerifyPassword(hashedPassword, password string) error {
 	return nil
 }
 
+// Algorithm 实现 AlgorithmReporter 接口
+// 返回 AlgorithmBcrypt
+func (b *bcryptCrypto) Algorithm() string {
+	return AlgorithmBcrypt
+}
+
 // UpdateConfig 实现 Crypto 接口
 // 原子化更新配置
 func (b *bcryptCrypto) UpdateConfig(opts ...Option) error {
@@ -125,14 +178,12 @@ func (b *bcryptCrypto) UpdateConfig(opts ...Option) error {
 
 // validatePassword 验证密码长度
 // 根据配置的长度限制检查密码是否合法
-func (b *bcryptCrypto) validatePassword(password string) error {
+func (b *bcryptCrypto) validatePassword(length int) error {
 	b.mu.RLock()
 	minLen := b.config.MinPasswordLength
 	maxLen := b.config.MaxPasswordLength
 	b.mu.RUnlock()
 
-	length := len(password)
-
 	if length < minLen {
 		return fmt.Errorf(ErrMsgPasswordTooShort, minLen)
 	}
@@ -0,0 +1,107 @@
+package crypto
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+// calibrationPassword 校准时使用的固定密码
+// 哈希耗时与密码内容无关,只取决于成本参数和硬件
+var calibrationPassword = []byte("calibration-password")
+
+// CalibrateBcryptCost 在当前机器上测量 bcrypt 哈希耗时,选出不超过目标耗时的最高成本
+// 从 MinBcryptCost 开始逐级测量,成本每增加 1 耗时约翻倍,
+// 首次超过目标耗时即停止,因此总耗时约为目标耗时的 2-3 倍
+// 参数:
+//
+//	targetDuration: 单次哈希的目标耗时,例如 250ms
+//
+// 返回:
+//
+//	cost: 耗时不超过 targetDuration 的最高成本
+//	measured: 该成本的实测耗时
+//	error: targetDuration <= 0 时返回 ErrInvalidConfig;
+//	       MinBcryptCost 已超过目标耗时时返回 ErrCalibrationFailed,
+//	       此时 cost 为 MinBcryptCost,measured 为其实测耗时
+//
+// 使用示例:
+//
+//	// 启动时自动校准,不同硬件上的登录耗时保持一致
+//	cost, measured, err := crypto.CalibrateBcryptCost(250 * time.Millisecond)
+//	if err != nil {
+//	    return err
+//	}
+//	log.Info("bcrypt cost calibrated", "cost", cost, "measured", measured)
+//	c, err := crypto.NewBcrypt(crypto.WithBcryptCost(max(cost, crypto.DefaultBcryptCost)))
+//
+// 注意:
+//   - 测量结果受当前负载影响,应在启动阶段、处理请求之前调用
+//   - 建议与 DefaultBcryptCost 取较大值,避免在慢机器上降低安全性
+func CalibrateBcryptCost(targetDuration time.Duration) (cost int, measured time.Duration, err error) {
+	return calibrate(targetDuration, MinBcryptCost, MaxBcryptCost, func(c int) int { return c + 1 },
+		func(c int) error {
+			_, err := bcrypt.GenerateFromPassword(calibrationPassword, c)
+			return err
+		})
+}
+
+// CalibrateScryptN 在当前机器上测量 scrypt 哈希耗时,选出不超过目标耗时的最大 N
+// r、p 和密钥长度使用默认值(DefaultScryptR、DefaultScryptP、DefaultScryptKeyLen),
+// N 从 MinScryptN 开始逐次翻倍,最大为 1<<MaxScryptLogN;
+// 内存占用约为 128 * N * r 字节,随 N 同步增长
+// 参数:
+//
+//	targetDuration: 单次哈希的目标耗时
+//
+// 返回:
+//
+//	n: 耗时不超过 targetDuration 的最大 N,可直接用于 WithScryptParams
+//	measured: 该 N 的实测耗时
+//	error: 与 CalibrateBcryptCost 相同
+//
+// 使用示例:
+//
+//	n, _, err := crypto.CalibrateScryptN(250 * time.Millisecond)
+//	c, err := crypto.NewScrypt(crypto.WithScryptParams(n, crypto.DefaultScryptR, crypto.DefaultScryptP, crypto.DefaultScryptKeyLen))
+func CalibrateScryptN(targetDuration time.Duration) (n int, measured time.Duration, err error) {
+	salt := make([]byte, ScryptSaltLength)
+	return calibrate(targetDuration, MinScryptN, 1<<MaxScryptLogN, func(n int) int { return n << 1 },
+		func(n int) error {
+			_, err := scrypt.Key(calibrationPassword, salt, n, DefaultScryptR, DefaultScryptP, DefaultScryptKeyLen)
+			return err
+		})
+}
+
+// calibrate 逐级测量哈希耗时
+// 参数:
+//
+//	target: 目标耗时
+//	lo, hi: 参数范围
+//	next: 计算下一级参数
+//	hash: 以指定参数执行一次哈希
+func calibrate(target time.Duration, lo, hi int, next func(int) int, hash func(int) error) (int, time.Duration, error) {
+	if target <= 0 {
+		return 0, 0, fmt.Errorf("%w: calibration target must be positive, got %v", ErrInvalidConfig, target)
+	}
+
+	best, bestTime := 0, time.Duration(0)
+	for param := lo; param <= hi; param = next(param) {
+		start := time.Now()
+		if err := hash(param); err != nil {
+			return 0, 0, fmt.Errorf(ErrMsgHashingFailed, err)
+		}
+		elapsed := time.Since(start)
+
+		if elapsed > target {
+			if best == 0 {
+				return lo, elapsed, fmt.Errorf(ErrMsgCalibrationFailed, ErrCalibrationFailed, lo, elapsed, target)
+			}
+			break
+		}
+		best, bestTime = param, elapsed
+	}
+	return best, bestTime, nil
+}
@@ -0,0 +1,32 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// ConstantTimeCompare 以恒定时间比较两个字符串是否相等
+// 用于比较令牌、API Key、锁令牌等秘密值，替代存在时序侧信道的 ==
+// 为什么不直接使用 subtle.ConstantTimeCompare:
+//   - 长度不同时它会立即返回，泄露秘密值的长度
+//   - 这里先对两边做 SHA-256，比较固定长度的摘要，耗时与输入内容和长度差异无关
+//
+// 参数:
+//
+//	a: 待比较的字符串(通常是用户提交的值)
+//	b: 待比较的字符串(通常是存储的值)
+//
+// 返回:
+//
+//	bool: 两个字符串完全相等时返回 true
+//
+// 使用示例:
+//
+//	if !crypto.ConstantTimeCompare(req.Header.Get("X-API-Key"), storedKey) {
+//	    return ErrUnauthorized
+//	}
+func ConstantTimeCompare(a, b string) bool {
+	ha := sha256.Sum256([]byte(a))
+	hb := sha256.Sum256([]byte(b))
+	return subtle.ConstantTimeCompare(ha[:], hb[:]) == 1
+}
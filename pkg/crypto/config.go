@@ -29,6 +29,23 @@ type Config struct {
 	// Argon2KeyLen argon2 密钥长度（可选，预留）
 	Argon2KeyLen uint32
 
+	// ScryptN scrypt CPU/内存成本参数
+	// 必须是 2 的幂，且不小于 MinScryptN
+	// 默认: 32768
+	ScryptN int
+
+	// ScryptR scrypt 块大小
+	// 默认: 8
+	ScryptR int
+
+	// ScryptP scrypt 并行度
+	// 默认: 1
+	ScryptP int
+
+	// ScryptKeyLen scrypt 密钥长度（字节）
+	// 默认: 32
+	ScryptKeyLen uint32
+
 	// MinPasswordLength 最小密码长度
 	// 默认: 8
 	MinPasswordLength int
@@ -36,6 +53,11 @@ type Config struct {
 	// MaxPasswordLength 最大密码长度
 	// 默认: 72（bcrypt 限制）
 	MaxPasswordLength int
+
+	// MinStrength 最低密码强度评分
+	// 范围: 0-4,HashPassword 拒绝 EstimateStrength 评分低于此值的密码
+	// 默认: 0(不检查)
+	MinStrength int
 }
 
 // DefaultConfig 返回默认配置
@@ -48,6 +70,10 @@ func DefaultConfig() *Config {
 		Argon2Memory:      DefaultArgon2Memory,
 		Argon2Threads:     DefaultArgon2Threads,
 		Argon2KeyLen:      DefaultArgon2KeyLen,
+		ScryptN:           DefaultScryptN,
+		ScryptR:           DefaultScryptR,
+		ScryptP:           DefaultScryptP,
+		ScryptKeyLen:      DefaultScryptKeyLen,
 		MinPasswordLength: DefaultMinPasswordLength,
 		MaxPasswordLength: DefaultMaxPasswordLength,
 	}
@@ -64,8 +90,7 @@ func (c *Config) Validate() error {
 	case AlgorithmBcrypt:
 		// bcrypt 算法，验证成本参数
 		if c.BcryptCost < MinBcryptCost || c.BcryptCost > MaxBcryptCost {
-			return fmt.Errorf("%w: bcrypt cost must be between %d and %d, got %d",
-				ErrInvalidConfig, MinBcryptCost, MaxBcryptCost, c.BcryptCost)
+			return fmt.Errorf(ErrMsgInvalidBcryptCost, ErrInvalidConfig, MinBcryptCost, MaxBcryptCost, c.BcryptCost)
 		}
 	case AlgorithmArgon2:
 		// argon2 算法（预留）
@@ -81,8 +106,29 @@ func (c *Config) Validate() error {
 		if c.Argon2KeyLen == 0 {
 			return fmt.Errorf("%w: argon2 key length must be greater than 0", ErrInvalidConfig)
 		}
+	case AlgorithmScrypt:
+		if c.ScryptN < MinScryptN || c.ScryptN&(c.ScryptN-1) != 0 {
+			return fmt.Errorf("%w: scrypt N must be a power of two and at least %d, got %d",
+				ErrInvalidConfig, MinScryptN, c.ScryptN)
+		}
+		if c.ScryptN > 1<<MaxScryptLogN {
+			return fmt.Errorf("%w: scrypt N cannot exceed %d, got %d", ErrInvalidConfig, 1<<MaxScryptLogN, c.ScryptN)
+		}
+		if c.ScryptR < MinScryptR {
+			return fmt.Errorf("%w: scrypt r must be at least %d, got %d", ErrInvalidConfig, MinScryptR, c.ScryptR)
+		}
+		if c.ScryptP < MinScryptP {
+			return fmt.Errorf("%w: scrypt p must be at least %d, got %d", ErrInvalidConfig, MinScryptP, c.ScryptP)
+		}
+		if c.ScryptR*c.ScryptP > MaxScryptRP {
+			return fmt.Errorf("%w: scrypt r*p cannot exceed %d", ErrInvalidConfig, MaxScryptRP)
+		}
+		if c.ScryptKeyLen < MinScryptKeyLen {
+			return fmt.Errorf("%w: scrypt key length must be at least %d, got %d",
+				ErrInvalidConfig, MinScryptKeyLen, c.ScryptKeyLen)
+		}
 	default:
-		return fmt.Errorf(ErrMsgInvalidAlgorithm, c.Algorithm, []string{AlgorithmBcrypt, AlgorithmArgon2})
+		return fmt.Errorf(ErrMsgInvalidAlgorithm, c.Algorithm, []string{AlgorithmBcrypt, AlgorithmArgon2, AlgorithmScrypt})
 	}
 
 	// 验证密码长度限制
@@ -96,6 +142,12 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("%w: max password length cannot exceed %d (bcrypt limit)", ErrInvalidConfig, MaxPasswordLength)
 	}
 
+	// 验证密码强度阈值
+	if c.MinStrength < StrengthVeryWeak || c.MinStrength > StrengthVeryStrong {
+		return fmt.Errorf("%w: min strength must be between %d and %d, got %d",
+			ErrInvalidConfig, StrengthVeryWeak, StrengthVeryStrong, c.MinStrength)
+	}
+
 	return nil
 }
 
@@ -109,8 +161,13 @@ func (c *Config) Clone() *Config {
 		Argon2Memory:      c.Argon2Memory,
 		Argon2Threads:     c.Argon2Threads,
 		Argon2KeyLen:      c.Argon2KeyLen,
+		ScryptN:           c.ScryptN,
+		ScryptR:           c.ScryptR,
+		ScryptP:           c.ScryptP,
+		ScryptKeyLen:      c.ScryptKeyLen,
 		MinPasswordLength: c.MinPasswordLength,
 		MaxPasswordLength: c.MaxPasswordLength,
+		MinStrength:       c.MinStrength,
 	}
 }
 
@@ -121,7 +178,7 @@ type Option func(*Config)
 // WithAlgorithm 设置加密算法
 // 参数:
 //
-//	algo: 算法名称，可选值 "bcrypt" 或 "argon2"
+//	algo: 算法名称，可选值 "bcrypt"、"argon2" 或 "scrypt"
 func WithAlgorithm(algo string) Option {
 	return func(c *Config) {
 		c.Algorithm = algo
@@ -150,6 +207,18 @@ func WithPasswordLength(min, max int) Option {
 	}
 }
 
+// WithMinStrength 设置最低密码强度
+// 设置后 HashPassword 会拒绝强度不足的密码并返回 ErrPasswordTooWeak
+// VerifyPassword 不受影响,已有的弱密码仍可正常登录
+// 参数:
+//
+//	score: 最低评分,范围 0-4,0 表示不检查
+func WithMinStrength(score int) Option {
+	return func(c *Config) {
+		c.MinStrength = score
+	}
+}
+
 // WithArgon2Params 设置 argon2 参数（预留）
 // 参数:
 //
@@ -165,3 +234,19 @@ func WithArgon2Params(time uint32, memory uint32, threads uint8, keyLen uint32)
 		c.Argon2KeyLen = keyLen
 	}
 }
+
+// WithScryptParams 设置 scrypt 参数
+// 参数:
+//
+//	n: CPU/内存成本，必须是 2 的幂，不小于 MinScryptN
+//	r: 块大小，不小于 MinScryptR
+//	p: 并行度，不小于 MinScryptP
+//	keyLen: 密钥长度（字节），不小于 MinScryptKeyLen
+func WithScryptParams(n, r, p int, keyLen uint32) Option {
+	return func(c *Config) {
+		c.ScryptN = n
+		c.ScryptR = r
+		c.ScryptP = p
+		c.ScryptKeyLen = keyLen
+	}
+}
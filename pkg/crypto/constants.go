@@ -39,6 +39,9 @@ const (
 	// AlgorithmArgon2 argon2 算法标识
 	AlgorithmArgon2 = "argon2"
 
+	// AlgorithmScrypt scrypt 算法标识
+	AlgorithmScrypt = "scrypt"
+
 	// DefaultAlgorithm 默认加密算法
 	DefaultAlgorithm = AlgorithmBcrypt
 )
@@ -57,3 +60,133 @@ const (
 	// DefaultArgon2KeyLen argon2 默认密钥长度
 	DefaultArgon2KeyLen = 32
 )
+
+// Scrypt 参数常量
+// 下限参考 OWASP 密码存储建议，低于下限的配置会被 Config.Validate 拒绝
+const (
+	// DefaultScryptN scrypt 默认 CPU/内存成本参数，必须是 2 的幂
+	// 内存占用约为 128 * N * r 字节，默认约 32MB
+	DefaultScryptN = 1 << 15
+
+	// DefaultScryptR scrypt 默认块大小
+	DefaultScryptR = 8
+
+	// DefaultScryptP scrypt 默认并行度
+	DefaultScryptP = 1
+
+	// DefaultScryptKeyLen scrypt 默认密钥长度（字节）
+	DefaultScryptKeyLen = 32
+
+	// MinScryptN scrypt 最小 N
+	MinScryptN = 1 << 14
+
+	// MinScryptR scrypt 最小块大小
+	MinScryptR = 8
+
+	// MinScryptP scrypt 最小并行度
+	MinScryptP = 1
+
+	// MinScryptKeyLen scrypt 最小密钥长度（字节）
+	MinScryptKeyLen = 16
+
+	// MaxScryptLogN 验证时允许的最大 log2(N)
+	// 防止篡改的哈希参数导致巨量内存分配
+	MaxScryptLogN = 22
+
+	// MaxScryptRP 验证时允许的 r*p 上限
+	MaxScryptRP = 1 << 10
+
+	// ScryptSaltLength scrypt 盐值长度（字节）
+	ScryptSaltLength = 16
+)
+
+// 哈希格式前缀常量
+// 用于根据哈希字符串识别算法
+const (
+	// PrefixBcrypt2a bcrypt $2a$ 前缀
+	PrefixBcrypt2a = "$2a$"
+
+	// PrefixBcrypt2b bcrypt $2b$ 前缀
+	PrefixBcrypt2b = "$2b$"
+
+	// PrefixBcrypt2y bcrypt $2y$ 前缀（PHP 生成）
+	PrefixBcrypt2y = "$2y$"
+
+	// PrefixArgon2id argon2id PHC 格式前缀
+	PrefixArgon2id = "$argon2id$"
+
+	// PrefixArgon2i argon2i PHC 格式前缀
+	PrefixArgon2i = "$argon2i$"
+
+	// PrefixScrypt scrypt PHC 风格前缀
+	PrefixScrypt = "$scrypt$"
+
+	// PrefixHMACSHA256 API Key HMAC-SHA256 哈希前缀
+	PrefixHMACSHA256 = "$hmac-sha256$"
+)
+
+// BcryptSaltLength bcrypt 盐值长度（字节）
+// bcrypt 固定使用 16 字节盐值，编码后为 22 个字符
+const BcryptSaltLength = 16
+
+// 密码强度评分常量
+// 用于 EstimateStrength 返回值和 WithMinStrength 选项
+const (
+	// StrengthVeryWeak 极弱: 常用密码或极短密码,几乎瞬间被破解
+	StrengthVeryWeak = 0
+
+	// StrengthWeak 弱: 可抵御在线猜测限速,无法抵御离线破解
+	StrengthWeak = 1
+
+	// StrengthModerate 中等: 可抵御一般的离线破解
+	StrengthModerate = 2
+
+	// StrengthStrong 强: 离线破解需要数天以上
+	StrengthStrong = 3
+
+	// StrengthVeryStrong 极强: 离线破解需要数年以上
+	StrengthVeryStrong = 4
+
+	// StrengthGuessesPerSecond 估算破解时间时假设的每秒猜测次数
+	// 对应离线破解 bcrypt 等慢哈希的速度
+	StrengthGuessesPerSecond = 1e4
+)
+
+// 密码强度改进建议常量
+// 出现在 StrengthResult.Feedback 中
+const (
+	// FeedbackTooShort 密码过短
+	FeedbackTooShort = "password is too short"
+
+	// FeedbackCommonPassword 属于常用密码
+	FeedbackCommonPassword = "password is too common"
+
+	// FeedbackOnlyDigits 只包含数字
+	FeedbackOnlyDigits = "password contains only digits"
+
+	// FeedbackRepeatedChars 包含重复字符
+	FeedbackRepeatedChars = "avoid repeated characters"
+
+	// FeedbackSequence 包含连续序列
+	FeedbackSequence = "avoid sequences like abc, 123 or qwerty"
+
+	// FeedbackAddVariety 字符种类单一或长度不足
+	FeedbackAddVariety = "use a longer password with mixed character types"
+)
+
+// API Key 常量
+// 用于 APIKeyHasher 和 APIKeyLookupPrefix
+const (
+	// APIKeySeparator Key 前缀与随机部分之间的分隔符
+	APIKeySeparator = "_"
+
+	// APIKeyRandomBytes Key 随机部分的字节数
+	// 160 位熵,编码后为 32 个字符
+	APIKeyRandomBytes = 20
+
+	// APIKeyLookupLength 查找前缀中保留的随机部分字符数
+	APIKeyLookupLength = 8
+
+	// MinAPIKeyPepperLength pepper 最小长度(字节)
+	MinAPIKeyPepperLength = 32
+)
@@ -0,0 +1,27 @@
+package crypto
+
+// CostHasher 支持按次指定成本的加密器
+// 内置的 bcrypt 和 Multi(主加密器为 bcrypt 时)加密器实现了该接口,
+// 用于同一个实例对不同账号使用不同的成本,如管理员使用 14、普通用户使用 12
+//
+// 使用示例:
+//
+//	cost := crypto.DefaultBcryptCost
+//	if user.IsAdmin {
+//	    cost = 14
+//	}
+//	if ch, ok := c.(crypto.CostHasher); ok {
+//	    hash, err = ch.HashPasswordWithCost(password, cost)
+//	}
+type CostHasher interface {
+	// HashPasswordWithCost 使用指定成本加密密码
+	// 长度和强度校验与 HashPassword 相同,不修改实例配置
+	// VerifyPassword 从哈希中读取成本,无需额外处理
+	// 参数:
+	//   password: 明文密码
+	//   cost: bcrypt 成本,范围 [MinBcryptCost, MaxBcryptCost]
+	// 返回:
+	//   string: 密码哈希值
+	//   error: cost 超出范围时返回包装了 ErrInvalidConfig 的错误,以及与 HashPassword 相同的错误
+	HashPasswordWithCost(password string, cost int) (string, error)
+}
@@ -3,7 +3,7 @@ Package crypto 提供安全的密码加密和验证功能
 
 # 设计目标
 
-- 安全性: 使用成熟的加密算法（bcrypt、argon2）
+- 安全性: 使用成熟的加密算法（bcrypt、argon2、scrypt）
 - 易用性: 简洁的接口，合理的默认配置
 - 灵活性: 支持多种算法和配置选项
 - 性能: 线程安全，支持并发操作
@@ -55,6 +55,25 @@ Argon2 是 2015 年密码哈希竞赛的冠军，是目前最先进的密码哈
   - 需要抵御 GPU 破解
   - 现代应用
 
+### Scrypt
+
+Scrypt 是内存困难型算法，部分合规要求或既有系统使用它。
+
+	c, _ := crypto.NewScrypt(crypto.WithScryptParams(1<<17, 8, 1, 32))
+	hash, _ := c.HashPassword(password) // $scrypt$ln=17,r=8,p=1$<salt>$<hash>
+
+参数随哈希保存，配合 NewMulti 可以验证从旧系统迁移来的 scrypt 哈希。
+
+## API Key
+
+API Key 由系统随机生成，熵足够高，使用 HMAC-SHA256 + pepper 即可，不需要慢哈希。
+Key 格式为 <prefix>_<随机部分>，数据库只保存哈希和 APIKeyLookupPrefix 返回的查找前缀：
+
+	hasher, _ := crypto.NewAPIKeyHasher(pepper)
+	key, hash, _ := hasher.GenerateAPIKey("sk_live")
+	lookup, _ := crypto.APIKeyLookupPrefix(key) // "sk_live_xxxxxxxx"
+	err := hasher.VerifyAPIKey(hash, key)
+
 # 使用示例
 
 ## 基本用法
@@ -161,6 +180,18 @@ Argon2 是 2015 年密码哈希竞赛的冠军，是目前最先进的密码哈
 	    return s.crypto.VerifyPassword(user.Password, password)
 	}
 
+安全内存(SecureBytes):
+
+	pw := crypto.NewSecureBytes(body.Password) // 复制后清零 body.Password
+	defer pw.Destroy()
+
+	if sh, ok := c.(crypto.SecureHasher); ok {
+	    hash, err = sh.HashPasswordSecure(pw)
+	}
+
+SecureBytes 在 Unix 平台上使用 mlock 锁定的内存,受 RLIMIT_MEMLOCK 限制,
+失败或在其他平台上退化为普通堆内存;Destroy 时清零。它无法防止调用方自行复制数据。
+
 # 最佳实践
 
 ## 1. 成本参数选择
@@ -171,15 +202,14 @@ Bcrypt 成本参数决定了计算时间，建议根据场景选择:
   - 一般应用: 成本 10-12（推荐）
   - 高安全应用: 成本 12-14（更安全）
 
-成本每增加 1，计算时间翻倍。建议在目标硬件上测试，确保登录响应时间在可接受范围内。
+成本每增加 1，计算时间翻倍。可以在启动时用 CalibrateBcryptCost 自动选出目标耗时内的最高成本
+（scrypt 对应 CalibrateScryptN），确保登录响应时间在可接受范围内:
 
-	// 测试不同成本的性能
-	for cost := 10; cost <= 14; cost++ {
-	    crypto, _ := crypto.NewBcrypt(crypto.WithBcryptCost(cost))
-	    start := time.Now()
-	    crypto.HashPassword("testpassword")
-	    fmt.Printf("Cost %d: %v\n", cost, time.Since(start))
+	cost, measured, err := crypto.CalibrateBcryptCost(250 * time.Millisecond)
+	if err != nil {
+	    return err
 	}
+	c, err := crypto.NewBcrypt(crypto.WithBcryptCost(max(cost, crypto.DefaultBcryptCost)))
 
 ## 2. 密码长度限制
 
@@ -328,6 +358,9 @@ Bcrypt 是 CPU 密集型算法，成本每增加 1，计算时间翻倍:
   - ErrVerificationFailed: 验证失败
   - ErrInvalidConfig: 配置无效
   - ErrInvalidAlgorithm: 算法无效
+  - ErrInvalidAPIKey: API Key 格式错误或与哈希不匹配
+  - ErrInvalidAPIKeyPrefix: API Key 前缀不符合命名约定
+  - ErrSecureBytesDestroyed: SecureBytes 为 nil 或已销毁
 
 使用示例:
 
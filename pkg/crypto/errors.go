@@ -25,6 +25,29 @@ var (
 
 	// ErrInvalidAlgorithm 无效算法错误
 	ErrInvalidAlgorithm = errors.New("invalid algorithm")
+
+	// ErrInvalidHash 哈希格式错误
+	// 算法可识别但哈希字符串无法解析
+	ErrInvalidHash = errors.New("invalid hash format")
+
+	// ErrPasswordTooWeak 密码强度不足错误
+	// 配置了 WithMinStrength 且密码评分低于阈值时返回
+	ErrPasswordTooWeak = errors.New("password too weak")
+
+	// ErrInvalidAPIKey API Key 无效错误
+	// Key 格式错误或与哈希不匹配时返回
+	ErrInvalidAPIKey = errors.New("invalid api key")
+
+	// ErrInvalidAPIKeyPrefix API Key 前缀格式错误
+	ErrInvalidAPIKeyPrefix = errors.New("invalid api key prefix")
+
+	// ErrSecureBytesDestroyed SecureBytes 已销毁错误
+	// 向 HashPasswordSecure/VerifyPasswordSecure 传入 nil 或已 Destroy 的 SecureBytes 时返回
+	ErrSecureBytesDestroyed = errors.New("secure bytes destroyed")
+
+	// ErrCalibrationFailed 成本校准失败错误
+	// 最低成本的哈希耗时已超过 CalibrateBcryptCost/CalibrateScryptN 的目标耗时
+	ErrCalibrationFailed = errors.New("calibration failed")
 )
 
 // 错误消息模板常量
@@ -47,4 +70,19 @@ const (
 
 	// ErrMsgInvalidAlgorithm 无效算法消息模板
 	ErrMsgInvalidAlgorithm = "invalid algorithm %q: supported algorithms are %v"
+
+	// ErrMsgPasswordTooWeak 密码强度不足消息模板
+	ErrMsgPasswordTooWeak = "%w: score %d is below minimum %d"
+
+	// ErrMsgInvalidAPIKeyPrefix API Key 前缀格式错误消息模板
+	ErrMsgInvalidAPIKeyPrefix = "%w: %q must be lowercase letters and digits separated by underscores"
+
+	// ErrMsgAPIKeyGenerationFailed API Key 生成失败消息模板
+	ErrMsgAPIKeyGenerationFailed = "failed to generate api key: %w"
+
+	// ErrMsgInvalidBcryptCost bcrypt 成本超出范围消息模板
+	ErrMsgInvalidBcryptCost = "%w: bcrypt cost must be between %d and %d, got %d"
+
+	// ErrMsgCalibrationFailed 成本校准失败消息模板
+	ErrMsgCalibrationFailed = "%w: minimum parameter %d took %v, exceeding target %v"
 )
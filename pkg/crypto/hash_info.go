@@ -0,0 +1,167 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Info 哈希参数信息
+// 从哈希字符串中解析，无需验证密码
+// 使用场景:
+//   - 审计用户表中哈希成本的分布
+//   - 规划强制重新哈希的迁移
+type Info struct {
+	// Algorithm 算法名称: "bcrypt"、"argon2" 或 "scrypt"
+	Algorithm string
+
+	// Variant 算法变体
+	// bcrypt: "2a"、"2b"、"2y"
+	// argon2: "argon2id"、"argon2i"
+	// scrypt: "scrypt"
+	Variant string
+
+	// Cost bcrypt 成本参数（仅 bcrypt）
+	Cost int
+
+	// Version argon2 版本号（仅 argon2）
+	Version int
+
+	// Memory argon2 内存使用，单位 KB（仅 argon2）
+	Memory uint32
+
+	// Time argon2 迭代次数（仅 argon2）
+	Time uint32
+
+	// Threads argon2 并行度（仅 argon2）
+	Threads uint8
+
+	// N scrypt CPU/内存成本参数（仅 scrypt）
+	N int
+
+	// R scrypt 块大小（仅 scrypt）
+	R int
+
+	// P scrypt 并行度（仅 scrypt）
+	P int
+
+	// SaltLength 盐值长度（字节）
+	SaltLength int
+
+	// KeyLength 哈希值长度（字节）（argon2、scrypt）
+	KeyLength int
+}
+
+// HashInfo 解析哈希字符串中的算法参数
+// 不验证密码，只读取哈希中自描述的参数
+// 参数:
+//
+//	hashedPassword: 存储的密码哈希值
+//
+// 返回:
+//
+//	Info: 哈希参数信息
+//	error: 前缀无法识别时返回 ErrInvalidAlgorithm，格式错误时返回 ErrInvalidHash
+//
+// 使用示例:
+//
+//	info, err := crypto.HashInfo(user.Password)
+//	if err == nil && info.Algorithm == crypto.AlgorithmBcrypt && info.Cost < 12 {
+//	    // 低成本哈希，计划重新哈希
+//	}
+func HashInfo(hashedPassword string) (Info, error) {
+	switch algorithmByPrefix(hashedPassword) {
+	case AlgorithmBcrypt:
+		return parseBcryptInfo(hashedPassword)
+	case AlgorithmArgon2:
+		return parseArgon2Info(hashedPassword)
+	case AlgorithmScrypt:
+		return parseScryptInfo(hashedPassword)
+	default:
+		return Info{}, fmt.Errorf("%w: unrecognized hash prefix", ErrInvalidAlgorithm)
+	}
+}
+
+// algorithmByPrefix 根据哈希前缀识别算法
+// 返回:
+//
+//	string: AlgorithmBcrypt、AlgorithmArgon2 或 AlgorithmScrypt，无法识别时返回空字符串
+func algorithmByPrefix(hashedPassword string) string {
+	switch {
+	case strings.HasPrefix(hashedPassword, PrefixBcrypt2a),
+		strings.HasPrefix(hashedPassword, PrefixBcrypt2b),
+		strings.HasPrefix(hashedPassword, PrefixBcrypt2y):
+		return AlgorithmBcrypt
+	case strings.HasPrefix(hashedPassword, PrefixArgon2id),
+		strings.HasPrefix(hashedPassword, PrefixArgon2i):
+		return AlgorithmArgon2
+	case strings.HasPrefix(hashedPassword, PrefixScrypt):
+		return AlgorithmScrypt
+	default:
+		return ""
+	}
+}
+
+// parseBcryptInfo 解析 bcrypt 哈希
+// 格式: $2a$10$<22 字符盐值><31 字符哈希>
+func parseBcryptInfo(hash string) (Info, error) {
+	parts := strings.Split(hash, "$")
+	// ["", "2a", "10", "<salt+hash>"]
+	if len(parts) != 4 || len(parts[3]) != 53 {
+		return Info{}, fmt.Errorf("%w: malformed bcrypt hash", ErrInvalidHash)
+	}
+
+	cost, err := strconv.Atoi(parts[2])
+	if err != nil || cost < MinBcryptCost || cost > MaxBcryptCost {
+		return Info{}, fmt.Errorf("%w: invalid bcrypt cost %q", ErrInvalidHash, parts[2])
+	}
+
+	return Info{
+		Algorithm:  AlgorithmBcrypt,
+		Variant:    parts[1],
+		Cost:       cost,
+		SaltLength: BcryptSaltLength,
+	}, nil
+}
+
+// parseArgon2Info 解析 argon2 PHC 格式哈希
+// 格式: $argon2id$v=19$m=65536,t=1,p=4$<base64 盐值>$<base64 哈希>
+func parseArgon2Info(hash string) (Info, error) {
+	parts := strings.Split(hash, "$")
+	// ["", "argon2id", "v=19", "m=65536,t=1,p=4", "<salt>", "<hash>"]
+	if len(parts) != 6 {
+		return Info{}, fmt.Errorf("%w: malformed argon2 hash", ErrInvalidHash)
+	}
+
+	info := Info{
+		Algorithm: AlgorithmArgon2,
+		Variant:   parts[1],
+	}
+
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &info.Version); err != nil {
+		return Info{}, fmt.Errorf("%w: invalid argon2 version %q", ErrInvalidHash, parts[2])
+	}
+
+	var threads uint32
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &info.Memory, &info.Time, &threads); err != nil {
+		return Info{}, fmt.Errorf("%w: invalid argon2 params %q", ErrInvalidHash, parts[3])
+	}
+	if threads > 255 {
+		return Info{}, fmt.Errorf("%w: invalid argon2 parallelism %d", ErrInvalidHash, threads)
+	}
+	info.Threads = uint8(threads)
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Info{}, fmt.Errorf("%w: invalid argon2 salt", ErrInvalidHash)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return Info{}, fmt.Errorf("%w: invalid argon2 key", ErrInvalidHash)
+	}
+	info.SaltLength = len(salt)
+	info.KeyLength = len(key)
+
+	return info, nil
+}
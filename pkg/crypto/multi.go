@@ -0,0 +1,167 @@
+package crypto
+
+import (
+	"errors"
+	"fmt"
+)
+
+// AlgorithmReporter 可报告自身算法的加密器
+// MultiCrypto 根据哈希前缀识别出的算法名称，
+// 将验证请求分发给 Algorithm() 相同的加密器
+type AlgorithmReporter interface {
+	// Algorithm 返回算法标识，如 AlgorithmBcrypt、AlgorithmArgon2、AlgorithmScrypt
+	Algorithm() string
+}
+
+// multiCrypto 组合多个加密器的实现
+// 实现 Crypto 接口:
+//   - HashPassword 始终使用主算法
+//   - VerifyPassword 根据哈希前缀分发到对应算法
+type multiCrypto struct {
+	// primary 主加密器，用于生成新哈希
+	primary Crypto
+
+	// all 所有加密器（primary 在首位），用于验证
+	all []Crypto
+}
+
+// NewMulti 创建支持多种哈希格式的加密器
+// 用于算法迁移期间，数据库中同时存在多种哈希格式的场景
+// 参数:
+//
+//	primary: 主加密器，HashPassword 始终使用它
+//	others: 其他加密器，仅用于验证旧格式哈希
+//
+// 返回:
+//
+//	Crypto: 组合加密器
+//
+// 分发规则:
+//   - 根据哈希前缀识别算法（$2a$/$2b$/$2y$ -> bcrypt，$argon2id$/$argon2i$ -> argon2，$scrypt$ -> scrypt）
+//   - 交给第一个 Algorithm() 匹配的加密器验证
+//   - 没有匹配时，依次尝试未实现 AlgorithmReporter 的加密器
+//   - 仍无法验证时返回 ErrInvalidAlgorithm
+//
+// 使用示例:
+//
+//	// argon2Crypto 为实现了 Crypto 和 AlgorithmReporter 的 argon2 加密器
+//	legacy, _ := crypto.NewBcrypt()
+//	c := crypto.NewMulti(argon2Crypto, legacy)
+//
+//	// 旧的 bcrypt 哈希和新的 argon2 哈希都能验证
+//	err := c.VerifyPassword(user.Password, input)
+func NewMulti(primary Crypto, others ...Crypto) Crypto {
+	all := make([]Crypto, 0, len(others)+1)
+	all = append(all, primary)
+	for _, c := range others {
+		if c != nil {
+			all = append(all, c)
+		}
+	}
+	return &multiCrypto{
+		primary: primary,
+		all:     all,
+	}
+}
+
+// HashPassword 实现 Crypto 接口
+// 始终使用主加密器生成哈希
+func (m *multiCrypto) HashPassword(password string) (string, error) {
+	return m.primary.HashPassword(password)
+}
+
+// HashPasswordWithCost 实现 CostHasher 接口
+// 主加密器未实现 CostHasher 时返回 ErrInvalidAlgorithm
+func (m *multiCrypto) HashPasswordWithCost(password string, cost int) (string, error) {
+	if ch, ok := m.primary.(CostHasher); ok {
+		return ch.HashPasswordWithCost(password, cost)
+	}
+	return "", fmt.Errorf("%w: primary crypto does not support cost override", ErrInvalidAlgorithm)
+}
+
+// VerifyPassword 实现 Crypto 接口
+// 根据哈希前缀选择加密器进行验证
+func (m *multiCrypto) VerifyPassword(hashedPassword, password string) error {
+	return m.verify(hashedPassword, func(c Crypto) error {
+		return c.VerifyPassword(hashedPassword, password)
+	})
+}
+
+// HashPasswordSecure 实现 SecureHasher 接口
+// 主加密器未实现 SecureHasher 时转换为 string 调用 HashPassword,该副本无法清零
+func (m *multiCrypto) HashPasswordSecure(password *SecureBytes) (hash string, err error) {
+	if sh, ok := m.primary.(SecureHasher); ok {
+		return sh.HashPasswordSecure(password)
+	}
+	err = password.Use(func(pw []byte) error {
+		hash, err = m.primary.HashPassword(string(pw))
+		return err
+	})
+	return hash, err
+}
+
+// VerifyPasswordSecure 实现 SecureHasher 接口
+// 分发规则与 VerifyPassword 相同
+func (m *multiCrypto) VerifyPasswordSecure(hashedPassword string, password *SecureBytes) error {
+	return m.verify(hashedPassword, func(c Crypto) error {
+		if sh, ok := c.(SecureHasher); ok {
+			return sh.VerifyPasswordSecure(hashedPassword, password)
+		}
+		return password.Use(func(pw []byte) error {
+			return c.VerifyPassword(hashedPassword, string(pw))
+		})
+	})
+}
+
+// verify 根据哈希前缀选择加密器,调用 fn 进行验证
+func (m *multiCrypto) verify(hashedPassword string, fn func(c Crypto) error) error {
+	algorithm := algorithmByPrefix(hashedPassword)
+
+	if algorithm != "" {
+		for _, c := range m.all {
+			if r, ok := c.(AlgorithmReporter); ok && r.Algorithm() == algorithm {
+				return fn(c)
+			}
+		}
+	}
+
+	// 无法通过前缀分发时，尝试不报告算法的自定义加密器
+	var lastErr error
+	for _, c := range m.all {
+		if _, ok := c.(AlgorithmReporter); ok {
+			continue
+		}
+		err := fn(c)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if errors.Is(err, ErrInvalidPassword) {
+			// 格式被识别但密码不匹配，不再继续尝试
+			return err
+		}
+	}
+	if lastErr != nil {
+		return lastErr
+	}
+
+	if algorithm == "" {
+		return fmt.Errorf("%w: unrecognized hash prefix", ErrInvalidAlgorithm)
+	}
+	return fmt.Errorf("%w: no verifier configured for %s", ErrInvalidAlgorithm, algorithm)
+}
+
+// UpdateConfig 实现 Crypto 接口
+// 只更新主加密器的配置，其他加密器仅用于验证
+func (m *multiCrypto) UpdateConfig(opts ...Option) error {
+	return m.primary.UpdateConfig(opts...)
+}
+
+// Algorithm 实现 AlgorithmReporter 接口
+// 返回主加密器的算法
+func (m *multiCrypto) Algorithm() string {
+	if r, ok := m.primary.(AlgorithmReporter); ok {
+		return r.Algorithm()
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package crypto
+
+// Rehasher 支持登录时透明升级哈希的加密器
+// 内置的 bcrypt、scrypt 和 Multi 加密器都实现了该接口
+// 调整成本参数或切换主算法后,已存储的哈希在用户下次登录时自动迁移
+//
+// 使用示例:
+//
+//	if r, ok := c.(crypto.Rehasher); ok {
+//	    newHash, err := r.VerifyAndUpgrade(user.Password, input)
+//	    if err != nil {
+//	        return ErrInvalidCredentials
+//	    }
+//	    if newHash != "" {
+//	        repo.UpdatePassword(user.ID, newHash)
+//	    }
+//	}
+type Rehasher interface {
+	// NeedsRehash 判断哈希是否需要按当前配置重新计算
+	// 参数:
+	//   hashedPassword: 存储的密码哈希值
+	// 返回:
+	//   bool: 算法与当前配置不一致、成本参数弱于当前配置、或哈希无法解析时返回 true
+	NeedsRehash(hashedPassword string) bool
+
+	// VerifyAndUpgrade 验证密码,哈希过时时返回新哈希
+	// 参数:
+	//   hashedPassword: 存储的密码哈希值
+	//   password: 待验证的明文密码
+	// 返回:
+	//   newHash: 按当前配置计算的新哈希,无需升级时为空字符串
+	//   err: 错误与 VerifyPassword 相同,非 nil 时 newHash 为空
+	// 说明:
+	//   重新哈希失败(如密码低于 WithMinStrength 要求)不影响验证结果,
+	//   此时 newHash 为空,保留旧哈希直到用户修改密码
+	VerifyAndUpgrade(hashedPassword, password string) (newHash string, err error)
+}
+
+// verifyAndUpgrade VerifyAndUpgrade 的通用实现
+// 依次调用 VerifyPassword、NeedsRehash 和 HashPassword
+func verifyAndUpgrade(c Crypto, needsRehash func(string) bool, hashedPassword, password string) (string, error) {
+	if err := c.VerifyPassword(hashedPassword, password); err != nil {
+		return "", err
+	}
+	if !needsRehash(hashedPassword) {
+		return "", nil
+	}
+
+	newHash, err := c.HashPassword(password)
+	if err != nil {
+		// 验证已通过,升级失败时保留旧哈希
+		return "", nil
+	}
+	return newHash, nil
+}
+
+// NeedsRehash 实现 Rehasher 接口
+// 哈希不是 bcrypt 或成本低于当前 BcryptCost 时返回 true
+// 成本更高的哈希(如 HashPasswordWithCost 为管理员生成的哈希)不视为过时,避免被降级
+func (b *bcryptCrypto) NeedsRehash(hashedPassword string) bool {
+	b.mu.RLock()
+	cost := b.config.BcryptCost
+	b.mu.RUnlock()
+
+	info, err := HashInfo(hashedPassword)
+	return err != nil || info.Algorithm != AlgorithmBcrypt || info.Cost < cost
+}
+
+// VerifyAndUpgrade 实现 Rehasher 接口
+func (b *bcryptCrypto) VerifyAndUpgrade(hashedPassword, password string) (string, error) {
+	return verifyAndUpgrade(b, b.NeedsRehash, hashedPassword, password)
+}
+
+// NeedsRehash 实现 Rehasher 接口
+// 哈希不是 scrypt 或 N、r、p、密钥长度与当前配置不同时返回 true
+func (s *scryptCrypto) NeedsRehash(hashedPassword string) bool {
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+
+	info, err := HashInfo(hashedPassword)
+	return err != nil || info.Algorithm != AlgorithmScrypt ||
+		info.N != config.ScryptN || info.R != config.ScryptR || info.P != config.ScryptP ||
+		info.KeyLength != int(config.ScryptKeyLen)
+}
+
+// VerifyAndUpgrade 实现 Rehasher 接口
+func (s *scryptCrypto) VerifyAndUpgrade(hashedPassword, password string) (string, error) {
+	return verifyAndUpgrade(s, s.NeedsRehash, hashedPassword, password)
+}
+
+// NeedsRehash 实现 Rehasher 接口
+// 主加密器实现了 Rehasher 时交给它判断;
+// 否则哈希前缀对应的算法与主加密器不同时返回 true,主加密器不报告算法时返回 false
+func (m *multiCrypto) NeedsRehash(hashedPassword string) bool {
+	if r, ok := m.primary.(Rehasher); ok {
+		return r.NeedsRehash(hashedPassword)
+	}
+	algorithm := m.Algorithm()
+	return algorithm != "" && algorithmByPrefix(hashedPassword) != algorithm
+}
+
+// VerifyAndUpgrade 实现 Rehasher 接口
+// 按哈希前缀分发验证,需要升级时使用主加密器生成新哈希
+func (m *multiCrypto) VerifyAndUpgrade(hashedPassword, password string) (string, error) {
+	return verifyAndUpgrade(m, m.NeedsRehash, hashedPassword, password)
+}
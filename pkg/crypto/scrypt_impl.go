@@ -0,0 +1,273 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"math/bits"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scryptCrypto scrypt 密码加密器实现
+// 实现 Crypto 和 AlgorithmReporter 接口
+// 哈希使用 PHC 风格的自描述格式，参数随哈希保存，调整参数后旧哈希仍可验证:
+//
+//	$scrypt$ln=15,r=8,p=1$<base64 盐值>$<base64 哈希>
+//
+// 其中 ln 为 log2(N)
+type scryptCrypto struct {
+	mu     sync.RWMutex // 保护配置的读写锁
+	config *Config      // 当前配置
+}
+
+// NewScrypt 创建 scrypt 密码加密器
+// 适用于合规要求或既有系统已使用 scrypt 的场景
+// 参数:
+//
+//	opts: 可选配置选项，scrypt 参数通过 WithScryptParams 设置
+//
+// 返回:
+//
+//	Crypto: 加密器实例，同时实现 AlgorithmReporter，可直接用于 NewMulti
+//	error: 配置无效时的错误（参数低于 MinScryptN/MinScryptR/MinScryptP 等）
+//
+// 使用示例:
+//
+//	// 使用默认参数 (N=32768, r=8, p=1)
+//	c, err := NewScrypt()
+//
+//	// 自定义参数
+//	c, err := NewScrypt(WithScryptParams(1<<17, 8, 1, 32))
+func NewScrypt(opts ...Option) (Crypto, error) {
+	// 创建默认配置
+	config := DefaultConfig()
+	config.Algorithm = AlgorithmScrypt
+
+	// 应用用户配置
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	// 验证配置
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf(ErrMsgInvalidConfig, err)
+	}
+
+	return &scryptCrypto{
+		config: config,
+	}, nil
+}
+
+// HashPassword 实现 Crypto 接口
+// 使用随机盐值和当前配置的参数计算 scrypt 哈希
+func (s *scryptCrypto) HashPassword(password string) (string, error) {
+	return s.hashBytes([]byte(password))
+}
+
+// HashPasswordSecure 实现 SecureHasher 接口
+func (s *scryptCrypto) HashPasswordSecure(password *SecureBytes) (hash string, err error) {
+	err = password.Use(func(pw []byte) error {
+		hash, err = s.hashBytes(pw)
+		return err
+	})
+	return hash, err
+}
+
+// hashBytes 计算字节形式密码的 scrypt 哈希
+func (s *scryptCrypto) hashBytes(password []byte) (string, error) {
+	// 读取当前配置
+	s.mu.RLock()
+	config := s.config
+	s.mu.RUnlock()
+
+	// 验证密码长度
+	if err := s.validatePassword(len(password)); err != nil {
+		return "", err
+	}
+
+	// 验证密码强度
+	if config.MinStrength > StrengthVeryWeak {
+		if score := EstimateStrength(bytesView(password)).Score; score < config.MinStrength {
+			return "", fmt.Errorf(ErrMsgPasswordTooWeak, ErrPasswordTooWeak, score, config.MinStrength)
+		}
+	}
+
+	// 生成随机盐值
+	salt := make([]byte, ScryptSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf(ErrMsgHashingFailed, err)
+	}
+
+	key, err := scrypt.Key(password, salt, config.ScryptN, config.ScryptR, config.ScryptP, int(config.ScryptKeyLen))
+	if err != nil {
+		return "", fmt.Errorf(ErrMsgHashingFailed, err)
+	}
+
+	return fmt.Sprintf("%sln=%d,r=%d,p=%d$%s$%s",
+		PrefixScrypt, bits.TrailingZeros(uint(config.ScryptN)), config.ScryptR, config.ScryptP,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// VerifyPassword 实现 Crypto 接口
+// 使用哈希中保存的参数重新计算并以常量时间比较
+// 哈希中的参数只做格式和上限检查，不要求满足 Min* 下限，以便验证既有系统迁移来的旧哈希
+func (s *scryptCrypto) VerifyPassword(hashedPassword, password string) error {
+	return s.verifyBytes(hashedPassword, []byte(password))
+}
+
+// VerifyPasswordSecure 实现 SecureHasher 接口
+func (s *scryptCrypto) VerifyPasswordSecure(hashedPassword string, password *SecureBytes) error {
+	return password.Use(func(pw []byte) error {
+		return s.verifyBytes(hashedPassword, pw)
+	})
+}
+
+// verifyBytes 验证字节形式的密码
+func (s *scryptCrypto) verifyBytes(hashedPassword string, password []byte) error {
+	// 验证密码长度
+	if err := s.validatePassword(len(password)); err != nil {
+		return err
+	}
+
+	params, err := parseScryptHash(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	key, err := scrypt.Key(password, params.salt, params.n, params.r, params.p, len(params.key))
+	if err != nil {
+		return fmt.Errorf(ErrMsgVerificationFailed, err)
+	}
+
+	if subtle.ConstantTimeCompare(key, params.key) != 1 {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+// Algorithm 实现 AlgorithmReporter 接口
+// 返回 AlgorithmScrypt
+func (s *scryptCrypto) Algorithm() string {
+	return AlgorithmScrypt
+}
+
+// UpdateConfig 实现 Crypto 接口
+// 原子化更新配置
+func (s *scryptCrypto) UpdateConfig(opts ...Option) error {
+	// 克隆当前配置
+	s.mu.RLock()
+	newConfig := s.config.Clone()
+	s.mu.RUnlock()
+
+	// 应用新配置选项
+	for _, opt := range opts {
+		opt(newConfig)
+	}
+
+	// 验证新配置
+	if err := newConfig.Validate(); err != nil {
+		return fmt.Errorf(ErrMsgInvalidConfig, err)
+	}
+
+	// 原子替换配置
+	s.mu.Lock()
+	s.config = newConfig
+	s.mu.Unlock()
+
+	return nil
+}
+
+// validatePassword 验证密码长度
+// 根据配置的长度限制检查密码是否合法
+func (s *scryptCrypto) validatePassword(length int) error {
+	s.mu.RLock()
+	minLen := s.config.MinPasswordLength
+	maxLen := s.config.MaxPasswordLength
+	s.mu.RUnlock()
+
+	if length < minLen {
+		return fmt.Errorf(ErrMsgPasswordTooShort, minLen)
+	}
+
+	if length > maxLen {
+		return fmt.Errorf(ErrMsgPasswordTooLong, maxLen)
+	}
+
+	return nil
+}
+
+// scryptParams 从哈希中解析出的 scrypt 参数
+type scryptParams struct {
+	n, r, p int
+	salt    []byte
+	key     []byte
+}
+
+// parseScryptHash 解析 scrypt 哈希
+// 格式: $scrypt$ln=15,r=8,p=1$<base64 盐值>$<base64 哈希>
+// 返回:
+//
+//	scryptParams: 解析结果
+//	error: 格式错误或参数超出 MaxScryptLogN 等上限时返回 ErrInvalidHash
+func parseScryptHash(hash string) (scryptParams, error) {
+	var ln, r, p int
+	var salt, key string
+	if _, err := fmt.Sscanf(hash, PrefixScrypt+"ln=%d,r=%d,p=%d$%s", &ln, &r, &p, &salt); err != nil {
+		return scryptParams{}, fmt.Errorf("%w: malformed scrypt hash", ErrInvalidHash)
+	}
+
+	// %s 读取了 "<盐值>$<哈希>"，按最后一个 $ 拆分
+	for i := len(salt) - 1; i >= 0; i-- {
+		if salt[i] == '$' {
+			salt, key = salt[:i], salt[i+1:]
+			break
+		}
+	}
+	if key == "" {
+		return scryptParams{}, fmt.Errorf("%w: malformed scrypt hash", ErrInvalidHash)
+	}
+
+	// 上限检查，防止被篡改的哈希触发巨量内存分配
+	if ln < 1 || ln > MaxScryptLogN || r < 1 || p < 1 || r*p > MaxScryptRP {
+		return scryptParams{}, fmt.Errorf("%w: scrypt params out of range (ln=%d, r=%d, p=%d)", ErrInvalidHash, ln, r, p)
+	}
+
+	saltBytes, err := base64.RawStdEncoding.DecodeString(salt)
+	if err != nil {
+		return scryptParams{}, fmt.Errorf("%w: invalid scrypt salt", ErrInvalidHash)
+	}
+	keyBytes, err := base64.RawStdEncoding.DecodeString(key)
+	if err != nil || len(keyBytes) == 0 {
+		return scryptParams{}, fmt.Errorf("%w: invalid scrypt key", ErrInvalidHash)
+	}
+
+	return scryptParams{
+		n:    1 << ln,
+		r:    r,
+		p:    p,
+		salt: saltBytes,
+		key:  keyBytes,
+	}, nil
+}
+
+// parseScryptInfo 解析 scrypt 哈希参数，供 HashInfo 使用
+func parseScryptInfo(hash string) (Info, error) {
+	params, err := parseScryptHash(hash)
+	if err != nil {
+		return Info{}, err
+	}
+	return Info{
+		Algorithm:  AlgorithmScrypt,
+		Variant:    AlgorithmScrypt,
+		N:          params.n,
+		R:          params.r,
+		P:          params.p,
+		SaltLength: len(params.salt),
+		KeyLength:  len(params.key),
+	}, nil
+}
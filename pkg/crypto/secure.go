@@ -0,0 +1,168 @@
+package crypto
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+)
+
+// SecureHasher 支持直接处理 SecureBytes 的加密器
+// 内置的 bcrypt、scrypt 和 Multi 加密器都实现了该接口,
+// 明文密码以字节切片传给哈希算法,不会转换为无法清零的 string
+//
+// 使用示例:
+//
+//	pw := crypto.NewSecureBytes(body.Password) // body.Password 为 []byte,会被清零
+//	defer pw.Destroy()
+//
+//	if sh, ok := c.(crypto.SecureHasher); ok {
+//	    hash, err = sh.HashPasswordSecure(pw)
+//	}
+type SecureHasher interface {
+	// HashPasswordSecure 加密 SecureBytes 中的密码
+	// 参数:
+	//   password: 明文密码,调用后不会被销毁,由调用方负责 Destroy
+	// 返回:
+	//   string: 密码哈希值
+	//   error: 加密失败或 password 已销毁时的错误
+	HashPasswordSecure(password *SecureBytes) (string, error)
+
+	// VerifyPasswordSecure 验证 SecureBytes 中的密码
+	// 参数:
+	//   hashedPassword: 存储的密码哈希值
+	//   password: 待验证的明文密码
+	// 返回:
+	//   error: 与 VerifyPassword 相同,password 已销毁时返回 ErrSecureBytesDestroyed
+	VerifyPasswordSecure(hashedPassword string, password *SecureBytes) error
+}
+
+// SecureBytes 可清零的敏感数据容器
+// Go 的 string 不可变,密码转成 string 后会一直留在内存中直到被 GC 覆盖;
+// SecureBytes 用字节切片保存敏感数据,使用完毕后调用 Destroy 立即清零
+//
+// 内存保护(尽力而为):
+//   - Unix 平台上数据存放在单独映射的内存页中,并通过 mlock 锁定,避免被换出到 swap
+//   - mlock 受 RLIMIT_MEMLOCK 限制,失败或在 Windows 等平台上时退化为普通堆内存,
+//     仍然会在 Destroy 时清零,可通过 Locked 查看是否锁定成功
+//   - 无法防止调用方自行复制数据(如在 Use 中执行 string(b)),也无法清除 TLS、
+//     HTTP 解析等上游环节留下的副本
+//
+// 数据只能在 Use 的回调中访问: 回调执行期间持有 SecureBytes,
+// 终结器不会在切片仍被使用时清零并解除映射
+//
+// 线程安全: 所有方法都是并发安全的
+type SecureBytes struct {
+	mu        sync.Mutex
+	buf       []byte
+	locked    bool
+	destroyed bool
+}
+
+// NewSecureBytes 创建 SecureBytes
+// 将 b 复制到受保护的内存中,并立即清零 b,调用后不应再使用 b
+// 参数:
+//
+//	b: 敏感数据,例如从请求体读取的密码字节
+//
+// 返回:
+//
+//	*SecureBytes: 安全容器,使用完毕后必须调用 Destroy
+//
+// 注意: 未调用 Destroy 时由 GC 回收时的终结器兜底清零,但时机不确定
+func NewSecureBytes(b []byte) *SecureBytes {
+	s := &SecureBytes{}
+
+	if len(b) > 0 {
+		if buf, ok := allocLocked(len(b)); ok {
+			s.buf = buf
+			s.locked = true
+		} else {
+			s.buf = make([]byte, len(b))
+		}
+		copy(s.buf, b)
+		wipe(b)
+	}
+
+	runtime.SetFinalizer(s, (*SecureBytes).Destroy)
+	return s
+}
+
+// Len 返回数据长度,Destroy 后为 0
+func (s *SecureBytes) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buf)
+}
+
+// Locked 返回数据所在内存是否已通过 mlock 锁定
+func (s *SecureBytes) Locked() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.locked
+}
+
+// Destroyed 返回是否已调用 Destroy
+func (s *SecureBytes) Destroyed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.destroyed
+}
+
+// Destroy 清零并释放数据
+// 可以重复调用,之后 Use 返回 ErrSecureBytesDestroyed
+func (s *SecureBytes) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.destroyed {
+		return
+	}
+	s.destroyed = true
+
+	wipe(s.buf)
+	if s.locked {
+		freeLocked(s.buf)
+		s.locked = false
+	}
+	s.buf = nil
+	runtime.SetFinalizer(s, nil)
+}
+
+// Use 在持有锁期间以字节切片访问数据(不复制)
+// 保证 fn 执行期间数据不会被其他 goroutine Destroy,也不会被终结器回收
+// 参数:
+//
+//	fn: 访问函数,b 与 SecureBytes 共享内存,fn 返回后不得保留 b 或转换为 string
+//
+// 返回:
+//
+//	error: SecureBytes 为 nil 或已销毁时返回 ErrSecureBytesDestroyed,否则返回 fn 的错误
+func (s *SecureBytes) Use(fn func(b []byte) error) error {
+	if s == nil {
+		return ErrSecureBytesDestroyed
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.destroyed {
+		return ErrSecureBytesDestroyed
+	}
+	return fn(s.buf)
+}
+
+// wipe 清零字节切片
+// KeepAlive 防止编译器将对即将释放的内存的写入优化掉
+func wipe(b []byte) {
+	clear(b)
+	runtime.KeepAlive(b)
+}
+
+// bytesView 返回与 b 共享内存的 string,不产生可残留的副本
+// 仅用于 EstimateStrength 等只读且不保留参数的调用
+func bytesView(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
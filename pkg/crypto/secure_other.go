@@ -0,0 +1,11 @@
+//go:build !unix
+
+package crypto
+
+// allocLocked 当前平台不支持 mlock,SecureBytes 退化为普通堆内存
+func allocLocked(n int) ([]byte, bool) {
+	return nil, false
+}
+
+// freeLocked 当前平台无需释放
+func freeLocked(buf []byte) {}
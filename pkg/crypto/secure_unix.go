@@ -0,0 +1,33 @@
+//go:build unix
+
+package crypto
+
+import "golang.org/x/sys/unix"
+
+// allocLocked 分配独立映射并通过 mlock 锁定的内存
+// 使用单独的匿名映射而不是 Go 堆内存:
+//   - 内存页不与其他对象共享,munlock 不会影响其他 SecureBytes
+//   - 不受 GC 管理,数据不会被运行时复制
+//
+// 返回:
+//
+//	[]byte: 长度为 n 的内存
+//	bool: 映射或锁定失败时返回 false,由调用方退化为普通堆内存
+func allocLocked(n int) ([]byte, bool) {
+	buf, err := unix.Mmap(-1, 0, n, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_ANON|unix.MAP_PRIVATE)
+	if err != nil {
+		return nil, false
+	}
+	if err := unix.Mlock(buf); err != nil {
+		_ = unix.Munmap(buf)
+		return nil, false
+	}
+	return buf, true
+}
+
+// freeLocked 解锁并释放 allocLocked 分配的内存
+// 调用方需先清零
+func freeLocked(buf []byte) {
+	_ = unix.Munlock(buf)
+	_ = unix.Munmap(buf)
+}
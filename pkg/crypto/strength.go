@@ -0,0 +1,267 @@
+package crypto
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// StrengthResult 密码强度评估结果
+type StrengthResult struct {
+	// Score 强度评分
+	// 范围: 0-4 (StrengthVeryWeak ~ StrengthVeryStrong)
+	Score int
+
+	// Guesses 估算的破解所需猜测次数
+	Guesses float64
+
+	// CrackTime 估算的离线破解时间
+	// 假设攻击者获得哈希并以 StrengthGuessesPerSecond 的速度尝试
+	// 超过 time.Duration 上限时取最大值
+	CrackTime time.Duration
+
+	// Feedback 改进建议
+	// 取值为 Feedback* 常量,密码足够强时为空
+	Feedback []string
+}
+
+// strengthKeyboardRows 键盘行,相邻按键视为序列
+var strengthKeyboardRows = []string{
+	"1234567890",
+	"qwertyuiop",
+	"asdfghjkl",
+	"zxcvbnm",
+}
+
+// strengthLeetReplacer 常见的字符替换,还原后再匹配常用密码
+var strengthLeetReplacer = strings.NewReplacer(
+	"@", "a", "4", "a",
+	"3", "e",
+	"1", "i", "!", "i",
+	"0", "o",
+	"$", "s", "5", "s",
+	"7", "t",
+)
+
+// strengthCommonPasswords 常用密码表
+// 只收录最常见的一小部分,用于拦截明显的弱密码
+var strengthCommonPasswords = map[string]struct{}{
+	"password": {}, "passw0rd": {}, "123456": {}, "12345678": {}, "123456789": {},
+	"1234567890": {}, "qwerty": {}, "qwertyuiop": {}, "abc123": {}, "111111": {},
+	"iloveyou": {}, "admin": {}, "administrator": {}, "welcome": {}, "monkey": {},
+	"dragon": {}, "letmein": {}, "football": {}, "baseball": {}, "master": {},
+	"sunshine": {}, "princess": {}, "shadow": {}, "superman": {}, "trustno1": {},
+	"login": {}, "starwars": {}, "whatever": {}, "freedom": {}, "secret": {},
+	"changeme": {}, "root": {}, "test": {}, "guest": {}, "default": {},
+}
+
+// EstimateStrength 估算密码强度
+// 参考 zxcvbn 的思路,但实现自包含、不依赖外部词典:
+//   - 常用密码(含 "p@ssw0rd" 这类字符替换和 "password123" 这类数字后缀)直接判为最弱
+//   - 重复字符(aaaa)和序列(abcd、1234、qwer)不计入有效长度
+//   - 按字符集大小和有效长度估算猜测次数,再换算为评分和破解时间
+//   - 纯数字密码评分最高为 StrengthWeak
+//
+// 参数:
+//
+//	password: 明文密码
+//
+// 返回:
+//
+//	StrengthResult: 评分、破解时间估算和改进建议
+//
+// 使用示例:
+//
+//	result := crypto.EstimateStrength(input)
+//	if result.Score < crypto.StrengthModerate {
+//	    return fmt.Errorf("密码太弱: %v", result.Feedback)
+//	}
+func EstimateStrength(password string) StrengthResult {
+	var feedback []string
+	runes := []rune(password)
+
+	if len(runes) < DefaultMinPasswordLength {
+		feedback = append(feedback, FeedbackTooShort)
+	}
+
+	var log10Guesses float64
+	if isCommonPassword(password) {
+		feedback = append(feedback, FeedbackCommonPassword)
+		log10Guesses = 1
+	} else {
+		effective, repeated, sequential := effectiveLength(runes)
+		if repeated {
+			feedback = append(feedback, FeedbackRepeatedChars)
+		}
+		if sequential {
+			feedback = append(feedback, FeedbackSequence)
+		}
+		log10Guesses = float64(effective) * math.Log10(float64(charsetSize(runes)))
+	}
+
+	score := scoreFromGuesses(log10Guesses)
+
+	// 纯数字密码常是日期、电话号码,攻击者会优先穷举,评分最高为 StrengthWeak
+	if len(runes) > 0 && isAllDigits(runes) {
+		feedback = append(feedback, FeedbackOnlyDigits)
+		score = min(score, StrengthWeak)
+	}
+	if score < StrengthStrong && len(feedback) == 0 {
+		feedback = append(feedback, FeedbackAddVariety)
+	}
+
+	return StrengthResult{
+		Score:     score,
+		Guesses:   math.Pow(10, log10Guesses),
+		CrackTime: crackTime(log10Guesses),
+		Feedback:  feedback,
+	}
+}
+
+// isCommonPassword 判断密码是否为常用密码
+// 忽略大小写、还原常见字符替换,并去掉末尾的数字和符号后再匹配
+func isCommonPassword(password string) bool {
+	lower := strings.ToLower(password)
+	candidates := []string{
+		lower,
+		strings.TrimRightFunc(lower, isDigitOrSymbol),
+		strengthLeetReplacer.Replace(lower),
+		strengthLeetReplacer.Replace(strings.TrimRightFunc(lower, isDigitOrSymbol)),
+	}
+	for _, c := range candidates {
+		if _, ok := strengthCommonPasswords[c]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveLength 计算有效长度
+// 与前一个字符相同或相邻(字母表、数字、键盘行)的字符不计入
+// 返回:
+//
+//	int: 有效长度
+//	bool: 是否包含重复字符
+//	bool: 是否包含序列
+func effectiveLength(runes []rune) (int, bool, bool) {
+	effective := 0
+	repeated, sequential := false, false
+	for i, r := range runes {
+		if i == 0 {
+			effective++
+			continue
+		}
+		prev := runes[i-1]
+		switch {
+		case unicode.ToLower(r) == unicode.ToLower(prev):
+			repeated = true
+		case isSequential(prev, r):
+			sequential = true
+		default:
+			effective++
+		}
+	}
+	return effective, repeated, sequential
+}
+
+// isSequential 判断两个字符是否相邻
+func isSequential(a, b rune) bool {
+	a, b = unicode.ToLower(a), unicode.ToLower(b)
+	if isASCIIAlnum(a) && isASCIIAlnum(b) && (b-a == 1 || a-b == 1) {
+		return true
+	}
+	for _, row := range strengthKeyboardRows {
+		i, j := strings.IndexRune(row, a), strings.IndexRune(row, b)
+		if i >= 0 && j >= 0 && (i-j == 1 || j-i == 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// charsetSize 根据出现的字符类别估算字符集大小
+func charsetSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII:
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	size := 0
+	if lower {
+		size += 26
+	}
+	if upper {
+		size += 26
+	}
+	if digit {
+		size += 10
+	}
+	if symbol {
+		size += 33
+	}
+	if other {
+		size += 100
+	}
+	if size == 0 {
+		size = 1
+	}
+	return size
+}
+
+// scoreFromGuesses 根据猜测次数(以 10 为底的对数)计算评分
+// 阈值与 zxcvbn 一致
+func scoreFromGuesses(log10Guesses float64) int {
+	switch {
+	case log10Guesses < 3:
+		return StrengthVeryWeak
+	case log10Guesses < 6:
+		return StrengthWeak
+	case log10Guesses < 8:
+		return StrengthModerate
+	case log10Guesses < 10:
+		return StrengthStrong
+	default:
+		return StrengthVeryStrong
+	}
+}
+
+// crackTime 根据猜测次数估算破解时间
+func crackTime(log10Guesses float64) time.Duration {
+	seconds := math.Pow(10, log10Guesses) / StrengthGuessesPerSecond
+	if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// isAllDigits 判断是否全部为数字
+func isAllDigits(runes []rune) bool {
+	for _, r := range runes {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isASCIIAlnum 判断是否为 ASCII 字母或数字
+func isASCIIAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// isDigitOrSymbol 判断是否为数字或 ASCII 符号
+func isDigitOrSymbol(r rune) bool {
+	return r < unicode.MaxASCII && !unicode.IsLetter(r)
+}
@@ -11,6 +11,49 @@ const (
 	// DefaultConnMaxLifetime 默认连接最大生命周期
 	// 如果配置中未指定,使用此默认值
 	DefaultConnMaxLifetime = time.Hour
+
+	// DefaultPingTimeout 默认 Ping 超时时间
+	// Ping() 使用此超时调用 PingContext
+	// 避免数据库无响应时健康检查被无限阻塞
+	DefaultPingTimeout = 5 * time.Second
+
+	// DefaultRetryDelay 默认连接重试间隔
+	// RetryConfig.Delay 未设置时使用
+	DefaultRetryDelay = time.Second
+
+	// DefaultMaxRetryDelay 连接重试间隔上限
+	// 防止指数退避导致等待时间过长
+	DefaultMaxRetryDelay = 30 * time.Second
+
+	// DefaultSlowThreshold 默认慢查询阈值
+	// Config.SlowThreshold 未设置时使用
+	// 执行时间超过此值的 SQL 会以 warn 级别记录
+	DefaultSlowThreshold = 200 * time.Millisecond
+)
+
+// SQL 日志级别
+// 对应 Config.LogLevel 的可选值
+const (
+	// LogLevelSilent 不记录任何 SQL 日志
+	LogLevelSilent = "silent"
+
+	// LogLevelError 只记录执行出错的 SQL
+	LogLevelError = "error"
+
+	// LogLevelWarn 记录出错的 SQL 和慢查询
+	LogLevelWarn = "warn"
+
+	// LogLevelInfo 记录所有 SQL(以 debug 级别输出)
+	LogLevelInfo = "info"
+
+	// DefaultLogLevel 默认 SQL 日志级别
+	DefaultLogLevel = LogLevelWarn
+)
+
+// 迁移相关常量
+const (
+	// MigrationTableName 记录已应用迁移的表名
+	MigrationTableName = "schema_migrations"
 )
 
 // 错误消息常量
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+
+	"github.com/rei0721/go-scaffold/pkg/dbtx"
+	"gorm.io/gorm"
+)
+
+// DBFromContext 返回当前 Context 应使用的数据库实例
+// Context 中携带事务（dbtx.WithTxContext 或 WithTx 的事务 Context）时返回该事务，
+// 否则返回 fallback
+// 为什么需要:
+//   - 仓储方法只接收 ctx，同一个方法在事务内外都能使用
+//   - 不再需要 CreateUser / CreateUserWithTx 这类成对的方法
+//
+// 参数:
+//
+//	ctx: 上下文
+//	fallback: 不在事务中时使用的数据库实例，通常是仓储持有的 *gorm.DB
+//
+// 返回:
+//
+//	*gorm.DB: 已绑定 ctx 的事务或 fallback，fallback 为 nil 且不在事务中时返回 nil
+//
+// 使用示例:
+//
+//	func (r *userRepository) Create(ctx context.Context, user *models.User) error {
+//	    return database.DBFromContext(ctx, r.db).Create(user).Error
+//	}
+//
+//	// Service 层
+//	err := txManager.WithTx(ctx, func(tx *gorm.DB) error {
+//	    return userRepo.Create(dbtx.WithTxContext(ctx, tx), user)
+//	})
+func DBFromContext(ctx context.Context, fallback *gorm.DB) *gorm.DB {
+	if tx, ok := dbtx.TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	if fallback == nil {
+		return nil
+	}
+	return fallback.WithContext(ctx)
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/rei0721/go-scaffold/pkg/dbtx"
+)
+
+// contextTestItem 测试用模型
+type contextTestItem struct {
+	ID   int64 `gorm:"primaryKey"`
+	Name string
+}
+
+// createItem 模拟只接收 ctx 的仓储方法
+func createItem(ctx context.Context, db *gorm.DB, name string) error {
+	return DBFromContext(ctx, db).Create(&contextTestItem{Name: name}).Error
+}
+
+// TestDBFromContext 测试事务内外使用同一个仓储方法
+func TestDBFromContext(t *testing.T) {
+	db := newTestDB(t).DB()
+	if err := db.AutoMigrate(&contextTestItem{}); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	mgr, err := dbtx.NewManager(db, nil)
+	if err != nil {
+		t.Fatalf("failed to create tx manager: %v", err)
+	}
+	ctx := context.Background()
+
+	if DBFromContext(ctx, nil) != nil {
+		t.Fatal("expected nil without transaction and fallback")
+	}
+
+	// 不在事务中,直接写入 fallback
+	if err := createItem(ctx, db, "outside"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 在事务中,随事务回滚
+	rollback := errors.New("rollback")
+	err = mgr.WithTx(ctx, func(tx *gorm.DB) error {
+		if err := createItem(dbtx.WithTxContext(ctx, tx), db, "rolled back"); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("expected rollback error, got %v", err)
+	}
+
+	// tx.Statement.Context 同样携带事务
+	err = mgr.WithTx(ctx, func(tx *gorm.DB) error {
+		return createItem(tx.Statement.Context, db, "committed")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	db.Model(&contextTestItem{}).Order("id").Pluck("name", &names)
+	if len(names) != 2 || names[0] != "outside" || names[1] != "committed" {
+		t.Fatalf("expected [outside committed], got %v", names)
+	}
+}
@@ -7,6 +7,8 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"time"
 
 	"gorm.io/gorm"
@@ -100,6 +102,49 @@ type Config struct {
 	// - 定期刷新连接,防止数据库端超时
 	// 推荐值: 5-30 分钟
 	MaxLifetime time.Duration `mapstructure:"maxLifetime"`
+
+	// ConnectRetry 建立连接时的重试策略
+	// 为空时不重试,连接失败立即返回错误
+	// 用途:
+	// - docker-compose/K8s 中应用可能先于数据库启动
+	// - 避免仅因启动顺序导致的 crash-loop
+	ConnectRetry *RetryConfig `mapstructure:"connectRetry"`
+
+	// LogLevel SQL 日志级别
+	// 可选值: silent, error, warn, info
+	// 为空时使用 DefaultLogLevel(warn)
+	// 只有通过 NewWithLogger 传入 Logger 时才会输出到应用日志
+	LogLevel string `mapstructure:"logLevel"`
+
+	// SlowThreshold 慢查询阈值
+	// 执行时间超过此值的 SQL 以 warn 级别记录,包含耗时和影响行数
+	// 为 0 时使用 DefaultSlowThreshold
+	SlowThreshold time.Duration `mapstructure:"slowThreshold"`
+}
+
+// RetryConfig 连接重试配置
+// 每次重试的等待时间按 Delay * Backoff^(n-1) 递增
+type RetryConfig struct {
+	// MaxAttempts 最大尝试次数(包含第一次)
+	// 小于等于 1 时只尝试一次
+	MaxAttempts int `mapstructure:"maxAttempts"`
+
+	// Delay 第一次重试前的等待时间
+	// 为 0 时使用 DefaultRetryDelay
+	Delay time.Duration `mapstructure:"delay"`
+
+	// Backoff 每次重试后等待时间的倍数
+	// 小于 1 时按 1 处理(固定间隔)
+	// 例如 2 表示指数退避: 1s, 2s, 4s...
+	Backoff float64 `mapstructure:"backoff"`
+
+	// OnRetry 每次尝试失败且即将重试时的回调
+	// 可用于记录日志,不从配置文件加载
+	// 参数:
+	//   attempt: 失败的尝试序号(从 1 开始)
+	//   err: 失败原因
+	//   delay: 下次重试前的等待时间
+	OnRetry func(attempt int, err error, delay time.Duration) `mapstructure:"-"`
 }
 
 // Reloader 定义数据库配置重载接口
@@ -148,19 +193,67 @@ type Database interface {
 	Close() error
 
 	// Ping 验证数据库连接是否存活
+	// 使用 DefaultPingTimeout 作为超时时间,等价于带超时的 PingContext
 	// 用途:
 	// - 健康检查接口
 	// - 初始化时验证配置是否正确
 	// - 定期检查连接状态
 	// 返回:
-	//   error: 如果连接失败或不可用
+	//   error: 如果连接失败、不可用或超时
 	Ping() error
 
+	// PingContext 使用指定的 context 验证数据库连接是否存活
+	// 调用方可以通过 context 控制超时和取消
+	// 用途:
+	// - readiness 探针等需要严格超时的场景
+	// 参数:
+	//   ctx: 控制超时和取消的上下文
+	// 返回:
+	//   error: 如果连接失败、不可用或 context 已结束
+	PingContext(ctx context.Context) error
+
+	// Stats 返回底层连接池的原始统计信息
+	// 用途:
+	// - 监控连接池使用情况
+	// - 判断 MaxOpenConns 是否成为瓶颈(WaitCount 持续增长)
+	// 返回:
+	//   sql.DBStats: 标准库连接池统计
+	Stats() sql.DBStats
+
+	// PoolStats 返回精简后的连接池统计信息
+	// 只保留监控最常用的几个指标,便于直接输出到日志
+	// 返回:
+	//   PoolStats: 连接池统计摘要
+	PoolStats() PoolStats
+
 	// Reloader 嵌入重载接口
 	// 支持数据库配置的热更新
 	Reloader
 }
 
+// PoolStats 连接池统计摘要
+// 是 sql.DBStats 的子集,只包含判断连接池健康度最关键的指标
+// 使用场景:
+// - 定期写入日志
+// - 上报监控系统
+type PoolStats struct {
+	// OpenConnections 当前已建立的连接数(使用中 + 空闲)
+	OpenConnections int
+
+	// InUse 正在被使用的连接数
+	InUse int
+
+	// Idle 空闲连接数
+	Idle int
+
+	// WaitCount 累计等待获取连接的次数
+	// 持续增长说明 MaxOpenConns 设置过小
+	WaitCount int64
+
+	// WaitDuration 累计等待获取连接的总时长
+	WaitDuration time.Duration
+}
+
 // Hook 定义数据库操作的回调接口
 // 这是一个扩展点,允许在数据库操作前后插入自定义逻辑
 // 使用场景:
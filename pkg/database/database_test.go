@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// newTestDB 创建基于临时 SQLite 文件的测试数据库
+func newTestDB(t *testing.T) Database {
+	t.Helper()
+	db, err := New(&Config{
+		Driver: DriverSQLite,
+		DBName: filepath.Join(t.TempDir(), "test.db"),
+	})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// TestPing 测试默认超时的 Ping
+func TestPing(t *testing.T) {
+	db := newTestDB(t)
+	if err := db.Ping(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestPingContext_Cancelled 测试已取消的 context 立即返回
+func TestPingContext_Cancelled(t *testing.T) {
+	db := newTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := db.PingContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("PingContext did not return promptly: %v", elapsed)
+	}
+}
+
+// TestNewWithLogger_SlowQuery 测试慢查询通过应用日志以 warn 级别输出
+func TestNewWithLogger_SlowQuery(t *testing.T) {
+	log, sink := logger.NewTestLogger()
+	db, err := NewWithLogger(&Config{
+		Driver:        DriverSQLite,
+		DBName:        filepath.Join(t.TempDir(), "test.db"),
+		SlowThreshold: time.Nanosecond,
+	}, log)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if err := db.DB().Exec("CREATE TABLE items (id INTEGER PRIMARY KEY)").Error; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var found bool
+	for _, e := range sink.Entries() {
+		if e.Level == logger.LevelWarn && e.Message == "slow sql" {
+			found = true
+			if _, ok := e.Fields["duration"]; !ok {
+				t.Errorf("expected duration field, got %v", e.Fields)
+			}
+			if _, ok := e.Fields["rows"]; !ok {
+				t.Errorf("expected rows field, got %v", e.Fields)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected slow sql warning, got %+v", sink.Entries())
+	}
+}
+
+// TestNewWithLogger_Error 测试执行出错的 SQL 以 error 级别输出,silent 级别不输出
+func TestNewWithLogger_Error(t *testing.T) {
+	log, sink := logger.NewTestLogger()
+	db, err := NewWithLogger(&Config{
+		Driver: DriverSQLite,
+		DBName: filepath.Join(t.TempDir(), "test.db"),
+	}, log)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	_ = db.DB().Exec("SELECT * FROM missing_table").Error
+	if !sink.Contains(logger.LevelError, "sql error") {
+		t.Fatalf("expected sql error log, got %+v", sink.Entries())
+	}
+
+	sink.Reset()
+	silent := db.DB().Session(&gorm.Session{Logger: db.DB().Logger.LogMode(gormlogger.Silent)})
+	_ = silent.Exec("SELECT * FROM missing_table").Error
+	if len(sink.Entries()) != 0 {
+		t.Fatalf("expected no logs in silent mode, got %+v", sink.Entries())
+	}
+}
@@ -0,0 +1,21 @@
+package database
+
+import "errors"
+
+// 预定义错误（Sentinel Errors）
+// 可使用 errors.Is() 判断
+var (
+	// ErrNilDB 数据库实例为空
+	ErrNilDB = errors.New("database instance is nil")
+
+	// ErrInvalidMigration 无效的迁移定义
+	// ID 为空、Up 为空或 ID 重复时返回
+	ErrInvalidMigration = errors.New("invalid migration")
+
+	// ErrMigrationNotFound 已应用的迁移在当前迁移列表中找不到
+	// 回滚时无法获取对应的 Down 函数
+	ErrMigrationNotFound = errors.New("migration not found")
+
+	// ErrMigrationIrreversible 迁移没有提供 Down 函数,无法回滚
+	ErrMigrationIrreversible = errors.New("migration is irreversible")
+)
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"sync"
@@ -10,6 +11,9 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
 )
 
 // database 实现 Database 接口
@@ -38,6 +42,11 @@ type database struct {
 	// - 关闭数据库连接
 	// 必须在持有锁的情况下访问
 	sqlDB *sql.DB
+
+	// log SQL 日志使用的应用日志记录器
+	// 通过 NewWithLogger 设置,Reload 时沿用
+	// 为 nil 时使用 GORM 默认日志
+	log logger.Logger
 }
 
 // DB 返回底层的 GORM 数据库实例
@@ -79,18 +88,67 @@ func (d *database) Close() error {
 // 返回:
 //
 //	error: 如果连接失败或超时
+//
+// 注意:
+//
+//	使用 DefaultPingTimeout 限制等待时间,数据库无响应时不会无限阻塞
 func (d *database) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultPingTimeout)
+	defer cancel()
+	return d.PingContext(ctx)
+}
+
+// PingContext 使用指定的 context 验证数据库连接是否存活
+// 实现 Database 接口
+// 参数:
+//
+//	ctx: 控制超时和取消的上下文
+//
+// 返回:
+//
+//	error: 如果连接失败、超时或 context 已取消
+func (d *database) PingContext(ctx context.Context) error {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
 	if d.sqlDB != nil {
 		// 执行 ping 操作
 		// 会建立一个测试连接并立即关闭
-		return d.sqlDB.Ping()
+		return d.sqlDB.PingContext(ctx)
 	}
 	return nil
 }
 
+// Stats 返回底层连接池的统计信息
+// 实现 Database 接口
+// 使用读锁保护,确保 Reload 期间读取到的是完整的连接池
+// 返回:
+//
+//	sql.DBStats: 连接池统计,未初始化时返回零值
+func (d *database) Stats() sql.DBStats {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.sqlDB != nil {
+		return d.sqlDB.Stats()
+	}
+	return sql.DBStats{}
+}
+
+// PoolStats 返回连接池统计摘要
+// 实现 Database 接口
+// 基于 Stats 提取常用指标
+func (d *database) PoolStats() PoolStats {
+	stats := d.Stats()
+	return PoolStats{
+		OpenConnections: stats.OpenConnections,
+		InUse:           stats.InUse,
+		Idle:            stats.Idle,
+		WaitCount:       stats.WaitCount,
+		WaitDuration:    stats.WaitDuration,
+	}
+}
+
 // Reload 使用新配置重新加载数据库连接
 // 实现 Reloader 接口
 // 这个方法允许在运行时热更新数据库配置,无需重启应用
@@ -116,15 +174,19 @@ func (d *database) Reload(cfg *Config) error {
 	// 在锁外创建,避免长时间持有锁
 	// 不带 hooks,因为 hooks 在初始化时已注册
 	// 如果需要重新注册 hooks,可以扩展此方法接受 hooks 参数
-	newDB, err := New(cfg)
+	// 沿用创建时传入的 Logger,保持 SQL 日志输出位置不变
+	newDB, err := newDatabase(context.Background(), cfg, d.log)
 	if err != nil {
 		// 新连接创建失败,保持原连接不变
 		return fmt.Errorf("failed to create new database connection: %w", err)
 	}
 
 	// 2. 验证新连接是否可用
-	// 执行 Ping 测试,确保新连接确实可用
-	if err := newDB.Ping(); err != nil {
+	// 执行带超时的 Ping 测试,确保新连接确实可用
+	// 新数据库无响应时不会让 Reload 无限阻塞
+	pingCtx, cancel := context.WithTimeout(context.Background(), DefaultPingTimeout)
+	defer cancel()
+	if err := newDB.PingContext(pingCtx); err != nil {
 		// 新连接不可用,关闭它并返回错误
 		_ = newDB.Close()
 		return fmt.Errorf("new database connection ping failed: %w", err)
@@ -202,6 +264,66 @@ func New(cfg *Config) (Database, error) {
 //   - 数据验证:在保存前验证数据
 //   - 自动填充:自动设置创建时间等字段
 func NewWithHooks(cfg *Config, hooks ...Hook) (Database, error) {
+	return NewWithContext(context.Background(), cfg, hooks...)
+}
+
+// NewWithLogger 创建一个将 SQL 日志输出到应用日志的 Database 实例
+// GORM 的日志通过适配器转发到 log:
+//   - 执行出错的 SQL 以 error 级别记录
+//   - 超过 cfg.SlowThreshold 的慢查询以 warn 级别记录,包含耗时和影响行数
+//   - cfg.LogLevel 为 info 时,所有 SQL 以 debug 级别记录
+//
+// 参数:
+//
+//	cfg: 数据库配置
+//	log: 应用日志记录器,为 nil 时等价于 NewWithHooks
+//	hooks: 可选的数据库钩子
+//
+// 返回:
+//
+//	Database: 数据库接口
+//	error: 创建失败时的错误
+//
+// 使用示例:
+//
+//	cfg.SlowThreshold = 100 * time.Millisecond
+//	db, err := database.NewWithLogger(cfg, log)
+func NewWithLogger(cfg *Config, log logger.Logger, hooks ...Hook) (Database, error) {
+	return newDatabase(context.Background(), cfg, log, hooks...)
+}
+
+// NewWithContext 创建一个 Database 实例,使用 ctx 限制连接重试的总时长
+// 当 cfg.ConnectRetry 不为空时,会重复执行"打开连接 + Ping"直到成功、
+// 次数耗尽或 ctx 结束
+// 参数:
+//
+//	ctx: 上下文,取消后停止重试
+//	cfg: 数据库配置
+//	hooks: 可选的数据库钩子
+//
+// 返回:
+//
+//	Database: 数据库接口
+//	error: 创建失败时的错误
+//
+// 使用示例:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	cfg.ConnectRetry = &database.RetryConfig{MaxAttempts: 10, Delay: time.Second, Backoff: 2}
+//	db, err := database.NewWithContext(ctx, cfg)
+func NewWithContext(ctx context.Context, cfg *Config, hooks ...Hook) (Database, error) {
+	return newDatabase(ctx, cfg, nil, hooks...)
+}
+
+// newDatabase 创建 Database 实例的内部实现
+// 参数:
+//
+//	ctx: 上下文,取消后停止重试
+//	cfg: 数据库配置
+//	log: SQL 日志使用的应用日志记录器,可为 nil
+//	hooks: 可选的数据库钩子
+func newDatabase(ctx context.Context, cfg *Config, log logger.Logger, hooks ...Hook) (Database, error) {
 	var dialector gorm.Dialector
 
 	// 1. 根据数据库驱动类型选择对应的 dialector
@@ -236,10 +358,65 @@ func NewWithHooks(cfg *Config, hooks ...Hook) (Database, error) {
 	// - NamingStrategy: 命名策略(表名、列名转换)
 	// - NowFunc: 自定义时间函数
 	// - DryRun: 模拟运行,不实际执行 SQL
-	// 这里使用空配置,采用 GORM 默认值
+	// 传入 Logger 时使用适配器转发 SQL 日志,否则采用 GORM 默认值
 	gormCfg := &gorm.Config{}
+	if log != nil {
+		gormCfg.Logger = newGormLogger(log, cfg)
+	} else if cfg.LogLevel != "" {
+		gormCfg.Logger = gormlogger.Default.LogMode(parseGormLogLevel(cfg.LogLevel))
+	}
+
+	// 3. 打开数据库连接并验证
+	// 配置了 ConnectRetry 时,失败会按退避策略重试
+	var (
+		db    *gorm.DB
+		sqlDB *sql.DB
+	)
+	err := retry(ctx, cfg.ConnectRetry, func() error {
+		var err error
+		db, sqlDB, err = connect(ctx, dialector, gormCfg)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. 配置连接池
+	// 连接池参数影响性能和资源使用
+	configureConnectionPool(sqlDB, cfg)
+
+	// 5. 注册 hooks(如果提供)
+	// Hooks 允许在 GORM 操作前后执行自定义逻辑
+	// 例如:审计、日志、验证等
+	if len(hooks) > 0 {
+		if err := registerHooks(db, hooks); err != nil {
+			_ = sqlDB.Close()
+			return nil, fmt.Errorf("failed to register hooks: %w", err)
+		}
+	}
 
-	// 3. 打开数据库连接
+	// 6. 返回数据库实例
+	return &database{
+		db:    db,    // GORM 实例
+		sqlDB: sqlDB, // 标准库 sql.DB
+		log:   log,   // SQL 日志记录器
+	}, nil
+}
+
+// connect 打开一次数据库连接并执行 Ping
+// 作为重试循环中的单次尝试,失败时会关闭已打开的连接
+// 参数:
+//
+//	ctx: 上下文,用于 Ping 超时
+//	dialector: GORM 方言
+//	gormCfg: GORM 配置
+//
+// 返回:
+//
+//	*gorm.DB: GORM 实例
+//	*sql.DB: 底层 sql.DB
+//	error: 连接或 Ping 失败时的错误
+func connect(ctx context.Context, dialector gorm.Dialector, gormCfg *gorm.Config) (*gorm.DB, *sql.DB, error) {
 	// gorm.Open 会:
 	// - 建立数据库连接
 	// - 初始化 GORM 实例
@@ -251,36 +428,27 @@ func NewWithHooks(cfg *Config, hooks ...Hook) (Database, error) {
 		// - 凭证错误
 		// - 网络问题
 		// - 数据库不存在
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// 4. 获取底层的 sql.DB
 	// GORM 内部使用标准库的 sql.DB
 	// 我们需要它来配置连接池和执行 Ping
 	sqlDB, err := db.DB()
 	if err != nil {
 		// 获取失败(极少发生)
-		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		return nil, nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	// 5. 配置连接池
-	// 连接池参数影响性能和资源使用
-	configureConnectionPool(sqlDB, cfg)
-
-	// 6. 注册 hooks(如果提供)
-	// Hooks 允许在 GORM 操作前后执行自定义逻辑
-	// 例如:审计、日志、验证等
-	if len(hooks) > 0 {
-		if err := registerHooks(db, hooks); err != nil {
-			return nil, fmt.Errorf("failed to register hooks: %w", err)
-		}
+	// 验证连接确实可用
+	// gorm.Open 对部分驱动不会真正建立连接
+	pingCtx, cancel := context.WithTimeout(ctx, DefaultPingTimeout)
+	defer cancel()
+	if err := sqlDB.PingContext(pingCtx); err != nil {
+		_ = sqlDB.Close()
+		return nil, nil, fmt.Errorf("%s: %w", ErrMsgConnectionPingFailed, err)
 	}
 
-	// 7. 返回数据库实例
-	return &database{
-		db:    db,    // GORM 实例
-		sqlDB: sqlDB, // 标准库 sql.DB
-	}, nil
+	return db, sqlDB, nil
 }
 
 // buildPostgresDSN 构建 PostgreSQL 连接字符串
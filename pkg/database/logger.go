@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// gormLogger 将 GORM 日志转发到 pkg/logger 的适配器
+// 实现 gormlogger.Interface
+// 日志规则:
+// - 执行出错(记录不存在除外)的 SQL 以 error 级别记录
+// - 耗时超过 slowThreshold 的 SQL 以 warn 级别记录
+// - LogLevel 为 info 时,所有 SQL 以 debug 级别记录
+type gormLogger struct {
+	// log 应用日志记录器
+	log logger.Logger
+
+	// level GORM 日志级别
+	level gormlogger.LogLevel
+
+	// slowThreshold 慢查询阈值
+	slowThreshold time.Duration
+}
+
+// newGormLogger 创建 GORM 日志适配器
+// 参数:
+//
+//	log: 应用日志记录器
+//	cfg: 数据库配置,使用其中的 LogLevel 和 SlowThreshold
+//
+// 返回:
+//
+//	gormlogger.Interface: GORM 日志接口
+func newGormLogger(log logger.Logger, cfg *Config) gormlogger.Interface {
+	slowThreshold := cfg.SlowThreshold
+	if slowThreshold <= 0 {
+		slowThreshold = DefaultSlowThreshold
+	}
+	return &gormLogger{
+		log:           log,
+		level:         parseGormLogLevel(cfg.LogLevel),
+		slowThreshold: slowThreshold,
+	}
+}
+
+// parseGormLogLevel 将配置中的日志级别字符串转换为 GORM 日志级别
+// 未设置或无法识别时使用 DefaultLogLevel
+func parseGormLogLevel(level string) gormlogger.LogLevel {
+	switch strings.ToLower(level) {
+	case LogLevelSilent:
+		return gormlogger.Silent
+	case LogLevelError:
+		return gormlogger.Error
+	case LogLevelInfo:
+		return gormlogger.Info
+	case LogLevelWarn:
+		return gormlogger.Warn
+	default:
+		return parseGormLogLevel(DefaultLogLevel)
+	}
+}
+
+// LogMode 返回指定日志级别的新适配器
+// 实现 gormlogger.Interface
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	nl := *l
+	nl.level = level
+	return &nl
+}
+
+// Info 记录 GORM 内部 info 日志
+// 实现 gormlogger.Interface
+func (l *gormLogger) Info(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Info(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Warn 记录 GORM 内部 warn 日志
+// 实现 gormlogger.Interface
+func (l *gormLogger) Warn(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Warn(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Error 记录 GORM 内部 error 日志
+// 实现 gormlogger.Interface
+func (l *gormLogger) Error(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Error(fmt.Sprintf(msg, args...))
+	}
+}
+
+// Trace 记录 SQL 执行情况
+// 实现 gormlogger.Interface
+// 每条 SQL 执行完成后由 GORM 调用
+// 参数:
+//
+//	begin: SQL 开始执行的时间
+//	fc: 返回 SQL 语句和影响行数的函数(仅在需要记录时调用)
+//	err: 执行错误
+func (l *gormLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		l.log.Error("sql error", "sql", sql, "rows", rows, "duration", elapsed, "error", err)
+	case elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		l.log.Warn("slow sql", "sql", sql, "rows", rows, "duration", elapsed, "threshold", l.slowThreshold)
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		l.log.Debug("sql", "sql", sql, "rows", rows, "duration", elapsed)
+	}
+}
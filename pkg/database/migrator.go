@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MigrateFunc 迁移函数签名
+// 参数:
+//
+//	tx: 迁移所在事务的 GORM 实例,所有操作都应通过它执行
+//
+// 返回:
+//
+//	error: 返回非 nil 时迁移事务回滚
+type MigrateFunc func(tx *gorm.DB) error
+
+// Migration 定义一个版本化的数据库迁移
+// 迁移按切片顺序执行,ID 作为版本号记录在 schema_migrations 表中
+// 推荐使用时间戳作为 ID,例如 "20240101120000_create_users"
+type Migration struct {
+	// ID 迁移的唯一标识,不可为空,不可重复
+	ID string
+
+	// Up 应用迁移,不可为空
+	Up MigrateFunc
+
+	// Down 撤销迁移
+	// 为空表示该迁移不可回滚
+	Down MigrateFunc
+}
+
+// schemaMigration 已应用迁移的记录
+// 对应 schema_migrations 表
+type schemaMigration struct {
+	// ID 迁移 ID
+	ID string `gorm:"primaryKey;size:255"`
+
+	// AppliedAt 应用时间
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName 指定表名
+func (schemaMigration) TableName() string {
+	return MigrationTableName
+}
+
+// Migrator 数据库迁移执行器
+// 负责按顺序应用迁移、记录版本并支持回滚
+// 设计考虑:
+// - 每个迁移在独立事务中执行,迁移和版本记录要么同时成功,要么同时回滚
+// - 已应用的迁移会被跳过,重复执行 Migrate 是安全的
+// - Rollback 需要的 Down 函数来自最近一次 Migrate 传入的迁移列表
+//
+// 注意:
+//
+//	MySQL 的 DDL 会隐式提交事务,迁移失败时可能留下部分变更
+type Migrator struct {
+	// db GORM 数据库实例
+	db *gorm.DB
+
+	// migrations 已知的迁移列表,按 ID 索引
+	// 由 Migrate 填充,供 Rollback 查找 Down 函数
+	migrations map[string]Migration
+}
+
+// NewMigrator 创建迁移执行器
+// 参数:
+//
+//	db: GORM 数据库实例,通常来自 Database.DB()
+//
+// 返回:
+//
+//	*Migrator: 迁移执行器
+//	error: db 为空时返回 ErrNilDB
+//
+// 使用示例:
+//
+//	m, err := database.NewMigrator(db.DB())
+//	if err != nil {
+//	    return err
+//	}
+//	err = m.Migrate(ctx, []database.Migration{
+//	    {ID: "20240101_create_users", Up: createUsers, Down: dropUsers},
+//	})
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	if db == nil {
+		return nil, ErrNilDB
+	}
+	return &Migrator{
+		db:         db,
+		migrations: make(map[string]Migration),
+	}, nil
+}
+
+// Migrate 按顺序应用尚未执行的迁移
+// 参数:
+//
+//	ctx: 上下文,用于取消和超时控制
+//	migrations: 有序的迁移列表
+//
+// 返回:
+//
+//	error: 迁移定义无效或任一迁移失败时返回,之前已成功的迁移不会回滚
+func (m *Migrator) Migrate(ctx context.Context, migrations []Migration) error {
+	if err := validateMigrations(migrations); err != nil {
+		return err
+	}
+
+	// 记录迁移定义,供 Rollback 使用
+	for _, mg := range migrations {
+		m.migrations[mg.ID] = mg
+	}
+
+	db := m.db.WithContext(ctx)
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", MigrationTableName, err)
+	}
+
+	applied, err := m.appliedIDs(db)
+	if err != nil {
+		return err
+	}
+
+	for _, mg := range migrations {
+		if _, ok := applied[mg.ID]; ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := mg.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{ID: mg.ID, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", mg.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Rollback 回滚最近应用的 steps 个迁移
+// 按应用时间倒序回滚,每个迁移在独立事务中执行 Down 并删除版本记录
+// 参数:
+//
+//	ctx: 上下文
+//	steps: 回滚的迁移数量,小于等于 0 时不做任何操作
+//
+// 返回:
+//
+//	error: 找不到迁移定义(ErrMigrationNotFound)、迁移不可回滚(ErrMigrationIrreversible)
+//	       或 Down 执行失败时返回
+func (m *Migrator) Rollback(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		return nil
+	}
+
+	db := m.db.WithContext(ctx)
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", MigrationTableName, err)
+	}
+
+	var records []schemaMigration
+	if err := db.Order("applied_at DESC").Order("id DESC").Limit(steps).Find(&records).Error; err != nil {
+		return fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		mg, ok := m.migrations[record.ID]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrMigrationNotFound, record.ID)
+		}
+		if mg.Down == nil {
+			return fmt.Errorf("%w: %s", ErrMigrationIrreversible, record.ID)
+		}
+
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := mg.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{ID: record.ID}).Error
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rollback migration %s: %w", record.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// Applied 返回已应用的迁移 ID 集合
+// 用于查看当前数据库的迁移状态
+func (m *Migrator) Applied(ctx context.Context) (map[string]struct{}, error) {
+	db := m.db.WithContext(ctx)
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return map[string]struct{}{}, nil
+	}
+	return m.appliedIDs(db)
+}
+
+// appliedIDs 查询已应用的迁移 ID
+func (m *Migrator) appliedIDs(db *gorm.DB) (map[string]struct{}, error) {
+	var ids []string
+	if err := db.Model(&schemaMigration{}).Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("failed to load applied migrations: %w", err)
+	}
+
+	applied := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		applied[id] = struct{}{}
+	}
+	return applied, nil
+}
+
+// validateMigrations 校验迁移列表
+// ID 不可为空且不可重复,Up 不可为空
+func validateMigrations(migrations []Migration) error {
+	seen := make(map[string]struct{}, len(migrations))
+	for i, mg := range migrations {
+		if mg.ID == "" {
+			return fmt.Errorf("%w: migration #%d has empty ID", ErrInvalidMigration, i)
+		}
+		if mg.Up == nil {
+			return fmt.Errorf("%w: migration %s has nil Up", ErrInvalidMigration, mg.ID)
+		}
+		if _, ok := seen[mg.ID]; ok {
+			return fmt.Errorf("%w: duplicate migration ID %s", ErrInvalidMigration, mg.ID)
+		}
+		seen[mg.ID] = struct{}{}
+	}
+	return nil
+}
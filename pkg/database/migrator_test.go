@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// testMigrations 返回用于测试的迁移列表
+func testMigrations() []Migration {
+	return []Migration{
+		{
+			ID:   "001_create_users",
+			Up:   func(tx *gorm.DB) error { return tx.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY)").Error },
+			Down: func(tx *gorm.DB) error { return tx.Exec("DROP TABLE users").Error },
+		},
+		{
+			ID:   "002_create_posts",
+			Up:   func(tx *gorm.DB) error { return tx.Exec("CREATE TABLE posts (id INTEGER PRIMARY KEY)").Error },
+			Down: func(tx *gorm.DB) error { return tx.Exec("DROP TABLE posts").Error },
+		},
+	}
+}
+
+// TestMigrator_MigrateAndRollback 测试迁移与回滚
+func TestMigrator_MigrateAndRollback(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	m, err := NewMigrator(db.DB())
+	if err != nil {
+		t.Fatalf("failed to create migrator: %v", err)
+	}
+
+	if err := m.Migrate(ctx, testMigrations()); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	// 重复执行应跳过已应用的迁移
+	if err := m.Migrate(ctx, testMigrations()); err != nil {
+		t.Fatalf("second migrate failed: %v", err)
+	}
+
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		t.Fatalf("failed to load applied: %v", err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("expected 2 applied migrations, got %d", len(applied))
+	}
+
+	if err := m.Rollback(ctx, 1); err != nil {
+		t.Fatalf("rollback failed: %v", err)
+	}
+	if db.DB().Migrator().HasTable("posts") {
+		t.Fatal("expected posts table to be dropped")
+	}
+	if !db.DB().Migrator().HasTable("users") {
+		t.Fatal("expected users table to remain")
+	}
+}
+
+// TestMigrator_FailedMigration 测试失败的迁移不会被记录
+func TestMigrator_FailedMigration(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	m, _ := NewMigrator(db.DB())
+
+	boom := errors.New("boom")
+	err := m.Migrate(ctx, []Migration{
+		{ID: "001_fail", Up: func(tx *gorm.DB) error { return boom }},
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+
+	applied, _ := m.Applied(ctx)
+	if len(applied) != 0 {
+		t.Fatalf("expected no applied migrations, got %d", len(applied))
+	}
+}
+
+// TestMigrator_InvalidMigrations 测试无效迁移定义
+func TestMigrator_InvalidMigrations(t *testing.T) {
+	db := newTestDB(t)
+	m, _ := NewMigrator(db.DB())
+	up := func(tx *gorm.DB) error { return nil }
+
+	err := m.Migrate(context.Background(), []Migration{{ID: "a", Up: up}, {ID: "a", Up: up}})
+	if !errors.Is(err, ErrInvalidMigration) {
+		t.Fatalf("expected ErrInvalidMigration, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// retry 按重试配置执行 fn,直到成功、次数耗尽或 ctx 结束
+// 参数:
+//
+//	ctx: 上下文,取消后立即停止重试
+//	cfg: 重试配置,为空时只执行一次
+//	fn: 需要重试的操作
+//
+// 返回:
+//
+//	error: 最后一次失败的错误;ctx 结束时返回 ctx.Err()
+func retry(ctx context.Context, cfg *RetryConfig, fn func() error) error {
+	attempts := 1
+	if cfg != nil && cfg.MaxAttempts > 1 {
+		attempts = cfg.MaxAttempts
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err != nil {
+				return err
+			}
+			return ctxErr
+		}
+
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		// 最后一次失败不再等待
+		if attempt == attempts {
+			break
+		}
+
+		delay := backoffDelay(cfg, attempt)
+		if cfg.OnRetry != nil {
+			cfg.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+
+	return err
+}
+
+// backoffDelay 计算第 attempt 次失败后的等待时间
+// 公式: Delay * Backoff^(attempt-1),上限为 DefaultMaxRetryDelay 与 Delay 中的较大值
+func backoffDelay(cfg *RetryConfig, attempt int) time.Duration {
+	delay := DefaultRetryDelay
+	backoff := 1.0
+	if cfg != nil {
+		if cfg.Delay > 0 {
+			delay = cfg.Delay
+		}
+		if cfg.Backoff > 1 {
+			backoff = cfg.Backoff
+		}
+	}
+
+	// 上限不低于初始间隔,避免显式配置的长间隔被截断
+	maxDelay := DefaultMaxRetryDelay
+	if delay > maxDelay {
+		maxDelay = delay
+	}
+
+	d := float64(delay) * math.Pow(backoff, float64(attempt-1))
+	if d > float64(maxDelay) {
+		return maxDelay
+	}
+	return time.Duration(d)
+}
@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRetry_SucceedsAfterFailures 测试失败 N 次后成功
+func TestRetry_SucceedsAfterFailures(t *testing.T) {
+	const failures = 3
+	calls := 0
+	retries := 0
+
+	cfg := &RetryConfig{
+		MaxAttempts: 5,
+		Delay:       time.Millisecond,
+		Backoff:     2,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			retries++
+		},
+	}
+
+	err := retry(context.Background(), cfg, func() error {
+		calls++
+		if calls <= failures {
+			return errors.New("database not ready")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != failures+1 {
+		t.Fatalf("expected %d calls, got %d", failures+1, calls)
+	}
+	if retries != failures {
+		t.Fatalf("expected %d retries, got %d", failures, retries)
+	}
+}
+
+// TestRetry_Exhausted 测试重试次数耗尽返回最后一次错误
+func TestRetry_Exhausted(t *testing.T) {
+	notReady := errors.New("database not ready")
+	calls := 0
+
+	err := retry(context.Background(), &RetryConfig{MaxAttempts: 3, Delay: time.Millisecond}, func() error {
+		calls++
+		return notReady
+	})
+	if !errors.Is(err, notReady) {
+		t.Fatalf("expected last error, got %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+// TestRetry_ContextCancelled 测试 ctx 取消时停止重试
+func TestRetry_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	calls := 0
+	start := time.Now()
+	err := retry(ctx, &RetryConfig{MaxAttempts: 100, Delay: time.Hour}, func() error {
+		calls++
+		return errors.New("database not ready")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("retry did not stop on context cancellation")
+	}
+}
+
+// TestBackoffDelay 测试退避间隔计算
+func TestBackoffDelay(t *testing.T) {
+	cfg := &RetryConfig{Delay: 100 * time.Millisecond, Backoff: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond}
+	for i, w := range want {
+		if got := backoffDelay(cfg, i+1); got != w {
+			t.Fatalf("attempt %d: expected %v, got %v", i+1, w, got)
+		}
+	}
+
+	if got := backoffDelay(cfg, 100); got != DefaultMaxRetryDelay {
+		t.Fatalf("expected delay capped at %v, got %v", DefaultMaxRetryDelay, got)
+	}
+}
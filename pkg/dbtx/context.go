@@ -0,0 +1,68 @@
+package dbtx
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// WithTxContext 将事务实例写入 Context
+// 仓储层通过 TxFromContext（或 database.DBFromContext）取出事务，
+// 不再需要为每个写操作额外提供接收 tx 参数的版本
+//
+// 参数:
+//
+//	ctx: 父 Context
+//	tx: 事务实例，为 nil 时原样返回 ctx
+//
+// 返回:
+//
+//	context.Context: 携带事务的 Context
+//
+// 说明:
+//   - tx 来自 WithTx 时沿用其嵌套深度，在返回的 Context 上再次调用 WithTx 会走 SavePoint
+//   - 也可以用于手动 Begin 的事务，此时视为最外层事务
+//
+// 示例:
+//
+//	err := manager.WithTx(ctx, func(tx *gorm.DB) error {
+//	    txCtx := dbtx.WithTxContext(ctx, tx)
+//	    if err := userRepo.Create(txCtx, &user); err != nil {
+//	        return err
+//	    }
+//	    return profileRepo.Create(txCtx, &profile)
+//	})
+func WithTxContext(ctx context.Context, tx *gorm.DB) context.Context {
+	if tx == nil {
+		return ctx
+	}
+
+	state := &txState{tx: tx, depth: 1}
+	if tx.Statement != nil {
+		if parent := txStateFromContext(tx.Statement.Context); parent != nil {
+			state.depth = parent.depth
+		}
+	}
+	return context.WithValue(ctx, txContextKey{}, state)
+}
+
+// TxFromContext 从 Context 中获取当前事务
+// 以下 Context 中可以取到事务:
+//   - WithTxContext 返回的 Context
+//   - WithTx 传给事务函数的 tx.Statement.Context
+//
+// 参数:
+//
+//	ctx: 上下文
+//
+// 返回:
+//
+//	*gorm.DB: 事务实例，不在事务中时为 nil
+//	bool: 是否在事务中
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	state := txStateFromContext(ctx)
+	if state == nil {
+		return nil, false
+	}
+	return state.tx, true
+}
@@ -82,4 +82,15 @@ type Manager interface {
 	// 返回:
 	//   *gorm.DB: GORM 数据库实例
 	GetDB() *gorm.DB
+
+	// SavepointSupported 报告当前数据库是否支持 SavePoint
+	// 嵌套事务依赖 SavePoint 实现内层的独立回滚
+	//
+	// 返回:
+	//   bool: 方言实现了 SavePoint 且未配置 DisableNestedTransaction 时为 true
+	//
+	// 说明:
+	//   不支持时，嵌套调用 WithTx 会返回 ErrNestedUnsupported，
+	//   而不是静默复用外层事务（内层回滚会影响外层）
+	SavepointSupported() bool
 }
@@ -42,18 +42,31 @@ Package dbtx 提供优雅的数据库事务管理
 
 ## 嵌套事务
 
-GORM 使用 SavePoint 机制支持嵌套事务。当内层事务回滚时，只回滚到 SavePoint，外层事务不受影响：
+GORM 使用 SavePoint 机制支持嵌套事务。当内层事务回滚时，只回滚到 SavePoint，外层事务不受影响。
+事务状态和嵌套深度保存在事务 Context 中，内层调用需传入 tx.Statement.Context：
 
 	txManager.WithTx(ctx, func(tx *gorm.DB) error {
 	    // 外层事务：创建用户
-	    db.Create(&user)
+	    tx.Create(&user)
 
 	    // 内层事务：分配角色（使用 SavePoint）
-	    return txManager.WithTx(ctx, func(tx2 *gorm.DB) error {
-	        return db.Create(&userRole)
+	    return txManager.WithTx(tx.Statement.Context, func(tx2 *gorm.DB) error {
+	        return tx2.Create(&userRole).Error
 	    })
 	})
 
+数据库不支持 SavePoint 时（见 Manager.SavepointSupported），嵌套调用返回 ErrNestedUnsupported，
+避免内层回滚静默影响外层事务。
+
+## 通过 Context 传递事务
+
+WithTxContext 将事务挂到 Context 上，TxFromContext 取出。
+仓储方法配合 database.DBFromContext 只接收 ctx，事务内外共用同一个方法：
+
+	txManager.WithTx(ctx, func(tx *gorm.DB) error {
+	    return userRepo.Create(dbtx.WithTxContext(ctx, tx), &user)
+	})
+
 # 使用示例
 
 ## 基本用法
@@ -25,6 +25,9 @@ var (
 
 	// ErrNilTxFunc 事务函数为空
 	ErrNilTxFunc = errors.New("transaction function is nil")
+
+	// ErrNestedUnsupported 数据库不支持 SavePoint，无法执行嵌套事务
+	ErrNestedUnsupported = errors.New("nested transaction unsupported: savepoints are not available")
 )
 
 // 错误消息模板常量
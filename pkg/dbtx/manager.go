@@ -10,6 +10,35 @@ import (
 	"gorm.io/gorm"
 )
 
+// txContextKey 在 Context 中保存当前事务状态的 key
+type txContextKey struct{}
+
+// txState 当前 Context 所处的事务状态
+// 外层 WithTx 开启事务后写入事务 Context，
+// 嵌套调用通过它识别已存在的事务和嵌套深度
+type txState struct {
+	// tx 当前事务实例
+	tx *gorm.DB
+
+	// depth 嵌套深度，最外层事务为 1
+	depth int
+}
+
+// txStateFromContext 从 Context 中获取事务状态
+// 返回:
+//
+//	*txState: 不在事务中时返回 nil
+func txStateFromContext(ctx context.Context) *txState {
+	if ctx == nil {
+		return nil
+	}
+	state, _ := ctx.Value(txContextKey{}).(*txState)
+	if state == nil || state.tx == nil {
+		return nil
+	}
+	return state
+}
+
 // manager 实现 Manager 接口
 // 提供基于 GORM 的事务管理功能
 type manager struct {
@@ -94,6 +123,18 @@ func (m *manager) GetDB() *gorm.DB {
 	return m.db
 }
 
+// SavepointSupported 报告当前数据库是否支持 SavePoint
+// 需要同时满足:
+//   - 方言实现了 gorm.SavePointerDialectorInterface
+//   - gorm.Config 未设置 DisableNestedTransaction
+func (m *manager) SavepointSupported() bool {
+	if m.db.Config != nil && m.db.DisableNestedTransaction {
+		return false
+	}
+	_, ok := m.db.Dialector.(gorm.SavePointerDialectorInterface)
+	return ok
+}
+
 // WithTx 在事务中执行函数
 // 使用默认选项
 func (m *manager) WithTx(ctx context.Context, fn TxFunc) error {
@@ -117,17 +158,22 @@ func (m *manager) WithTxOptions(ctx context.Context, opts *TxOptions, fn TxFunc)
 		}
 	}
 
-	// 3. 创建带超时的 Context
-	txCtx := ctx
+	// 3. 检查是否在事务中（嵌套事务检测）
+	// ctx 来自外层事务（tx.Statement.Context）时视为嵌套调用
+	if parent := txStateFromContext(ctx); parent != nil {
+		return m.withNestedTx(ctx, parent, opts, fn)
+	}
+
+	// 4. 创建带超时的 Context
+	// 事务状态写入 Context，供嵌套调用识别
+	state := &txState{depth: 1}
+	txCtx := context.WithValue(ctx, txContextKey{}, state)
 	var cancel context.CancelFunc
 	if opts.Timeout > 0 {
-		txCtx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		txCtx, cancel = context.WithTimeout(txCtx, opts.Timeout)
 		defer cancel()
 	}
 
-	// 4. 检查是否在事务中（嵌套事务检测）
-	// GORM 会自动处理嵌套事务（使用 SavePoint）
-	// 如果禁用嵌套事务，需要检查
 	tx := m.db.WithContext(txCtx)
 
 	// 5. 配置事务选项
@@ -148,6 +194,7 @@ func (m *manager) WithTxOptions(ctx context.Context, opts *TxOptions, fn TxFunc)
 		m.logEvent(LogEventError, "failed to begin transaction", "error", tx.Error)
 		return fmt.Errorf(ErrMsgBeginFailed, tx.Error)
 	}
+	state.tx = tx
 
 	// 7. 确保事务会被提交或回滚
 	var committed bool
@@ -199,3 +246,57 @@ func (m *manager) WithTxOptions(ctx context.Context, opts *TxOptions, fn TxFunc)
 	m.logEvent(LogEventCommit, "transaction committed successfully")
 	return nil
 }
+
+// withNestedTx 在已有事务中执行嵌套事务
+// 使用 SavePoint 实现内层的独立回滚，内层失败不影响外层已执行的操作
+// 隔离级别、只读和超时沿用外层事务，opts 中只有 DisableNestedTransaction 生效
+//
+// 参数:
+//
+//	ctx: 外层事务的 Context
+//	parent: 外层事务状态
+//	opts: 事务选项
+//	fn: 事务函数
+//
+// 返回:
+//
+//	error: 禁用嵌套时返回 ErrTxAlreadyStarted，
+//	       不支持 SavePoint 时返回 ErrNestedUnsupported
+func (m *manager) withNestedTx(ctx context.Context, parent *txState, opts *TxOptions, fn TxFunc) error {
+	depth := parent.depth + 1
+
+	if opts.DisableNestedTransaction {
+		m.logEvent(LogEventNested, "nested transaction disabled", "depth", depth)
+		return ErrTxAlreadyStarted
+	}
+
+	// 不支持 SavePoint 时 GORM 会直接复用外层事务，
+	// 内层回滚会影响外层，这里显式拒绝
+	if !m.SavepointSupported() {
+		if log := m.getLogger(); log != nil {
+			log.Warn("nested transaction rejected: savepoints unsupported", "depth", depth)
+		}
+		return ErrNestedUnsupported
+	}
+
+	m.logEvent(LogEventNested, "starting nested transaction", "depth", depth)
+
+	state := &txState{depth: depth}
+	nestedCtx := context.WithValue(ctx, txContextKey{}, state)
+
+	// GORM 的 Transaction 在已有事务上执行时使用 SavePoint
+	err := parent.tx.Transaction(func(tx *gorm.DB) error {
+		state.tx = tx.WithContext(nestedCtx)
+		return fn(state.tx)
+	})
+	if err != nil {
+		m.logEvent(LogEventRollback, "nested transaction rolled back to savepoint",
+			"depth", depth,
+			"error", err,
+		)
+		return fmt.Errorf(ErrMsgTxFuncError, err)
+	}
+
+	m.logEvent(LogEventCommit, "nested transaction released", "depth", depth)
+	return nil
+}
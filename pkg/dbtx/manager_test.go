@@ -405,3 +405,141 @@ func BenchmarkWithTx(b *testing.B) {
 		})
 	}
 }
+
+// TestSavepointSupported 测试 SavePoint 能力检测
+func TestSavepointSupported(t *testing.T) {
+	db := setupTestDB(t)
+	mgr, _ := NewManager(db, nil)
+	if !mgr.SavepointSupported() {
+		t.Fatal("expected sqlite to support savepoints")
+	}
+
+	db.Config.DisableNestedTransaction = true
+	if mgr.SavepointSupported() {
+		t.Fatal("expected savepoints to be unsupported when DisableNestedTransaction is set")
+	}
+}
+
+// TestWithTx_NestedSavepointRollback 测试通过事务 Context 嵌套时内层回滚到 SavePoint
+func TestWithTx_NestedSavepointRollback(t *testing.T) {
+	db := setupTestDB(t)
+	mgr, _ := NewManager(db, nil)
+
+	err := mgr.WithTx(context.Background(), func(tx *gorm.DB) error {
+		if err := tx.Create(&TestUser{Name: "User1", Email: "user1@example.com"}).Error; err != nil {
+			return err
+		}
+
+		innerErr := mgr.WithTx(tx.Statement.Context, func(tx2 *gorm.DB) error {
+			if err := tx2.Create(&TestUser{Name: "User2", Email: "user2@example.com"}).Error; err != nil {
+				return err
+			}
+			return errors.New("inner transaction failed")
+		})
+		if innerErr == nil {
+			t.Error("expected inner error")
+		}
+
+		return mgr.WithTx(tx.Statement.Context, func(tx3 *gorm.DB) error {
+			return tx3.Create(&TestUser{Name: "User3", Email: "user3@example.com"}).Error
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	db.Model(&TestUser{}).Order("id").Pluck("name", &names)
+	if len(names) != 2 || names[0] != "User1" || names[1] != "User3" {
+		t.Fatalf("expected [User1 User3], got %v", names)
+	}
+}
+
+// TestWithTx_NestedUnsupported 测试不支持 SavePoint 时嵌套调用返回 ErrNestedUnsupported
+func TestWithTx_NestedUnsupported(t *testing.T) {
+	db := setupTestDB(t)
+	db.Config.DisableNestedTransaction = true
+	mgr, _ := NewManager(db, nil)
+
+	var innerErr error
+	err := mgr.WithTx(context.Background(), func(tx *gorm.DB) error {
+		innerErr = mgr.WithTx(tx.Statement.Context, func(tx2 *gorm.DB) error {
+			return nil
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !errors.Is(innerErr, ErrNestedUnsupported) {
+		t.Fatalf("expected ErrNestedUnsupported, got %v", innerErr)
+	}
+}
+
+// TestWithTx_NestedDisabled 测试禁用嵌套事务时返回 ErrTxAlreadyStarted
+func TestWithTx_NestedDisabled(t *testing.T) {
+	db := setupTestDB(t)
+	mgr, _ := NewManager(db, nil)
+
+	var innerErr error
+	_ = mgr.WithTx(context.Background(), func(tx *gorm.DB) error {
+		opts := DefaultOptions().WithDisableNested(true)
+		innerErr = mgr.WithTxOptions(tx.Statement.Context, opts, func(tx2 *gorm.DB) error {
+			return nil
+		})
+		return nil
+	})
+	if !errors.Is(innerErr, ErrTxAlreadyStarted) {
+		t.Fatalf("expected ErrTxAlreadyStarted, got %v", innerErr)
+	}
+}
+
+// TestWithTxContext 测试通过 Context 传递事务
+func TestWithTxContext(t *testing.T) {
+	db := setupTestDB(t)
+	mgr, _ := NewManager(db, nil)
+	ctx := context.Background()
+
+	if _, ok := TxFromContext(ctx); ok {
+		t.Fatal("expected no transaction in background context")
+	}
+	if got := WithTxContext(ctx, nil); got != ctx {
+		t.Fatal("expected nil tx to return ctx unchanged")
+	}
+
+	err := mgr.WithTx(ctx, func(tx *gorm.DB) error {
+		if got, ok := TxFromContext(tx.Statement.Context); !ok || got != tx {
+			t.Error("expected transaction from tx.Statement.Context")
+		}
+
+		txCtx := WithTxContext(ctx, tx)
+		got, ok := TxFromContext(txCtx)
+		if !ok || got != tx {
+			t.Fatal("expected transaction from WithTxContext")
+		}
+		if err := got.Create(&TestUser{Name: "User1", Email: "user1@example.com"}).Error; err != nil {
+			return err
+		}
+
+		// 在携带事务的 Context 上嵌套调用走 SavePoint
+		innerErr := mgr.WithTx(txCtx, func(tx2 *gorm.DB) error {
+			if err := tx2.Create(&TestUser{Name: "User2", Email: "user2@example.com"}).Error; err != nil {
+				return err
+			}
+			return errors.New("inner transaction failed")
+		})
+		if innerErr == nil {
+			t.Error("expected inner error")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	db.Model(&TestUser{}).Order("id").Pluck("name", &names)
+	if len(names) != 1 || names[0] != "User1" {
+		t.Fatalf("expected [User1], got %v", names)
+	}
+}
@@ -33,8 +33,9 @@ type TxOptions struct {
 	Timeout time.Duration
 
 	// DisableNestedTransaction 禁用嵌套事务
-	// 如果为 true，嵌套调用 WithTx 时会返回错误
+	// 如果为 true，嵌套调用 WithTx 时会返回 ErrTxAlreadyStarted
 	// 如果为 false（默认），使用 GORM SavePoint 机制
+	// 数据库不支持 SavePoint 时，嵌套调用返回 ErrNestedUnsupported
 	DisableNestedTransaction bool
 }
 
@@ -0,0 +1,162 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ExecuteAll 向指定池提交一组任务并等待全部完成
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//	tasks: 任务列表
+//
+// 返回:
+//
+//	error: 提交失败或任务 panic 时的错误
+func (m *manager) ExecuteAll(poolName PoolName, tasks []func()) error {
+	return m.ExecuteAllCtx(context.Background(), poolName, tasks)
+}
+
+// ExecuteAllCtx 向指定池提交一组任务并等待全部完成,支持取消
+// 实现 Manager 接口
+// 设计考虑:
+//   - 同时在执行的任务数不超过池容量,剩余任务在有任务完成后再提交
+//     这样非阻塞池不会因任务数大于池容量而返回 ErrPoolOverload
+//   - 池被其他调用方占满时,等待本批次已提交的任务完成后重试
+//   - 每个任务自行 recover,panic 汇总到返回的错误中,不会触发池的 OnPanic
+//
+// 参数:
+//
+//	ctx: 上下文,取消后不再提交剩余任务
+//	poolName: 池名称
+//	tasks: 任务列表
+//
+// 返回:
+//
+//	error: 提交失败、上下文取消或任务 panic 时的错误
+func (m *manager) ExecuteAllCtx(ctx context.Context, poolName PoolName, tasks []func()) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	limit, err := m.poolCap(poolName)
+	if err != nil {
+		return err
+	}
+
+	b := &batch{done: make(chan struct{}, len(tasks))}
+	var submitErr error
+
+	for i, task := range tasks {
+		if submitErr = b.submit(ctx, m, poolName, limit, i, task); submitErr != nil {
+			break
+		}
+	}
+
+	// 已提交的任务无法中断,等待其完成后再返回
+	b.wg.Wait()
+
+	return errors.Join(append([]error{submitErr}, b.panics...)...)
+}
+
+// batch 记录 ExecuteAllCtx 一次调用的执行状态
+type batch struct {
+	// wg 等待已提交的任务完成
+	wg sync.WaitGroup
+
+	// done 每个任务完成时发送一次,容量等于任务数,发送不会阻塞
+	done chan struct{}
+
+	// inflight 已提交但尚未确认完成的任务数
+	// 只在提交 goroutine 中读写
+	inflight int
+
+	// mu 保护 panics
+	mu sync.Mutex
+
+	// panics 任务 panic 转换成的错误
+	panics []error
+}
+
+// submit 提交单个任务
+// 同时执行的任务数达到 limit 时,等待已提交的任务完成
+// 池过载时,等待本批次的任务完成后重试
+func (b *batch) submit(ctx context.Context, m *manager, poolName PoolName, limit, index int, task func()) error {
+	for b.inflight >= limit {
+		if err := b.waitOne(ctx); err != nil {
+			return err
+		}
+	}
+
+	wrapped := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				b.mu.Lock()
+				b.panics = append(b.panics, fmt.Errorf(ErrMsgTaskPanicked, ErrTaskPanicked, index, r))
+				b.mu.Unlock()
+			}
+			b.done <- struct{}{}
+			b.wg.Done()
+		}()
+		task()
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var poolErr error
+		b.wg.Add(1)
+		err := m.submit(poolName, func(pool *poolWrapper) error {
+			poolErr = pool.Submit(wrapped)
+			return poolErr
+		})
+		if err == nil {
+			b.inflight++
+			return nil
+		}
+		b.wg.Done()
+
+		// 池被其他调用方占满,等待本批次释放 worker 后重试
+		if poolErr == ErrPoolOverload && b.inflight > 0 {
+			if err := b.waitOne(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		return err
+	}
+}
+
+// waitOne 等待一个已提交的任务完成
+func (b *batch) waitOne(ctx context.Context) error {
+	select {
+	case <-b.done:
+		b.inflight--
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// poolCap 返回指定池的容量
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	int: 池容量
+//	error: 管理器已关闭或池不存在时的错误
+func (m *manager) poolCap(poolName PoolName) (int, error) {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return 0, err
+	}
+	return max(pool.Cap(), MinPoolSize), nil
+}
@@ -13,7 +13,8 @@ const (
 	ErrMsgPoolNotFound = "pool not found: %s"
 
 	// ErrMsgPoolOverload 池过载的错误消息模板
-	ErrMsgPoolOverload = "pool overloaded: %s"
+	// 参数依次为 ErrPoolOverload、池名称,可用 errors.Is(err, ErrPoolOverload) 判断
+	ErrMsgPoolOverload = "%w: %s"
 
 	// ErrMsgInvalidConfig 无效配置的错误消息模板
 	ErrMsgInvalidConfig = "invalid config: %w"
@@ -26,6 +27,22 @@ const (
 
 	// ErrMsgShutdownTimeout 关闭超时的错误消息
 	ErrMsgShutdownTimeout = "shutdown timeout exceeded"
+
+	// ErrMsgTaskPanicked 批量任务 panic 的错误消息模板
+	// 参数依次为 ErrTaskPanicked、任务下标、panic 值
+	ErrMsgTaskPanicked = "%w: task %d: %v"
+
+	// ErrMsgRateLimited 超出提交速率的错误消息模板
+	// 参数依次为 ErrRateLimited、池名称
+	ErrMsgRateLimited = "%w: %s"
+
+	// ErrMsgPoolDraining 池正在排空的错误消息模板
+	// 参数依次为 ErrPoolDraining、池名称
+	ErrMsgPoolDraining = "%w: %s"
+
+	// ErrMsgTaskDeduplicated 重复任务的错误消息模板
+	// 参数依次为 ErrTaskDeduplicated、池名称、去重键
+	ErrMsgTaskDeduplicated = "%w: %s: %s"
 )
 
 // 预定义错误
@@ -47,6 +64,25 @@ var (
 	// ErrInvalidConfig 无效配置错误
 	// 配置验证失败时返回
 	ErrInvalidConfig = errors.New("invalid config")
+
+	// ErrTaskPanicked 任务 panic 错误
+	// ExecuteAll 中的任务 panic 时,汇总到返回的错误中
+	ErrTaskPanicked = errors.New("task panicked")
+
+	// ErrRateLimited 超出提交速率错误
+	// 池配置了 RateLimit 且 Wait=false 时,令牌不足时返回
+	// 可用 errors.Is(err, ErrRateLimited) 判断
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrPoolDraining 池正在排空错误
+	// 池被 Drain 标记后,新的提交返回该错误,已接受的任务继续执行
+	// 可用 errors.Is(err, ErrPoolDraining) 判断
+	ErrPoolDraining = errors.New("pool draining")
+
+	// ErrTaskDeduplicated 重复任务错误
+	// ExecuteDedup 提交时相同 key 的任务仍在排队或执行中,新任务被丢弃
+	// 这不是故障,调用方通常可以忽略,可用 errors.Is(err, ErrTaskDeduplicated) 判断
+	ErrTaskDeduplicated = errors.New("task deduplicated")
 )
 
 // 默认配置常量
@@ -84,4 +120,11 @@ const (
 	// 防止配置过大导致系统资源耗尽
 	// 10000 是一个安全的上限
 	MaxPoolSize = 10000
+
+	// MaxQueueSize 最大缓冲队列长度
+	// 队列过长意味着任务等待时间过长,此时应扩大池或直接拒绝
+	MaxQueueSize = 100000
+
+	// DrainPollInterval WaitDrained 检查未完成任务数的间隔
+	DrainPollInterval = 10 * time.Millisecond
 )
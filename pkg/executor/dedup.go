@@ -0,0 +1,43 @@
+package executor
+
+import "fmt"
+
+// dedupKey 去重键
+// 按池隔离,不同池中的相同 key 互不影响
+type dedupKey struct {
+	pool PoolName
+	key  string
+}
+
+// ExecuteDedup 按 key 去重后向指定池提交任务
+// 实现 Manager 接口
+// 流程:
+//  1. 在 inflight 中登记 key,已存在时返回 ErrTaskDeduplicated
+//  2. 以 key 作为任务名称提交,任务结束(包括 panic)后删除 key
+//  3. 提交失败时立即删除 key,下次提交不会被误判为重复
+//
+// 参数:
+//
+//	poolName: 池名称
+//	key: 去重键
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	error: 同 Execute,重复提交时返回包装了 ErrTaskDeduplicated 的错误
+func (m *manager) ExecuteDedup(poolName PoolName, key string, task func()) error {
+	k := dedupKey{pool: poolName, key: key}
+	if _, loaded := m.inflight.LoadOrStore(k, struct{}{}); loaded {
+		return fmt.Errorf(ErrMsgTaskDeduplicated, ErrTaskDeduplicated, poolName, key)
+	}
+
+	err := m.ExecuteNamed(poolName, key, func() {
+		// panic 由池的恢复包装处理,defer 保证 key 一定被删除
+		defer m.inflight.Delete(k)
+		task()
+	})
+	if err != nil {
+		m.inflight.Delete(k)
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PoolDiagnostics 池的诊断快照
+// 由 Manager.Diagnostics 返回,用于监控和排查卡死的任务
+type PoolDiagnostics struct {
+	// Name 池名称
+	Name PoolName
+
+	// Cap 池容量
+	Cap int
+
+	// Running 正在运行的 worker 数
+	Running int
+
+	// Free 空闲的 worker 数
+	Free int
+
+	// Queued 排队等待 worker 的任务数
+	Queued int
+
+	// Tasks 正在执行的任务数
+	Tasks int
+
+	// OldestTaskName 运行最久的任务名称,未命名任务为空
+	OldestTaskName string
+
+	// OldestTaskAge 运行最久的任务已运行的时长,没有正在执行的任务时为 0
+	OldestTaskAge time.Duration
+
+	// LongRunning 已运行超过 LongRunningThreshold 的任务数
+	// 未配置阈值时总是 0
+	LongRunning int
+
+	// LongRunningThreshold 池配置的长任务阈值
+	LongRunningThreshold time.Duration
+}
+
+// runningTask 正在执行的任务
+type runningTask struct {
+	name  string
+	start time.Time
+}
+
+// taskRegistry 正在执行任务的登记表
+// 任务开始时登记,结束时注销,用于计算运行最久的任务和长任务数
+type taskRegistry struct {
+	mu     sync.Mutex
+	nextID uint64
+	tasks  map[uint64]runningTask
+}
+
+// add 登记任务,返回用于注销的 id
+func (r *taskRegistry) add(name string, start time.Time) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tasks == nil {
+		r.tasks = make(map[uint64]runningTask)
+	}
+	r.nextID++
+	r.tasks[r.nextID] = runningTask{name: name, start: start}
+	return r.nextID
+}
+
+// remove 注销任务
+func (r *taskRegistry) remove(id uint64) {
+	r.mu.Lock()
+	delete(r.tasks, id)
+	r.mu.Unlock()
+}
+
+// fill 将正在执行任务的统计写入诊断快照
+// 参数:
+//
+//	d: 诊断快照
+//	now: 当前时间
+func (r *taskRegistry) fill(d *PoolDiagnostics, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	d.Tasks = len(r.tasks)
+	for _, t := range r.tasks {
+		age := now.Sub(t.start)
+		if age > d.OldestTaskAge {
+			d.OldestTaskAge = age
+			d.OldestTaskName = t.name
+		}
+		if d.LongRunningThreshold > 0 && age >= d.LongRunningThreshold {
+			d.LongRunning++
+		}
+	}
+}
+
+// track 包装任务,执行期间登记到任务登记表
+// 配置了 LongRunningThreshold 时启动定时器,任务超时未结束则触发一次告警
+// 参数:
+//
+//	taskName: 任务名称,可以为空
+//	task: 已包装 panic 恢复的任务
+//
+// 返回:
+//
+//	func(): 包装后的任务函数
+func (p *poolWrapper) track(taskName string, task func()) func() {
+	return func() {
+		start := time.Now()
+		id := p.tasks.add(taskName, start)
+		defer p.tasks.remove(id)
+
+		if threshold := p.config.LongRunningThreshold; threshold > 0 {
+			timer := time.AfterFunc(threshold, func() {
+				p.warnLongRunning(taskName, time.Since(start))
+			})
+			defer timer.Stop()
+		}
+
+		task()
+	}
+}
+
+// warnLongRunning 报告运行超过阈值的任务
+// 优先调用池配置的 OnLongRunning 回调,未设置时输出到标准输出
+// 与 panic 处理相同,pkg 层不依赖 logger,通过回调注入日志记录
+func (p *poolWrapper) warnLongRunning(taskName string, elapsed time.Duration) {
+	if p.config.OnLongRunning != nil {
+		p.config.OnLongRunning(p.name, taskName, elapsed)
+		return
+	}
+	fmt.Printf("[EXECUTOR WARN] pool=%s task=%s long running: elapsed=%s threshold=%s\n",
+		p.name, taskName, elapsed, p.config.LongRunningThreshold)
+}
+
+// Diagnostics 返回池的诊断快照
+func (p *poolWrapper) Diagnostics() PoolDiagnostics {
+	d := PoolDiagnostics{
+		Name:                 p.name,
+		Cap:                  p.Cap(),
+		Running:              p.Running(),
+		Free:                 p.Free(),
+		Queued:               p.QueueLen(),
+		LongRunningThreshold: p.config.LongRunningThreshold,
+	}
+	p.tasks.fill(&d, time.Now())
+	return d
+}
+
+// Diagnostics 返回指定池的诊断快照
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	PoolDiagnostics: 诊断快照,池不存在或管理器已关闭时返回零值
+func (m *manager) Diagnostics(poolName PoolName) PoolDiagnostics {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return PoolDiagnostics{}
+	}
+	return pool.Diagnostics()
+}
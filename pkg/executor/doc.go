@@ -94,6 +94,32 @@ executor 包为 rei0721 项目提供了统一的异步任务执行基础设施
 	    }
 	}
 
+## 批量任务
+
+	// 提交一组任务并等待全部完成,任务数可以大于池容量
+	err := mgr.ExecuteAll("background", tasks)
+	if errors.Is(err, executor.ErrTaskPanicked) {
+	    // 部分任务 panic
+	}
+
+## 降级路由
+
+	// 主池过载时提交到备用池,都过载时返回 ErrPoolOverload
+	err := mgr.ExecuteWithFallback("http", "background", task)
+
+	// 按顺序尝试多个池
+	err = mgr.ExecuteAny([]executor.PoolName{"http", "background", "batch"}, task)
+
+## 预热
+
+	// 创建池时预先启动 50 个 worker,避免冷启动时的首批请求承担创建开销
+	configs := []executor.Config{
+	    {Name: "http", Size: 200, PreSpawn: 50},
+	}
+
+	// 流量高峰前按需再次预热
+	err := mgr.WarmUp("http", 100)
+
 ## 错误处理
 
 	err := mgr.Execute("mypool", task)
@@ -160,6 +186,25 @@ executor 包为 rei0721 项目提供了统一的异步任务执行基础设施
   - CLI 工具: 可选 false
   - 后台任务: 推荐 true
 
+## 缓冲队列 (QueueSize)
+
+  - 仅非阻塞模式生效,池满时先排队,队列满时才返回 ErrPoolOverload
+  - 突发流量明显的 HTTP 服务: 设置为池容量的 10%-50%
+  - 通过 QueueLen 观察积压
+
+## 速率限制 (RateLimit)
+
+  - 池级别令牌桶,限制每秒提交的任务数,与 Size 的并发限制互补
+  - Wait=false 时超出速率返回 ErrRateLimited,Wait=true 时阻塞等待令牌
+  - 调用有频率限制的外部 API(邮件、短信)的池推荐配置
+  - 通过 RateLimitState 观察当前可用令牌数
+
+## 长任务检测 (LongRunningThreshold)
+
+  - 任务运行超过阈值时触发一次 OnLongRunning 回调,未设置时输出到标准输出
+  - 通过 Diagnostics 查看运行最久的任务、其已运行时长和超过阈值的任务数
+  - 推荐设置为正常任务耗时的数倍,用于发现卡死在外部调用中的任务
+
 # 最佳实践
 
 // ## 1. 定义池名称常量
@@ -208,9 +253,13 @@ executor 包为 rei0721 项目提供了统一的异步任务执行基础设施
 
 定期检查池的运行状态:
 
-	pool.Running()  // 当前运行的 worker 数
-	pool.Free()     // 当前空闲的 worker 数
-	pool.Cap()      // 池容量
+	d := mgr.Diagnostics("background")
+	d.Running        // 当前运行的 worker 数
+	d.Free           // 当前空闲的 worker 数
+	d.Cap            // 池容量
+	d.Queued         // 排队任务数
+	d.OldestTaskAge  // 运行最久的任务已运行的时长
+	d.LongRunning    // 超过 LongRunningThreshold 的任务数
 
 ## 3. 优雅关闭
 
@@ -0,0 +1,99 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Drain 将指定池标记为排空状态
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	error: 池不存在或管理器已关闭时的错误
+func (m *manager) Drain(poolName PoolName) error {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return err
+	}
+	pool.draining.Store(true)
+	return nil
+}
+
+// WaitDrained 阻塞等待指定池中已接受的任务全部完成
+// 实现 Manager 接口
+// 每隔 DrainPollInterval 检查一次未完成的任务数
+// Reload 替换后同名旧池中未完成的任务同样计入
+// 参数:
+//
+//	ctx: 上下文,取消或超时后返回 ctx.Err()
+//	poolName: 池名称
+//
+// 返回:
+//
+//	error: 池不存在、管理器已关闭或 ctx 结束时的错误
+func (m *manager) WaitDrained(ctx context.Context, poolName PoolName) error {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(DrainPollInterval)
+	defer ticker.Stop()
+
+	for pool.pendingTotal() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Resume 取消指定池的排空状态
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	error: 池不存在或管理器已关闭时的错误
+func (m *manager) Resume(poolName PoolName) error {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return err
+	}
+	pool.draining.Store(false)
+	return nil
+}
+
+// lookup 查找池
+// 所有按名称访问池的方法统一经过这里,先检查管理器是否已关闭,再在读锁下查找
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	*poolWrapper: 池包装器
+//	error: 池不存在或管理器已关闭时的错误
+func (m *manager) lookup(poolName PoolName) (*poolWrapper, error) {
+	if m.closed.Load() {
+		return nil, ErrManagerClosed
+	}
+
+	m.mu.RLock()
+	pool, exists := m.pools[poolName]
+	m.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf(ErrMsgPoolNotFound, poolName)
+	}
+	return pool, nil
+}
@@ -7,7 +7,10 @@
 // - 接口化设计,便于依赖注入和单元测试
 package executor
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // PoolName 定义池的名称类型
 // 使用类型别名提供类型安全,防止字符串拼写错误
@@ -51,6 +54,57 @@ type Config struct {
 	// - CLI 工具: 阻塞等待
 	// - 后台任务: 重试或丢弃
 	NonBlocking bool `json:"nonBlocking" yaml:"nonBlocking" mapstructure:"nonBlocking"`
+
+	// OnPanic 任务 panic 时的回调(可选)
+	// 设置后优先于全局 panic 处理器,用于记录日志或上报监控
+	// 参数:
+	//   pool: 发生 panic 的池名称
+	//   taskName: 任务名称,通过 ExecuteNamed 提交时设置,否则为空
+	//   recovered: recover() 返回的值
+	//   stack: panic 发生时的调用栈
+	// 注意: 回调在 worker goroutine 中执行,不应阻塞或再次 panic
+	OnPanic func(pool PoolName, taskName string, recovered interface{}, stack []byte) `json:"-" yaml:"-" mapstructure:"-"`
+
+	// Priority 通过 Execute/ExecuteNamed 提交的任务的默认优先级
+	// 数值越大越优先,0 为 PriorityNormal
+	// 仅在阻塞模式(NonBlocking=false)池满时生效:
+	// 排队中的任务按优先级获得 worker,同优先级按提交顺序
+	Priority int `json:"priority" yaml:"priority" mapstructure:"priority"`
+
+	// QueueSize 非阻塞模式下的有界缓冲队列长度
+	// 0 为不缓冲:池满时立即返回 ErrPoolOverload
+	// 大于 0 时任务先进入队列,由分发 goroutine 依次提交到池,
+	// 队列满时才返回 ErrPoolOverload,用于吸收突发流量
+	// 阻塞模式(NonBlocking=false)下无效果
+	QueueSize int `json:"queueSize" yaml:"queueSize" mapstructure:"queueSize"`
+
+	// RateLimit 提交速率限制(可选)
+	// nil 为不限制;设置后 Execute 等提交方法先经过令牌桶,
+	// 超出速率时按 RateLimit.Wait 返回 ErrRateLimited 或阻塞等待
+	// 限制的是提交速率,任务仍受 Size、NonBlocking、QueueSize 约束
+	RateLimit *RateLimitConfig `json:"rateLimit" yaml:"rateLimit" mapstructure:"rateLimit"`
+
+	// LongRunningThreshold 长时间运行任务的判定阈值
+	// 0 为不检测;大于 0 时任务运行超过该时长会触发一次 OnLongRunning 告警,
+	// 并计入 Diagnostics 返回的 LongRunning
+	// 用于发现卡死在外部调用或死锁中的任务
+	LongRunningThreshold time.Duration `json:"longRunningThreshold" yaml:"longRunningThreshold" mapstructure:"longRunningThreshold"`
+
+	// OnLongRunning 任务运行超过 LongRunningThreshold 时的回调(可选)
+	// 未设置时输出到标准输出,建议注入日志记录 Warn 级别告警
+	// 参数:
+	//   pool: 池名称
+	//   taskName: 任务名称,通过 ExecuteNamed 提交时设置,否则为空
+	//   elapsed: 任务已运行的时长
+	// 注意: 回调在定时器 goroutine 中执行,每个任务最多触发一次,任务本身不会被中断
+	OnLongRunning func(pool PoolName, taskName string, elapsed time.Duration) `json:"-" yaml:"-" mapstructure:"-"`
+
+	// PreSpawn 创建池时预先启动的 worker 数
+	// 0 为不预热,worker 按需创建;超过 Size 时按 Size 处理
+	// ants 按需创建 worker,启动后的第一波请求需要承担创建开销,
+	// 预热后池在真实流量到达前就已就绪,适用于冷启动延迟敏感的服务
+	// 注意: 闲置超过 Expiry 的 worker 仍会被回收,Reload 创建的新池同样会预热
+	PreSpawn int `json:"preSpawn" yaml:"preSpawn" mapstructure:"preSpawn"`
 }
 
 // Validate 验证配置有效性
@@ -78,6 +132,37 @@ func (c *Config) Validate() error {
 		c.Expiry = DefaultWorkerExpiry
 	}
 
+	// 验证队列长度
+	if c.QueueSize < 0 {
+		c.QueueSize = 0
+	}
+	if c.QueueSize > MaxQueueSize {
+		c.QueueSize = MaxQueueSize
+	}
+
+	// 验证速率限制
+	// 复制一份再补全默认值,避免修改调用方的配置
+	if c.RateLimit != nil {
+		rl := *c.RateLimit
+		if err := rl.validate(); err != nil {
+			return err
+		}
+		c.RateLimit = &rl
+	}
+
+	// 验证长任务阈值
+	if c.LongRunningThreshold < 0 {
+		c.LongRunningThreshold = 0
+	}
+
+	// 验证预热数量
+	if c.PreSpawn < 0 {
+		c.PreSpawn = 0
+	}
+	if c.PreSpawn > c.Size {
+		c.PreSpawn = c.Size
+	}
+
 	return nil
 }
 
@@ -96,17 +181,266 @@ type Manager interface {
 	//   error: 提交失败时的错误
 	// 可能的错误:
 	//   - ErrPoolNotFound: 池不存在
-	//   - ErrPoolOverload: 池已满(仅当 NonBlocking=true)
+	//   - ErrPoolOverload: 池已满且缓冲队列已满(仅当 NonBlocking=true)
+	//   - ErrRateLimited: 超出池的提交速率(仅当配置了 RateLimit 且 Wait=false)
 	//   - ErrManagerClosed: 管理器已关闭
 	// 使用示例:
 	//   err := mgr.Execute("http", func() {
 	//       // 处理 HTTP 请求
 	//   })
-	//   if err == executor.ErrPoolOverload {
+	//   if errors.Is(err, executor.ErrPoolOverload) {
 	//       // 处理过载情况
 	//   }
 	Execute(poolName PoolName, task func()) error
 
+	// ExecuteNamed 向指定名称的池提交带名称的任务
+	// 与 Execute 相同,但任务 panic 时会在日志和 OnPanic 回调中带上任务名称
+	// 便于定位是哪个逻辑任务发生了 panic
+	// 参数:
+	//   poolName: 池名称
+	//   taskName: 任务名称,例如 "send-welcome-email"
+	//   task: 要执行的任务函数
+	// 返回:
+	//   error: 同 Execute
+	// 使用示例:
+	//   err := mgr.ExecuteNamed("background", "send-welcome-email", func() {
+	//       sendEmail(user)
+	//   })
+	ExecuteNamed(poolName PoolName, taskName string, task func()) error
+
+	// ExecutePriority 按优先级向指定池提交任务
+	// 阻塞模式下池满时,高优先级任务会先于已排队的低优先级任务获得 worker
+	// 非阻塞模式下优先级无效果,池满时返回 ErrPoolOverload
+	// 参数:
+	//   poolName: 池名称
+	//   priority: 任务优先级,如 PriorityHigh、PriorityLow
+	//   task: 要执行的任务函数
+	// 返回:
+	//   error: 同 Execute
+	// 使用示例:
+	//   // 确认邮件优先于统计上报
+	//   mgr.ExecutePriority("background", executor.PriorityHigh, sendConfirmEmail)
+	//   mgr.ExecutePriority("background", executor.PriorityLow, reportAnalytics)
+	ExecutePriority(poolName PoolName, priority Priority, task func()) error
+
+	// ExecuteDedup 按 key 去重后向指定池提交任务
+	// 相同池中相同 key 的任务仍在排队或执行时,丢弃新提交并返回 ErrTaskDeduplicated
+	// 任务结束(包括 panic)后 key 被释放,之后可以再次提交
+	// 用于合并重复的后台任务,例如并发登录时多次预热同一用户的缓存
+	// 参数:
+	//   poolName: 池名称
+	//   key: 去重键,同时作为任务名称出现在 panic 诊断中
+	//   task: 要执行的任务函数
+	// 返回:
+	//   error: 同 Execute,重复提交时返回包装了 ErrTaskDeduplicated 的错误
+	// 注意:
+	//   被丢弃的是新任务,已在执行的任务不会重新执行;
+	//   任务依赖提交时的数据且需要最新数据时不适合去重
+	// 使用示例:
+	//   err := mgr.ExecuteDedup("cache", fmt.Sprintf("refresh:user:%d", id), refresh)
+	//   if err != nil && !errors.Is(err, executor.ErrTaskDeduplicated) {
+	//       log.Warn("submit failed", "error", err)
+	//   }
+	ExecuteDedup(poolName PoolName, key string, task func()) error
+
+	// ExecuteWithFallback 向主池提交任务,主池过载时改为提交到备用池
+	// 用于降级路由,例如 HTTP 池满时将溢出的任务交给后台池执行
+	// 参数:
+	//   primary: 主池名称
+	//   fallback: 备用池名称
+	//   task: 要执行的任务函数
+	// 返回:
+	//   error: 同 ExecuteAny
+	// 使用示例:
+	//   err := mgr.ExecuteWithFallback("http", "background", handle)
+	//   if errors.Is(err, executor.ErrPoolOverload) {
+	//       // 两个池都已满
+	//   }
+	ExecuteWithFallback(primary, fallback PoolName, task func()) error
+
+	// ExecuteAny 按顺序尝试向多个池提交任务,提交到第一个未过载的池
+	// 参数:
+	//   poolNames: 池名称列表,按优先顺序排列
+	//   task: 要执行的任务函数
+	// 返回:
+	//   error: 提交失败时的错误
+	// 可能的错误:
+	//   - ErrPoolOverload: 所有池都过载,错误信息包含全部池名称
+	//   - ErrPoolNotFound: 池不存在或 poolNames 为空,不再尝试后续的池
+	//   - ErrRateLimited、ErrManagerClosed: 同 Execute,不再尝试后续的池
+	// 注意:
+	//   只有过载才会尝试下一个池;阻塞模式的池不会过载,应放在列表末尾
+	// 使用示例:
+	//   err := mgr.ExecuteAny([]executor.PoolName{"http", "background", "batch"}, handle)
+	ExecuteAny(poolNames []PoolName, task func()) error
+
+	// ExecuteAfter 延迟 delay 后将任务提交到指定池
+	// 使用定时器实现,到期时才占用池中的 worker
+	// 参数:
+	//   poolName: 池名称
+	//   delay: 延迟时间,小于等于 0 时立即提交
+	//   task: 要执行的任务函数
+	// 返回:
+	//   cancel: 取消尚未触发的任务,已触发时无效果,可重复调用
+	//   error: 管理器已关闭或池不存在时返回
+	// 注意:
+	//   到期时提交失败(如池过载)任务会被丢弃
+	//   Shutdown 会取消所有尚未触发的任务
+	// 使用示例:
+	//   cancel, err := mgr.ExecuteAfter("background", 30*time.Second, retrySend)
+	ExecuteAfter(poolName PoolName, delay time.Duration, task func()) (cancel func(), err error)
+
+	// ExecuteEvery 每隔 interval 将任务提交到指定池
+	// 参数:
+	//   poolName: 池名称
+	//   interval: 执行间隔,必须大于 0
+	//   task: 要执行的任务函数
+	// 返回:
+	//   stop: 停止周期任务,可重复调用
+	//   error: 管理器已关闭、池不存在或 interval 无效时返回
+	// 注意:
+	//   某次提交失败(如池过载)只会跳过该次执行,不影响后续周期
+	//   阻塞模式池满时上一次提交仍在等待,期间到期的周期被跳过;
+	//   stop 立即生效,等待中的提交即使之后被 worker 取到也不再执行任务
+	//   Shutdown 会停止所有周期任务,之后注册返回 ErrManagerClosed
+	// 使用示例:
+	//   stop, err := mgr.ExecuteEvery("background", time.Minute, flushMetrics)
+	//   defer stop()
+	ExecuteEvery(poolName PoolName, interval time.Duration, task func()) (stop func(), err error)
+
+	// ExecuteAll 向指定池提交一组任务并阻塞等待全部完成
+	// 同时执行的任务数不超过池容量,任务数大于池容量时分批提交,不会死锁或过载
+	// 参数:
+	//   poolName: 池名称
+	//   tasks: 任务列表
+	// 返回:
+	//   error: 提交失败或任务 panic 时的错误
+	//   多个任务 panic 时用 errors.Join 汇总,可用 errors.Is(err, ErrTaskPanicked) 判断
+	// 注意:
+	//   提交失败时不再提交剩余任务,但会等待已提交的任务完成后再返回
+	//   不要在任务中对同一个池调用 ExecuteAll,可能耗尽 worker
+	// 使用示例:
+	//   tasks := make([]func(), len(items))
+	//   for i, item := range items {
+	//       tasks[i] = func() { process(item) }
+	//   }
+	//   if err := mgr.ExecuteAll("background", tasks); err != nil {
+	//       log.Error("batch failed", "error", err)
+	//   }
+	ExecuteAll(poolName PoolName, tasks []func()) error
+
+	// ExecuteAllCtx 同 ExecuteAll,支持通过 ctx 取消
+	// ctx 取消后不再提交剩余任务,等待已提交的任务完成后返回 ctx.Err()
+	// 正在执行的任务不会被中断,需要中断时由任务自行检查 ctx
+	// 参数:
+	//   ctx: 上下文
+	//   poolName: 池名称
+	//   tasks: 任务列表
+	// 返回:
+	//   error: 同 ExecuteAll,取消时包含 ctx.Err()
+	// 使用示例:
+	//   ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	//   defer cancel()
+	//   err := mgr.ExecuteAllCtx(ctx, "background", tasks)
+	ExecuteAllCtx(ctx context.Context, poolName PoolName, tasks []func()) error
+
+	// QueueLen 返回指定池中排队等待 worker 的任务数
+	// 非阻塞模式为缓冲队列(QueueSize)中的任务数,阻塞模式为优先级队列中的任务数
+	// 参数:
+	//   poolName: 池名称
+	// 返回:
+	//   int: 排队任务数,池不存在或管理器已关闭时返回 0
+	// 使用示例:
+	//   // 队列积压时提前降级
+	//   if mgr.QueueLen("http") > 50 {
+	//       return c.JSON(http.StatusServiceUnavailable, nil)
+	//   }
+	QueueLen(poolName PoolName) int
+
+	// RateLimitState 返回指定池的速率限制状态
+	// 参数:
+	//   poolName: 池名称
+	// 返回:
+	//   RateLimitState: 速率、突发容量和当前可用令牌数
+	//   bool: 池不存在、未配置 RateLimit 或管理器已关闭时返回 false
+	// 使用示例:
+	//   if state, ok := mgr.RateLimitState("email"); ok && state.Tokens < 1 {
+	//       log.Warn("email pool is being throttled")
+	//   }
+	RateLimitState(poolName PoolName) (RateLimitState, bool)
+
+	// Diagnostics 返回指定池的诊断快照,用于排查卡死任务
+	// 除 worker 和排队计数外,还包括运行最久的任务及其已运行时长,
+	// 以及超过 LongRunningThreshold 的任务数
+	// 参数:
+	//   poolName: 池名称
+	// 返回:
+	//   PoolDiagnostics: 诊断快照,池不存在或管理器已关闭时返回零值
+	// 使用示例:
+	//   d := mgr.Diagnostics("background")
+	//   if d.LongRunning > 0 {
+	//       log.Warn("stuck tasks detected", "pool", d.Name,
+	//           "oldest_task", d.OldestTaskName, "oldest_age", d.OldestTaskAge)
+	//   }
+	Diagnostics(poolName PoolName) PoolDiagnostics
+
+	// WarmUp 预先创建 worker,使池在真实流量到达前就已就绪
+	// 只创建尚未创建的 worker,不占用已有的 worker,返回时池中的 worker 数一般不少于 n
+	// (池容量不足时为容量);占位任务不经过速率限制和优先级队列
+	// 参数:
+	//   poolName: 池名称
+	//   n: 需要就绪的 worker 数
+	// 返回:
+	//   error: 池不存在或管理器已关闭时的错误
+	// 说明:
+	//   创建池时预热使用 Config.PreSpawn;WarmUp 用于按需再次预热,
+	//   例如在预期的流量高峰前调用。已有 n 个 worker 时直接返回,
+	//   不会为预热占满池导致非阻塞池拒绝任务或阻塞池等待忙碌的 worker
+	//   空闲 worker 可能被占位任务复用,此时新建的 worker 少于差额
+	// 使用示例:
+	//   if err := mgr.WarmUp("http", 50); err != nil {
+	//       log.Warn("warm up failed", "error", err)
+	//   }
+	WarmUp(poolName PoolName, n int) error
+
+	// Drain 将指定池标记为排空状态,停止接收新任务
+	// 之后向该池提交的任务返回 ErrPoolDraining,已接受的任务(包括排队中的)继续执行
+	// ExecuteWithFallback/ExecuteAny 会跳过正在排空的池
+	// 参数:
+	//   poolName: 池名称
+	// 返回:
+	//   error: 池不存在或管理器已关闭时的错误
+	// 说明:
+	//   只影响单个池,不关闭管理器;Reload 后同名的新池保留排空状态,通过 Resume 恢复
+	// 使用示例:
+	//   // 数据库切换前排空 database 池
+	//   _ = mgr.Drain("database")
+	//   ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	//   defer cancel()
+	//   if err := mgr.WaitDrained(ctx, "database"); err != nil {
+	//       log.Warn("database pool not drained", "error", err)
+	//   }
+	Drain(poolName PoolName) error
+
+	// WaitDrained 阻塞等待指定池中已接受的任务全部完成
+	// 通常在 Drain 之后调用;未调用 Drain 时新任务仍可提交,可能一直无法返回
+	// Reload 替换池后,旧池中尚未完成的任务同样会被等待
+	// 参数:
+	//   ctx: 上下文,用于控制等待时长
+	//   poolName: 池名称
+	// 返回:
+	//   error: 池不存在或管理器已关闭时的错误,ctx 结束时返回 ctx.Err()
+	WaitDrained(ctx context.Context, poolName PoolName) error
+
+	// Resume 取消指定池的排空状态,重新接收新任务
+	// 参数:
+	//   poolName: 池名称
+	// 返回:
+	//   error: 池不存在或管理器已关闭时的错误
+	// 说明:
+	//   池未处于排空状态时无操作
+	Resume(poolName PoolName) error
+
 	// Reload 使用新配置热重载所有池
 	// 这是一个原子操作,失败时保持原配置不变
 	// 参数:
@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExecuteWithFallback 向主池提交任务,主池过载或正在排空时提交到备用池
+// 实现 Manager 接口
+// 参数:
+//
+//	primary: 主池名称
+//	fallback: 备用池名称
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	error: 同 ExecuteAny
+func (m *manager) ExecuteWithFallback(primary, fallback PoolName, task func()) error {
+	return m.ExecuteAny([]PoolName{primary, fallback}, task)
+}
+
+// ExecuteAny 依次尝试向 poolNames 中的池提交任务
+// 实现 Manager 接口
+// 基于 Execute 实现:只有 ErrPoolOverload 和 ErrPoolDraining 会尝试下一个池,
+// 其他错误(池不存在、限流、管理器已关闭)立即返回
+// 参数:
+//
+//	poolNames: 池名称列表,按优先顺序排列
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	error: 提交失败时的错误,有池过载时返回包含全部池名称的 ErrPoolOverload,
+//	所有池都在排空时返回最后一个池的 ErrPoolDraining
+func (m *manager) ExecuteAny(poolNames []PoolName, task func()) error {
+	if len(poolNames) == 0 {
+		return ErrPoolNotFound
+	}
+
+	var overloaded bool
+	var drainErr error
+	for _, name := range poolNames {
+		err := m.Execute(name, task)
+		switch {
+		case errors.Is(err, ErrPoolOverload):
+			overloaded = true
+		case errors.Is(err, ErrPoolDraining):
+			drainErr = err
+		default:
+			return err
+		}
+	}
+	if !overloaded {
+		return drainErr
+	}
+
+	names := make([]string, len(poolNames))
+	for i, name := range poolNames {
+		names[i] = string(name)
+	}
+	return fmt.Errorf(ErrMsgPoolOverload, ErrPoolOverload, strings.Join(names, ", "))
+}
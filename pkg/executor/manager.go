@@ -30,6 +30,14 @@ type manager struct {
 	// closed 标记管理器是否已关闭
 	// 使用 atomic 实现无锁检查
 	closed atomic.Bool
+
+	// schedules 管理延迟任务和周期任务的停止函数
+	// Shutdown 时统一停止
+	schedules scheduleRegistry
+
+	// inflight 通过 ExecuteDedup 提交、尚未结束的任务
+	// key: dedupKey,任务结束后删除
+	inflight sync.Map
 }
 
 // NewManager 创建一个新的执行器管理器
@@ -84,7 +92,8 @@ func NewManager(configs []Config) (Manager, error) {
 	}
 
 	return &manager{
-		pools: pools,
+		pools:     pools,
+		schedules: newScheduleRegistry(),
 	}, nil
 }
 
@@ -103,28 +112,71 @@ func NewManager(configs []Config) (Manager, error) {
 //
 //	使用读锁保护,允许并发调用
 func (m *manager) Execute(poolName PoolName, task func()) error {
-	// 快速检查管理器是否已关闭
-	// 使用 atomic 无锁检查,性能更好
-	if m.closed.Load() {
-		return ErrManagerClosed
-	}
+	return m.ExecuteNamed(poolName, "", task)
+}
 
-	// 获取读锁
-	// 允许多个 goroutine 同时执行
-	m.mu.RLock()
-	pool, exists := m.pools[poolName]
-	m.mu.RUnlock()
+// ExecuteNamed 向指定池提交带名称的任务
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//	taskName: 任务名称,用于 panic 诊断
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	error: 提交失败时的错误
+func (m *manager) ExecuteNamed(poolName PoolName, taskName string, task func()) error {
+	return m.submit(poolName, func(pool *poolWrapper) error {
+		return pool.SubmitNamed(taskName, task)
+	})
+}
+
+// ExecutePriority 按优先级向指定池提交任务
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//	priority: 任务优先级
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	error: 提交失败时的错误
+func (m *manager) ExecutePriority(poolName PoolName, priority Priority, task func()) error {
+	return m.submit(poolName, func(pool *poolWrapper) error {
+		return pool.SubmitPriority("", priority, task)
+	})
+}
 
-	// 检查池是否存在
-	if !exists {
-		return fmt.Errorf(ErrMsgPoolNotFound, poolName)
+// submit 查找池并执行提交函数
+// 统一处理管理器关闭、池不存在和过载错误
+// 参数:
+//
+//	poolName: 池名称
+//	fn: 提交函数
+//
+// 返回:
+//
+//	error: 提交失败时的错误
+func (m *manager) submit(poolName PoolName, fn func(pool *poolWrapper) error) error {
+	// 检查管理器是否已关闭并查找池
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return err
 	}
 
 	// 提交任务到池
-	if err := pool.Submit(task); err != nil {
+	if err := fn(pool); err != nil {
 		// 如果是池过载错误,添加池名称信息
 		if err == ErrPoolOverload {
-			return fmt.Errorf(ErrMsgPoolOverload, poolName)
+			return fmt.Errorf(ErrMsgPoolOverload, ErrPoolOverload, poolName)
+		}
+		if err == ErrRateLimited {
+			return fmt.Errorf(ErrMsgRateLimited, ErrRateLimited, poolName)
+		}
+		if err == ErrPoolDraining {
+			return fmt.Errorf(ErrMsgPoolDraining, ErrPoolDraining, poolName)
 		}
 		return err
 	}
@@ -132,6 +184,23 @@ func (m *manager) Execute(poolName PoolName, task func()) error {
 	return nil
 }
 
+// QueueLen 返回指定池中排队等待 worker 的任务数
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	int: 排队任务数,池不存在或管理器已关闭时返回 0
+func (m *manager) QueueLen(poolName PoolName) int {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return 0
+	}
+	return pool.QueueLen()
+}
+
 // Reload 使用新配置重新加载所有池
 // 实现 Manager 接口
 // 这是一个原子操作,遵循以下步骤:
@@ -188,6 +257,17 @@ func (m *manager) Reload(configs []Config) error {
 	// 保存旧池的引用,用于后续清理
 	oldPools := m.pools
 
+	// 排空状态跟随池名称保留,避免重载让已排空的池重新接受任务
+	// 同名旧池记录到新池上,WaitDrained 同时等待旧池中未完成的任务
+	for name, pool := range newPools {
+		if old, ok := oldPools[name]; ok {
+			pool.retired.Store(old)
+			if old.draining.Load() {
+				pool.draining.Store(true)
+			}
+		}
+	}
+
 	// 3. 原子地替换池 map
 	// 从这一刻起,Execute 会使用新池
 	m.pools = newPools
@@ -202,6 +282,11 @@ func (m *manager) Reload(configs []Config) error {
 	// ReleaseTimeout 会等待任务完成或超时
 	releasePools(oldPools)
 
+	// 旧池的任务已全部完成时断开引用
+	for _, pool := range newPools {
+		pool.pendingTotal()
+	}
+
 	return nil
 }
 
@@ -221,6 +306,9 @@ func (m *manager) Shutdown() {
 	// 使用 atomic 确保线程安全
 	m.closed.Store(true)
 
+	// 停止所有尚未触发的延迟任务和周期任务
+	m.schedules.stopAll()
+
 	// 获取写锁
 	m.mu.Lock()
 	pools := m.pools
@@ -0,0 +1,865 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestManager 创建单池测试管理器
+func newTestManager(t *testing.T, cfg Config) Manager {
+	t.Helper()
+	mgr, err := NewManager([]Config{cfg})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	t.Cleanup(mgr.Shutdown)
+	return mgr
+}
+
+// TestExecuteNamed_OnPanic 测试 panic 回调包含任务名称和调用栈
+func TestExecuteNamed_OnPanic(t *testing.T) {
+	type panicInfo struct {
+		pool      PoolName
+		taskName  string
+		recovered interface{}
+		stack     []byte
+	}
+	got := make(chan panicInfo, 1)
+
+	mgr := newTestManager(t, Config{
+		Name: "test",
+		Size: 1,
+		OnPanic: func(pool PoolName, taskName string, recovered interface{}, stack []byte) {
+			got <- panicInfo{pool, taskName, recovered, stack}
+		},
+	})
+
+	if err := mgr.ExecuteNamed("test", "boom-task", func() { panic("boom") }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case info := <-got:
+		if info.pool != "test" || info.taskName != "boom-task" || info.recovered != "boom" {
+			t.Fatalf("unexpected panic info: %+v", info)
+		}
+		if len(info.stack) == 0 {
+			t.Fatal("expected stack trace")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnPanic was not called")
+	}
+
+	// panic 后池仍然可用
+	var wg sync.WaitGroup
+	wg.Add(1)
+	if err := mgr.Execute("test", wg.Done); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	wg.Wait()
+}
+
+// TestExecuteAfter 测试延迟任务执行与取消
+func TestExecuteAfter(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 2})
+
+	fired := make(chan struct{}, 1)
+	if _, err := mgr.ExecuteAfter("test", 10*time.Millisecond, func() { fired <- struct{}{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("delayed task did not run")
+	}
+
+	cancelled := make(chan struct{}, 1)
+	cancel, err := mgr.ExecuteAfter("test", 20*time.Millisecond, func() { cancelled <- struct{}{} })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cancel()
+	select {
+	case <-cancelled:
+		t.Fatal("cancelled task should not run")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestExecuteEvery 测试周期任务执行与停止
+func TestExecuteEvery(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 2})
+
+	ticks := make(chan struct{}, 10)
+	stop, err := mgr.ExecuteEvery("test", 5*time.Millisecond, func() {
+		select {
+		case ticks <- struct{}{}:
+		default:
+		}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-ticks:
+		case <-time.After(time.Second):
+			t.Fatal("periodic task did not run")
+		}
+	}
+	stop()
+	stop() // 可重复调用
+
+	if _, err := mgr.ExecuteEvery("test", 0, func() {}); err == nil {
+		t.Fatal("expected error for non-positive interval")
+	}
+	if _, err := mgr.ExecuteEvery("missing", time.Second, func() {}); err == nil {
+		t.Fatal("expected error for unknown pool")
+	}
+}
+
+// TestExecuteEvery_StopWhileSubmitPending 测试阻塞模式池满时 stop 立即生效
+func TestExecuteEvery_StopWhileSubmitPending(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 1, NonBlocking: false})
+
+	// 占用唯一的 worker,周期任务的提交会一直等待
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	if err := mgr.Execute("test", func() {
+		close(started)
+		<-gate
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+
+	var runs atomic.Int32
+	stop, err := mgr.ExecuteEvery("test", time.Millisecond, func() { runs.Add(1) })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+	stop()
+
+	// 释放 worker 后,停止前等待中的提交不再执行任务
+	close(gate)
+	time.Sleep(50 * time.Millisecond)
+	if n := runs.Load(); n != 0 {
+		t.Fatalf("periodic task ran %d times after stop", n)
+	}
+}
+
+// TestSchedule_RejectsAfterShutdown 测试管理器关闭后拒绝注册延迟任务和周期任务
+func TestSchedule_RejectsAfterShutdown(t *testing.T) {
+	m := newTestManager(t, Config{Name: "test", Size: 1}).(*manager)
+
+	// 模拟 Shutdown 与注册并发:lookup 已通过,注册表已经关闭
+	m.schedules.stopAll()
+	if _, err := m.ExecuteEvery("test", time.Millisecond, func() {}); !errors.Is(err, ErrManagerClosed) {
+		t.Errorf("ExecuteEvery() error = %v, want ErrManagerClosed", err)
+	}
+	if _, err := m.ExecuteAfter("test", time.Millisecond, func() {}); !errors.Is(err, ErrManagerClosed) {
+		t.Errorf("ExecuteAfter() error = %v, want ErrManagerClosed", err)
+	}
+
+	m.Shutdown()
+	if _, err := m.ExecuteEvery("test", time.Millisecond, func() {}); !errors.Is(err, ErrManagerClosed) {
+		t.Errorf("ExecuteEvery() after Shutdown error = %v, want ErrManagerClosed", err)
+	}
+}
+
+// TestShutdown_CancelsSchedules 测试 Shutdown 取消未触发的延迟任务
+func TestShutdown_CancelsSchedules(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "test", Size: 1}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	fired := make(chan struct{}, 1)
+	if _, err := mgr.ExecuteAfter("test", 20*time.Millisecond, func() { fired <- struct{}{} }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mgr.Shutdown()
+
+	select {
+	case <-fired:
+		t.Fatal("task should be cancelled by Shutdown")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestExecutePriority_HighJumpsAhead 测试阻塞模式池满时高优先级任务先于排队中的低优先级任务执行
+func TestExecutePriority_HighJumpsAhead(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 1, NonBlocking: false})
+	queue := mgr.(*manager).pools["test"].queue
+
+	// 占用唯一的 worker
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	if err := mgr.Execute("test", func() {
+		close(started)
+		<-gate
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+
+	var (
+		mu    sync.Mutex
+		order []string
+		wg    sync.WaitGroup
+	)
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			wg.Done()
+		}
+	}
+	submit := func(name string, priority Priority) {
+		wg.Add(1)
+		go func() {
+			if err := mgr.ExecutePriority("test", priority, record(name)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	waitQueued := func(n int) {
+		deadline := time.Now().Add(time.Second)
+		for queue.len() != n {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected %d queued tasks, got %d", n, queue.len())
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// low1 被分发协程取出后阻塞在 ants 上,其余任务在优先级队列中排队
+	submit("low1", PriorityLow)
+	time.Sleep(20 * time.Millisecond)
+	submit("low2", PriorityLow)
+	waitQueued(1)
+	submit("low3", PriorityLow)
+	waitQueued(2)
+	submit("high", PriorityHigh)
+	waitQueued(3)
+
+	close(gate)
+	wg.Wait()
+
+	expected := []string{"low1", "high", "low2", "low3"}
+	for i, name := range expected {
+		if order[i] != name {
+			t.Fatalf("expected order %v, got %v", expected, order)
+		}
+	}
+}
+
+// TestExecutePriority_ShutdownRejectsQueued 测试关闭时排队中的任务返回 ErrManagerClosed
+func TestExecutePriority_ShutdownRejectsQueued(t *testing.T) {
+	mgr, err := NewManager([]Config{{Name: "test", Size: 1, NonBlocking: false}})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	_ = mgr.Execute("test", func() {
+		close(started)
+		<-gate
+	})
+	<-started
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { errs <- mgr.ExecutePriority("test", PriorityHigh, func() {}) }()
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	go mgr.Shutdown()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errs:
+			if err != ErrManagerClosed {
+				t.Fatalf("expected ErrManagerClosed, got %v", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("queued task was not released on shutdown")
+		}
+	}
+	close(gate)
+}
+
+// TestQueueSize_BuffersUntilFull 测试非阻塞池在缓冲队列满之前接受任务
+func TestQueueSize_BuffersUntilFull(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 1, NonBlocking: true, QueueSize: 2})
+
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	if err := mgr.Execute("test", func() {
+		close(started)
+		<-gate
+	}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-started
+
+	// worker 已占满: 分发 goroutine 最多持有 1 个任务,缓冲再容纳 2 个
+	var wg sync.WaitGroup
+	accepted := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		if err := mgr.Execute("test", wg.Done); err != nil {
+			wg.Done()
+			break
+		}
+		accepted++
+		time.Sleep(5 * time.Millisecond)
+	}
+	if accepted < 2 || accepted > 3 {
+		t.Fatalf("expected 2-3 buffered tasks before overload, got %d", accepted)
+	}
+	if n := mgr.QueueLen("test"); n != 2 {
+		t.Fatalf("expected QueueLen 2, got %d", n)
+	}
+
+	close(gate)
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("buffered tasks were not executed")
+	}
+	if n := mgr.QueueLen("test"); n != 0 {
+		t.Fatalf("expected empty queue, got %d", n)
+	}
+	if n := mgr.QueueLen("missing"); n != 0 {
+		t.Fatalf("expected 0 for missing pool, got %d", n)
+	}
+}
+
+// TestExecuteAll_MoreTasksThanWorkers 测试任务数大于池容量时全部执行且汇总 panic
+func TestExecuteAll_MoreTasksThanWorkers(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 2, NonBlocking: true})
+
+	var count atomic.Int32
+	tasks := make([]func(), 20)
+	for i := range tasks {
+		tasks[i] = func() {
+			time.Sleep(time.Millisecond)
+			count.Add(1)
+		}
+	}
+	tasks[3] = func() { panic("boom") }
+	tasks[7] = func() { panic("bang") }
+
+	err := mgr.ExecuteAll("test", tasks)
+	if !errors.Is(err, ErrTaskPanicked) {
+		t.Fatalf("expected ErrTaskPanicked, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "boom") || !strings.Contains(err.Error(), "bang") {
+		t.Fatalf("expected both panics in error, got %v", err)
+	}
+	if n := count.Load(); n != 18 {
+		t.Fatalf("expected 18 completed tasks, got %d", n)
+	}
+}
+
+// TestExecuteAllCtx_Cancel 测试取消后不再提交剩余任务
+func TestExecuteAllCtx_Cancel(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 1, NonBlocking: true})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var count atomic.Int32
+	tasks := make([]func(), 10)
+	for i := range tasks {
+		tasks[i] = func() {
+			if count.Add(1) == 2 {
+				cancel()
+			}
+		}
+	}
+
+	err := mgr.ExecuteAllCtx(ctx, "test", tasks)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if n := count.Load(); n >= 10 {
+		t.Fatalf("expected remaining tasks to be skipped, got %d executed", n)
+	}
+	if err := mgr.ExecuteAll("missing", tasks); err == nil {
+		t.Fatal("expected error for missing pool")
+	}
+}
+
+// TestRateLimit_Reject 测试超出速率时返回 ErrRateLimited
+func TestRateLimit_Reject(t *testing.T) {
+	mgr := newTestManager(t, Config{
+		Name:        "email",
+		Size:        10,
+		NonBlocking: true,
+		RateLimit:   &RateLimitConfig{RPS: 1, Burst: 2},
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := mgr.Execute("email", func() {}); err != nil {
+			t.Fatalf("submit %d: unexpected error: %v", i, err)
+		}
+	}
+	err := mgr.Execute("email", func() {})
+	if !errors.Is(err, ErrRateLimited) || !strings.Contains(err.Error(), "email") {
+		t.Fatalf("expected ErrRateLimited with pool name, got %v", err)
+	}
+
+	state, ok := mgr.RateLimitState("email")
+	if !ok || state.RPS != 1 || state.Burst != 2 || state.Wait || state.Tokens >= 1 {
+		t.Fatalf("unexpected rate limit state: %+v, %v", state, ok)
+	}
+}
+
+// TestRateLimit_Wait 测试等待模式阻塞到获得令牌
+func TestRateLimit_Wait(t *testing.T) {
+	mgr := newTestManager(t, Config{
+		Name:        "sms",
+		Size:        10,
+		NonBlocking: true,
+		RateLimit:   &RateLimitConfig{RPS: 20, Burst: 1, Wait: true},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := mgr.Execute("sms", func() {}); err != nil {
+			t.Fatalf("submit %d: unexpected error: %v", i, err)
+		}
+	}
+	// 突发 1 个,其余 2 个各等待 50ms
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("expected submissions to be throttled, took %v", elapsed)
+	}
+}
+
+// TestRateLimit_Config 测试速率限制配置校验和默认值
+func TestRateLimit_Config(t *testing.T) {
+	if _, err := NewManager([]Config{{Name: "bad", RateLimit: &RateLimitConfig{RPS: 0}}}); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("expected ErrInvalidConfig, got %v", err)
+	}
+
+	rl := &RateLimitConfig{RPS: 2.5}
+	mgr := newTestManager(t, Config{Name: "api", NonBlocking: true, RateLimit: rl})
+	if state, ok := mgr.RateLimitState("api"); !ok || state.Burst != 3 {
+		t.Fatalf("expected default burst 3, got %+v", state)
+	}
+	if rl.Burst != 0 {
+		t.Fatal("Validate must not modify the caller's RateLimitConfig")
+	}
+
+	other := newTestManager(t, Config{Name: "plain", NonBlocking: true})
+	if _, ok := other.RateLimitState("plain"); ok {
+		t.Fatal("expected no rate limit state for pool without RateLimit")
+	}
+}
+
+// TestDiagnostics_LongRunning 测试诊断快照报告运行最久的任务并触发长任务告警
+func TestDiagnostics_LongRunning(t *testing.T) {
+	warned := make(chan string, 1)
+	mgr := newTestManager(t, Config{
+		Name:                 "test",
+		Size:                 2,
+		LongRunningThreshold: 20 * time.Millisecond,
+		OnLongRunning: func(pool PoolName, taskName string, elapsed time.Duration) {
+			if elapsed < 20*time.Millisecond {
+				t.Errorf("elapsed %v below threshold", elapsed)
+			}
+			warned <- taskName
+		},
+	})
+
+	release := make(chan struct{})
+	if err := mgr.ExecuteNamed("test", "stuck", func() { <-release }); err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+
+	select {
+	case name := <-warned:
+		if name != "stuck" {
+			t.Errorf("warned task = %q, want stuck", name)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("long running warning not emitted")
+	}
+
+	d := mgr.Diagnostics("test")
+	if d.Name != "test" || d.Cap != 2 || d.Tasks != 1 || d.LongRunning != 1 {
+		t.Errorf("unexpected diagnostics: %+v", d)
+	}
+	if d.OldestTaskName != "stuck" || d.OldestTaskAge < 20*time.Millisecond {
+		t.Errorf("unexpected oldest task: %q %v", d.OldestTaskName, d.OldestTaskAge)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for mgr.Diagnostics("test").Tasks != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("task still registered after completion")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if d := mgr.Diagnostics("missing"); d != (PoolDiagnostics{}) {
+		t.Errorf("missing pool diagnostics = %+v, want zero", d)
+	}
+}
+
+// TestExecuteWithFallback 测试主池过载时提交到备用池,全部过载时返回 ErrPoolOverload
+func TestExecuteWithFallback(t *testing.T) {
+	mgr, err := NewManager([]Config{
+		{Name: "http", Size: 1, NonBlocking: true},
+		{Name: "background", Size: 1, NonBlocking: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	t.Cleanup(mgr.Shutdown)
+
+	gate := make(chan struct{})
+	defer close(gate)
+	started := make(chan struct{})
+	if err := mgr.Execute("http", func() {
+		close(started)
+		<-gate
+	}); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-started
+
+	if err := mgr.Execute("http", func() {}); !errors.Is(err, ErrPoolOverload) {
+		t.Fatalf("expected ErrPoolOverload, got %v", err)
+	}
+
+	// 主池已满,任务在备用池执行并占满备用池
+	started = make(chan struct{})
+	if err := mgr.ExecuteWithFallback("http", "background", func() {
+		close(started)
+		<-gate
+	}); err != nil {
+		t.Fatalf("ExecuteWithFallback failed: %v", err)
+	}
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("task was not executed in fallback pool")
+	}
+
+	err = mgr.ExecuteAny([]PoolName{"http", "background"}, func() {})
+	if !errors.Is(err, ErrPoolOverload) || !strings.Contains(err.Error(), "http, background") {
+		t.Fatalf("expected overload for all pools, got %v", err)
+	}
+	if err := mgr.ExecuteAny([]PoolName{"http", "missing"}, func() {}); err == nil || errors.Is(err, ErrPoolOverload) {
+		t.Fatalf("expected pool not found error, got %v", err)
+	}
+	if err := mgr.ExecuteAny(nil, func() {}); !errors.Is(err, ErrPoolNotFound) {
+		t.Fatalf("expected ErrPoolNotFound for empty list, got %v", err)
+	}
+}
+
+func TestWarmUp(t *testing.T) {
+	mgr, err := NewManager([]Config{
+		{Name: "http", Size: 8, NonBlocking: true, PreSpawn: 4, Expiry: time.Minute},
+		{Name: "background", Size: 3, Expiry: time.Minute},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	t.Cleanup(mgr.Shutdown)
+
+	if d := mgr.Diagnostics("http"); d.Running != 4 {
+		t.Errorf("expected 4 pre-spawned workers, got %d", d.Running)
+	}
+	if d := mgr.Diagnostics("background"); d.Running != 0 {
+		t.Errorf("expected no workers without PreSpawn, got %d", d.Running)
+	}
+
+	// 超过容量时按容量预热
+	if err := mgr.WarmUp("background", 10); err != nil {
+		t.Fatalf("WarmUp failed: %v", err)
+	}
+	if d := mgr.Diagnostics("background"); d.Running != 3 {
+		t.Errorf("expected 3 workers after WarmUp, got %d", d.Running)
+	}
+
+	// 预热的 worker 空闲,可以立即执行任务
+	done := make(chan struct{})
+	if err := mgr.Execute("http", func() { close(done) }); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	<-done
+
+	if err := mgr.WarmUp("missing", 1); err == nil {
+		t.Error("expected error for missing pool")
+	}
+}
+
+// TestWarmUp_DoesNotOccupyWorkers 测试运行期间预热不占用已有的 worker
+func TestWarmUp_DoesNotOccupyWorkers(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 2, NonBlocking: false, PreSpawn: 2, Expiry: time.Minute})
+
+	// 一个 worker 忙碌,另一个空闲
+	gate := make(chan struct{})
+	defer close(gate)
+	started := make(chan struct{})
+	if err := mgr.Execute("test", func() {
+		close(started)
+		<-gate
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+
+	// worker 已全部创建,预热不提交占位任务,不会等待忙碌的 worker
+	warmed := make(chan error, 1)
+	go func() { warmed <- mgr.WarmUp("test", 2) }()
+	select {
+	case err := <-warmed:
+		if err != nil {
+			t.Fatalf("WarmUp failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WarmUp blocked on a busy worker")
+	}
+
+	// 空闲的 worker 仍然可以立即执行任务
+	done := make(chan struct{})
+	if err := mgr.Execute("test", func() { close(done) }); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("idle worker was not available")
+	}
+}
+
+func TestDrain(t *testing.T) {
+	mgr, err := NewManager([]Config{
+		{Name: "database", Size: 1, NonBlocking: true, QueueSize: 4},
+		{Name: "background", Size: 1, NonBlocking: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	t.Cleanup(mgr.Shutdown)
+
+	// 一个任务正在执行,一个在缓冲队列中排队
+	gate := make(chan struct{})
+	var finished atomic.Int32
+	for i := 0; i < 2; i++ {
+		if err := mgr.Execute("database", func() {
+			<-gate
+			finished.Add(1)
+		}); err != nil {
+			t.Fatalf("Execute failed: %v", err)
+		}
+	}
+
+	if err := mgr.Drain("database"); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+	if err := mgr.Execute("database", func() {}); !errors.Is(err, ErrPoolDraining) {
+		t.Fatalf("expected ErrPoolDraining, got %v", err)
+	}
+
+	// 正在排空的池由备用池接管
+	done := make(chan struct{})
+	if err := mgr.ExecuteWithFallback("database", "background", func() { close(done) }); err != nil {
+		t.Fatalf("ExecuteWithFallback failed: %v", err)
+	}
+	<-done
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := mgr.WaitDrained(ctx, "database"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected DeadlineExceeded while tasks are running, got %v", err)
+	}
+
+	close(gate)
+	if err := mgr.WaitDrained(context.Background(), "database"); err != nil {
+		t.Fatalf("WaitDrained failed: %v", err)
+	}
+	if n := finished.Load(); n != 2 {
+		t.Fatalf("expected accepted tasks to finish, got %d", n)
+	}
+
+	// 重载后保留排空状态,Resume 后恢复
+	if err := mgr.Reload([]Config{
+		{Name: "database", Size: 1, NonBlocking: true},
+		{Name: "background", Size: 1, NonBlocking: true},
+	}); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+	if err := mgr.Execute("database", func() {}); !errors.Is(err, ErrPoolDraining) {
+		t.Fatalf("expected ErrPoolDraining after Reload, got %v", err)
+	}
+	if err := mgr.Execute("background", func() {}); err != nil {
+		t.Fatalf("background pool should not be draining: %v", err)
+	}
+	if err := mgr.Resume("database"); err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if err := mgr.Execute("database", func() {}); err != nil {
+		t.Fatalf("expected Execute to succeed after Resume, got %v", err)
+	}
+
+	if err := mgr.Drain("missing"); err == nil {
+		t.Error("expected error for missing pool")
+	}
+	if err := mgr.Resume("missing"); err == nil {
+		t.Error("expected error for missing pool")
+	}
+}
+
+// TestWaitDrained_AfterReload 测试 Reload 后 WaitDrained 等待旧池中未完成的任务
+func TestWaitDrained_AfterReload(t *testing.T) {
+	mgr := newTestManager(t, Config{Name: "test", Size: 1, NonBlocking: true})
+	m := mgr.(*manager)
+
+	// 旧池中有一个执行中的任务
+	gate := make(chan struct{})
+	started := make(chan struct{})
+	if err := mgr.Execute("test", func() {
+		close(started)
+		<-gate
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	<-started
+
+	m.mu.RLock()
+	old := m.pools["test"]
+	m.mu.RUnlock()
+
+	// Reload 在旧池释放完成前阻塞,在后台执行
+	reloaded := make(chan error, 1)
+	go func() { reloaded <- mgr.Reload([]Config{{Name: "test", Size: 1, NonBlocking: true}}) }()
+	deadline := time.Now().Add(time.Second)
+	for {
+		m.mu.RLock()
+		swapped := m.pools["test"] != old
+		m.mu.RUnlock()
+		if swapped {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Reload did not swap the pool")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := mgr.Drain("test"); err != nil {
+		t.Fatalf("Drain failed: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := mgr.WaitDrained(ctx, "test"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitDrained should wait for the retired pool, got %v", err)
+	}
+
+	close(gate)
+	if err := mgr.WaitDrained(context.Background(), "test"); err != nil {
+		t.Fatalf("WaitDrained failed: %v", err)
+	}
+	if err := <-reloaded; err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	// 旧池释放且任务完成后不再被引用
+	m.mu.RLock()
+	retired := m.pools["test"].retired.Load()
+	m.mu.RUnlock()
+	if retired != nil {
+		t.Error("retired pool should be dropped after its tasks complete")
+	}
+}
+
+func TestExecuteDedup(t *testing.T) {
+	mgr, err := NewManager([]Config{
+		{Name: "cache", Size: 2, NonBlocking: true},
+		{Name: "background", Size: 1, NonBlocking: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+	t.Cleanup(mgr.Shutdown)
+
+	gate := make(chan struct{})
+	done := make(chan struct{})
+	var runs atomic.Int32
+	if err := mgr.ExecuteDedup("cache", "user:1", func() {
+		<-gate
+		runs.Add(1)
+		close(done)
+	}); err != nil {
+		t.Fatalf("ExecuteDedup failed: %v", err)
+	}
+
+	// 相同 key 在执行中:新提交被丢弃
+	if err := mgr.ExecuteDedup("cache", "user:1", func() { runs.Add(1) }); !errors.Is(err, ErrTaskDeduplicated) {
+		t.Fatalf("expected ErrTaskDeduplicated, got %v", err)
+	}
+
+	// 不同池中的相同 key 不受影响
+	other := make(chan struct{})
+	if err := mgr.ExecuteDedup("background", "user:1", func() { close(other) }); err != nil {
+		t.Fatalf("ExecuteDedup on other pool failed: %v", err)
+	}
+	<-other
+
+	close(gate)
+	<-done
+
+	// 任务结束后 key 被释放;panic 同样释放
+	panicked := make(chan struct{})
+	for i := 0; ; i++ {
+		err := mgr.ExecuteDedup("cache", "user:1", func() {
+			defer close(panicked)
+			panic("boom")
+		})
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, ErrTaskDeduplicated) || i > 100 {
+			t.Fatalf("key not released after task finished: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	<-panicked
+	for i := 0; mgr.ExecuteDedup("cache", "user:1", func() {}) != nil; i++ {
+		if i > 100 {
+			t.Fatal("key not released after task panicked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// 提交失败时立即释放 key
+	_ = mgr.Drain("background")
+	for i := 0; i < 2; i++ {
+		if err := mgr.ExecuteDedup("background", "user:2", func() {}); !errors.Is(err, ErrPoolDraining) {
+			t.Fatalf("attempt %d: expected ErrPoolDraining, got %v", i, err)
+		}
+	}
+
+	if n := runs.Load(); n != 1 {
+		t.Errorf("expected deduplicated task to run once, got %d", n)
+	}
+}
@@ -3,10 +3,13 @@ package executor
 import (
 	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/panjf2000/ants/v2"
+	"golang.org/x/time/rate"
 )
 
 // poolWrapper 封装 ants.Pool,提供额外功能
@@ -24,6 +27,45 @@ type poolWrapper struct {
 
 	// config 池配置,用于重建
 	config Config
+
+	// queue 优先级队列(仅阻塞模式)
+	// 池满时提交的任务在此排队,按优先级分发到 ants 池
+	// 非阻塞模式下为 nil
+	queue *priorityQueue
+
+	// buffer 有界缓冲队列(仅非阻塞模式且 QueueSize > 0)
+	// 任务先进入缓冲,由 drain goroutine 提交到阻塞模式的 ants 池
+	buffer chan func()
+
+	// bufferMu 保护 buffer 的关闭
+	// 提交者持读锁发送,Release 持写锁关闭,避免向已关闭的通道发送
+	bufferMu sync.RWMutex
+
+	// bufferClosed 缓冲队列是否已关闭
+	bufferClosed bool
+
+	// drained drain goroutine 退出时关闭
+	drained chan struct{}
+
+	// limiter 提交速率限制的令牌桶
+	// 未配置 RateLimit 时为 nil
+	limiter *rate.Limiter
+
+	// tasks 正在执行任务的登记表,用于 Diagnostics
+	tasks taskRegistry
+
+	// draining 是否处于排空状态
+	// 由 Manager.Drain 设置,之后的提交返回 ErrPoolDraining
+	draining atomic.Bool
+
+	// pending 已接受但尚未执行完成的任务数
+	// 包括排队中和正在执行的任务,WaitDrained 等待其归零
+	pending atomic.Int64
+
+	// retired 被 Reload 替换的同名旧池
+	// 旧池的任务可能在 Reload 后仍在执行,WaitDrained 需要一并等待
+	// 旧池释放且任务全部完成后由 pendingTotal 断开
+	retired atomic.Pointer[poolWrapper]
 }
 
 // newPoolWrapper 创建新的池包装器
@@ -41,12 +83,15 @@ func newPoolWrapper(cfg Config) (*poolWrapper, error) {
 		return nil, fmt.Errorf(ErrMsgInvalidConfig, err)
 	}
 
+	// 有缓冲队列时由 drain goroutine 阻塞提交,ants 池需为阻塞模式
+	buffered := cfg.NonBlocking && cfg.QueueSize > 0
+
 	// 配置 ants 池选项
 	options := []ants.Option{
 		// 设置 worker 过期时间
 		ants.WithExpiryDuration(cfg.Expiry),
 		// 设置非阻塞模式
-		ants.WithNonblocking(cfg.NonBlocking),
+		ants.WithNonblocking(cfg.NonBlocking && !buffered),
 		// 禁用预分配,按需创建 worker
 		ants.WithPreAlloc(false),
 	}
@@ -57,11 +102,32 @@ func newPoolWrapper(cfg Config) (*poolWrapper, error) {
 		return nil, fmt.Errorf("failed to create ants pool: %w", err)
 	}
 
-	return &poolWrapper{
-		name:   cfg.Name,
-		pool:   pool,
-		config: cfg,
-	}, nil
+	p := &poolWrapper{
+		name:    cfg.Name,
+		pool:    pool,
+		config:  cfg,
+		limiter: newRateLimiter(cfg.RateLimit),
+	}
+
+	// 阻塞模式下启动优先级分发
+	// 非阻塞模式池满时进入缓冲队列,未配置缓冲时直接返回 ErrPoolOverload
+	switch {
+	case !cfg.NonBlocking:
+		p.queue = newPriorityQueue()
+		go p.dispatch()
+	case buffered:
+		p.buffer = make(chan func(), cfg.QueueSize)
+		p.drained = make(chan struct{})
+		go p.drain()
+	}
+
+	// 预热 worker
+	if err := p.warmUp(cfg.PreSpawn); err != nil {
+		p.Release()
+		return nil, fmt.Errorf("failed to pre-spawn workers: %w", err)
+	}
+
+	return p, nil
 }
 
 // Submit 提交任务到池
@@ -74,9 +140,189 @@ func newPoolWrapper(cfg Config) (*poolWrapper, error) {
 //
 //	error: 提交失败时的错误
 func (p *poolWrapper) Submit(task func()) error {
-	// 包装任务,添加 panic 恢复
-	wrapped := wrapTaskWithRecover(p.name, task)
+	return p.SubmitNamed("", task)
+}
 
+// SubmitNamed 提交带名称的任务到池
+// 任务名称会出现在 panic 诊断信息中
+// 参数:
+//
+//	taskName: 任务名称,可以为空
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	error: 提交失败时的错误
+func (p *poolWrapper) SubmitNamed(taskName string, task func()) error {
+	return p.SubmitPriority(taskName, Priority(p.config.Priority), task)
+}
+
+// SubmitPriority 按优先级提交任务到池
+// 阻塞模式下池满时进入优先级队列,高优先级任务先获得 worker;
+// 非阻塞模式下优先级无效果,池满时直接返回 ErrPoolOverload
+// 参数:
+//
+//	taskName: 任务名称,可以为空
+//	priority: 任务优先级
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	error: 提交失败时的错误
+func (p *poolWrapper) SubmitPriority(taskName string, priority Priority, task func()) error {
+	// 先计数再检查排空状态:
+	// WaitDrained 看到计数归零之后,新的提交一定会看到 draining 并被拒绝
+	p.pending.Add(1)
+	if p.draining.Load() {
+		p.pending.Add(-1)
+		return ErrPoolDraining
+	}
+
+	// 包装任务,添加 panic 恢复和运行登记
+	tracked := p.track(taskName, wrapTaskWithRecover(p.name, taskName, p.config.OnPanic, task))
+	wrapped := func() {
+		defer p.pending.Add(-1)
+		tracked()
+	}
+
+	if err := p.submitPriority(priority, wrapped); err != nil {
+		p.pending.Add(-1)
+		return err
+	}
+	return nil
+}
+
+// pendingTotal 返回本池和被 Reload 替换的同名旧池中未完成的任务数
+// 顺带断开已释放且任务全部完成的旧池,避免多次 Reload 后旧池链一直增长
+func (p *poolWrapper) pendingTotal() int64 {
+	total := p.pending.Load()
+	prev := p
+	for old := prev.retired.Load(); old != nil; old = prev.retired.Load() {
+		n := old.pending.Load()
+		if n == 0 && old.pool.IsClosed() {
+			prev.retired.CompareAndSwap(old, old.retired.Load())
+			continue
+		}
+		total += n
+		prev = old
+	}
+	return total
+}
+
+// submitPriority 提交已包装的任务
+// 参数:
+//
+//	priority: 任务优先级
+//	wrapped: 已包装 panic 恢复和运行登记的任务
+//
+// 返回:
+//
+//	error: 提交失败时的错误,此时任务不会执行
+func (p *poolWrapper) submitPriority(priority Priority, wrapped func()) error {
+	// 速率限制先于排队,被限流的任务不占用队列
+	if err := p.acquire(); err != nil {
+		return err
+	}
+
+	if p.buffer != nil {
+		return p.enqueue(wrapped)
+	}
+	if p.queue == nil {
+		return p.submit(wrapped)
+	}
+
+	// 快速路径:没有排队任务且有空闲 worker 时直接提交
+	if p.queue.len() == 0 && p.pool.Free() > 0 {
+		return p.submit(wrapped)
+	}
+
+	// 池已满:进入优先级队列,等待分发
+	return <-p.queue.push(priority, wrapped)
+}
+
+// dispatch 优先级分发循环
+// 依次取出优先级最高的任务提交到 ants 池
+// 只有本 goroutine 阻塞在 ants.Submit 上,因此等待顺序由优先级决定
+// 队列关闭时退出
+func (p *poolWrapper) dispatch() {
+	for {
+		item := p.queue.pop()
+		if item == nil {
+			return
+		}
+		item.done <- p.submit(item.task)
+	}
+}
+
+// enqueue 将任务放入缓冲队列
+// 队列已满时立即返回 ErrPoolOverload,不阻塞调用方
+// 参数:
+//
+//	wrapped: 已包装 panic 恢复的任务
+//
+// 返回:
+//
+//	error: 队列已满或已关闭时的错误
+func (p *poolWrapper) enqueue(wrapped func()) error {
+	p.bufferMu.RLock()
+	defer p.bufferMu.RUnlock()
+	if p.bufferClosed {
+		return ErrManagerClosed
+	}
+
+	select {
+	case p.buffer <- wrapped:
+		return nil
+	default:
+		return ErrPoolOverload
+	}
+}
+
+// drain 缓冲队列分发循环
+// 依次取出缓冲中的任务,阻塞提交到 ants 池
+// 缓冲关闭后提交完剩余任务再退出,已接受的任务不会丢失
+func (p *poolWrapper) drain() {
+	defer close(p.drained)
+	for task := range p.buffer {
+		_ = p.submit(task)
+	}
+}
+
+// closeBuffer 关闭缓冲队列并等待剩余任务提交到池
+func (p *poolWrapper) closeBuffer() {
+	if p.buffer == nil {
+		return
+	}
+	p.bufferMu.Lock()
+	if !p.bufferClosed {
+		p.bufferClosed = true
+		close(p.buffer)
+	}
+	p.bufferMu.Unlock()
+	<-p.drained
+}
+
+// QueueLen 返回排队等待 worker 的任务数
+func (p *poolWrapper) QueueLen() int {
+	switch {
+	case p.buffer != nil:
+		return len(p.buffer)
+	case p.queue != nil:
+		return p.queue.len()
+	default:
+		return 0
+	}
+}
+
+// submit 提交已包装的任务到 ants 池
+// 参数:
+//
+//	wrapped: 已包装 panic 恢复的任务
+//
+// 返回:
+//
+//	error: 提交失败时的错误
+func (p *poolWrapper) submit(wrapped func()) error {
 	// 提交到 ants 池
 	if err := p.pool.Submit(wrapped); err != nil {
 		// 转换 ants 错误为项目错误
@@ -95,6 +341,10 @@ func (p *poolWrapper) Submit(task func()) error {
 // Release 释放池资源
 // 优雅关闭,等待所有任务完成
 func (p *poolWrapper) Release() {
+	if p.queue != nil {
+		p.queue.close()
+	}
+	p.closeBuffer()
 	if p.pool != nil {
 		p.pool.Release()
 	}
@@ -109,6 +359,10 @@ func (p *poolWrapper) Release() {
 //
 //	error: 超时时返回错误
 func (p *poolWrapper) ReleaseTimeout(timeout time.Duration) error {
+	if p.queue != nil {
+		// 排队中的任务不再分发,返回 ErrManagerClosed
+		p.queue.close()
+	}
 	if p.pool == nil {
 		return nil
 	}
@@ -117,6 +371,8 @@ func (p *poolWrapper) ReleaseTimeout(timeout time.Duration) error {
 	done := make(chan struct{})
 
 	go func() {
+		// 缓冲中已接受的任务先提交到池,再等待执行完成
+		p.closeBuffer()
 		p.pool.Release()
 		close(done)
 	}()
@@ -162,16 +418,30 @@ func (p *poolWrapper) Cap() int {
 // 参数:
 //
 //	poolName: 池名称,用于日志
+//	taskName: 任务名称,用于定位发生 panic 的任务,可以为空
+//	onPanic: 池级别的 panic 回调,可以为空
 //	task: 原始任务函数
 //
 // 返回:
 //
 //	func(): 包装后的任务函数
-func wrapTaskWithRecover(poolName PoolName, task func()) func() {
+//
+// panic 处理优先级:
+//  1. 池配置的 OnPanic 回调
+//  2. 全局 panic 处理器(SetPanicHandler)
+//  3. 输出到标准输出
+func wrapTaskWithRecover(poolName PoolName, taskName string, onPanic func(PoolName, string, interface{}, []byte), task func()) func() {
 	return func() {
 		// 使用 defer + recover 捕获 panic
 		defer func() {
 			if r := recover(); r != nil {
+				// 记录调用栈,便于定位问题
+				stack := debug.Stack()
+
+				if onPanic != nil {
+					onPanic(poolName, taskName, r, stack)
+					return
+				}
 				if handler := getPanicHandler(); handler != nil {
 					handler.HandlePanic(poolName, r)
 					return
@@ -181,12 +451,8 @@ func wrapTaskWithRecover(poolName PoolName, task func()) func() {
 				// 1. pkg 层不应依赖 internal 层
 				// 2. logger 可能还未初始化
 				// 3. 避免循环依赖
-				// 最佳实践是让业务层注入 logger 或使用标准库
-				fmt.Printf("[EXECUTOR PANIC] pool=%s panic=%v\n", poolName, r)
-				// 在真实场景中,可以考虑:
-				// - 通过回调函数记录到日志
-				// - 发送到监控系统
-				// - 增加 panic 计数器
+				// 最佳实践是通过 Config.OnPanic 注入日志记录
+				fmt.Printf("[EXECUTOR PANIC] pool=%s task=%s panic=%v\n%s\n", poolName, taskName, r, stack)
 			}
 		}()
 
@@ -0,0 +1,143 @@
+package executor
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// Priority 任务优先级
+// 数值越大越优先,阻塞模式下池满时高优先级任务先于低优先级任务获得 worker
+type Priority int
+
+const (
+	// PriorityLow 低优先级
+	// 适用于可延后的任务,如统计上报
+	PriorityLow Priority = -1
+
+	// PriorityNormal 普通优先级
+	// Config.Priority 未设置时的默认值
+	PriorityNormal Priority = 0
+
+	// PriorityHigh 高优先级
+	// 适用于用户可感知的任务,如确认邮件
+	PriorityHigh Priority = 1
+)
+
+// priorityItem 等待分发的任务
+type priorityItem struct {
+	// task 已包装 panic 恢复的任务
+	task func()
+
+	// priority 任务优先级
+	priority Priority
+
+	// seq 入队序号,保证同优先级任务按提交顺序执行
+	seq uint64
+
+	// done 提交结果通知,提交者在此等待
+	done chan error
+}
+
+// priorityHeap 按优先级降序、入队序号升序排列的堆
+// 实现 heap.Interface
+type priorityHeap []*priorityItem
+
+func (h priorityHeap) Len() int { return len(h) }
+
+func (h priorityHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h priorityHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *priorityHeap) Push(x interface{}) { *h = append(*h, x.(*priorityItem)) }
+
+func (h *priorityHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return item
+}
+
+// priorityQueue 阻塞模式池的优先级队列
+// 池满时提交者不直接阻塞在 ants 的 FIFO 队列上,而是进入优先级队列,
+// 由单个分发 goroutine 按优先级依次提交到 ants 池
+// 设计考虑:
+// - 只有分发 goroutine 阻塞在 ants.Submit 上,等待顺序完全由优先级决定
+// - 提交者等待 done 通道,保持阻塞模式的背压语义
+type priorityQueue struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	items  priorityHeap
+	seq    uint64
+	closed bool
+}
+
+// newPriorityQueue 创建优先级队列
+func newPriorityQueue() *priorityQueue {
+	q := &priorityQueue{}
+	q.cond = sync.NewCond(&q.mu)
+	return q
+}
+
+// push 将任务加入队列
+// 返回:
+//
+//	<-chan error: 任务被提交到池后收到提交结果
+func (q *priorityQueue) push(priority Priority, task func()) <-chan error {
+	done := make(chan error, 1)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		done <- ErrManagerClosed
+		return done
+	}
+	q.seq++
+	heap.Push(&q.items, &priorityItem{task: task, priority: priority, seq: q.seq, done: done})
+	q.cond.Signal()
+	return done
+}
+
+// pop 取出优先级最高的任务,队列为空时阻塞
+// 返回:
+//
+//	*priorityItem: 任务,队列关闭时返回 nil
+func (q *priorityQueue) pop() *priorityItem {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for len(q.items) == 0 && !q.closed {
+		q.cond.Wait()
+	}
+	if q.closed {
+		return nil
+	}
+	return heap.Pop(&q.items).(*priorityItem)
+}
+
+// len 返回排队中的任务数
+func (q *priorityQueue) len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.items)
+}
+
+// close 关闭队列,所有排队中的任务返回 ErrManagerClosed
+func (q *priorityQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	for _, item := range q.items {
+		item.done <- ErrManagerClosed
+	}
+	q.items = nil
+	q.cond.Broadcast()
+}
@@ -0,0 +1,119 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitConfig 池级别的提交速率限制
+// 基于令牌桶,与池容量配合使用:
+//   - Size 控制同时执行的任务数(并发)
+//   - RateLimit 控制每秒接受的任务数(吞吐)
+//
+// 适用于调用有频率限制的外部 API 的池,如邮件、短信服务商
+type RateLimitConfig struct {
+	// RPS 每秒允许提交的任务数,必须大于 0
+	RPS float64 `json:"rps" yaml:"rps" mapstructure:"rps"`
+
+	// Burst 令牌桶容量,即允许的瞬时突发任务数
+	// 小于等于 0 时取 RPS 向上取整(至少为 1)
+	Burst int `json:"burst" yaml:"burst" mapstructure:"burst"`
+
+	// Wait 超出速率时的处理方式
+	// false: 立即返回 ErrRateLimited,由调用方决定重试或丢弃
+	// true:  阻塞等待直到获得令牌
+	Wait bool `json:"wait" yaml:"wait" mapstructure:"wait"`
+}
+
+// validate 验证速率限制配置并补全默认值
+func (c *RateLimitConfig) validate() error {
+	if c.RPS <= 0 || math.IsInf(c.RPS, 0) || math.IsNaN(c.RPS) {
+		return fmt.Errorf("%w: rate limit rps must be positive, got %v", ErrInvalidConfig, c.RPS)
+	}
+	if c.Burst <= 0 {
+		c.Burst = max(int(math.Ceil(c.RPS)), 1)
+	}
+	return nil
+}
+
+// RateLimitState 池的速率限制状态
+// 由 Manager.RateLimitState 返回,用于监控和调试
+type RateLimitState struct {
+	// RPS 每秒允许提交的任务数
+	RPS float64
+
+	// Burst 令牌桶容量
+	Burst int
+
+	// Tokens 当前可用的令牌数
+	// 等待模式下有调用方排队时可能为负数
+	Tokens float64
+
+	// Wait 是否为等待模式
+	Wait bool
+}
+
+// newRateLimiter 根据配置创建令牌桶
+// 未配置速率限制时返回 nil
+func newRateLimiter(cfg *RateLimitConfig) *rate.Limiter {
+	if cfg == nil {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(cfg.RPS), cfg.Burst)
+}
+
+// acquire 获取一个提交令牌
+// 未配置速率限制时直接返回
+// 返回:
+//
+//	error: 非等待模式下超出速率时返回 ErrRateLimited
+func (p *poolWrapper) acquire() error {
+	if p.limiter == nil {
+		return nil
+	}
+	if p.config.RateLimit.Wait {
+		return p.limiter.Wait(context.Background())
+	}
+	if !p.limiter.Allow() {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// RateLimitState 返回池的速率限制状态
+// 返回:
+//
+//	RateLimitState: 速率限制状态
+//	bool: 池未配置速率限制时返回 false
+func (p *poolWrapper) RateLimitState() (RateLimitState, bool) {
+	if p.limiter == nil {
+		return RateLimitState{}, false
+	}
+	return RateLimitState{
+		RPS:    float64(p.limiter.Limit()),
+		Burst:  p.limiter.Burst(),
+		Tokens: p.limiter.Tokens(),
+		Wait:   p.config.RateLimit.Wait,
+	}, true
+}
+
+// RateLimitState 返回指定池的速率限制状态
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//
+// 返回:
+//
+//	RateLimitState: 速率限制状态
+//	bool: 池不存在、未配置速率限制或管理器已关闭时返回 false
+func (m *manager) RateLimitState(poolName PoolName) (RateLimitState, bool) {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return RateLimitState{}, false
+	}
+	return pool.RateLimitState()
+}
@@ -0,0 +1,183 @@
+package executor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// scheduleRegistry 记录所有延迟任务和周期任务的停止函数
+// 用于在 Shutdown 时统一取消,避免定时器在管理器关闭后继续触发
+type scheduleRegistry struct {
+	mu     sync.Mutex
+	nextID uint64
+	stops  map[uint64]func()
+
+	// closed stopAll 之后为 true,不再接受注册
+	// 与 stops 在同一把锁下检查,避免关闭后注册的任务无人停止
+	closed bool
+}
+
+// newScheduleRegistry 创建调度注册表
+func newScheduleRegistry() scheduleRegistry {
+	return scheduleRegistry{stops: make(map[uint64]func())}
+}
+
+// add 注册停止函数,返回注册 ID
+// 注册表已关闭时返回 ErrManagerClosed
+func (r *scheduleRegistry) add(stop func()) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return 0, ErrManagerClosed
+	}
+	r.nextID++
+	r.stops[r.nextID] = stop
+	return r.nextID, nil
+}
+
+// remove 移除注册的停止函数
+func (r *scheduleRegistry) remove(id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.stops, id)
+}
+
+// stopAll 调用并清空所有停止函数
+func (r *scheduleRegistry) stopAll() {
+	r.mu.Lock()
+	stops := r.stops
+	r.stops = make(map[uint64]func())
+	r.closed = true
+	r.mu.Unlock()
+
+	for _, stop := range stops {
+		stop()
+	}
+}
+
+// ExecuteAfter 延迟 delay 后将任务提交到指定池
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//	delay: 延迟时间
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	cancel: 取消函数
+//	error: 管理器已关闭或池不存在时的错误
+func (m *manager) ExecuteAfter(poolName PoolName, delay time.Duration, task func()) (func(), error) {
+	if _, err := m.lookup(poolName); err != nil {
+		return nil, err
+	}
+
+	var (
+		once  sync.Once
+		id    uint64
+		timer *time.Timer
+	)
+
+	cancel := func() {
+		once.Do(func() {
+			if timer != nil {
+				timer.Stop()
+			}
+			m.schedules.remove(id)
+		})
+	}
+
+	// 在注册表锁内分配 ID 并启动定时器
+	// 保证 Shutdown 调用 cancel 时 timer 已经赋值
+	m.schedules.mu.Lock()
+	if m.schedules.closed {
+		m.schedules.mu.Unlock()
+		return nil, ErrManagerClosed
+	}
+	m.schedules.nextID++
+	id = m.schedules.nextID
+	m.schedules.stops[id] = cancel
+	timer = time.AfterFunc(delay, func() {
+		m.schedules.remove(id)
+		// 到期提交失败时丢弃任务
+		_ = m.Execute(poolName, task)
+	})
+	m.schedules.mu.Unlock()
+
+	return cancel, nil
+}
+
+// ExecuteEvery 每隔 interval 将任务提交到指定池
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//	interval: 执行间隔
+//	task: 要执行的任务函数
+//
+// 返回:
+//
+//	stop: 停止函数
+//	error: 管理器已关闭、池不存在或 interval 无效时的错误
+func (m *manager) ExecuteEvery(poolName PoolName, interval time.Duration, task func()) (func(), error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf(ErrMsgInvalidConfig, fmt.Errorf("interval must be positive, got %s", interval))
+	}
+	if _, err := m.lookup(poolName); err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	var once sync.Once
+	var id uint64
+
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			m.schedules.remove(id)
+		})
+	}
+	var err error
+	if id, err = m.schedules.add(stop); err != nil {
+		return nil, err
+	}
+
+	// 停止后才被 worker 取到的任务不再执行
+	run := func() {
+		select {
+		case <-done:
+		default:
+			task()
+		}
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		// pending 非 nil 表示有一次提交仍在等待
+		// 阻塞模式池满时 Execute 会一直阻塞,在独立 goroutine 中提交,
+		// 保证停止时周期 goroutine 立即退出
+		var pending chan error
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// 上一次提交仍在等待时跳过本次执行,不累积提交
+				if pending != nil {
+					continue
+				}
+				ch := make(chan error, 1)
+				pending = ch
+				go func() { ch <- m.Execute(poolName, run) }()
+			case <-pending:
+				// 提交失败时跳过本次执行
+				pending = nil
+			}
+		}
+	}()
+
+	return stop, nil
+}
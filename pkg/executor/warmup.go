@@ -0,0 +1,74 @@
+package executor
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/panjf2000/ants/v2"
+)
+
+// WarmUp 预先创建 worker
+// 实现 Manager 接口
+// 参数:
+//
+//	poolName: 池名称
+//	n: 需要就绪的 worker 数,超过池容量时按容量处理
+//
+// 返回:
+//
+//	error: 池不存在或管理器已关闭时的错误
+func (m *manager) WarmUp(poolName PoolName, n int) error {
+	pool, err := m.lookup(poolName)
+	if err != nil {
+		return err
+	}
+	return pool.warmUp(n)
+}
+
+// warmUp 提交占位任务,强制 ants 补齐到 n 个 worker
+// ants 按需创建 worker,立即返回的空任务会复用同一个 worker,
+// 因此占位任务先全部阻塞,直到全部同时运行后再一起返回
+// 占位任务直接提交到 ants 池,不经过速率限制和优先级队列,也不计入 Diagnostics
+// 运行期间调用时占位任务会暂时占用 worker,因此只提交尚未创建的 worker 数(Free),
+// 已有的 worker 保持可用,非阻塞池不会因为预热拒绝真实任务,阻塞池也不会等待忙碌的 worker
+// 参数:
+//
+//	n: worker 数,超过池容量时按容量处理
+//
+// 返回:
+//
+//	error: 池已关闭时返回 ErrManagerClosed;池已满时视为已预热,返回 nil
+func (p *poolWrapper) warmUp(n int) error {
+	n = min(n-p.pool.Running(), p.pool.Free())
+	if n <= 0 {
+		return nil
+	}
+
+	var started sync.WaitGroup
+	release := make(chan struct{})
+	defer close(release)
+
+	for i := 0; i < n; i++ {
+		started.Add(1)
+		err := p.pool.Submit(func() {
+			started.Done()
+			<-release
+		})
+		if err == nil {
+			continue
+		}
+
+		started.Done()
+		if errors.Is(err, ants.ErrPoolOverload) {
+			// 非阻塞池已满,所有 worker 都已存在
+			break
+		}
+		if errors.Is(err, ants.ErrPoolClosed) {
+			return ErrManagerClosed
+		}
+		return err
+	}
+
+	started.Wait()
+	return nil
+}
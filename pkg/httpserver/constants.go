@@ -1,6 +1,10 @@
 package httpserver
 
-import "time"
+import (
+	"errors"
+	"net/http"
+	"time"
+)
 
 // 默认配置常量
 const (
@@ -21,8 +25,95 @@ const (
 	// DefaultIdleTimeout 默认空闲连接超时时间
 	// Keep-Alive 连接的最大空闲时间
 	DefaultIdleTimeout = 60 * time.Second
+
+	// DefaultNetwork 默认监听的网络类型
+	DefaultNetwork = NetworkTCP
+)
+
+// 网络类型常量
+const (
+	// NetworkTCP 监听 TCP 地址 (Host:Port)
+	NetworkTCP = "tcp"
+
+	// NetworkUnix 监听 Unix domain socket (SocketPath)
+	NetworkUnix = "unix"
+)
+
+// 中间件常量
+const (
+	// RequestIDHeader 请求关联 ID 的 HTTP 头
+	RequestIDHeader = "X-Request-ID"
+
+	// TraceIDContextKey Gin 上下文中存放追踪 ID 的键
+	// 与 internal/middleware.TraceIDKey 保持一致
+	TraceIDContextKey = "traceId"
+
+	// TimeoutMessage TimeoutMiddleware 超时响应的消息
+	TimeoutMessage = "request timeout"
+
+	// BodyTooLargeMessage MaxBodyMiddleware 拒绝请求时的消息
+	BodyTooLargeMessage = "request body too large"
+
+	// PanicMessage RecoveryMiddleware 默认响应的消息
+	// 固定文本,不包含 panic 值,避免向客户端泄露内部信息
+	PanicMessage = "internal server error"
+)
+
+// 中间件错误
+// 传给 ErrorResponder,可用 errors.Is 区分触发错误响应的原因
+var (
+	// ErrPanicRecovered 处理器 panic,由 RecoveryMiddleware 传出
+	// 错误中不包含 panic 值,panic 值和堆栈只记录到日志
+	ErrPanicRecovered = errors.New("panic recovered")
+
+	// ErrRequestTimeout 处理超时,由 TimeoutMiddleware 传出
+	ErrRequestTimeout = errors.New(TimeoutMessage)
+
+	// ErrBodyTooLarge 请求体超过限制,由 MaxBodyMiddleware 传出
+	ErrBodyTooLarge = errors.New(BodyTooLargeMessage)
+)
+
+// 指标常量
+const (
+	// DefaultMetricsPath 默认指标暴露路径
+	DefaultMetricsPath = "/metrics"
+
+	// MetricsContentType Prometheus 文本格式的 Content-Type
+	MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+	// MetricRequestsTotal 请求总数指标名
+	MetricRequestsTotal = "http_requests_total"
+
+	// MetricRequestDuration 请求延迟直方图指标名
+	MetricRequestDuration = "http_request_duration_seconds"
+
+	// MetricsUnmatchedRoute 未匹配任何路由的请求使用的 route 标签
+	// 404 扫描请求的原始路径不会进入标签,避免高基数
+	MetricsUnmatchedRoute = "unmatched"
+
+	// MetricsOtherMethod 非标准 HTTP 方法使用的 method 标签
+	MetricsOtherMethod = "OTHER"
+
+	// MetricsUnknownStatus 无效状态码使用的 status 标签
+	MetricsUnknownStatus = "unknown"
 )
 
+// DefaultMetricsBuckets 默认延迟直方图桶上界(秒)
+// 与 Prometheus 客户端库的默认值一致
+var DefaultMetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// DefaultCORSMethods CORSConfig.AllowMethods 为空时允许的请求方法
+var DefaultCORSMethods = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodHead, http.MethodOptions,
+}
+
+// DefaultCORSHeaders CORSConfig.AllowHeaders 为空时允许的请求头
+var DefaultCORSHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", RequestIDHeader}
+
+// DefaultSkipPaths 默认不记录访问日志的健康检查路径
+var DefaultSkipPaths = []string{"/health", "/healthz", "/livez", "/readyz"}
+
 // 错误消息常量
 const (
 	// ErrMsgInvalidAddress 无效的监听地址
@@ -48,4 +139,10 @@ const (
 
 	// ErrMsgReloadFailed 配置重载失败
 	ErrMsgReloadFailed = "failed to reload server config"
+
+	// ErrMsgSocketPathInUse Unix socket 路径被非 socket 文件占用
+	ErrMsgSocketPathInUse = "socket path is occupied by a non-socket file"
+
+	// ErrMsgReusePortUnsupported 当前平台不支持 SO_REUSEPORT
+	ErrMsgReusePortUnsupported = "SO_REUSEPORT is not supported on this platform"
 )
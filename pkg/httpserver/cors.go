@@ -0,0 +1,287 @@
+package httpserver
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig 跨域资源共享(CORS)中间件配置
+// 零值不可用,至少需要配置 AllowOrigins 或 AllowOriginRegex
+type CORSConfig struct {
+	// AllowOrigins 允许的来源列表,忽略大小写
+	// 支持三种写法:
+	//   - "*": 允许任意来源,不能与 AllowCredentials 同时使用
+	//   - 精确匹配: "https://app.example.com"
+	//   - 通配符: "https://*.example.com",* 匹配不含 / 的任意字符
+	AllowOrigins []string
+
+	// AllowOriginRegex 允许的来源正则表达式列表(可选)
+	// 对转为小写的完整来源匹配,例如 `^https://(app|admin)\.example\.com$`
+	AllowOriginRegex []string
+
+	// AllowMethods 允许的请求方法
+	// 为空时使用 DefaultCORSMethods
+	AllowMethods []string
+
+	// AllowHeaders 允许的请求头
+	// 为空时使用 DefaultCORSHeaders;包含 "*" 时原样回显预检请求的请求头
+	AllowHeaders []string
+
+	// ExposeHeaders 允许浏览器脚本读取的响应头,例如 X-Request-ID
+	ExposeHeaders []string
+
+	// AllowCredentials 是否允许携带 Cookie 和 Authorization 等凭证
+	// 为 true 时浏览器要求响应明确的来源,因此不能与 "*" 来源同时使用
+	AllowCredentials bool
+
+	// MaxAge 预检结果的缓存时间,按秒取整
+	// 0 时不返回 Access-Control-Max-Age,由浏览器使用默认值
+	MaxAge time.Duration
+}
+
+// Validate 验证 CORS 配置
+// 返回:
+//
+//	error: 配置无效时返回 *ConfigError
+func (c *CORSConfig) Validate() error {
+	if len(c.AllowOrigins) == 0 && len(c.AllowOriginRegex) == 0 {
+		return &ConfigError{
+			Field:   "AllowOrigins",
+			Value:   c.AllowOrigins,
+			Message: "at least one allowed origin is required",
+		}
+	}
+
+	// 凭证与任意来源同时开启会把用户的登录态暴露给任意网站
+	if c.AllowCredentials && c.allowAll() {
+		return &ConfigError{
+			Field:   "AllowCredentials",
+			Value:   c.AllowCredentials,
+			Message: "credentials cannot be allowed for wildcard origin *",
+		}
+	}
+
+	for _, expr := range c.AllowOriginRegex {
+		if _, err := regexp.Compile(expr); err != nil {
+			return &ConfigError{
+				Field:   "AllowOriginRegex",
+				Value:   expr,
+				Message: err.Error(),
+			}
+		}
+	}
+
+	if c.MaxAge < 0 {
+		return &ConfigError{
+			Field:   "MaxAge",
+			Value:   c.MaxAge,
+			Message: "max age must be non-negative",
+		}
+	}
+
+	return nil
+}
+
+// allowAll 是否配置了任意来源 "*"
+func (c *CORSConfig) allowAll() bool {
+	for _, origin := range c.AllowOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// corsPolicy 由 CORSConfig 预处理得到的匹配规则和响应头
+type corsPolicy struct {
+	allowAll      bool
+	origins       map[string]struct{}
+	patterns      []*regexp.Regexp
+	methods       map[string]struct{}
+	allowMethods  string
+	allowHeaders  string
+	reflectHeader bool
+	exposeHeaders string
+	credentials   bool
+	maxAge        string
+}
+
+// newCORSPolicy 编译 CORS 配置
+// 调用方需先通过 Validate 验证配置
+func newCORSPolicy(cfg CORSConfig) *corsPolicy {
+	p := &corsPolicy{
+		allowAll:      cfg.allowAll(),
+		origins:       make(map[string]struct{}),
+		methods:       make(map[string]struct{}),
+		exposeHeaders: strings.Join(cfg.ExposeHeaders, ", "),
+		credentials:   cfg.AllowCredentials,
+	}
+
+	for _, origin := range cfg.AllowOrigins {
+		origin = strings.ToLower(origin)
+		switch {
+		case origin == "*":
+		case strings.Contains(origin, "*"):
+			expr := strings.ReplaceAll(regexp.QuoteMeta(origin), `\*`, `[^/]*`)
+			p.patterns = append(p.patterns, regexp.MustCompile("^"+expr+"$"))
+		default:
+			p.origins[origin] = struct{}{}
+		}
+	}
+	for _, expr := range cfg.AllowOriginRegex {
+		p.patterns = append(p.patterns, regexp.MustCompile(expr))
+	}
+
+	methods := cfg.AllowMethods
+	if len(methods) == 0 {
+		methods = DefaultCORSMethods
+	}
+	upper := make([]string, len(methods))
+	for i, m := range methods {
+		upper[i] = strings.ToUpper(m)
+		p.methods[upper[i]] = struct{}{}
+	}
+	p.allowMethods = strings.Join(upper, ", ")
+
+	headers := cfg.AllowHeaders
+	if len(headers) == 0 {
+		headers = DefaultCORSHeaders
+	}
+	for _, h := range headers {
+		if h == "*" {
+			p.reflectHeader = true
+		}
+	}
+	p.allowHeaders = strings.Join(headers, ", ")
+
+	if cfg.MaxAge > 0 {
+		p.maxAge = strconv.FormatInt(int64(cfg.MaxAge/time.Second), 10)
+	}
+
+	return p
+}
+
+// allowOrigin 判断来源是否被允许
+func (p *corsPolicy) allowOrigin(origin string) bool {
+	if p.allowAll {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if _, ok := p.origins[origin]; ok {
+		return true
+	}
+	for _, re := range p.patterns {
+		if re.MatchString(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORSMiddleware 返回处理跨域请求的 Gin 中间件
+// 行为:
+//   - 没有 Origin 请求头的请求(同源或非浏览器)直接放行
+//   - 预检请求(OPTIONS + Access-Control-Request-Method)在中间件内以 204 结束,
+//     来源或方法不被允许时返回 403
+//   - 实际请求来源被允许时添加 CORS 响应头,不被允许时不添加,由浏览器拦截响应
+//   - 除 "*" 且不带凭证的情况外,Access-Control-Allow-Origin 回显请求来源并设置 Vary: Origin
+//
+// 参数:
+//
+//	cfg: CORS 配置
+//
+// 返回:
+//
+//	gin.HandlerFunc: Gin 中间件
+//
+// 注意: 配置无效时(见 CORSConfig.Validate)在创建中间件时 panic,
+// 以便在启动阶段暴露配置错误
+//
+// 使用示例:
+//
+//	router.Use(httpserver.CORSMiddleware(httpserver.CORSConfig{
+//	    AllowOrigins:     []string{"https://app.example.com", "https://*.example.com"},
+//	    ExposeHeaders:    []string{httpserver.RequestIDHeader},
+//	    AllowCredentials: true,
+//	    MaxAge:           12 * time.Hour,
+//	}))
+func CORSMiddleware(cfg CORSConfig) gin.HandlerFunc {
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
+	p := newCORSPolicy(cfg)
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		preflight := c.Request.Method == http.MethodOptions &&
+			c.GetHeader("Access-Control-Request-Method") != ""
+
+		// 响应随来源变化,告知缓存按 Origin 区分
+		if !p.allowAll || p.credentials {
+			header.Add("Vary", "Origin")
+		}
+		if preflight {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+		}
+
+		if !p.allowOrigin(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		// 预检请求的方法不被允许时直接拒绝,不返回任何 CORS 响应头
+		if preflight {
+			method := strings.ToUpper(c.GetHeader("Access-Control-Request-Method"))
+			if _, ok := p.methods[method]; !ok {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+		}
+
+		if p.allowAll && !p.credentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if p.credentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if p.exposeHeaders != "" {
+				header.Set("Access-Control-Expose-Headers", p.exposeHeaders)
+			}
+			c.Next()
+			return
+		}
+
+		header.Set("Access-Control-Allow-Methods", p.allowMethods)
+		if p.reflectHeader {
+			if requested := c.GetHeader("Access-Control-Request-Headers"); requested != "" {
+				header.Set("Access-Control-Allow-Headers", requested)
+			}
+		} else {
+			header.Set("Access-Control-Allow-Headers", p.allowHeaders)
+		}
+		if p.maxAge != "" {
+			header.Set("Access-Control-Max-Age", p.maxAge)
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,147 @@
+package httpserver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newCORSRouter 创建使用 CORS 中间件的路由
+func newCORSRouter(cfg CORSConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(CORSMiddleware(cfg))
+	r.Any("/api", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	return r
+}
+
+// corsRequest 发送带 Origin 的请求,preflightMethod 非空时为预检请求
+func corsRequest(r http.Handler, origin, preflightMethod string) *httptest.ResponseRecorder {
+	method := http.MethodGet
+	if preflightMethod != "" {
+		method = http.MethodOptions
+	}
+	req := httptest.NewRequest(method, "/api", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	if preflightMethod != "" {
+		req.Header.Set("Access-Control-Request-Method", preflightMethod)
+		req.Header.Set("Access-Control-Request-Headers", "X-Custom")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// TestCORSMiddleware_Preflight 测试预检请求的放行和拒绝
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	r := newCORSRouter(CORSConfig{
+		AllowOrigins:     []string{"https://app.example.com", "https://*.example.org"},
+		AllowMethods:     []string{"GET", "POST"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	})
+
+	tests := []struct {
+		name   string
+		origin string
+		method string
+		want   int
+	}{
+		{"exact origin", "https://app.example.com", "POST", http.StatusNoContent},
+		{"origin case-insensitive", "https://APP.example.com", "GET", http.StatusNoContent},
+		{"wildcard origin", "https://api.example.org", "GET", http.StatusNoContent},
+		{"wildcard does not cross path", "https://evil.com/.example.org", "GET", http.StatusForbidden},
+		{"unknown origin", "https://evil.com", "GET", http.StatusForbidden},
+		{"method not allowed", "https://app.example.com", "DELETE", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := corsRequest(r, tt.origin, tt.method)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d", w.Code, tt.want)
+			}
+			if w.Body.Len() != 0 {
+				t.Errorf("preflight should not reach handler, body = %q", w.Body.String())
+			}
+			if tt.want != http.StatusNoContent {
+				if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+					t.Errorf("rejected preflight has Allow-Origin %q", got)
+				}
+				return
+			}
+			h := w.Header()
+			if h.Get("Access-Control-Allow-Origin") != tt.origin || h.Get("Access-Control-Allow-Credentials") != "true" {
+				t.Errorf("headers = %v", h)
+			}
+			if h.Get("Access-Control-Allow-Methods") != "GET, POST" || h.Get("Access-Control-Max-Age") != "3600" {
+				t.Errorf("headers = %v", h)
+			}
+		})
+	}
+}
+
+// TestCORSMiddleware_Simple 测试实际请求的响应头
+func TestCORSMiddleware_Simple(t *testing.T) {
+	r := newCORSRouter(CORSConfig{
+		AllowOrigins:  []string{"*"},
+		ExposeHeaders: []string{RequestIDHeader},
+	})
+
+	w := corsRequest(r, "https://any.example.com", "")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("status = %d, headers = %v", w.Code, w.Header())
+	}
+	if w.Header().Get("Access-Control-Expose-Headers") != RequestIDHeader {
+		t.Errorf("headers = %v", w.Header())
+	}
+
+	// 没有 Origin 的请求不添加 CORS 响应头
+	w = corsRequest(r, "", "")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("status = %d, headers = %v", w.Code, w.Header())
+	}
+
+	// 不被允许的来源照常处理,但不返回 CORS 响应头,由浏览器拦截
+	r = newCORSRouter(CORSConfig{AllowOrigins: []string{"https://app.example.com"}})
+	w = corsRequest(r, "https://evil.com", "")
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("status = %d, headers = %v", w.Code, w.Header())
+	}
+	if w.Header().Get("Vary") != "Origin" {
+		t.Errorf("Vary = %q, want Origin", w.Header().Get("Vary"))
+	}
+}
+
+// TestCORSConfig_Validate 测试凭证与任意来源同时开启被拒绝
+func TestCORSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   CORSConfig
+		field string
+	}{
+		{"no origin", CORSConfig{}, "AllowOrigins"},
+		{"credentials with *", CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true}, "AllowCredentials"},
+		{"bad regex", CORSConfig{AllowOriginRegex: []string{"("}}, "AllowOriginRegex"},
+		{"negative max age", CORSConfig{AllowOrigins: []string{"*"}, MaxAge: -time.Second}, "MaxAge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfgErr *ConfigError
+			if err := tt.cfg.Validate(); !errors.As(err, &cfgErr) || cfgErr.Field != tt.field {
+				t.Errorf("Validate() = %v, want ConfigError on %s", err, tt.field)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("CORSMiddleware should panic on credentials with *")
+		}
+	}()
+	CORSMiddleware(CORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true})
+}
@@ -23,6 +23,8 @@
 //   - ReadTimeout: 读取超时
 //   - WriteTimeout: 写入超时
 //   - IdleTimeout: 空闲连接超时
+//   - SlowRequestThreshold: 慢请求阈值,超过时输出 Warn 日志
+//   - ReusePort: 以 SO_REUSEPORT 监听,用于零停机升级
 //
 // # 使用示例
 //
@@ -41,15 +43,19 @@
 //	    log.Fatal(err)
 //	}
 //
-// 创建 HTTP Server 实例（带 Executor 支持）:
+// 自定义底层 http.Server（Config 未覆盖的字段）:
 //
-//	// 使用 WithExecutor 选项注入协程池管理器
+//	// 回调在 Start 和每次 Reload 创建服务器后调用
 //	server, err := httpserver.New(router, config, logger,
-//	    httpserver.WithExecutor(executorManager))
+//	    httpserver.WithServerOptions(func(srv *http.Server) {
+//	        srv.MaxHeaderBytes = 64 << 10
+//	    }))
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
 //
+// 协程池管理器通过 SetExecutor 延迟注入。
+//
 // 启动服务器:
 //
 //	// 非阻塞启动
@@ -80,6 +86,43 @@
 //	    log.Error("reload error", "error", err)
 //	}
 //
+// Unix domain socket:
+//
+//	config := &httpserver.Config{
+//	    Network:    httpserver.NetworkUnix,
+//	    SocketPath: "/run/app/http.sock", // 启动时删除残留 socket,关闭时删除
+//	}
+//
+// 通用中间件:
+//
+//	router.Use(
+//	    httpserver.RecoveryMiddleware(log),                    // panic → 500 + 关联 ID,记录堆栈
+//	    httpserver.LoggingMiddleware(log, httpserver.WithSkipPaths("/health")), // 访问日志
+//	    httpserver.MaxBodyMiddleware(1<<20),                   // 请求体超过 1MB → 413
+//	)
+//	router.POST("/upload", httpserver.TimeoutMiddleware(2*time.Minute), upload) // 超时 → 503
+//
+// 错误响应格式默认为 result.Response,可通过 WithErrorResponder 替换为应用自己的格式:
+//
+//	respond := httpserver.WithErrorResponder(func(c *gin.Context, status int, err error) {
+//	    c.AbortWithStatusJSON(status, gin.H{"error": http.StatusText(status)})
+//	})
+//	router.Use(httpserver.RecoveryMiddleware(log, respond))
+//
+// 请求指标 (Prometheus 文本格式):
+//
+//	metrics := httpserver.NewMetrics(httpserver.WithMetricsNamespace("myapp"))
+//	router.Use(metrics.MetricsMiddleware()) // 按 method、路由模板、状态码类别统计
+//	metrics.Register(router)                // GET /metrics
+//
+// 跨域 (CORS):
+//
+//	router.Use(httpserver.CORSMiddleware(httpserver.CORSConfig{
+//	    AllowOrigins:     []string{"https://app.example.com", "https://*.example.com"},
+//	    AllowCredentials: true, // 不能与 "*" 来源同时使用,否则创建时 panic
+//	    MaxAge:           12 * time.Hour,
+//	}))
+//
 // # 使用场景
 //
 // 1. Web 应用服务器:
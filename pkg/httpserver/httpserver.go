@@ -3,10 +3,14 @@ package httpserver
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"sync"
 	"sync/atomic"
 
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
 	"github.com/rei0721/go-scaffold/pkg/logger"
 	"github.com/rei0721/go-scaffold/pkg/utils"
 )
@@ -38,6 +42,10 @@ type httpServer struct {
 
 	// errChan 服务器错误通道
 	errChan chan error
+
+	// serverOptions 自定义 http.Server 的回调
+	// 每次 Start/Reload 创建服务器后依次调用
+	serverOptions []func(*http.Server)
 }
 
 // New 创建新的 HTTP Server 实例
@@ -46,6 +54,7 @@ type httpServer struct {
 //	handler: HTTP 请求处理器，通常是 Gin Router
 //	cfg: 服务器配置
 //	log: 日志记录器
+//	opts: 可选配置,如 WithServerOptions
 //
 // 返回:
 //
@@ -55,7 +64,7 @@ type httpServer struct {
 // 注意:
 //
 //	Executor需要通过SetExecutor方法延迟注入
-func New(handler Handler, cfg *Config, log logger.Logger) (HTTPServer, error) {
+func New(handler Handler, cfg *Config, log logger.Logger, opts ...Option) (HTTPServer, error) {
 	if handler == nil {
 		return nil, &ServerError{
 			Op:      "new",
@@ -86,6 +95,10 @@ func New(handler Handler, cfg *Config, log logger.Logger) (HTTPServer, error) {
 		errChan: make(chan error, 1),
 	}
 
+	for _, opt := range opts {
+		opt(s)
+	}
+
 	// 设置初始状态为已停止
 	s.state.Store(int32(stateStopped))
 
@@ -110,7 +123,8 @@ func (s *httpServer) Start(ctx context.Context) error {
 	s.state.Store(int32(stateStarting))
 
 	// 如果端口为 0，自动分配可用端口
-	if s.config.Port == 0 {
+	// Unix socket 不使用端口
+	if s.config.Network != NetworkUnix && s.config.Port == 0 {
 		port, err := utils.GetAvailablePort(9000, 30000)
 		if err != nil {
 			s.state.Store(int32(stateStopped))
@@ -123,27 +137,23 @@ func (s *httpServer) Start(ctx context.Context) error {
 		s.config.Port = port
 	}
 
-	// 构造监听地址
-	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
-
-	// 校验地址是否合法
-	if err := utils.IsValidHTTPListenAddr(addr); err != nil {
-		// 如果地址不合法，使用默认 host
-		s.logger.Warn("invalid listen address, using default host", "addr", addr, "error", err)
-		addr = fmt.Sprintf("%s:%d", DefaultHost, s.config.Port)
+	// 构造监听地址,Unix socket 在此创建监听器
+	addr, ln, err := s.prepareListener(s.config)
+	if err != nil {
+		s.state.Store(int32(stateStopped))
+		return &ServerError{
+			Op:      "start",
+			Message: ErrMsgServerStartFailed,
+			Err:     err,
+		}
 	}
 
 	// 创建 HTTP 服务器实例
-	s.server = &http.Server{
-		Addr:         addr,
-		Handler:      s.handler,
-		ReadTimeout:  s.config.ReadTimeout,
-		WriteTimeout: s.config.WriteTimeout,
-		IdleTimeout:  s.config.IdleTimeout,
-	}
+	server := s.newServer(addr, s.config)
+	s.server = server
 
 	// 记录启动信息
-	s.logger.Info(fmt.Sprintf("starting HTTP server on http://%s", addr), "addr", addr)
+	s.logger.Info(fmt.Sprintf("starting HTTP server on %s", displayAddr(addr, ln)), "addr", addr)
 
 	// 在新的 goroutine 中启动服务器
 	go func() {
@@ -151,7 +161,7 @@ func (s *httpServer) Start(ctx context.Context) error {
 		s.state.Store(int32(stateRunning))
 
 		// 启动服务器并开始监听
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := serve(server, ln); err != nil && err != http.ErrServerClosed {
 			// ErrServerClosed 是正常的关闭，不是错误
 			s.logger.Error("HTTP server error", "error", err)
 			s.errChan <- &ServerError{
@@ -185,7 +195,9 @@ func (s *httpServer) Shutdown(ctx context.Context) error {
 
 	// 执行优雅关闭
 	if s.server != nil {
-		if err := s.server.Shutdown(ctx); err != nil {
+		err := s.server.Shutdown(ctx)
+		s.cleanupSocket(s.config)
+		if err != nil {
 			s.state.Store(int32(stateStopped))
 			return &ServerError{
 				Op:      "shutdown",
@@ -240,16 +252,20 @@ func (s *httpServer) Reload(ctx context.Context, cfg *Config) error {
 	// 保存旧服务器实例
 	oldServer := s.server
 
-	// 构造新的监听地址
-	newAddr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
-
-	// 检查端口是否变化
-	oldAddr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	// 检查监听地址是否变化
+	oldCfg := s.config
+	oldAddr, newAddr := listenTarget(oldCfg), listenTarget(cfg)
 	portChanged := newAddr != oldAddr
 
+	// 新旧服务器能否同时监听
+	// 地址不变时只有新旧配置都启用 ReusePort 才能同时监听同一端口,
+	// 此时先启动新服务器再关闭旧服务器,重载期间不拒绝连接
+	overlap := !portChanged && oldCfg.ReusePort && cfg.ReusePort
+
 	// 如果端口变化，需要先关闭旧服务器
-	if portChanged {
-		s.logger.Info("port changed, shutting down old server first", "old", oldAddr, "new", newAddr)
+	// 地址不变但无法同时监听(未启用 ReusePort、Unix socket 路径)时,同样先关闭旧服务器
+	if !overlap {
+		s.logger.Info("listen address changed or cannot be shared, shutting down old server first", "old", oldAddr, "new", newAddr)
 
 		// 创建一个临时上下文用于关闭旧服务器
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
@@ -263,51 +279,126 @@ func (s *httpServer) Reload(ctx context.Context, cfg *Config) error {
 				Err:     err,
 			}
 		}
+		s.cleanupSocket(oldCfg)
 	}
 
-	// 更新配置
+	// 同步创建监听器,监听失败时旧配置保持不变
+	newAddr, ln, err := s.openListener(cfg)
+	if err != nil {
+		s.logger.Error("failed to listen with new config during reload", "addr", newAddr, "error", err)
+		// 旧服务器已经关闭时按旧配置恢复,同时监听时旧服务器仍在运行,无需处理
+		if !overlap {
+			s.restore(oldCfg)
+		}
+		return &ServerError{
+			Op:      "reload",
+			Message: ErrMsgReloadFailed,
+			Err:     err,
+		}
+	}
+
+	// 监听成功后再更新配置
 	s.config = cfg
+	s.startServer(newAddr, ln, cfg, "restarting")
 
-	// 校验地址是否合法
-	if err := utils.IsValidHTTPListenAddr(newAddr); err != nil {
-		s.logger.Warn("invalid listen address, using default host", "addr", newAddr, "error", err)
-		newAddr = fmt.Sprintf("%s:%d", DefaultHost, cfg.Port)
+	// 新旧服务器同时监听时，现在关闭旧服务器
+	if overlap && oldServer != nil {
+		go func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
+			defer cancel()
+
+			if err := oldServer.Shutdown(shutdownCtx); err != nil {
+				s.logger.Error("failed to shutdown old server after reload", "error", err)
+			}
+		}()
 	}
 
-	// 创建新的服务器实例
-	s.server = &http.Server{
-		Addr:         newAddr,
-		Handler:      s.handler,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-		IdleTimeout:  cfg.IdleTimeout,
+	s.logger.Info("HTTP server reloaded successfully")
+	return nil
+}
+
+// restore 重载失败后按旧配置重新启动服务器
+// 在旧服务器已关闭、新配置监听失败时调用;旧配置同样无法监听时服务器进入已停止状态,
+// 避免状态仍为运行中却没有服务器在监听
+// 调用方需持有写锁
+// 参数:
+//
+//	oldCfg: 重载前的配置
+func (s *httpServer) restore(oldCfg *Config) {
+	addr, ln, err := s.openListener(oldCfg)
+	if err != nil {
+		s.logger.Error("failed to restore HTTP server after reload failure, server stopped", "error", err)
+		s.server = nil
+		s.state.Store(int32(stateStopped))
+		return
 	}
+	s.startServer(addr, ln, oldCfg, "restoring")
+}
+
+// startServer 在监听器上启动服务器(Reload 路径)
+// 后台运行出错时发送到 errChan,当前服务器仍是它时将状态置为已停止
+// 调用方需持有写锁
+// 参数:
+//
+//	addr: 监听地址
+//	ln: 已创建的监听器
+//	cfg: 服务器配置
+//	action: 日志中的动作描述,如 "restarting"
+func (s *httpServer) startServer(addr string, ln net.Listener, cfg *Config, action string) {
+	server := s.newServer(addr, cfg)
+	s.server = server
 
-	// 启动新服务器
 	go func() {
-		s.logger.Info(fmt.Sprintf("restarting HTTP server on http://%s", newAddr), "addr", newAddr)
-		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		s.logger.Info(fmt.Sprintf("%s HTTP server on %s", action, displayAddr(addr, ln)), "addr", addr)
+		if err := serve(server, ln); err != nil && err != http.ErrServerClosed {
 			s.logger.Error("reloaded HTTP server error", "error", err)
 			s.errChan <- &ServerError{
 				Op:      "reload",
 				Message: ErrMsgReloadFailed,
 				Err:     err,
 			}
+
+			s.mu.Lock()
+			if s.server == server {
+				s.state.Store(int32(stateStopped))
+			}
+			s.mu.Unlock()
 		}
 	}()
+}
 
-	// 如果端口未变化，现在关闭旧服务器
-	if !portChanged && oldServer != nil {
-		go func() {
-			shutdownCtx, cancel := context.WithTimeout(context.Background(), DefaultWriteTimeout)
-			defer cancel()
+// newServer 根据配置创建 http.Server
+// Start 和 Reload 共用,保证两条路径的服务器构造一致
+// 参数:
+//
+//	addr: 监听地址
+//	cfg: 服务器配置
+//
+// 返回:
+//
+//	*http.Server: 服务器实例
+func (s *httpServer) newServer(addr string, cfg *Config) *http.Server {
+	// 慢请求检测包装在路由外层,覆盖所有路由
+	handler := newSlowRequestHandler(s.handler, cfg.SlowRequestThreshold, s.logger)
 
-			if err := oldServer.Shutdown(shutdownCtx); err != nil {
-				s.logger.Error("failed to shutdown old server after reload", "error", err)
-			}
-		}()
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      handler,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
 	}
 
-	s.logger.Info("HTTP server reloaded successfully")
-	return nil
+	if cfg.H2C {
+		// 明文 HTTP/2:包装 handler,识别 h2c 升级和 prior knowledge 请求
+		h2s := &http2.Server{IdleTimeout: cfg.IdleTimeout}
+		server.Handler = h2c.NewHandler(handler, h2s)
+	}
+
+	// 最后应用自定义选项,覆盖上面未涉及的字段
+	for _, fn := range s.serverOptions {
+		fn(server)
+	}
+
+	return server
 }
@@ -0,0 +1,118 @@
+package httpserver
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// freePort 返回一个当前空闲的本地端口
+func freePort(t *testing.T) int {
+	t.Helper()
+	ln, err := net.Listen(NetworkTCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().(*net.TCPAddr).Port
+}
+
+// startTestServer 按配置启动服务器并等待进入运行状态
+func startTestServer(t *testing.T, cfg *Config) *httpServer {
+	t.Helper()
+	log, _ := logger.NewTestLogger()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+	srv, err := New(handler, cfg, log)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := srv.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	s := srv.(*httpServer)
+	t.Cleanup(func() { _ = s.Shutdown(context.Background()) })
+
+	deadline := time.Now().Add(time.Second)
+	for serverState(s.state.Load()) != stateRunning {
+		if time.Now().After(deadline) {
+			t.Fatal("server did not reach running state")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return s
+}
+
+// TestReloadListenFailure 测试新配置监听失败时按旧配置恢复服务
+func TestReloadListenFailure(t *testing.T) {
+	oldPort := freePort(t)
+	s := startTestServer(t, &Config{Host: "127.0.0.1", Port: oldPort})
+
+	// 新端口已被占用
+	busy, err := net.Listen(NetworkTCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer busy.Close()
+	busyPort := busy.Addr().(*net.TCPAddr).Port
+
+	if err := s.Reload(context.Background(), &Config{Host: "127.0.0.1", Port: busyPort}); err == nil {
+		t.Fatal("Reload() to a busy port should fail")
+	}
+	if st := serverState(s.state.Load()); st != stateRunning {
+		t.Errorf("state after failed reload = %v, want running", st)
+	}
+	if s.config.Port != oldPort {
+		t.Errorf("config port after failed reload = %d, want %d", s.config.Port, oldPort)
+	}
+
+	resp, err := http.Get("http://127.0.0.1:" + strconv.Itoa(oldPort))
+	if err != nil {
+		t.Fatalf("old address not served after failed reload: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
+
+// TestReloadRestoreFailure 测试旧配置也无法恢复时服务器进入已停止状态
+func TestReloadRestoreFailure(t *testing.T) {
+	dir := t.TempDir()
+	sock := filepath.Join(dir, "app.sock")
+	if ln, err := net.Listen(NetworkUnix, sock); err != nil {
+		t.Skipf("unix socket not supported: %v", err)
+	} else {
+		_ = ln.Close()
+	}
+	s := startTestServer(t, &Config{Network: NetworkUnix, SocketPath: sock})
+
+	// 旧 socket 所在目录被删除,旧配置无法重新监听
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "new.sock")
+	if err := s.Reload(context.Background(), &Config{Network: NetworkUnix, SocketPath: missing}); err == nil {
+		t.Fatal("Reload() to an unusable socket path should fail")
+	}
+	if st := serverState(s.state.Load()); st != stateStopped {
+		t.Errorf("state after failed restore = %v, want stopped", st)
+	}
+	if s.config.SocketPath != sock {
+		t.Errorf("config socket path = %q, want %q", s.config.SocketPath, sock)
+	}
+
+	// 已停止的服务器可以重新启动
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Errorf("Start() after failed reload error = %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutMiddleware 返回限制单个路由处理时间的 Gin 中间件
+// 语义与 http.TimeoutHandler 一致:
+//   - 后续处理器的响应先写入缓冲,按时完成时再写给客户端
+//   - 超时后立即向客户端返回 503,处理器之后的写入返回 http.ErrHandlerTimeout 并被丢弃
+//   - 请求的 context 在超时时被取消,处理器应通过 c.Request.Context() 尽早退出
+//
+// 与 http.TimeoutHandler 不同的是,超时后中间件仍会等待处理器返回再结束本次请求,
+// 因为 gin.Context 会被复用,处理器在请求结束后继续访问它会导致数据竞争
+//
+// 超时响应由 WithErrorResponder 设置的函数写入,err 为 ErrRequestTimeout;
+// 此时处理器仍在使用 gin.Context,函数收到的是请求开始时的副本(c.Copy),
+// 写入直接发往客户端,其中的 Keys 不包含处理器之后设置的值
+//
+// 参数:
+//
+//	d: 处理超时时间,小于等于 0 时不限制
+//	opts: 可选配置,如 WithErrorResponder
+//
+// 返回:
+//
+//	gin.HandlerFunc: Gin 中间件
+//
+// 使用示例:
+//
+//	router.GET("/health", httpserver.TimeoutMiddleware(time.Second), health)
+//	router.POST("/upload", httpserver.TimeoutMiddleware(2*time.Minute), upload)
+func TimeoutMiddleware(d time.Duration, opts ...MiddlewareOption) gin.HandlerFunc {
+	cfg := newMiddlewareConfig(opts)
+
+	return func(c *gin.Context) {
+		if d <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		// 在启动处理器 goroutine 之前取关联 ID,避免与处理器并发访问 gin.Context
+		id := requestID(c)
+		if id == "" {
+			id = requestIDGenerator.NextIDString()
+		}
+
+		// 超时响应使用的上下文副本,直接写入底层 writer
+		// 必须在处理器 goroutine 启动前创建,之后处理器会并发修改 gin.Context
+		tc := c.Copy()
+
+		w := c.Writer
+		tc.Writer = w
+		tw := newTimeoutWriter(w)
+		c.Writer = tw
+		defer func() { c.Writer = w }()
+
+		done := make(chan struct{})
+		panicChan := make(chan interface{}, 1)
+		go func() {
+			defer close(done)
+			defer func() {
+				if r := recover(); r != nil {
+					panicChan <- r
+				}
+			}()
+			c.Next()
+		}()
+
+		select {
+		case <-done:
+			tw.flush()
+		case <-ctx.Done():
+			tw.timeout()
+			w.Header().Set(RequestIDHeader, id)
+			cfg.errorResponder(tc, http.StatusServiceUnavailable, ErrRequestTimeout)
+			w.Flush()
+			<-done
+		}
+
+		select {
+		case r := <-panicChan:
+			// 在请求 goroutine 中重新 panic,交给 RecoveryMiddleware 处理
+			panic(r)
+		default:
+		}
+	}
+}
+
+// MaxBodyMiddleware 返回限制请求体大小的 Gin 中间件
+// Content-Length 已知且超过限制时直接返回 413;
+// 否则使用 http.MaxBytesReader 包装请求体,读取超过限制时返回 *http.MaxBytesError
+//
+// 413 响应由 WithErrorResponder 设置的函数写入,err 为 ErrBodyTooLarge
+//
+// 参数:
+//
+//	n: 请求体最大字节数,小于等于 0 时不限制
+//	opts: 可选配置,如 WithErrorResponder
+//
+// 返回:
+//
+//	gin.HandlerFunc: Gin 中间件
+//
+// 使用示例:
+//
+//	router.Use(httpserver.MaxBodyMiddleware(1 << 20))                          // 全局 1MB
+//	router.POST("/upload", httpserver.MaxBodyMiddleware(100<<20), upload) // 上传 100MB
+func MaxBodyMiddleware(n int64, opts ...MiddlewareOption) gin.HandlerFunc {
+	cfg := newMiddlewareConfig(opts)
+
+	return func(c *gin.Context) {
+		if n <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > n {
+			if id := requestID(c); id != "" {
+				c.Header(RequestIDHeader, id)
+			}
+			cfg.errorResponder(c, http.StatusRequestEntityTooLarge, ErrBodyTooLarge)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, n)
+		c.Next()
+	}
+}
+
+// timeoutWriter 缓冲处理器响应的 gin.ResponseWriter
+// 按时完成时由 flush 写给底层 writer,超时后丢弃所有写入
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	status   int
+	written  bool
+	timedOut bool
+}
+
+// newTimeoutWriter 创建缓冲 writer,继承底层 writer 已设置的响应头
+func newTimeoutWriter(w gin.ResponseWriter) *timeoutWriter {
+	return &timeoutWriter{
+		ResponseWriter: w,
+		header:         w.Header().Clone(),
+		status:         http.StatusOK,
+	}
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.written {
+		return
+	}
+	w.status = code
+	w.written = true
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.WriteHeader(w.Status())
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	w.written = true
+	return w.buf.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.written {
+		return -1
+	}
+	return w.buf.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.written
+}
+
+// Flush 缓冲模式下不向客户端刷新
+func (w *timeoutWriter) Flush() {}
+
+// timeout 标记超时,之后的写入全部丢弃
+func (w *timeoutWriter) timeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timedOut = true
+}
+
+// flush 将缓冲的响应头、状态码和响应体写给底层 writer
+func (w *timeoutWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	dst := w.ResponseWriter.Header()
+	for k := range dst {
+		if _, ok := w.header[k]; !ok {
+			dst.Del(k)
+		}
+	}
+	for k, v := range w.header {
+		dst[k] = v
+	}
+
+	if !w.written {
+		return
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+}
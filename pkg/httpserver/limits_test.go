@@ -0,0 +1,118 @@
+package httpserver
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestTimeoutMiddleware 测试按时完成的响应正常写出
+func TestTimeoutMiddleware(t *testing.T) {
+	r := gin.New()
+	r.GET("/fast", TimeoutMiddleware(time.Second), func(c *gin.Context) {
+		c.Header("X-Handler", "1")
+		c.String(http.StatusCreated, "ok")
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/fast", nil))
+
+	if w.Code != http.StatusCreated || w.Body.String() != "ok" || w.Header().Get("X-Handler") != "1" {
+		t.Errorf("status = %d, body = %q, header = %v", w.Code, w.Body.String(), w.Header())
+	}
+}
+
+// TestTimeoutMiddleware_Timeout 测试超时返回 503,处理器之后的写入被丢弃
+func TestTimeoutMiddleware_Timeout(t *testing.T) {
+	writeErr := make(chan error, 1)
+	r := gin.New()
+	r.GET("/slow", TimeoutMiddleware(20*time.Millisecond), func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		// 等待中间件写出超时响应后再写入
+		time.Sleep(20 * time.Millisecond)
+		c.Header("X-Late", "1")
+		_, err := c.Writer.WriteString("late")
+		writeErr <- err
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/slow", nil)
+	req.Header.Set(RequestIDHeader, "req-timeout")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "late") || w.Header().Get("X-Late") != "" {
+		t.Errorf("late write leaked: body = %q, header = %v", w.Body.String(), w.Header())
+	}
+	body := decodeErrorBody(t, w)
+	if body.Message != TimeoutMessage || body.TraceID != "req-timeout" {
+		t.Errorf("body = %+v", body)
+	}
+	if err := <-writeErr; !errors.Is(err, http.ErrHandlerTimeout) {
+		t.Errorf("late write error = %v, want http.ErrHandlerTimeout", err)
+	}
+}
+
+// TestTimeoutMiddleware_Panic 测试处理器 panic 交给外层 RecoveryMiddleware
+func TestTimeoutMiddleware_Panic(t *testing.T) {
+	r := gin.New()
+	r.Use(gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusTeapot)
+	}))
+	r.GET("/panic", TimeoutMiddleware(time.Second), func(c *gin.Context) { panic("boom") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if w.Code != http.StatusTeapot {
+		t.Errorf("status = %d, want 418", w.Code)
+	}
+}
+
+// TestMaxBodyMiddleware 测试请求体大小限制
+func TestMaxBodyMiddleware(t *testing.T) {
+	r := gin.New()
+	r.POST("/upload", MaxBodyMiddleware(8), func(c *gin.Context) {
+		data, err := io.ReadAll(c.Request.Body)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.String(http.StatusRequestEntityTooLarge, "read limit")
+			return
+		}
+		c.String(http.StatusOK, string(data))
+	})
+
+	// 未超过限制
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("small")))
+	if w.Code != http.StatusOK || w.Body.String() != "small" {
+		t.Errorf("status = %d, body = %q", w.Code, w.Body.String())
+	}
+
+	// Content-Length 超过限制时直接拒绝,不进入处理器
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader("this body is too large")))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("status = %d, want 413", w.Code)
+	}
+	if body := decodeErrorBody(t, w); body.Message != BodyTooLargeMessage {
+		t.Errorf("body = %+v", body)
+	}
+
+	// Content-Length 未知时在读取时限制
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/upload", io.NopCloser(strings.NewReader("this body is too large")))
+	req.ContentLength = -1
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge || w.Body.String() != "read limit" {
+		t.Errorf("status = %d, body = %q", w.Code, w.Body.String())
+	}
+}
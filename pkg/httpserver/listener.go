@@ -0,0 +1,159 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/rei0721/go-scaffold/pkg/utils"
+)
+
+// listenTarget 返回配置对应的监听目标,用于日志和判断重载时监听地址是否变化
+// TCP 为 "host:port",Unix socket 为 "unix:" 加 socket 路径
+func listenTarget(cfg *Config) string {
+	if cfg.Network == NetworkUnix {
+		return NetworkUnix + ":" + cfg.SocketPath
+	}
+	return fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
+}
+
+// listenUnix 在 cfg.SocketPath 上创建 Unix socket 监听器
+// 路径上残留的 socket 文件(上次进程异常退出未清理)会先被删除;
+// 路径被普通文件或目录占用时返回错误,不会误删
+// 参数:
+//
+//	path: socket 文件路径
+//
+// 返回:
+//
+//	net.Listener: 监听器,Close 时由标准库删除 socket 文件
+//	error: 创建失败时的错误
+func listenUnix(path string) (net.Listener, error) {
+	if err := removeSocket(path); err != nil {
+		return nil, err
+	}
+	return net.Listen(NetworkUnix, path)
+}
+
+// removeSocket 删除 socket 文件
+// 文件不存在时忽略,不是 socket 时返回错误
+func removeSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode().Type() != fs.ModeSocket {
+		return fmt.Errorf("%s: %s", ErrMsgSocketPathInUse, path)
+	}
+	return os.Remove(path)
+}
+
+// listenReusePort 以 SO_REUSEPORT 监听 TCP 地址
+// 参数:
+//
+//	addr: 监听地址 "host:port"
+//
+// 返回:
+//
+//	net.Listener: 监听器,其他设置了 SO_REUSEPORT 的进程可以同时监听该地址
+//	error: 创建失败时的错误
+func listenReusePort(addr string) (net.Listener, error) {
+	lc := net.ListenConfig{Control: reusePortControl}
+	return lc.Listen(context.Background(), NetworkTCP, addr)
+}
+
+// prepareListener 按配置准备监听
+// Unix socket 和启用 ReusePort 的 TCP 在此同步创建监听器,启动失败(如目录不存在、权限不足)直接返回给调用方;
+// 其他 TCP 返回校验后的监听地址,由 ListenAndServe 在后台监听
+// 参数:
+//
+//	cfg: 服务器配置
+//
+// 返回:
+//
+//	string: 用于 http.Server.Addr 和日志的监听地址
+//	net.Listener: 同步创建的监听器,由 ListenAndServe 监听时为 nil
+//	error: 创建监听器失败时的错误
+func (s *httpServer) prepareListener(cfg *Config) (string, net.Listener, error) {
+	if cfg.Network == NetworkUnix {
+		ln, err := listenUnix(cfg.SocketPath)
+		if err != nil {
+			return "", nil, err
+		}
+		return listenTarget(cfg), ln, nil
+	}
+
+	addr := listenTarget(cfg)
+
+	// 校验地址是否合法
+	if err := utils.IsValidHTTPListenAddr(addr); err != nil {
+		// 如果地址不合法，使用默认 host
+		s.logger.Warn("invalid listen address, using default host", "addr", addr, "error", err)
+		addr = fmt.Sprintf("%s:%d", DefaultHost, cfg.Port)
+	}
+
+	if cfg.ReusePort {
+		ln, err := listenReusePort(addr)
+		if err != nil {
+			return "", nil, err
+		}
+		return addr, ln, nil
+	}
+	return addr, nil, nil
+}
+
+// openListener 按配置同步创建监听器
+// 与 prepareListener 相同,但普通 TCP 也在此监听,端口被占用等错误直接返回给调用方
+// Reload 使用,保证监听失败时还能恢复旧配置
+// 返回:
+//
+//	string: 监听地址
+//	net.Listener: 监听器
+//	error: 创建监听器失败时的错误
+func (s *httpServer) openListener(cfg *Config) (string, net.Listener, error) {
+	addr, ln, err := s.prepareListener(cfg)
+	if err != nil || ln != nil {
+		return addr, ln, err
+	}
+	ln, err = net.Listen(NetworkTCP, addr)
+	if err != nil {
+		return addr, nil, err
+	}
+	return addr, ln, nil
+}
+
+// serve 启动服务器,阻塞直到服务器关闭
+// ln 不为 nil 时在该监听器上提供服务,否则按 server.Addr 监听 TCP
+func serve(server *http.Server, ln net.Listener) error {
+	if ln != nil {
+		return server.Serve(ln)
+	}
+	return server.ListenAndServe()
+}
+
+// displayAddr 返回用于日志的监听地址
+// TCP 为 "http://host:port",Unix socket 为 "unix:" 加 socket 路径
+func displayAddr(addr string, ln net.Listener) string {
+	if ln != nil && ln.Addr().Network() == NetworkUnix {
+		return addr
+	}
+	return "http://" + addr
+}
+
+// cleanupSocket 服务器关闭后删除 Unix socket 文件
+// 标准库关闭监听器时通常已删除,这里兜底处理 Serve 未运行就关闭等情况
+func (s *httpServer) cleanupSocket(cfg *Config) {
+	if cfg.Network != NetworkUnix {
+		return
+	}
+	if err := removeSocket(cfg.SocketPath); err != nil {
+		s.logger.Warn("failed to remove unix socket", "path", cfg.SocketPath, "error", err)
+	}
+}
@@ -0,0 +1,48 @@
+package httpserver
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestListenUnix 测试 Unix socket 监听会清理残留的 socket 文件,但不删除普通文件
+func TestListenUnix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+
+	// 模拟上次进程异常退出留下的 socket 文件
+	stale, err := net.Listen(NetworkUnix, path)
+	if err != nil {
+		t.Skipf("unix socket not supported: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = stale.Close()
+
+	ln, err := listenUnix(path)
+	if err != nil {
+		t.Fatalf("listenUnix() with stale socket error = %v", err)
+	}
+	_ = ln.Close()
+
+	file := filepath.Join(t.TempDir(), "data.txt")
+	if err := os.WriteFile(file, []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := listenUnix(file); err == nil {
+		t.Fatal("listenUnix() should refuse to replace a regular file")
+	}
+	if data, _ := os.ReadFile(file); string(data) != "keep" {
+		t.Errorf("regular file was modified: %q", data)
+	}
+}
+
+// TestDisplayAddr 测试日志中的监听地址
+func TestDisplayAddr(t *testing.T) {
+	if got := displayAddr("localhost:8080", nil); got != "http://localhost:8080" {
+		t.Errorf("displayAddr(tcp) = %q", got)
+	}
+	if got := listenTarget(&Config{Network: NetworkUnix, SocketPath: "/tmp/app.sock"}); got != "unix:/tmp/app.sock" {
+		t.Errorf("listenTarget(unix) = %q", got)
+	}
+}
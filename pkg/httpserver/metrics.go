@@ -0,0 +1,323 @@
+package httpserver
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsOption 指标采集配置选项
+type MetricsOption func(*Metrics)
+
+// WithMetricsPath 设置指标暴露路径
+// 默认 DefaultMetricsPath ("/metrics"),该路径本身的请求不计入指标
+//
+// 参数:
+//
+//	path: 指标路径,必须以 "/" 开头
+func WithMetricsPath(path string) MetricsOption {
+	return func(m *Metrics) {
+		if strings.HasPrefix(path, "/") {
+			m.path = path
+		}
+	}
+}
+
+// WithMetricsNamespace 设置指标名前缀
+// 例如 "myapp" 生成 myapp_http_requests_total
+//
+// 参数:
+//
+//	namespace: 指标名前缀,为空时不加前缀
+func WithMetricsNamespace(namespace string) MetricsOption {
+	return func(m *Metrics) {
+		m.namespace = namespace
+	}
+}
+
+// WithMetricsBuckets 设置延迟直方图的桶上界(秒)
+// 默认 DefaultMetricsBuckets;传入的值会排序并去重,非正数被忽略
+//
+// 参数:
+//
+//	buckets: 桶上界列表
+func WithMetricsBuckets(buckets ...float64) MetricsOption {
+	return func(m *Metrics) {
+		if b := normalizeBuckets(buckets); len(b) > 0 {
+			m.buckets = b
+		}
+	}
+}
+
+// Metrics HTTP 请求指标采集器
+// 按 method、路由模板和状态码类别统计请求数和延迟直方图,
+// 以 Prometheus 文本格式暴露,无需引入 Prometheus 客户端库
+//
+// 暴露的指标:
+//   - <ns>_http_requests_total{method,route,status}: 请求总数(counter)
+//   - <ns>_http_request_duration_seconds{method,route,status}: 请求延迟(histogram)
+//
+// 标签取值:
+//   - method: 标准 HTTP 方法,其他方法统一为 "OTHER"
+//   - route: Gin 路由模板(如 /users/:id),未匹配路由的请求为 MetricsUnmatchedRoute
+//   - status: 状态码类别,如 "2xx"、"4xx"
+//
+// 使用路由模板而非原始路径,避免 /users/1、/users/2 产生无限多的时间序列
+type Metrics struct {
+	// path 指标暴露路径
+	path string
+
+	// namespace 指标名前缀
+	namespace string
+
+	// buckets 直方图桶上界(秒),升序
+	buckets []float64
+
+	// mu 保护 series
+	mu sync.Mutex
+
+	// series 按标签组合保存的统计数据
+	series map[metricsKey]*metricsSeries
+}
+
+// metricsKey 时间序列的标签组合
+type metricsKey struct {
+	method string
+	route  string
+	status string
+}
+
+// metricsSeries 单个标签组合的统计数据
+type metricsSeries struct {
+	// count 请求总数
+	count uint64
+
+	// sum 延迟总和(秒)
+	sum float64
+
+	// buckets 每个桶内(非累计)的请求数,最后一个元素对应 +Inf
+	buckets []uint64
+}
+
+// NewMetrics 创建 HTTP 请求指标采集器
+//
+// 参数:
+//
+//	opts: 可选配置,如 WithMetricsPath、WithMetricsNamespace、WithMetricsBuckets
+//
+// 返回:
+//
+//	*Metrics: 指标采集器
+//
+// 使用示例:
+//
+//	metrics := httpserver.NewMetrics(httpserver.WithMetricsNamespace("myapp"))
+//	router.Use(metrics.MetricsMiddleware())
+//	metrics.Register(router) // GET /metrics
+func NewMetrics(opts ...MetricsOption) *Metrics {
+	m := &Metrics{
+		path:    DefaultMetricsPath,
+		buckets: DefaultMetricsBuckets,
+		series:  make(map[metricsKey]*metricsSeries),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Path 返回指标暴露路径
+func (m *Metrics) Path() string {
+	return m.path
+}
+
+// MetricsMiddleware 返回采集请求指标的 Gin 中间件
+// 应尽量靠前注册,使延迟包含后续中间件的耗时
+// 指标路径本身的请求不计入
+//
+// 返回:
+//
+//	gin.HandlerFunc: Gin 中间件
+func (m *Metrics) MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.URL.Path == m.path {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = MetricsUnmatchedRoute
+		}
+		m.observe(metricsKey{
+			method: normalizeMethod(c.Request.Method),
+			route:  route,
+			status: statusClass(c.Writer.Status()),
+		}, time.Since(start))
+	}
+}
+
+// Handler 返回以 Prometheus 文本格式输出指标的 http.Handler
+//
+// 返回:
+//
+//	http.Handler: 指标处理器
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", MetricsContentType)
+		bw := bufio.NewWriter(w)
+		m.writeTo(bw)
+		_ = bw.Flush()
+	})
+}
+
+// Register 在 Gin 路由上注册 GET 指标路径
+//
+// 参数:
+//
+//	r: Gin 路由,通常是 *gin.Engine
+func (m *Metrics) Register(r gin.IRoutes) {
+	r.GET(m.path, gin.WrapH(m.Handler()))
+}
+
+// observe 记录一次请求
+func (m *Metrics) observe(key metricsKey, latency time.Duration) {
+	seconds := latency.Seconds()
+	i := sort.SearchFloat64s(m.buckets, seconds)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.series[key]
+	if !ok {
+		s = &metricsSeries{buckets: make([]uint64, len(m.buckets)+1)}
+		m.series[key] = s
+	}
+	s.count++
+	s.sum += seconds
+	s.buckets[i]++
+}
+
+// writeTo 以 Prometheus 文本格式写出所有指标
+// 时间序列按标签排序,输出稳定
+func (m *Metrics) writeTo(w *bufio.Writer) {
+	m.mu.Lock()
+	keys := make([]metricsKey, 0, len(m.series))
+	snapshot := make(map[metricsKey]metricsSeries, len(m.series))
+	for k, s := range m.series {
+		keys = append(keys, k)
+		snapshot[k] = metricsSeries{count: s.count, sum: s.sum, buckets: append([]uint64(nil), s.buckets...)}
+	}
+	m.mu.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.route != b.route {
+			return a.route < b.route
+		}
+		if a.method != b.method {
+			return a.method < b.method
+		}
+		return a.status < b.status
+	})
+
+	total := m.metricName(MetricRequestsTotal)
+	fmt.Fprintf(w, "# HELP %s Total number of HTTP requests.\n", total)
+	fmt.Fprintf(w, "# TYPE %s counter\n", total)
+	for _, k := range keys {
+		fmt.Fprintf(w, "%s{%s} %d\n", total, k.labels(), snapshot[k].count)
+	}
+
+	duration := m.metricName(MetricRequestDuration)
+	fmt.Fprintf(w, "# HELP %s HTTP request latency in seconds.\n", duration)
+	fmt.Fprintf(w, "# TYPE %s histogram\n", duration)
+	for _, k := range keys {
+		s := snapshot[k]
+		labels := k.labels()
+		var cumulative uint64
+		for i, le := range m.buckets {
+			cumulative += s.buckets[i]
+			fmt.Fprintf(w, "%s_bucket{%s,le=\"%s\"} %d\n", duration, labels, formatFloat(le), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=\"+Inf\"} %d\n", duration, labels, s.count)
+		fmt.Fprintf(w, "%s_sum{%s} %s\n", duration, labels, formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count{%s} %d\n", duration, labels, s.count)
+	}
+}
+
+// metricName 返回带命名空间前缀的指标名
+func (m *Metrics) metricName(name string) string {
+	if m.namespace == "" {
+		return name
+	}
+	return m.namespace + "_" + name
+}
+
+// labels 返回 Prometheus 标签字符串
+func (k metricsKey) labels() string {
+	return fmt.Sprintf(`method="%s",route="%s",status="%s"`,
+		escapeLabel(k.method), escapeLabel(k.route), escapeLabel(k.status))
+}
+
+// normalizeMethod 将非标准 HTTP 方法归为 "OTHER"
+// 防止客户端构造任意方法名制造高基数
+func normalizeMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace:
+		return method
+	default:
+		return MetricsOtherMethod
+	}
+}
+
+// statusClass 返回状态码类别,如 200 -> "2xx"
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return MetricsUnknownStatus
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// normalizeBuckets 排序并去重桶上界,忽略非正数
+func normalizeBuckets(buckets []float64) []float64 {
+	out := make([]float64, 0, len(buckets))
+	for _, b := range buckets {
+		if b > 0 {
+			out = append(out, b)
+		}
+	}
+	sort.Float64s(out)
+
+	n := 0
+	for i, b := range out {
+		if i == 0 || b != out[n-1] {
+			out[n] = b
+			n++
+		}
+	}
+	return out[:n]
+}
+
+// formatFloat 按 Prometheus 文本格式输出浮点数
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// labelEscaper 转义 Prometheus 标签值中的特殊字符
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel 转义标签值
+func escapeLabel(v string) string {
+	return labelEscaper.Replace(v)
+}
@@ -0,0 +1,46 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestMetrics 测试按路由模板统计请求并以 Prometheus 文本格式输出
+func TestMetrics(t *testing.T) {
+	m := NewMetrics(WithMetricsNamespace("app"), WithMetricsBuckets(1, 0.1, -1))
+	r := gin.New()
+	r.Use(m.MetricsMiddleware())
+	m.Register(r)
+	r.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{"/users/1", "/users/2", "/missing", "/metrics"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := w.Body.String()
+
+	for _, want := range []string{
+		`app_http_requests_total{method="GET",route="/users/:id",status="2xx"} 2`,
+		`app_http_requests_total{method="GET",route="unmatched",status="4xx"} 1`,
+		`app_http_request_duration_seconds_bucket{method="GET",route="/users/:id",status="2xx",le="0.1"} 2`,
+		`app_http_request_duration_seconds_bucket{method="GET",route="/users/:id",status="2xx",le="+Inf"} 2`,
+		`app_http_request_duration_seconds_count{method="GET",route="/users/:id",status="2xx"} 2`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	// 指标路径本身不计入,原始路径不作为标签
+	if strings.Contains(out, `route="/metrics"`) || strings.Contains(out, "/users/1") {
+		t.Errorf("unexpected series in:\n%s", out)
+	}
+	if got := w.Header().Get("Content-Type"); got != MetricsContentType {
+		t.Errorf("Content-Type = %q", got)
+	}
+}
@@ -0,0 +1,191 @@
+package httpserver
+
+import (
+	stderrors "errors"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+	"github.com/rei0721/go-scaffold/pkg/utils"
+	"github.com/rei0721/go-scaffold/types/errors"
+	"github.com/rei0721/go-scaffold/types/result"
+)
+
+// MiddlewareOption 中间件配置选项
+type MiddlewareOption func(*middlewareConfig)
+
+// ErrorResponder 写入错误响应的函数
+// RecoveryMiddleware、TimeoutMiddleware 和 MaxBodyMiddleware 需要返回错误时调用,
+// 调用前响应头 X-Request-ID 已设置,可通过 c.Writer.Header().Get(RequestIDHeader) 读取
+// 参数:
+//
+//	c: 请求上下文,应调用 c.AbortWithStatusJSON 等方法写入响应
+//	status: HTTP 状态码,如 500、503、413
+//	err: 错误原因,ErrPanicRecovered、ErrRequestTimeout 或 ErrBodyTooLarge
+type ErrorResponder func(c *gin.Context, status int, err error)
+
+// middlewareConfig 中间件配置
+type middlewareConfig struct {
+	// skipPaths 不记录访问日志的路径集合
+	skipPaths map[string]struct{}
+
+	// errorResponder 写入错误响应的函数
+	errorResponder ErrorResponder
+}
+
+// newMiddlewareConfig 创建中间件配置并应用选项
+// 默认跳过 DefaultSkipPaths 中的健康检查路径,使用 defaultErrorResponder 写入错误响应
+func newMiddlewareConfig(opts []MiddlewareOption) *middlewareConfig {
+	cfg := &middlewareConfig{errorResponder: defaultErrorResponder}
+	WithSkipPaths(DefaultSkipPaths...)(cfg)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// WithSkipPaths 设置不记录访问日志的路径 (精确匹配)
+// 会替换默认的 DefaultSkipPaths,不传参数表示记录所有路径
+//
+// 使用示例:
+//
+//	router.Use(httpserver.LoggingMiddleware(log,
+//	    httpserver.WithSkipPaths("/health", "/metrics")))
+func WithSkipPaths(paths ...string) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.skipPaths = make(map[string]struct{}, len(paths))
+		for _, p := range paths {
+			cfg.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithErrorResponder 设置写入错误响应的函数
+// 用于让 panic、超时和请求体过大的响应与应用其他接口的错误格式保持一致
+// 未设置或传入 nil 时使用默认的 result.Response JSON 格式
+//
+// 使用示例:
+//
+//	respond := httpserver.WithErrorResponder(func(c *gin.Context, status int, err error) {
+//	    c.AbortWithStatusJSON(status, gin.H{"error": http.StatusText(status)})
+//	})
+//	router.Use(httpserver.RecoveryMiddleware(log, respond))
+//	router.GET("/report", httpserver.TimeoutMiddleware(5*time.Second, respond), report)
+func WithErrorResponder(fn ErrorResponder) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		if fn != nil {
+			cfg.errorResponder = fn
+		}
+	}
+}
+
+// defaultErrorResponder 默认的错误响应
+// 返回 result.Response 格式的 JSON,消息为固定文本,不包含 panic 值等内部信息
+func defaultErrorResponder(c *gin.Context, status int, err error) {
+	code, message := errors.ErrInternalServer, PanicMessage
+	switch {
+	case stderrors.Is(err, ErrRequestTimeout):
+		message = TimeoutMessage
+	case stderrors.Is(err, ErrBodyTooLarge):
+		code, message = errors.ErrInvalidParams, BodyTooLargeMessage
+	}
+	c.AbortWithStatusJSON(status, result.ErrorWithTrace(code, message, c.Writer.Header().Get(RequestIDHeader)))
+}
+
+// requestIDGenerator 用于在上游未提供关联 ID 时生成新 ID
+var requestIDGenerator = utils.DefaultSnowflake()
+
+// LoggingMiddleware 返回记录访问日志的 Gin 中间件
+// 每个请求完成后记录 method、path、status、latency 等信息
+// 默认跳过健康检查路径,可通过 WithSkipPaths 调整
+//
+// 参数:
+//
+//	log: 日志记录器
+//	opts: 可选配置
+//
+// 返回:
+//
+//	gin.HandlerFunc: Gin 中间件
+func LoggingMiddleware(log logger.Logger, opts ...MiddlewareOption) gin.HandlerFunc {
+	cfg := newMiddlewareConfig(opts)
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if _, skip := cfg.skipPaths[path]; skip {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		log.Info("request completed",
+			"method", c.Request.Method,
+			"path", path,
+			"status", c.Writer.Status(),
+			"latency", latency.String(),
+			"latencyMs", latency.Milliseconds(),
+			"clientIP", c.ClientIP(),
+			"requestId", requestID(c),
+		)
+	}
+}
+
+// RecoveryMiddleware 返回从 panic 中恢复的 Gin 中间件
+// 与 executor 的任务 panic 处理保持一致: panic 不会导致进程崩溃,
+// 而是记录 panic 值和完整堆栈,并向客户端返回 500 及关联 ID
+//
+// 关联 ID 优先取自上游中间件设置的 traceId,其次是 X-Request-ID 请求头,
+// 都不存在时生成新的 ID,并写入响应头 X-Request-ID
+//
+// 响应体由 WithErrorResponder 设置的函数写入,err 为 ErrPanicRecovered
+//
+// 参数:
+//
+//	log: 日志记录器
+//	opts: 可选配置,如 WithErrorResponder
+//
+// 返回:
+//
+//	gin.HandlerFunc: Gin 中间件
+func RecoveryMiddleware(log logger.Logger, opts ...MiddlewareOption) gin.HandlerFunc {
+	cfg := newMiddlewareConfig(opts)
+
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				id := requestID(c)
+				if id == "" {
+					id = requestIDGenerator.NextIDString()
+				}
+
+				log.Error("panic recovered",
+					"panic", r,
+					"method", c.Request.Method,
+					"path", c.Request.URL.Path,
+					"requestId", id,
+					"stack", string(debug.Stack()),
+				)
+
+				c.Header(RequestIDHeader, id)
+				cfg.errorResponder(c, http.StatusInternalServerError, ErrPanicRecovered)
+			}
+		}()
+
+		c.Next()
+	}
+}
+
+// requestID 获取当前请求的关联 ID
+// 依次查找 Gin 上下文中的 traceId 和 X-Request-ID 请求头
+func requestID(c *gin.Context) string {
+	if id := c.GetString(TraceIDContextKey); id != "" {
+		return id
+	}
+	return c.GetHeader(RequestIDHeader)
+}
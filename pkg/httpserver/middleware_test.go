@@ -0,0 +1,92 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// errorBody 默认错误响应中测试关心的字段
+type errorBody struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	TraceID string `json:"traceId"`
+}
+
+// decodeErrorBody 解析默认错误响应
+func decodeErrorBody(t *testing.T, w *httptest.ResponseRecorder) errorBody {
+	t.Helper()
+	var body errorBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid json body %q: %v", w.Body.String(), err)
+	}
+	return body
+}
+
+// TestRecoveryMiddleware 测试 panic 返回 500,响应中不包含 panic 值
+func TestRecoveryMiddleware(t *testing.T) {
+	log, sink := logger.NewTestLogger()
+	r := gin.New()
+	r.Use(RecoveryMiddleware(log))
+	r.GET("/panic", func(c *gin.Context) { panic("db password is hunter2") })
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "hunter2") {
+		t.Errorf("response leaks panic value: %s", w.Body.String())
+	}
+	body := decodeErrorBody(t, w)
+	if body.Message != PanicMessage || body.TraceID != "req-1" {
+		t.Errorf("body = %+v", body)
+	}
+	if got := w.Header().Get(RequestIDHeader); got != "req-1" {
+		t.Errorf("X-Request-ID = %q, want req-1", got)
+	}
+
+	// panic 值只写入日志
+	entries := sink.Entries()
+	if len(entries) != 1 || entries[0].Fields["panic"] != "db password is hunter2" {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+// TestRecoveryMiddleware_ErrorResponder 测试自定义错误响应
+func TestRecoveryMiddleware_ErrorResponder(t *testing.T) {
+	log, _ := logger.NewTestLogger()
+	var gotErr error
+	r := gin.New()
+	r.Use(RecoveryMiddleware(log, WithErrorResponder(func(c *gin.Context, status int, err error) {
+		gotErr = err
+		c.AbortWithStatusJSON(status, gin.H{"error": "oops", "id": c.Writer.Header().Get(RequestIDHeader)})
+	})))
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if w.Code != http.StatusInternalServerError || gotErr != ErrPanicRecovered {
+		t.Fatalf("status = %d, err = %v", w.Code, gotErr)
+	}
+	if w.Header().Get(RequestIDHeader) == "" {
+		t.Error("expected generated X-Request-ID")
+	}
+	if !strings.Contains(w.Body.String(), `"error":"oops"`) || strings.Contains(w.Body.String(), "boom") {
+		t.Errorf("body = %s", w.Body.String())
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package httpserver
+
+import (
+	"errors"
+	"syscall"
+)
+
+// reusePortSupported 当前平台不支持 SO_REUSEPORT,Config.Validate 拒绝 ReusePort
+const reusePortSupported = false
+
+// reusePortControl 当前平台不支持 SO_REUSEPORT
+func reusePortControl(network, address string, c syscall.RawConn) error {
+	return errors.New(ErrMsgReusePortUnsupported)
+}
@@ -0,0 +1,29 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package httpserver
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// reusePortSupported 当前平台支持 SO_REUSEPORT
+const reusePortSupported = true
+
+// reusePortControl 在 bind 之前为 socket 设置 SO_REUSEADDR 和 SO_REUSEPORT
+// 设置后多个进程可以同时监听同一个地址,内核在它们之间分发新连接
+// 用作 net.ListenConfig.Control
+func reusePortControl(network, address string, c syscall.RawConn) error {
+	var sockErr error
+	err := c.Control(func(fd uintptr) {
+		if sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); sockErr != nil {
+			return
+		}
+		sockErr = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_REUSEPORT, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
@@ -0,0 +1,159 @@
+package httpserver
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// slowRequestHandler 记录慢请求的 http.Handler 包装
+// 由 newServer 在 Config.SlowRequestThreshold > 0 时自动包装在路由外层,
+// 无需在路由上注册中间件;与 LoggingMiddleware 不同,它只记录超过阈值的请求,
+// 且不受 WithSkipPaths 影响,健康检查变慢同样值得告警
+type slowRequestHandler struct {
+	// next 被包装的处理器 (Gin Router)
+	next http.Handler
+
+	// threshold 慢请求阈值
+	threshold time.Duration
+
+	// logger 日志记录器
+	logger logger.Logger
+}
+
+// newSlowRequestHandler 创建慢请求记录包装
+// 参数:
+//
+//	next: 被包装的处理器
+//	threshold: 慢请求阈值,小于等于 0 时直接返回 next
+//	log: 日志记录器
+//
+// 返回:
+//
+//	http.Handler: 包装后的处理器
+func newSlowRequestHandler(next http.Handler, threshold time.Duration, log logger.Logger) http.Handler {
+	if threshold <= 0 || log == nil {
+		return next
+	}
+	return &slowRequestHandler{next: next, threshold: threshold, logger: log}
+}
+
+// ServeHTTP 实现 http.Handler 接口
+// 请求处理完成后耗时超过阈值时输出 Warn 日志
+func (h *slowRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	sw := &statusWriter{ResponseWriter: w}
+	h.next.ServeHTTP(sw, r)
+
+	elapsed := time.Since(start)
+	if elapsed <= h.threshold {
+		return
+	}
+
+	h.logger.Warn("slow request",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", sw.statusCode(),
+		"latency", elapsed.String(),
+		"latencyMs", elapsed.Milliseconds(),
+		"thresholdMs", h.threshold.Milliseconds(),
+		"requestId", r.Header.Get(RequestIDHeader),
+	)
+}
+
+// statusWriter 记录响应状态码的 http.ResponseWriter 包装
+// 透传 Flush、Hijack、CloseNotify、Push 和 ReadFrom,保证流式响应、SSE
+// 和 WebSocket 升级不受影响 (Gin 的 Stream 会对 ResponseWriter 断言 http.CloseNotifier)
+type statusWriter struct {
+	http.ResponseWriter
+
+	// status 已写入的状态码,0 表示尚未写入
+	status int
+}
+
+// WriteHeader 记录状态码
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write 未显式调用 WriteHeader 时状态码为 200
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush 实现 http.Flusher 接口
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack 实现 http.Hijacker 接口
+// 连接被接管后状态码记为 101 (Switching Protocols)
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return hj.Hijack()
+}
+
+// CloseNotify 实现 http.CloseNotifier 接口
+// 底层 ResponseWriter 不支持时返回永不触发的通道
+func (w *statusWriter) CloseNotify() <-chan bool {
+	if cn, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return cn.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+// Push 实现 http.Pusher 接口 (HTTP/2 服务器推送)
+func (w *statusWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// ReadFrom 实现 io.ReaderFrom 接口
+// 底层 ResponseWriter 支持时可使用 sendfile 等零拷贝路径
+func (w *statusWriter) ReadFrom(r io.Reader) (int64, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		return rf.ReadFrom(r)
+	}
+	// 隐藏 ReadFrom,避免 io.Copy 递归调用自身
+	return io.Copy(struct{ io.Writer }{w.ResponseWriter}, r)
+}
+
+// Unwrap 返回底层 ResponseWriter,供 http.ResponseController 使用
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// statusCode 返回记录的状态码
+// 处理器未写入任何内容时 net/http 会返回 200
+func (w *statusWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
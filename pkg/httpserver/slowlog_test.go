@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/pkg/logger"
+)
+
+// newStreamRouter 创建使用 c.Stream 输出三段数据的路由
+func newStreamRouter() *gin.Engine {
+	r := gin.New()
+	r.GET("/stream", func(c *gin.Context) {
+		n := 0
+		c.Stream(func(w io.Writer) bool {
+			_, _ = w.Write([]byte("chunk\n"))
+			n++
+			return n < 3
+		})
+	})
+	return r
+}
+
+// TestSlowRequestHandler_Stream 测试慢请求包装不影响 c.Stream 流式响应
+func TestSlowRequestHandler_Stream(t *testing.T) {
+	log, sink := logger.NewTestLogger()
+	srv := httptest.NewServer(newSlowRequestHandler(newStreamRouter(), time.Nanosecond, log))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/stream")
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, body = %q", resp.StatusCode, body)
+	}
+	if string(body) != "chunk\nchunk\nchunk\n" {
+		t.Errorf("body = %q", body)
+	}
+	if !sink.Contains(logger.LevelWarn, "slow request") {
+		t.Error("expected slow request log")
+	}
+}
+
+// TestSlowRequestHandler_StreamRecorder 测试底层不支持 CloseNotify 时流式响应不会 panic
+func TestSlowRequestHandler_StreamRecorder(t *testing.T) {
+	log, _ := logger.NewTestLogger()
+	h := newSlowRequestHandler(newStreamRouter(), time.Nanosecond, log)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/stream", nil))
+
+	if w.Code != http.StatusOK || w.Body.String() != "chunk\nchunk\nchunk\n" {
+		t.Errorf("status = %d, body = %q", w.Code, w.Body.String())
+	}
+}
+
+// TestSlowRequestHandler_Status 测试慢请求日志记录处理器写入的状态码
+func TestSlowRequestHandler_Status(t *testing.T) {
+	log, sink := logger.NewTestLogger()
+	r := gin.New()
+	r.GET("/missing", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+	h := newSlowRequestHandler(r, time.Nanosecond, log)
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	entries := sink.Entries()
+	if len(entries) != 1 || entries[0].Fields["status"] != int64(http.StatusNotFound) {
+		t.Errorf("entries = %+v", entries)
+	}
+}
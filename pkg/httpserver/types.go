@@ -73,6 +73,37 @@ type Config struct {
 	// Keep-Alive 连接的最大空闲时间
 	// 超时后连接将被关闭
 	IdleTimeout time.Duration
+
+	// H2C 是否启用明文 HTTP/2 (h2c)
+	// 用于部署在 L7 代理之后、代理以明文 HTTP/2 转发的场景(如 gRPC-gateway)
+	// 同一端口仍然可以处理 HTTP/1.1 请求
+	// 本包不终结 TLS,HTTP/2 只能以 h2c 方式提供
+	H2C bool
+
+	// Network 监听的网络类型
+	// 可选值: "tcp"(默认)、"unix"
+	// "unix" 时监听 SocketPath 上的 Unix domain socket,Host 和 Port 不生效,
+	// 用于 sidecar/反向代理通过本地 socket 转发以及本机进程间通信
+	Network string
+
+	// SocketPath Unix socket 文件路径
+	// Network 为 "unix" 时必填,例如 "/run/app/http.sock"
+	// 启动时删除残留的 socket 文件,关闭时删除本次创建的 socket 文件;
+	// 路径被普通文件占用时启动失败
+	SocketPath string
+
+	// SlowRequestThreshold 慢请求阈值
+	// 请求处理耗时超过该值时以 Warn 级别记录 method、path、status 和耗时,
+	// 用于发现性能退化;与访问日志不同,慢请求总是被记录,不受采样和 WithSkipPaths 影响
+	// 0 表示不检测
+	SlowRequestThreshold time.Duration
+
+	// ReusePort 是否以 SO_REUSEPORT 监听 TCP 地址
+	// 启用后多个进程可以同时监听同一端口,用于无负载均衡器的零停机升级:
+	// 新版本进程启动并监听同一端口后,旧进程调用 Shutdown 排空请求再退出
+	// 同一进程内监听地址不变的 Reload 也会先启动新服务器再关闭旧服务器,不拒绝连接
+	// 仅支持 Linux、macOS 和 BSD 等支持 SO_REUSEPORT 的平台,且只对 TCP 生效
+	ReusePort bool
 }
 
 // Validate 验证配置是否有效
@@ -114,6 +145,51 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.SlowRequestThreshold < 0 {
+		return &ConfigError{
+			Field:   "SlowRequestThreshold",
+			Value:   c.SlowRequestThreshold,
+			Message: "slow request threshold must be non-negative",
+		}
+	}
+
+	// 网络类型验证
+	switch c.Network {
+	case NetworkTCP:
+	case NetworkUnix:
+		if c.SocketPath == "" {
+			return &ConfigError{
+				Field:   "SocketPath",
+				Value:   c.SocketPath,
+				Message: "socket path is required when network is unix",
+			}
+		}
+	default:
+		return &ConfigError{
+			Field:   "Network",
+			Value:   c.Network,
+			Message: "network must be tcp or unix",
+		}
+	}
+
+	// SO_REUSEPORT 验证
+	if c.ReusePort {
+		if c.Network == NetworkUnix {
+			return &ConfigError{
+				Field:   "ReusePort",
+				Value:   c.ReusePort,
+				Message: "reuse port is only supported for tcp",
+			}
+		}
+		if !reusePortSupported {
+			return &ConfigError{
+				Field:   "ReusePort",
+				Value:   c.ReusePort,
+				Message: ErrMsgReusePortUnsupported,
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -134,6 +210,9 @@ func (c *Config) ApplyDefaults() {
 	if c.IdleTimeout == 0 {
 		c.IdleTimeout = DefaultIdleTimeout
 	}
+	if c.Network == "" {
+		c.Network = DefaultNetwork
+	}
 }
 
 // ConfigError 配置错误
@@ -209,3 +288,34 @@ type Handler = http.Handler
 // 用于在创建 HTTPServer 时应用可选配置
 // 采用函数选项模式(Functional Options Pattern)提高API扩展性
 type Option func(*httpServer)
+
+// WithServerOptions 自定义底层 http.Server
+// 用于设置 Config 未覆盖的字段,如 MaxHeaderBytes、ConnState、BaseContext、ErrorLog
+// 回调在每次 Start 和 Reload 创建服务器后调用,因此自定义在热重载后依然生效
+// 注意:
+//
+//	Addr、Handler 和各项超时由本包根据 Config 管理,不应在回调中覆盖
+//	多次使用时按传入顺序依次调用
+//
+// 参数:
+//
+//	fn: 修改 http.Server 的回调
+//
+// 返回:
+//
+//	Option: 配置选项
+//
+// 使用示例:
+//
+//	server, err := httpserver.New(router, cfg, log,
+//	    httpserver.WithServerOptions(func(srv *http.Server) {
+//	        srv.MaxHeaderBytes = 64 << 10
+//	        srv.ErrorLog = log.StdLogger("error")
+//	    }))
+func WithServerOptions(fn func(*http.Server)) Option {
+	return func(s *httpServer) {
+		if fn != nil {
+			s.serverOptions = append(s.serverOptions, fn)
+		}
+	}
+}
@@ -128,7 +128,7 @@
 //   - Bundle 创建: 应用启动时创建一次
 //   - 消息加载: 启动时加载,不会影响运行时性能
 //   - 翻译查询: 使用内存中的 map,非常快速
-//   - Localizer: 每次翻译创建,开销很小
+//   - Localizer: 按语言缓存复用,LoadMessages 后自动失效
 //
 // # 线程安全
 //
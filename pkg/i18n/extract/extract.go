@@ -0,0 +1,158 @@
+// Package extract 提供从 Go 源码中提取 i18n 消息 ID 的工具
+// 通过 go/ast 静态扫描 T/MustT 调用中的字符串字面量消息 ID
+// 使用场景:
+// - 在测试中对比代码引用的消息 ID 与翻译文件,防止遗漏翻译
+// - 在 CI 中发现运行时才会暴露的"返回消息 ID"回退
+//
+// 使用示例:
+//
+//	ids, err := extract.ExtractMessageIDs("./internal")
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defined := make(map[string]bool)
+//	for _, id := range i18nInstance.MessageIDs("zh-CN") {
+//	    defined[id] = true
+//	}
+//	for _, id := range ids {
+//	    if !defined[id] {
+//	        t.Errorf("missing translation for %q", id)
+//	    }
+//	}
+package extract
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// translateFuncs 被识别为翻译调用的方法名
+var translateFuncs = map[string]bool{
+	"T":     true,
+	"MustT": true,
+}
+
+// ExtractMessageIDs 扫描目录下所有 Go 源文件,返回 T/MustT 调用中引用的消息 ID
+// 识别规则:
+//   - 调用形式为 x.T(...) 或 x.MustT(...)
+//   - i18n.I18n 的签名为 T(lang, messageID, ...),取第二个参数
+//   - utils.I18nUtils 的签名为 T(messageID, ...),只有一个参数,
+//     或第二个参数为 nil、复合字面量(模板数据)时取第一个参数
+//   - 第二个参数为变量等其他表达式时无法区分两种签名,整个调用被忽略,
+//     避免把 T("en", id) 中的语言代码误认为消息 ID
+//   - 只收集字符串字面量,变量形式的消息 ID 无法静态确定,会被忽略
+//
+// 会跳过 vendor、testdata、隐藏目录以及 _test.go 文件
+// 参数:
+//
+//	goSrcDir: 源码根目录
+//
+// 返回:
+//
+//	[]string: 去重并排序后的消息 ID
+//	error: 遍历或解析失败时的错误
+func ExtractMessageIDs(goSrcDir string) ([]string, error) {
+	fset := token.NewFileSet()
+	found := make(map[string]struct{})
+
+	err := filepath.WalkDir(goSrcDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != goSrcDir && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			if id, ok := messageIDFromCall(call); ok {
+				found[id] = struct{}{}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// messageIDFromCall 从 T/MustT 调用中提取消息 ID
+func messageIDFromCall(call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !translateFuncs[sel.Sel.Name] || len(call.Args) == 0 {
+		return "", false
+	}
+
+	// T(messageID)
+	if len(call.Args) == 1 {
+		return stringLiteral(call.Args[0])
+	}
+
+	// T(lang, messageID, ...)
+	if id, ok := stringLiteral(call.Args[1]); ok {
+		return id, true
+	}
+
+	// T(messageID, templateData): 第二个参数明显是模板数据
+	if isTemplateData(call.Args[1]) {
+		return stringLiteral(call.Args[0])
+	}
+	return "", false
+}
+
+// isTemplateData 判断表达式是否为 nil 或复合字面量(如 map[string]interface{}{...})
+// 这两种形式不可能是 T(lang, messageID) 中的消息 ID
+func isTemplateData(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name == "nil"
+	case *ast.CompositeLit:
+		return true
+	}
+	return false
+}
+
+// stringLiteral 如果表达式是字符串字面量,返回其值
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
@@ -0,0 +1,47 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestExtractMessageIDs 测试从源码中提取消息 ID
+func TestExtractMessageIDs(t *testing.T) {
+	dir := t.TempDir()
+	src := `package demo
+
+func handler(i18n translator, utils helper, lang, dynamic string) {
+	_ = i18n.T(lang, "user.created")
+	_ = i18n.MustT("zh-CN", "user.deleted", nil)
+	_ = utils.T("auth.failed")
+	_ = utils.T("auth.failed", nil)
+	_ = utils.T("auth.locked", map[string]interface{}{"Minutes": 5})
+	_ = i18n.T(lang, dynamic)
+	_ = i18n.T("en", dynamic)
+	_ = i18n.MustT("zh-CN", dynamic, nil)
+	_ = other("not.a.message")
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "demo.go"), []byte(src), 0o644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+	// 测试文件应被忽略
+	if err := os.WriteFile(filepath.Join(dir, "demo_test.go"), []byte(`package demo
+func f(i translator) { _ = i.T("x", "test.only") }
+`), 0o644); err != nil {
+		t.Fatalf("failed to write test source: %v", err)
+	}
+
+	ids, err := ExtractMessageIDs(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// T("en", dynamic) 中的语言代码不应被当作消息 ID
+	want := []string{"auth.failed", "auth.locked", "user.created", "user.deleted"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+}
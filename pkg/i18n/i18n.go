@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
@@ -67,6 +69,14 @@ type I18n interface {
 	// 返回:
 	//   error: 加载失败时的错误
 	LoadMessages(dir string) error
+
+	// MessageIDs 返回指定语言已加载的消息 ID 列表
+	// 用于检查翻译文件是否完整,例如与代码中引用的消息 ID 对比
+	// 参数:
+	//   lang: 语言代码
+	// 返回:
+	//   []string: 排序后的消息 ID 列表,语言未加载时返回空切片
+	MessageIDs(lang string) []string
 }
 
 // Config I18n 配置
@@ -100,6 +110,18 @@ type i18nImpl struct {
 	// supportedLanguages 支持的语言集合
 	// 使用 map 提高查询效率
 	supportedLanguages map[string]bool
+
+	// localizers 按语言缓存的本地化器 (lang -> *i18n.Localizer)
+	// 创建 Localizer 需要重新做语言匹配,高 QPS 下开销明显
+	// Bundle 不变时 Localizer 可以安全复用,LoadMessages 后清空
+	localizers sync.Map
+
+	// mu 保护 messageIDs
+	mu sync.RWMutex
+
+	// messageIDs 每种语言已加载的消息 ID 集合 (lang -> id set)
+	// go-i18n 的 Bundle 不提供消息列表,在加载文件时自行记录
+	messageIDs map[string]map[string]struct{}
 }
 
 // New 创建一个新的 I18n 实例
@@ -156,6 +178,7 @@ func New(cfg *Config) (I18n, error) {
 		bundle:             bundle,
 		defaultLanguage:    cfg.DefaultLanguage,
 		supportedLanguages: supportedLangs,
+		messageIDs:         make(map[string]map[string]struct{}),
 	}
 
 	// 如果指定了消息目录,加载翻译文件
@@ -171,13 +194,8 @@ func New(cfg *Config) (I18n, error) {
 // T 翻译消息
 // 实现 I18n 接口
 func (impl *i18nImpl) T(lang string, messageID string, templateData ...map[string]interface{}) string {
-	// 如果语言不支持,使用默认语言
-	if !impl.IsSupported(lang) {
-		lang = impl.defaultLanguage
-	}
-
-	// 创建本地化器
-	localizer := i18n.NewLocalizer(impl.bundle, lang)
+	// 获取本地化器(不支持的语言使用默认语言)
+	localizer := impl.localizer(lang)
 
 	// 构建配置
 	config := &i18n.LocalizeConfig{
@@ -203,13 +221,8 @@ func (impl *i18nImpl) T(lang string, messageID string, templateData ...map[strin
 // MustT 翻译消息,失败时 panic
 // 实现 I18n 接口
 func (impl *i18nImpl) MustT(lang string, messageID string, templateData ...map[string]interface{}) string {
-	// 如果语言不支持,使用默认语言
-	if !impl.IsSupported(lang) {
-		lang = impl.defaultLanguage
-	}
-
-	// 创建本地化器
-	localizer := i18n.NewLocalizer(impl.bundle, lang)
+	// 获取本地化器(不支持的语言使用默认语言)
+	localizer := impl.localizer(lang)
 
 	// 构建配置
 	config := &i18n.LocalizeConfig{
@@ -231,6 +244,23 @@ func (impl *i18nImpl) MustT(lang string, messageID string, templateData ...map[s
 	return msg
 }
 
+// localizer 获取指定语言的本地化器
+// 优先从缓存读取,不存在时创建并缓存
+// 如果语言不支持,使用默认语言
+func (impl *i18nImpl) localizer(lang string) *i18n.Localizer {
+	if !impl.IsSupported(lang) {
+		lang = impl.defaultLanguage
+	}
+
+	if cached, ok := impl.localizers.Load(lang); ok {
+		return cached.(*i18n.Localizer)
+	}
+
+	// 并发情况下可能重复创建,LoadOrStore 保证只保留一个
+	localizer, _ := impl.localizers.LoadOrStore(lang, i18n.NewLocalizer(impl.bundle, lang))
+	return localizer.(*i18n.Localizer)
+}
+
 // IsSupported 检查语言是否被支持
 // 实现 I18n 接口
 func (impl *i18nImpl) IsSupported(lang string) bool {
@@ -295,9 +325,11 @@ func (impl *i18nImpl) LoadMessages(dir string) error {
 
 		// 加载翻译文件
 		fullPath := filepath.Join(dir, filename)
-		if _, err := impl.bundle.LoadMessageFile(fullPath); err != nil {
+		messageFile, err := impl.bundle.LoadMessageFile(fullPath)
+		if err != nil {
 			return fmt.Errorf("failed to load message file %s: %w", filename, err)
 		}
+		impl.recordMessageIDs(messageFile)
 
 		loaded++
 	}
@@ -307,9 +339,42 @@ func (impl *i18nImpl) LoadMessages(dir string) error {
 		return fmt.Errorf("no message files found in directory: %s", dir)
 	}
 
+	// 消息已变化,清空本地化器缓存
+	impl.localizers.Clear()
+
 	return nil
 }
 
+// MessageIDs 返回指定语言已加载的消息 ID 列表
+// 实现 I18n 接口
+func (impl *i18nImpl) MessageIDs(lang string) []string {
+	impl.mu.RLock()
+	defer impl.mu.RUnlock()
+
+	ids := make([]string, 0, len(impl.messageIDs[lang]))
+	for id := range impl.messageIDs[lang] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// recordMessageIDs 记录消息文件中定义的消息 ID
+func (impl *i18nImpl) recordMessageIDs(messageFile *i18n.MessageFile) {
+	impl.mu.Lock()
+	defer impl.mu.Unlock()
+
+	lang := messageFile.Tag.String()
+	ids, ok := impl.messageIDs[lang]
+	if !ok {
+		ids = make(map[string]struct{}, len(messageFile.Messages))
+		impl.messageIDs[lang] = ids
+	}
+	for _, msg := range messageFile.Messages {
+		ids[msg.ID] = struct{}{}
+	}
+}
+
 // Default 创建一个使用默认配置的 I18n 实例
 // 默认配置:
 //   - 默认语言: zh-CN
@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+)
+
+// newTestI18n 创建加载了临时翻译文件的 I18n 实例
+func newTestI18n(tb testing.TB) *i18nImpl {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	files := map[string]string{
+		"zh-CN.yaml": "greeting: \"你好, {{.Name}}\"\n",
+		"en-US.yaml": "greeting: \"Hello, {{.Name}}\"\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			tb.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	impl, err := New(&Config{
+		DefaultLanguage:    LanguageChinese,
+		SupportedLanguages: []string{LanguageChinese, LanguageEnglish},
+		MessagesDir:        dir,
+	})
+	if err != nil {
+		tb.Fatalf("failed to create i18n: %v", err)
+	}
+	return impl.(*i18nImpl)
+}
+
+// TestT_CachedLocalizer 测试缓存的本地化器翻译结果正确
+func TestT_CachedLocalizer(t *testing.T) {
+	impl := newTestI18n(t)
+	data := map[string]interface{}{"Name": "Alice"}
+
+	for i := 0; i < 2; i++ {
+		if got := impl.T(LanguageEnglish, "greeting", data); got != "Hello, Alice" {
+			t.Fatalf("unexpected translation: %q", got)
+		}
+		if got := impl.T(LanguageChinese, "greeting", data); got != "你好, Alice" {
+			t.Fatalf("unexpected translation: %q", got)
+		}
+	}
+
+	// 不支持的语言回退到默认语言
+	if got := impl.T(LanguageJapanese, "greeting", data); got != "你好, Alice" {
+		t.Fatalf("unexpected fallback translation: %q", got)
+	}
+}
+
+// TestLoadMessages_InvalidatesCache 测试重新加载消息后缓存失效
+func TestLoadMessages_InvalidatesCache(t *testing.T) {
+	impl := newTestI18n(t)
+	_ = impl.T(LanguageEnglish, "greeting")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "en-US.yaml"), []byte("farewell: \"Bye\"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := impl.LoadMessages(dir); err != nil {
+		t.Fatalf("failed to load messages: %v", err)
+	}
+
+	if _, ok := impl.localizers.Load(LanguageEnglish); ok {
+		t.Fatal("expected localizer cache to be cleared")
+	}
+	if got := impl.T(LanguageEnglish, "farewell"); got != "Bye" {
+		t.Fatalf("unexpected translation: %q", got)
+	}
+}
+
+// BenchmarkT 基准测试: 复用缓存的本地化器
+func BenchmarkT(b *testing.B) {
+	impl := newTestI18n(b)
+	data := map[string]interface{}{"Name": "Alice"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = impl.T(LanguageEnglish, "greeting", data)
+	}
+}
+
+// BenchmarkT_Uncached 基准测试: 每次翻译都创建本地化器(缓存前的行为)
+func BenchmarkT_Uncached(b *testing.B) {
+	impl := newTestI18n(b)
+	data := map[string]interface{}{"Name": "Alice"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		localizer := goi18n.NewLocalizer(impl.bundle, LanguageEnglish)
+		_, _ = localizer.Localize(&goi18n.LocalizeConfig{MessageID: "greeting", TemplateData: data})
+	}
+}
+
+// TestMessageIDs 测试按语言列出已加载的消息 ID
+func TestMessageIDs(t *testing.T) {
+	impl := newTestI18n(t)
+
+	ids := impl.MessageIDs(LanguageEnglish)
+	if len(ids) != 1 || ids[0] != "greeting" {
+		t.Fatalf("unexpected message IDs: %v", ids)
+	}
+	if ids := impl.MessageIDs(LanguageJapanese); len(ids) != 0 {
+		t.Fatalf("expected no message IDs, got %v", ids)
+	}
+}
@@ -0,0 +1,121 @@
+package jwt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"net"
+	"strings"
+)
+
+// WithBindIP 将令牌绑定到客户端 IP
+// 载荷中只写入 IP 的 HMAC 摘要,不暴露原始 IP
+// 绑定后需使用 ValidateTokenBound 验证,IP 不一致时返回 ErrTokenBindingMismatch;
+// ValidateToken 遇到绑定令牌时返回 ErrTokenBound
+// 注意: 移动网络和部分代理下客户端 IP 会频繁变化,适合内网或固定出口的场景
+// 参数:
+//
+//	ip: 客户端 IP,为空时不绑定
+//
+// 使用示例:
+//
+//	token, err := j.GenerateTokenWithOptions(userID, username, jwt.WithBindIP(c.ClientIP()))
+func WithBindIP(ip string) TokenOption {
+	return func(o *tokenOptions) {
+		o.bindIP = normalizeIP(ip)
+	}
+}
+
+// WithBindDevice 将令牌绑定到设备
+// 载荷中只写入设备 ID 的 HMAC 摘要
+// 绑定后需使用 ValidateTokenBound 验证,设备不一致时返回 ErrTokenBindingMismatch
+// 参数:
+//
+//	deviceID: 设备 ID,可使用 utils.GenerateDeviceID 生成,为空时不绑定
+//
+// 使用示例:
+//
+//	token, err := j.GenerateTokenWithOptions(userID, username,
+//	    jwt.WithBindDevice(req.DeviceID))
+func WithBindDevice(deviceID string) TokenOption {
+	return func(o *tokenOptions) {
+		o.bindDevice = strings.TrimSpace(deviceID)
+	}
+}
+
+// ValidateTokenBound 验证令牌并检查绑定
+// 实现JWT接口的ValidateTokenBound方法
+// 参数:
+//
+//	tokenString: JWT token字符串
+//	ip: 当前请求的客户端 IP
+//	deviceID: 当前请求的设备 ID
+//
+// 返回:
+//
+//	*Claims: 解析后的载荷信息
+//	error: ValidateToken 的错误,或绑定不一致时的 ErrTokenBindingMismatch
+//
+// 说明:
+//
+//	只检查令牌中存在的绑定:只绑定了 IP 的令牌忽略 deviceID,反之亦然
+//	未绑定的令牌与 ValidateToken 行为一致
+func (m *jwtManager) ValidateTokenBound(tokenString, ip, deviceID string) (*Claims, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	claims, err := m.parseClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.TokenType == TokenTypeRefresh {
+		return nil, ErrInvalidTokenType
+	}
+
+	if !m.bindingMatches(claims.BindIP, bindingPurposeIP, normalizeIP(ip)) ||
+		!m.bindingMatches(claims.BindDevice, bindingPurposeDevice, strings.TrimSpace(deviceID)) {
+		return nil, ErrTokenBindingMismatch
+	}
+	return claims, nil
+}
+
+// isBound 报告令牌是否带有 IP 或设备绑定
+func (c *Claims) isBound() bool {
+	return c.BindIP != "" || c.BindDevice != ""
+}
+
+// bindingMatches 检查绑定摘要是否与当前值一致
+// 令牌未绑定该项时返回 true
+// 调用方需持有读锁
+func (m *jwtManager) bindingMatches(bound, purpose, value string) bool {
+	if bound == "" {
+		return true
+	}
+	if value == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(bound), []byte(m.bindingDigest(purpose, value))) == 1
+}
+
+// bindingDigest 计算绑定值的摘要
+// 使用签名密钥做 HMAC,载荷中的摘要无法被离线反推或伪造
+// purpose 区分 IP 和设备,避免两类值互相冒用
+// 调用方需持有读锁
+func (m *jwtManager) bindingDigest(purpose, value string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(purpose))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:bindingDigestBytes])
+}
+
+// normalizeIP 规范化 IP 地址
+// 同一地址的不同写法(如 IPv4 映射的 IPv6)得到相同结果,无法解析时原样返回
+func normalizeIP(ip string) string {
+	ip = strings.TrimSpace(ip)
+	if parsed := net.ParseIP(ip); parsed != nil {
+		return parsed.String()
+	}
+	return ip
+}
@@ -0,0 +1,114 @@
+package jwt
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestValidateTokenBound 测试 IP/设备绑定的匹配与不匹配
+func TestValidateTokenBound(t *testing.T) {
+	j := newTestJWT(t, 0)
+
+	token, err := j.GenerateTokenWithOptions(1, "alice",
+		WithBindIP("10.0.0.1"), WithBindDevice("device-a"))
+	if err != nil {
+		t.Fatalf("GenerateTokenWithOptions() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		ip       string
+		deviceID string
+		want     error
+	}{
+		{"match", "10.0.0.1", "device-a", nil},
+		{"match mapped ipv6", "::ffff:10.0.0.1", "device-a", nil},
+		{"ip mismatch", "10.0.0.2", "device-a", ErrTokenBindingMismatch},
+		{"device mismatch", "10.0.0.1", "device-b", ErrTokenBindingMismatch},
+		{"device missing", "10.0.0.1", "", ErrTokenBindingMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := j.ValidateTokenBound(token, tt.ip, tt.deviceID)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateTokenBound() error = %v, want %v", err, tt.want)
+			}
+			if err == nil && claims.Username != "alice" {
+				t.Errorf("ValidateTokenBound() username = %q, want alice", claims.Username)
+			}
+		})
+	}
+
+	// 未绑定的令牌与 ValidateToken 行为一致
+	plain, _ := j.GenerateToken(1, "alice")
+	if _, err := j.ValidateTokenBound(plain, "", ""); err != nil {
+		t.Errorf("ValidateTokenBound() on unbound token error = %v", err)
+	}
+}
+
+// TestValidateTokenRejectsBound 测试无法检查绑定的验证方法拒绝绑定令牌
+func TestValidateTokenRejectsBound(t *testing.T) {
+	j := newTestJWT(t, 8)
+
+	ipBound, _ := j.GenerateTokenWithOptions(1, "alice", WithBindIP("10.0.0.1"))
+	deviceBound, _ := j.GenerateTokenWithOptions(1, "alice", WithBindDevice("device-a"))
+	for name, token := range map[string]string{"ip": ipBound, "device": deviceBound} {
+		if _, err := j.ValidateToken(token); !errors.Is(err, ErrTokenBound) {
+			t.Errorf("ValidateToken(%s bound) error = %v, want ErrTokenBound", name, err)
+		}
+		if _, err := j.ValidateTokenCached(token); !errors.Is(err, ErrTokenBound) {
+			t.Errorf("ValidateTokenCached(%s bound) error = %v, want ErrTokenBound", name, err)
+		}
+	}
+	if n := j.cache.len(); n != 0 {
+		t.Errorf("bound tokens should not be cached, got %d entries", n)
+	}
+}
+
+// TestMiddlewareBinding 测试中间件使用请求的 IP 和设备请求头检查绑定
+func TestMiddlewareBinding(t *testing.T) {
+	j := newTestJWT(t, 0)
+	token, _ := j.GenerateTokenWithOptions(1, "alice",
+		WithBindIP("10.0.0.1"), WithBindDevice("device-a"))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var reason error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		if last := c.Errors.Last(); last != nil {
+			reason = last.Err
+		}
+	})
+	router.GET("/", MiddlewareWithExtractor(j, nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		deviceID   string
+		wantCode   int
+		wantErr    error
+	}{
+		{"match", "10.0.0.1:1234", "device-a", http.StatusOK, nil},
+		{"other ip", "10.0.0.2:1234", "device-a", http.StatusUnauthorized, ErrTokenBindingMismatch},
+		{"other device", "10.0.0.1:1234", "device-b", http.StatusUnauthorized, ErrTokenBindingMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason = nil
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(AuthorizationHeader, BearerScheme+" "+token)
+			req.Header.Set(DeviceIDHeader, tt.deviceID)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantCode || !errors.Is(reason, tt.wantErr) {
+				t.Errorf("status = %d, reason = %v, want %d and %v", w.Code, reason, tt.wantCode, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package jwt
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// validationCache 令牌验证结果的 LRU 缓存
+// 以令牌的 SHA-256 摘要为键,缓存验证通过的 Claims 直到令牌自身的 exp,
+// 同一令牌重复出现时跳过解析和签名验证
+// 设计要点:
+//   - 只缓存验证成功的结果,失败的令牌每次都重新验证
+//   - 命中时检查 exp,过期条目立即删除并重新验证,缓存不会延长令牌有效期
+//   - 容量满时淘汰最久未使用的条目
+//
+// 线程安全: 使用互斥锁保护,LRU 的读操作也会修改链表
+type validationCache struct {
+	mu sync.Mutex
+
+	// capacity 最大条目数
+	capacity int
+
+	// ll 按最近使用排序的链表,表头为最近使用
+	ll *list.List
+
+	// items 令牌摘要到链表节点的索引
+	items map[[sha256.Size]byte]*list.Element
+}
+
+// cacheEntry 缓存条目
+type cacheEntry struct {
+	// key 令牌摘要,淘汰时用于删除索引
+	key [sha256.Size]byte
+
+	// claims 验证通过的载荷
+	claims *Claims
+
+	// expiresAt 令牌的过期时间 (exp)
+	expiresAt time.Time
+}
+
+// newValidationCache 创建验证缓存
+// 参数:
+//
+//	capacity: 最大条目数
+func newValidationCache(capacity int) *validationCache {
+	return &validationCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[[sha256.Size]byte]*list.Element),
+	}
+}
+
+// get 查找令牌的缓存结果
+// 返回:
+//
+//	*Claims: 载荷副本,调用方修改不会影响缓存
+//	bool: 未命中或条目已过期时返回 false
+func (c *validationCache) get(token string, now time.Time) (*Claims, bool) {
+	key := sha256.Sum256([]byte(token))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+	if !now.Before(entry.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	return cloneClaims(entry.claims), true
+}
+
+// add 缓存验证通过的令牌
+// 没有 exp 的令牌无法确定缓存期限,不缓存
+func (c *validationCache) add(token string, claims *Claims) {
+	if claims.ExpiresAt == nil {
+		return
+	}
+	key := sha256.Sum256([]byte(token))
+
+	// 保存副本,调用方修改返回的 Claims 不会影响缓存
+	entry := &cacheEntry{key: key, claims: cloneClaims(claims), expiresAt: claims.ExpiresAt.Time}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		el.Value = entry
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(entry)
+	if c.ll.Len() > c.capacity {
+		c.remove(c.ll.Back())
+	}
+}
+
+// len 返回当前条目数
+func (c *validationCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// remove 删除条目,调用方需持有锁
+func (c *validationCache) remove(el *list.Element) {
+	c.ll.Remove(el)
+	delete(c.items, el.Value.(*cacheEntry).key)
+}
+
+// ValidateTokenCached 验证令牌,重复验证同一令牌时使用缓存结果
+// 实现JWT接口的ValidateTokenCached方法
+// 参数:
+//
+//	tokenString: JWT token字符串
+//
+// 返回:
+//
+//	*Claims: 解析后的载荷信息,每次返回新的副本
+//	error: 与 ValidateToken 相同
+//
+// 注意:
+//
+//	ValidateToken 是无状态校验(签名、时间、受众、令牌类型),只依赖令牌本身和不可变的配置,
+//	因此在 exp 之前缓存结果与重新验证一致;本包没有访问令牌黑名单,
+//	如果在外部按 jti 吊销令牌,需要在缓存命中后同样检查黑名单
+func (m *jwtManager) ValidateTokenCached(tokenString string) (*Claims, error) {
+	if claims, ok := m.cache.get(tokenString, time.Now()); ok {
+		return claims, nil
+	}
+
+	claims, err := m.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	m.cache.add(tokenString, claims)
+	return claims, nil
+}
+
+// cloneClaims 深拷贝 Claims
+// 结构体赋值只复制 Audience 切片头和时间声明指针,需要单独复制,
+// 否则修改返回值(如 claims.Audience[0]、*claims.ExpiresAt)会改动缓存中的条目
+func cloneClaims(c *Claims) *Claims {
+	clone := *c
+	clone.Audience = slices.Clone(c.Audience)
+	clone.ExpiresAt = cloneNumericDate(c.ExpiresAt)
+	clone.IssuedAt = cloneNumericDate(c.IssuedAt)
+	clone.NotBefore = cloneNumericDate(c.NotBefore)
+	return &clone
+}
+
+// cloneNumericDate 复制时间声明,nil 保持为 nil
+func cloneNumericDate(d *jwt.NumericDate) *jwt.NumericDate {
+	if d == nil {
+		return nil
+	}
+	clone := *d
+	return &clone
+}
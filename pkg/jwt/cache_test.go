@@ -0,0 +1,101 @@
+package jwt
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newTestJWT 创建测试用 JWT 管理器
+func newTestJWT(tb testing.TB, cacheSize int) *jwtManager {
+	tb.Helper()
+	j, err := New(&Config{Secret: "0123456789abcdef0123456789abcdef", ValidationCacheSize: cacheSize})
+	if err != nil {
+		tb.Fatalf("New() error = %v", err)
+	}
+	return j.(*jwtManager)
+}
+
+// TestValidateTokenCached 测试缓存命中、淘汰和过期
+func TestValidateTokenCached(t *testing.T) {
+	j := newTestJWT(t, 2)
+
+	token, _ := j.GenerateTokenWithOptions(1, "alice", WithAudience("api"))
+	claims, err := j.ValidateTokenCached(token)
+	if err != nil || claims.Username != "alice" {
+		t.Fatalf("ValidateTokenCached() = %+v, %v", claims, err)
+	}
+
+	// 修改返回值不影响缓存,包括切片和时间指针
+	exp := claims.ExpiresAt.Time
+	aud := slices.Clone(claims.Audience)
+	claims.Username = "mallory"
+	claims.ExpiresAt.Time = exp.Add(time.Hour)
+	claims.IssuedAt.Time = time.Time{}
+	claims.Audience[0] = "evil"
+	cached, _ := j.ValidateTokenCached(token)
+	if cached.Username != "alice" {
+		t.Errorf("cached claims were mutated: %q", cached.Username)
+	}
+	if !cached.ExpiresAt.Equal(exp) || cached.IssuedAt.IsZero() || !slices.Equal(cached.Audience, aud) {
+		t.Errorf("cached claims share memory with returned claims: %+v", cached)
+	}
+
+	// 失败的验证不缓存
+	if _, err := j.ValidateTokenCached(token + "x"); !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Errorf("expected ErrTokenSignatureInvalid, got %v", err)
+	}
+	if n := j.cache.len(); n != 1 {
+		t.Errorf("expected 1 cached token, got %d", n)
+	}
+
+	// 超出容量时淘汰最久未使用的令牌
+	for i := int64(2); i <= 3; i++ {
+		tok, _ := j.GenerateToken(i, "user")
+		if _, err := j.ValidateTokenCached(tok); err != nil {
+			t.Fatalf("ValidateTokenCached() error = %v", err)
+		}
+	}
+	if n := j.cache.len(); n != 2 {
+		t.Errorf("expected cache capped at 2, got %d", n)
+	}
+	if _, ok := j.cache.get(token, time.Now()); ok {
+		t.Error("least recently used token should be evicted")
+	}
+
+	// 缓存不会延长令牌有效期
+	short, _ := j.GenerateTokenWithOptions(4, "bob", WithTTL(time.Second))
+	if _, err := j.ValidateTokenCached(short); err != nil {
+		t.Fatalf("ValidateTokenCached() error = %v", err)
+	}
+	if _, ok := j.cache.get(short, time.Now().Add(2*time.Second)); ok {
+		t.Error("expired token should not be served from cache")
+	}
+}
+
+// BenchmarkValidateToken 每次都解析并验证签名
+func BenchmarkValidateToken(b *testing.B) {
+	j := newTestJWT(b, 0)
+	token, _ := j.GenerateToken(1, "alice")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := j.ValidateToken(token); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkValidateTokenCached 同一令牌重复验证,命中缓存
+func BenchmarkValidateTokenCached(b *testing.B) {
+	j := newTestJWT(b, 0)
+	token, _ := j.GenerateToken(1, "alice")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := j.ValidateTokenCached(token); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package jwt
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // 默认配置
 const (
@@ -9,6 +13,60 @@ const (
 
 	// DefaultIssuer 默认签发者
 	DefaultIssuer = "go-scaffold"
+
+	// DefaultRefreshExpiresIn 默认刷新令牌过期时间（7天）
+	DefaultRefreshExpiresIn = 7 * 24 * 3600
+
+	// DefaultValidationCacheSize ValidateTokenCached 默认缓存的最大令牌数
+	DefaultValidationCacheSize = 10000
+
+	// DerivedKeyLength WithHKDF 派生的签名密钥字节数
+	// 与 HMAC-SHA256 的输出长度相同
+	DerivedKeyLength = 32
+)
+
+// 令牌类型
+const (
+	// TokenTypeRefresh 刷新令牌
+	TokenTypeRefresh = "refresh"
+)
+
+// HTTP 常量
+const (
+	// AuthorizationHeader 携带令牌的请求头
+	AuthorizationHeader = "Authorization"
+
+	// BearerScheme Authorization 请求头的认证方案
+	BearerScheme = "Bearer"
+
+	// TokenExpiresInHeader 访问令牌剩余有效期(秒)的响应头
+	// 由 MiddlewareWithExtractor 设置,客户端据此在令牌过期前主动刷新
+	TokenExpiresInHeader = "X-Token-Expires-In"
+
+	// DeviceIDHeader 携带设备 ID 的请求头
+	// 认证中间件读取该请求头,与绑定了设备的令牌(WithBindDevice)比对
+	DeviceIDHeader = "X-Device-ID"
+
+	// ContextKeyClaims MiddlewareWithExtractor 在 Gin 上下文中存放 Claims 的键
+	ContextKeyClaims = "jwt_claims"
+)
+
+// 内部常量
+const (
+	// tokenIDBytes jti 和令牌家族标识的随机字节数
+	tokenIDBytes = 16
+
+	// refreshStoreSweepInterval 内存刷新令牌存储清理过期记录的间隔
+	refreshStoreSweepInterval = time.Minute
+
+	// bindingDigestBytes 绑定摘要保留的 HMAC 字节数
+	bindingDigestBytes = 16
+
+	// bindingPurposeIP IP 绑定摘要的用途标识
+	bindingPurposeIP = "ip"
+
+	// bindingPurposeDevice 设备绑定摘要的用途标识
+	bindingPurposeDevice = "device"
 )
 
 // 预定义错误
@@ -16,17 +74,68 @@ var (
 	// ErrInvalidToken token 无效
 	ErrInvalidToken = errors.New("invalid token")
 
+	// ErrTokenExpired token 已过期
+	// 令牌本身合法,调用方可以提示客户端使用刷新令牌换取新令牌
+	ErrTokenExpired = errors.New(ErrMsgExpiredToken)
+
+	// ErrTokenNotValidYet token 尚未生效(nbf 晚于当前时间)
+	// 通常是签发方与本机时钟不一致
+	ErrTokenNotValidYet = errors.New(ErrMsgTokenNotYetValid)
+
+	// ErrTokenSignatureInvalid 签名无效
+	// 令牌被篡改或使用了错误的密钥,应直接拒绝并视为可疑请求
+	ErrTokenSignatureInvalid = errors.New(ErrMsgInvalidSignature)
+
+	// ErrTokenMalformed token 格式错误
+	// 不是合法的 JWT(分段数量、Base64 或 JSON 错误),应直接拒绝
+	// 包装了 ErrInvalidToken,errors.Is(err, ErrInvalidToken) 同样成立
+	ErrTokenMalformed = fmt.Errorf("%w: %s", ErrInvalidToken, ErrMsgTokenMalformed)
+
 	// ErrExpiredToken token 已过期
-	ErrExpiredToken = errors.New("token has expired")
+	//
+	// Deprecated: 使用 ErrTokenExpired
+	ErrExpiredToken = ErrTokenExpired
 
 	// ErrTokenNotYetValid token 尚未生效
-	ErrTokenNotYetValid = errors.New("token not yet valid")
+	//
+	// Deprecated: 使用 ErrTokenNotValidYet
+	ErrTokenNotYetValid = ErrTokenNotValidYet
 
 	// ErrInvalidSignature 签名无效
-	ErrInvalidSignature = errors.New("invalid signature")
+	//
+	// Deprecated: 使用 ErrTokenSignatureInvalid
+	ErrInvalidSignature = ErrTokenSignatureInvalid
 
 	// ErrMissingSecret 缺少签名密钥
 	ErrMissingSecret = errors.New("jwt secret is required")
+
+	// ErrInvalidAudience 受众不匹配
+	ErrInvalidAudience = errors.New("invalid token audience")
+
+	// ErrInvalidTokenType 令牌类型不匹配
+	// 刷新令牌用作访问令牌,或访问令牌用于轮换时返回
+	ErrInvalidTokenType = errors.New("invalid token type")
+
+	// ErrRefreshTokenReused 刷新令牌被重复使用
+	// 已轮换的旧令牌再次出现,可能已被窃取,整个令牌家族已被撤销
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+
+	// ErrTokenBindingMismatch 令牌绑定不匹配
+	// 令牌绑定的 IP 或设备与当前请求不一致,可能是令牌被盗用
+	ErrTokenBindingMismatch = errors.New("token binding mismatch")
+
+	// ErrTokenBound 令牌带有 IP/设备绑定
+	// ValidateToken 和 ValidateTokenCached 无法检查绑定,遇到绑定令牌时返回该错误,
+	// 调用方应改用 ValidateTokenBound 传入当前请求的 IP 和设备 ID
+	ErrTokenBound = errors.New("token is bound, use ValidateTokenBound")
+
+	// ErrTokenNotFound 请求中没有令牌
+	// TokenExtractor 在对应来源找不到令牌时返回
+	ErrTokenNotFound = errors.New("token not found in request")
+
+	// ErrInvalidAuthHeader Authorization 请求头格式错误
+	// 不是 "Bearer <token>" 格式时返回
+	ErrInvalidAuthHeader = errors.New("invalid authorization header")
 )
 
 // 错误消息常量
@@ -43,9 +152,31 @@ const (
 	// ErrMsgInvalidSignature 签名无效错误消息
 	ErrMsgInvalidSignature = "invalid signature"
 
+	// ErrMsgTokenMalformed token 格式错误消息
+	ErrMsgTokenMalformed = "token is malformed"
+
+	// ErrMsgInvalidAudience 受众不匹配错误消息
+	ErrMsgInvalidAudience = "invalid token audience"
+
 	// ErrMsgMissingSecret 缺少密钥错误消息
 	ErrMsgMissingSecret = "jwt secret is required"
 
 	// ErrMsgSecretTooShort 密钥太短错误消息
 	ErrMsgSecretTooShort = "jwt secret must be at least 32 characters"
+
+	// ErrMsgDeriveKeyFailed 派生签名密钥失败错误消息模板
+	ErrMsgDeriveKeyFailed = "failed to derive jwt signing key: %w"
+
+	// ErrMsgTokenNotFound 缺少令牌的响应消息
+	ErrMsgTokenNotFound = "Missing authorization token"
+
+	// ErrMsgInvalidAuthHeader 认证头格式错误的响应消息
+	ErrMsgInvalidAuthHeader = "Invalid authorization format"
+
+	// ErrMsgInvalidOrExpiredToken 令牌验证失败的响应消息
+	// 不区分无效和过期,避免向客户端泄露具体原因
+	ErrMsgInvalidOrExpiredToken = "Invalid or expired token"
+
+	// ErrMsgRefreshStoreFailed 刷新令牌存储访问失败错误消息模板
+	ErrMsgRefreshStoreFailed = "refresh token store failed: %w"
 )
@@ -0,0 +1,36 @@
+package jwt
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DecodeUnverified 解码令牌载荷,不验证签名和任何声明
+// 警告: 返回的 Claims 完全由令牌持有者控制,任何人都可以伪造,
+// 绝不能用于认证或授权,认证请使用 ValidateToken
+// 使用场景:
+//   - 只记录日志的网关,没有签名密钥
+//   - 管理 CLI 显示"令牌属于谁、何时过期"
+//   - 排查问题时查看令牌内容
+//
+// 参数:
+//
+//	tokenString: JWT token字符串
+//
+// 返回:
+//
+//	*Claims: 未经验证的载荷
+//	error: 不是三段式 JWT 或载荷无法解码时返回 ErrInvalidToken
+//
+// 使用示例:
+//
+//	claims, err := jwt.DecodeUnverified(token)
+//	if err == nil {
+//	    log.Info("token info", "userId", claims.UserID, "exp", claims.ExpiresAt)
+//	}
+func DecodeUnverified(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
@@ -50,32 +50,65 @@ JWT是一种开放标准(RFC 7519),用于在各方之间安全地传输信息。
 	}
 	fmt.Printf("UserID: %d, Username: %s\n", claims.UserID, claims.Username)
 
-与HTTP中间件配合使用:
+为不同用途的令牌设置不同的有效期和受众:
 
-	import (
-		"github.com/gin-gonic/gin"
-		"github.com/rei0721/go-scaffold/pkg/jwt"
+	confirmToken, err := jwtManager.GenerateTokenWithOptions(12345, "john_doe",
+		jwt.WithTTL(15*time.Minute),
+		jwt.WithAudience("email-confirm"),
+	)
+
+绑定 IP/设备（令牌在其他 IP 或设备上使用时返回 ErrTokenBindingMismatch，
+绑定令牌传给 ValidateToken 时返回 ErrTokenBound）:
+
+	token, err := jwtManager.GenerateTokenWithOptions(12345, "john_doe",
+		jwt.WithBindIP(clientIP),
+		jwt.WithBindDevice(deviceID),
 	)
+	claims, err := jwtManager.ValidateTokenBound(token, clientIP, deviceID)
+
+高吞吐网关缓存验证结果（LRU，缓存到令牌自身的 exp，不会延长有效期）:
+
+	claims, err := jwtManager.ValidateTokenCached(token)
+
+内省令牌状态（过期令牌返回 Active=false 而不是错误）:
+
+	info, err := jwtManager.Introspect(token)
+	if err == nil && !info.Active {
+		// 签名有效但已过期、未生效或受众不匹配
+	}
 
-	func AuthMiddleware(jwtManager jwt.JWT) gin.HandlerFunc {
-		return func(c *gin.Context) {
-			token := c.GetHeader("Authorization")
-			if token == "" {
-				c.AbortWithStatus(401)
-				return
-			}
-
-			claims, err := jwtManager.ValidateToken(token)
-			if err != nil {
-				c.AbortWithStatus(401)
-				return
-			}
-
-			c.Set("user_id", claims.UserID)
-			c.Next()
-		}
+刷新令牌轮换（旧刷新令牌只能使用一次，重放时撤销整个令牌家族）:
+
+	refresh, err := jwtManager.GenerateRefreshToken(12345, "john_doe")
+	access, newRefresh, err := jwtManager.RotateRefreshToken(refresh)
+	if errors.Is(err, jwt.ErrRefreshTokenReused) {
+		// 令牌可能被窃取,要求重新登录
 	}
 
+查询剩余有效期，在过期前主动刷新:
+
+	remaining, err := jwtManager.TimeUntilExpiry(token)
+	refresh, err := jwtManager.ShouldRefresh(token, 5*time.Minute)
+
+不验证签名地查看令牌内容（仅用于日志和调试，不能用于授权）:
+
+	claims, err := jwt.DecodeUnverified(token)
+
+与HTTP中间件配合使用:
+
+	// 依次尝试 Authorization 头和 httpOnly Cookie
+	// 验证通过后响应头 X-Token-Expires-In 返回令牌剩余秒数
+	extractor := jwt.FromMultiple(jwt.FromHeader(), jwt.FromCookie("access_token"))
+	router.Use(jwt.MiddlewareWithExtractor(jwtManager, extractor))
+
+	func profile(c *gin.Context) {
+		claims, _ := jwt.ClaimsFromContext(c)
+		// 使用 claims.UserID...
+	}
+
+内置提取器: FromHeader、FromCookie(name)、FromQuery(param)、FromMultiple(...)。
+请求中没有令牌时返回 ErrTokenNotFound,Authorization 头格式错误时返回 ErrInvalidAuthHeader。
+
 # 最佳实践
 
 1. 密钥管理
@@ -94,6 +127,7 @@ JWT是一种开放标准(RFC 7519),用于在各方之间安全地传输信息。
   - 使用HTTPS传输token
   - 在HTTP请求头中使用Authorization: Bearer <token>
   - 前端存储: 使用httpOnly cookie或内存存储,避免XSS攻击
+  - 使用httpOnly cookie时通过 FromCookie 提取令牌,并配合 SameSite 防御CSRF
 
 4. 错误处理
   - 验证失败时不要泄露具体原因给客户端
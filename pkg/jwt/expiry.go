@@ -0,0 +1,62 @@
+package jwt
+
+import (
+	"time"
+)
+
+// TimeUntilExpiry 返回令牌的剩余有效期
+// 实现JWT接口的TimeUntilExpiry方法
+// 参数:
+//
+//	tokenString: JWT token字符串
+//
+// 返回:
+//
+//	time.Duration: exp 距当前时间的时长
+//	error: 与 ValidateToken 相同;令牌没有 exp 时返回 ErrInvalidToken
+func (m *jwtManager) TimeUntilExpiry(tokenString string) (time.Duration, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	claims, err := m.parseClaims(tokenString)
+	if err != nil {
+		return 0, err
+	}
+	return remainingValidity(claims, time.Now())
+}
+
+// ShouldRefresh 判断令牌是否应该提前刷新
+// 实现JWT接口的ShouldRefresh方法
+// 参数:
+//
+//	tokenString: JWT token字符串
+//	threshold: 刷新阈值
+//
+// 返回:
+//
+//	bool: 剩余有效期不超过 threshold 时返回 true
+//	error: 与 TimeUntilExpiry 相同
+func (m *jwtManager) ShouldRefresh(tokenString string, threshold time.Duration) (bool, error) {
+	remaining, err := m.TimeUntilExpiry(tokenString)
+	if err != nil {
+		return false, err
+	}
+	return remaining <= threshold, nil
+}
+
+// remainingValidity 计算载荷在 now 时刻的剩余有效期
+// 参数:
+//
+//	claims: 已验证的令牌载荷
+//	now: 当前时间
+//
+// 返回:
+//
+//	time.Duration: 剩余有效期,已过期时为 0
+//	error: 载荷没有 exp 时返回 ErrInvalidToken
+func remainingValidity(claims *Claims, now time.Time) (time.Duration, error) {
+	if claims.ExpiresAt == nil {
+		return 0, ErrInvalidToken
+	}
+	return max(claims.ExpiresAt.Sub(now), 0), nil
+}
@@ -0,0 +1,53 @@
+package jwt
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestTimeUntilExpiry 测试剩余有效期、刷新建议和中间件响应头
+func TestTimeUntilExpiry(t *testing.T) {
+	j := newTestJWT(t, 0)
+
+	token, _ := j.GenerateTokenWithOptions(1, "alice", WithTTL(10*time.Minute))
+	remaining, err := j.TimeUntilExpiry(token)
+	if err != nil || remaining <= 9*time.Minute || remaining > 10*time.Minute {
+		t.Fatalf("TimeUntilExpiry() = %v, %v, want about 10m", remaining, err)
+	}
+
+	if refresh, err := j.ShouldRefresh(token, 5*time.Minute); err != nil || refresh {
+		t.Errorf("ShouldRefresh(5m) = %v, %v, want false", refresh, err)
+	}
+	if refresh, err := j.ShouldRefresh(token, 15*time.Minute); err != nil || !refresh {
+		t.Errorf("ShouldRefresh(15m) = %v, %v, want true", refresh, err)
+	}
+
+	// 无效令牌返回验证错误
+	if _, err := j.TimeUntilExpiry(token + "x"); !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Errorf("expected ErrTokenSignatureInvalid, got %v", err)
+	}
+	if refresh, err := j.ShouldRefresh("garbage", time.Hour); !errors.Is(err, ErrInvalidToken) || refresh {
+		t.Errorf("ShouldRefresh(garbage) = %v, %v, want false, ErrInvalidToken", refresh, err)
+	}
+
+	// 中间件通过响应头告知剩余有效期
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", MiddlewareWithExtractor(j, nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(AuthorizationHeader, BearerScheme+" "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	seconds, err := strconv.Atoi(w.Header().Get(TokenExpiresInHeader))
+	if w.Code != http.StatusOK || err != nil || seconds <= 540 || seconds > 600 {
+		t.Errorf("status = %d, %s = %q, want about 600", w.Code, TokenExpiresInHeader, w.Header().Get(TokenExpiresInHeader))
+	}
+}
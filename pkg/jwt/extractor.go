@@ -0,0 +1,139 @@
+package jwt
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// TokenExtractor 从 HTTP 请求中提取令牌
+// 内置实现: FromHeader、FromCookie、FromQuery、FromMultiple
+// 约定:
+//   - 请求中没有令牌时返回 ErrTokenNotFound
+//   - 令牌存在但格式错误时返回 ErrInvalidAuthHeader 等其他错误
+type TokenExtractor interface {
+	// ExtractToken 提取令牌字符串
+	// 参数:
+	//   r: HTTP 请求
+	// 返回:
+	//   string: 令牌字符串
+	//   error: 提取失败时的错误
+	ExtractToken(r *http.Request) (string, error)
+}
+
+// TokenExtractorFunc 函数形式的 TokenExtractor
+// 使用示例:
+//
+//	custom := jwt.TokenExtractorFunc(func(r *http.Request) (string, error) {
+//	    if token := r.Header.Get("X-Api-Token"); token != "" {
+//	        return token, nil
+//	    }
+//	    return "", jwt.ErrTokenNotFound
+//	})
+type TokenExtractorFunc func(r *http.Request) (string, error)
+
+// ExtractToken 实现 TokenExtractor 接口
+func (f TokenExtractorFunc) ExtractToken(r *http.Request) (string, error) {
+	return f(r)
+}
+
+// FromHeader 从 Authorization 请求头提取 Bearer 令牌
+// 格式: Authorization: Bearer <token>,Bearer 不区分大小写
+// 返回:
+//
+//	TokenExtractor: 请求头缺失时返回 ErrTokenNotFound,格式错误时返回 ErrInvalidAuthHeader
+func FromHeader() TokenExtractor {
+	return TokenExtractorFunc(func(r *http.Request) (string, error) {
+		header := r.Header.Get(AuthorizationHeader)
+		if header == "" {
+			return "", ErrTokenNotFound
+		}
+
+		// 使用 SplitN 限制分割次数,令牌本身不包含空格
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || !strings.EqualFold(parts[0], BearerScheme) {
+			return "", ErrInvalidAuthHeader
+		}
+		token := strings.TrimSpace(parts[1])
+		if token == "" {
+			return "", ErrInvalidAuthHeader
+		}
+		return token, nil
+	})
+}
+
+// FromCookie 从指定 Cookie 提取令牌
+// 适用于 SPA 将令牌存放在 httpOnly Cookie 中的场景,避免 XSS 读取令牌
+// 注意: Cookie 会被浏览器自动携带,需要配合 SameSite 或 CSRF 令牌防御跨站请求伪造
+// 参数:
+//
+//	name: Cookie 名称,例如 "access_token"
+//
+// 返回:
+//
+//	TokenExtractor: Cookie 不存在或为空时返回 ErrTokenNotFound
+func FromCookie(name string) TokenExtractor {
+	return TokenExtractorFunc(func(r *http.Request) (string, error) {
+		cookie, err := r.Cookie(name)
+		if err != nil || cookie.Value == "" {
+			return "", ErrTokenNotFound
+		}
+		return cookie.Value, nil
+	})
+}
+
+// FromQuery 从 URL 查询参数提取令牌
+// 仅用于无法设置请求头的场景,如 WebSocket 握手和 SSE
+// 注意: URL 会出现在访问日志和浏览器历史中,令牌应尽量短期有效
+// 参数:
+//
+//	param: 查询参数名,例如 "token"
+//
+// 返回:
+//
+//	TokenExtractor: 参数不存在或为空时返回 ErrTokenNotFound
+func FromQuery(param string) TokenExtractor {
+	return TokenExtractorFunc(func(r *http.Request) (string, error) {
+		token := r.URL.Query().Get(param)
+		if token == "" {
+			return "", ErrTokenNotFound
+		}
+		return token, nil
+	})
+}
+
+// FromMultiple 按顺序尝试多个提取器,返回第一个提取到的令牌
+// 某个来源格式错误时继续尝试后续来源,都没有提取到时:
+//   - 存在格式错误的来源时返回第一个格式错误
+//   - 否则返回 ErrTokenNotFound
+//
+// 参数:
+//
+//	extractors: 提取器列表,按优先级排列
+//
+// 返回:
+//
+//	TokenExtractor: 组合后的提取器
+//
+// 使用示例:
+//
+//	// 优先使用请求头,浏览器请求回退到 Cookie
+//	extractor := jwt.FromMultiple(jwt.FromHeader(), jwt.FromCookie("access_token"))
+func FromMultiple(extractors ...TokenExtractor) TokenExtractor {
+	return TokenExtractorFunc(func(r *http.Request) (string, error) {
+		var firstErr error
+		for _, e := range extractors {
+			token, err := e.ExtractToken(r)
+			if err == nil {
+				return token, nil
+			}
+			if !errors.Is(err, ErrTokenNotFound) && firstErr == nil {
+				firstErr = err
+			}
+		}
+		if firstErr != nil {
+			return "", firstErr
+		}
+		return "", ErrTokenNotFound
+	})
+}
@@ -0,0 +1,68 @@
+package jwt
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Option JWT 管理器的创建选项
+// 用于 New,与 Config 中的字段不同,选项通常只在代码中设置
+type Option func(*managerOptions)
+
+// managerOptions JWT 管理器的创建选项
+type managerOptions struct {
+	// hkdf 是否使用 HKDF 从 Secret 派生签名密钥
+	hkdf bool
+
+	// hkdfSalt HKDF 的盐值
+	hkdfSalt []byte
+
+	// hkdfInfo HKDF 的上下文信息
+	hkdfInfo string
+}
+
+// WithHKDF 使用 HKDF-SHA256 从 Config.Secret 派生签名密钥
+// 启用后 Secret 不再要求至少 32 个字符,任意非空的口令都会派生出 DerivedKeyLength 字节的密钥,
+// 相同的 Secret、salt 和 info 总是得到相同的密钥,多实例部署时各实例签发的令牌可以互相验证
+//
+// 注意:
+//   - HKDF 不能增加口令本身的熵,简短、常见的口令仍可能被离线猜测,生产环境仍应使用随机密钥
+//   - 修改 salt 或 info 会改变派生的密钥,已签发的令牌(包括刷新令牌)全部失效
+//   - salt 和 info 不是秘密,可以写在代码或配置中
+//
+// 参数:
+//
+//	salt: 盐值,建议使用固定的随机字节,为 nil 时按 RFC 5869 使用全零盐值
+//	info: 上下文信息,用于区分同一口令派生出的不同用途的密钥,如 "go-scaffold/jwt/v1"
+//
+// 使用示例:
+//
+//	j, err := jwt.New(&jwt.Config{Secret: os.Getenv("JWT_PASSPHRASE")},
+//	    jwt.WithHKDF([]byte("8f1c2e..."), "myapp/jwt/v1"))
+func WithHKDF(salt []byte, info string) Option {
+	return func(o *managerOptions) {
+		o.hkdf = true
+		o.hkdfSalt = salt
+		o.hkdfInfo = info
+	}
+}
+
+// deriveKey 使用 HKDF-SHA256 从口令派生签名密钥
+// 参数:
+//
+//	secret: 口令
+//	salt: 盐值
+//	info: 上下文信息
+//
+// 返回:
+//
+//	[]byte: DerivedKeyLength 字节的密钥
+//	error: 派生失败时的错误
+func deriveKey(secret string, salt []byte, info string) ([]byte, error) {
+	key, err := hkdf.Key(sha256.New, []byte(secret), salt, info, DerivedKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf(ErrMsgDeriveKeyFailed, err)
+	}
+	return key, nil
+}
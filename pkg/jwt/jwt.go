@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -25,15 +27,32 @@ type JWT interface {
 	//   3. 生成完整的JWT token
 	GenerateToken(userID int64, username string) (string, error)
 
+	// GenerateTokenWithOptions 使用自定义选项生成令牌
+	// 参数:
+	//   userID: 用户ID
+	//   username: 用户名
+	//   opts: 令牌选项,用于覆盖有效期或设置 aud/sub/nbf
+	// 返回:
+	//   string: JWT token字符串
+	//   error: 生成失败时的错误
+	// 使用场景:
+	//   不同用途的令牌需要不同的有效期,如短期的邮箱确认令牌和长期的会话令牌
+	GenerateTokenWithOptions(userID int64, username string, opts ...TokenOption) (string, error)
+
 	// ValidateToken 验证并解析令牌
 	// 参数:
 	//   tokenString: JWT token字符串
 	// 返回:
 	//   *Claims: 解析后的载荷信息
-	//   error: 验证失败时的错误,如:
-	//     - ErrInvalidToken: token格式无效
-	//     - ErrExpiredToken: token已过期
-	//     - ErrInvalidSignature: 签名验证失败
+	//   error: 验证失败时的错误,可用 errors.Is 判断,如:
+	//     - ErrTokenMalformed: token格式无效(同时满足 errors.Is(err, ErrInvalidToken))
+	//     - ErrTokenSignatureInvalid: 签名验证失败
+	//     - ErrTokenExpired: token已过期,可提示客户端刷新
+	//     - ErrTokenNotValidYet: token尚未生效
+	//     - ErrInvalidAudience: 受众不匹配（配置了 Audience 时）
+	//     - ErrInvalidTokenType: 传入的是刷新令牌
+	//     - ErrTokenBound: 令牌带有 IP/设备绑定,需使用 ValidateTokenBound
+	//   返回给客户端时应统一为 401,不泄露具体原因
 	// 业务流程:
 	//   1. 解析token字符串
 	//   2. 验证签名
@@ -41,6 +60,35 @@ type JWT interface {
 	//   4. 返回claims
 	ValidateToken(tokenString string) (*Claims, error)
 
+	// ValidateTokenCached 验证令牌并缓存结果
+	// 用于每秒验证大量令牌的网关:同一令牌在有效期内重复出现时,
+	// 直接返回缓存的载荷,跳过解析和签名验证
+	// 参数:
+	//   tokenString: JWT token字符串
+	// 返回:
+	//   *Claims: 解析后的载荷信息(副本,可以修改)
+	//   error: 与 ValidateToken 相同
+	// 说明:
+	//   - 只缓存验证成功的结果,缓存期限为令牌自身的 exp,不会延长令牌有效期
+	//   - 缓存按 LRU 淘汰,容量由 Config.ValidationCacheSize 决定
+	//   - 缓存以令牌的 SHA-256 摘要为键,内存中不保存令牌原文
+	ValidateTokenCached(tokenString string) (*Claims, error)
+
+	// ValidateTokenBound 验证令牌并检查 IP/设备绑定
+	// 参数:
+	//   tokenString: JWT token字符串
+	//   ip: 当前请求的客户端 IP
+	//   deviceID: 当前请求的设备 ID
+	// 返回:
+	//   *Claims: 解析后的载荷信息
+	//   error: 与 ValidateToken 相同的错误,或:
+	//     - ErrTokenBindingMismatch: 令牌绑定的 IP 或设备与当前请求不一致
+	// 说明:
+	//   只检查令牌中存在的绑定(见 WithBindIP、WithBindDevice),
+	//   未绑定的令牌与 ValidateToken 行为一致;
+	//   绑定令牌只能通过本方法验证,ValidateToken 对其返回 ErrTokenBound
+	ValidateTokenBound(tokenString, ip, deviceID string) (*Claims, error)
+
 	// RefreshToken 刷新令牌（可选实现）
 	// 参数:
 	//   tokenString: 旧的JWT token字符串
@@ -50,6 +98,75 @@ type JWT interface {
 	// 注意:
 	//   当前实现可能暂不支持此功能,返回 ErrNotImplemented
 	RefreshToken(tokenString string) (string, error)
+
+	// GenerateRefreshToken 生成刷新令牌
+	// 参数:
+	//   userID: 用户ID
+	//   username: 用户名
+	// 返回:
+	//   string: 刷新令牌,有效期为 Config.RefreshExpiresIn
+	//   error: 生成失败时的错误
+	// 说明:
+	//   每次调用开启一个新的令牌家族(通常在登录时调用),
+	//   刷新令牌只能用于 RotateRefreshToken,ValidateToken 会拒绝它
+	GenerateRefreshToken(userID int64, username string) (string, error)
+
+	// RotateRefreshToken 轮换刷新令牌
+	// 验证旧刷新令牌,签发新的访问令牌和刷新令牌,旧令牌随即作废
+	// 参数:
+	//   oldRefresh: 当前持有的刷新令牌
+	// 返回:
+	//   access: 新的访问令牌
+	//   newRefresh: 新的刷新令牌,有效期重新计算(滑动会话)
+	//   err: 轮换失败时的错误,如:
+	//     - ErrTokenExpired: 刷新令牌已过期,需要重新登录
+	//     - ErrInvalidTokenType: 传入的不是刷新令牌
+	//     - ErrRefreshTokenReused: 旧令牌已被使用过,整个令牌家族已被撤销
+	// 注意:
+	//   重放检测依赖 Config.RefreshStore,未配置时只做无状态校验
+	// 使用示例:
+	//   access, refresh, err := j.RotateRefreshToken(req.RefreshToken)
+	//   if errors.Is(err, jwt.ErrRefreshTokenReused) {
+	//       // 令牌可能被窃取,要求重新登录
+	//   }
+	RotateRefreshToken(oldRefresh string) (access, newRefresh string, err error)
+
+	// Introspect 内省令牌,返回令牌状态和标准声明
+	// 参数:
+	//   tokenString: JWT token字符串
+	// 返回:
+	//   *Introspection: 内省结果,过期、未生效或受众不匹配时 Active 为 false
+	//   error: 令牌无法信任时的错误,如:
+	//     - ErrTokenMalformed: token格式无效
+	//     - ErrTokenSignatureInvalid: 签名验证失败
+	// 与 ValidateToken 的区别:
+	//   仍然验证签名,但时间和受众校验失败不返回错误,而是体现在 Active 字段上
+	//   适用于 OAuth 风格的内省端点、管理工具和网关
+	Introspect(tokenString string) (*Introspection, error)
+
+	// TimeUntilExpiry 返回令牌的剩余有效期
+	// 参数:
+	//   tokenString: JWT token字符串(访问令牌或刷新令牌)
+	// 返回:
+	//   time.Duration: exp 距当前时间的时长
+	//   error: 与 ValidateToken 相同的验证错误(过期令牌返回 ErrTokenExpired),
+	//     令牌没有 exp 时返回 ErrInvalidToken
+	// 说明:
+	//   令牌先经过完整验证,不会对伪造令牌给出剩余有效期
+	TimeUntilExpiry(tokenString string) (time.Duration, error)
+
+	// ShouldRefresh 判断令牌是否应该提前刷新
+	// 参数:
+	//   tokenString: JWT token字符串
+	//   threshold: 刷新阈值,剩余有效期不超过该值时建议刷新
+	// 返回:
+	//   bool: 剩余有效期 <= threshold 时返回 true
+	//   error: 与 TimeUntilExpiry 相同,出错时返回 false
+	// 使用示例:
+	//   if refresh, _ := j.ShouldRefresh(token, 5*time.Minute); refresh {
+	//       c.Header("X-Token-Refresh", "true")
+	//   }
+	ShouldRefresh(tokenString string, threshold time.Duration) (bool, error)
 }
 
 // Claims JWT载荷
@@ -67,6 +184,22 @@ type Claims struct {
 	// 用于显示或日志记录
 	Username string `json:"username"`
 
+	// TokenType 令牌类型
+	// 刷新令牌为 TokenTypeRefresh,访问令牌为空
+	TokenType string `json:"token_type,omitempty"`
+
+	// Family 刷新令牌家族标识
+	// 同一次登录轮换产生的刷新令牌共享同一家族,检测到重放时整个家族被撤销
+	Family string `json:"family,omitempty"`
+
+	// BindIP 绑定的客户端 IP 摘要
+	// 由 WithBindIP 写入,只保存 HMAC 摘要而非原始 IP
+	BindIP string `json:"bip,omitempty"`
+
+	// BindDevice 绑定的设备 ID 摘要
+	// 由 WithBindDevice 写入,只保存 HMAC 摘要而非原始设备 ID
+	BindDevice string `json:"bdv,omitempty"`
+
 	// jwt.RegisteredClaims 包含标准JWT字段:
 	// - Issuer: 签发者
 	// - Subject: 主题
@@ -78,6 +211,42 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// Introspection 令牌内省结果
+// 字段命名参照 RFC 7662 (OAuth 2.0 Token Introspection)
+// 时间字段为 Unix 秒,未设置时为 0
+type Introspection struct {
+	// Active 令牌当前是否有效
+	// 签名正确且未过期、已生效、受众匹配时为 true
+	Active bool `json:"active"`
+
+	// Sub 主题 (sub)
+	Sub string `json:"sub,omitempty"`
+
+	// Exp 过期时间 (exp)
+	Exp int64 `json:"exp,omitempty"`
+
+	// Iat 签发时间 (iat)
+	Iat int64 `json:"iat,omitempty"`
+
+	// Nbf 生效时间 (nbf)
+	Nbf int64 `json:"nbf,omitempty"`
+
+	// Aud 受众 (aud)
+	Aud []string `json:"aud,omitempty"`
+
+	// Iss 签发者 (iss)
+	Iss string `json:"iss,omitempty"`
+
+	// Jti 令牌唯一标识 (jti)
+	Jti string `json:"jti,omitempty"`
+
+	// UserID 用户ID
+	UserID int64 `json:"user_id,omitempty"`
+
+	// Username 用户名
+	Username string `json:"username,omitempty"`
+}
+
 // Config JWT配置
 // 用于初始化JWT管理器
 type Config struct {
@@ -98,4 +267,24 @@ type Config struct {
 	// 标识令牌由哪个系统签发
 	// 用于多系统环境下区分token来源
 	Issuer string
+
+	// Audience 期望的受众 (aud)
+	// 非空时,ValidateToken 只接受 aud 包含该值的令牌
+	// 为空时不校验受众
+	Audience string
+
+	// RefreshExpiresIn 刷新令牌有效期（秒）
+	// 默认: 604800（7天）
+	// 每次轮换都会签发完整有效期的新刷新令牌
+	RefreshExpiresIn int
+
+	// RefreshStore 刷新令牌状态存储（可选）
+	// 配置后刷新令牌只能使用一次,旧令牌被重放时撤销整个令牌家族
+	// 为 nil 时不做重放检测
+	RefreshStore RefreshTokenStore
+
+	// ValidationCacheSize ValidateTokenCached 缓存的最大令牌数
+	// 默认: 10000
+	// 超出时淘汰最久未使用的令牌;只调用 ValidateToken 时不占用内存
+	ValidationCacheSize int
 }
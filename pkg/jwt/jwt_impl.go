@@ -29,6 +29,20 @@ type jwtManager struct {
 	// 用于标识token的来源
 	issuer string
 
+	// audience 期望的受众
+	// 非空时验证令牌的 aud 必须包含该值
+	audience string
+
+	// refreshExpiresIn 刷新令牌有效期
+	refreshExpiresIn time.Duration
+
+	// refreshStore 刷新令牌状态存储
+	// 为 nil 时不做重放检测
+	refreshStore RefreshTokenStore
+
+	// cache ValidateTokenCached 的验证结果缓存
+	cache *validationCache
+
 	// mu 读写锁
 	// 保护配置字段的并发访问
 	// 读多写少的场景使用RWMutex性能更好
@@ -40,6 +54,7 @@ type jwtManager struct {
 // 参数:
 //
 //	cfg: JWT配置对象
+//	opts: 可选配置,如 WithHKDF
 //
 // 返回:
 //
@@ -49,16 +64,29 @@ type jwtManager struct {
 // 验证规则:
 //
 //  1. secret不能为空
-//  2. secret长度至少32个字符（安全性考虑）
+//  2. secret长度至少32个字符（安全性考虑）,使用 WithHKDF 派生密钥时不限制
 //  3. expiresIn必须大于0
-func New(cfg *Config) (JWT, error) {
+func New(cfg *Config, opts ...Option) (JWT, error) {
+	var o managerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// 1. 验证配置
 	if cfg.Secret == "" {
 		return nil, ErrMissingSecret
 	}
 
 	// 2. 验证密钥长度（安全性要求）
-	if len(cfg.Secret) < 32 {
+	// 使用 HKDF 时签名密钥由派生得到,长度固定为 DerivedKeyLength
+	secret := []byte(cfg.Secret)
+	if o.hkdf {
+		key, err := deriveKey(cfg.Secret, o.hkdfSalt, o.hkdfInfo)
+		if err != nil {
+			return nil, err
+		}
+		secret = key
+	} else if len(cfg.Secret) < 32 {
 		return nil, errors.New(ErrMsgSecretTooShort)
 	}
 
@@ -73,20 +101,51 @@ func New(cfg *Config) (JWT, error) {
 		issuer = DefaultIssuer
 	}
 
+	refreshExpiresIn := cfg.RefreshExpiresIn
+	if refreshExpiresIn <= 0 {
+		refreshExpiresIn = DefaultRefreshExpiresIn
+	}
+
+	cacheSize := cfg.ValidationCacheSize
+	if cacheSize <= 0 {
+		cacheSize = DefaultValidationCacheSize
+	}
+
 	// 4. 创建实例
 	return &jwtManager{
-		secret:    []byte(cfg.Secret),
-		expiresIn: time.Duration(expiresIn) * time.Second,
-		issuer:    issuer,
+		secret:           secret,
+		expiresIn:        time.Duration(expiresIn) * time.Second,
+		issuer:           issuer,
+		audience:         cfg.Audience,
+		refreshExpiresIn: time.Duration(refreshExpiresIn) * time.Second,
+		refreshStore:     cfg.RefreshStore,
+		cache:            newValidationCache(cacheSize),
 	}, nil
 }
 
 // GenerateToken 生成访问令牌
 // 实现JWT接口的GenerateToken方法
+// 使用 Config 中的默认有效期,等价于不带选项调用 GenerateTokenWithOptions
+// 参数:
+//
+//	userID: 用户ID
+//	username: 用户名
+//
+// 返回:
+//
+//	string: JWT token字符串
+//	error: 生成失败时的错误
+func (m *jwtManager) GenerateToken(userID int64, username string) (string, error) {
+	return m.GenerateTokenWithOptions(userID, username)
+}
+
+// GenerateTokenWithOptions 使用自定义选项生成令牌
+// 实现JWT接口的GenerateTokenWithOptions方法
 // 参数:
 //
 //	userID: 用户ID
 //	username: 用户名
+//	opts: 令牌选项 (WithTTL、WithAudience、WithSubject、WithNotBefore)
 //
 // 返回:
 //
@@ -98,13 +157,44 @@ func New(cfg *Config) (JWT, error) {
 //  2. 创建JWT token对象
 //  3. 使用HMAC-SHA256算法签名
 //  4. 生成完整的token字符串
-func (m *jwtManager) GenerateToken(userID int64, username string) (string, error) {
+func (m *jwtManager) GenerateTokenWithOptions(userID int64, username string, opts ...TokenOption) (string, error) {
 	// 使用读锁保护配置读取
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	// 应用选项,未设置的项使用全局配置
+	o := &tokenOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
 	// 1. 创建claims
+	claims := m.newClaims(userID, username, o)
+
+	// 2. 创建token对象并签名
+	// SigningMethodHS256 使用HMAC-SHA256算法
+	// SignedString会:
+	// - 将header和claims编码为base64
+	// - 使用secret对它们进行HMAC-SHA256签名
+	// - 拼接成完整的JWT: header.claims.signature
+	return m.signToken(claims)
+}
+
+// newClaims 根据选项创建令牌载荷
+// 未设置有效期时使用 Config.ExpiresIn
+// 调用方需持有读锁
+func (m *jwtManager) newClaims(userID int64, username string, o *tokenOptions) *Claims {
+	ttl := o.ttl
+	if ttl <= 0 {
+		ttl = m.expiresIn
+	}
+
 	now := time.Now()
+	notBefore := now
+	if !o.notBefore.IsZero() {
+		notBefore = o.notBefore
+	}
+
 	claims := &Claims{
 		UserID:   userID,
 		Username: username,
@@ -112,33 +202,29 @@ func (m *jwtManager) GenerateToken(userID int64, username string) (string, error
 			// 签发者
 			Issuer: m.issuer,
 
+			// 主题和受众,未设置时不写入载荷
+			Subject:  o.subject,
+			Audience: o.audience,
+
 			// 签发时间
 			IssuedAt: jwt.NewNumericDate(now),
 
 			// 过期时间 = 当前时间 + 有效期
-			ExpiresAt: jwt.NewNumericDate(now.Add(m.expiresIn)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
 
-			// 生效时间 = 当前时间
-			// token立即生效,不设置延迟
-			NotBefore: jwt.NewNumericDate(now),
+			// 生效时间,默认立即生效
+			NotBefore: jwt.NewNumericDate(notBefore),
 		},
 	}
 
-	// 2. 创建token对象
-	// SigningMethodHS256 使用HMAC-SHA256算法
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// 3. 签名并生成token字符串
-	// SignedString会:
-	// - 将header和claims编码为base64
-	// - 使用secret对它们进行HMAC-SHA256签名
-	// - 拼接成完整的JWT: header.claims.signature
-	tokenString, err := token.SignedString(m.secret)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+	// IP/设备绑定,只写入摘要
+	if o.bindIP != "" {
+		claims.BindIP = m.bindingDigest(bindingPurposeIP, o.bindIP)
 	}
-
-	return tokenString, nil
+	if o.bindDevice != "" {
+		claims.BindDevice = m.bindingDigest(bindingPurposeDevice, o.bindDevice)
+	}
+	return claims
 }
 
 // ValidateToken 验证并解析令牌
@@ -157,42 +243,46 @@ func (m *jwtManager) GenerateToken(userID int64, username string) (string, error
 //  2. 验证签名
 //  3. 检查过期时间
 //  4. 检查生效时间
-//  5. 提取claims
+//  5. 提取claims,拒绝刷新令牌
+//  6. 拒绝带有 IP/设备绑定的令牌(ErrTokenBound),绑定令牌需使用 ValidateTokenBound
 func (m *jwtManager) ValidateToken(tokenString string) (*Claims, error) {
 	// 使用读锁保护配置读取
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	claims, err := m.parseClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	// 刷新令牌有效期更长,不能当作访问令牌使用
+	if claims.TokenType == TokenTypeRefresh {
+		return nil, ErrInvalidTokenType
+	}
+
+	// 这里拿不到请求的 IP 和设备,放行绑定令牌等于绑定失效
+	if claims.isBound() {
+		return nil, ErrTokenBound
+	}
+
+	return claims, nil
+}
+
+// parseClaims 解析并验证令牌,返回载荷
+// 验证签名、有效期和受众,不检查令牌类型
+// 调用方需持有读锁
+func (m *jwtManager) parseClaims(tokenString string) (*Claims, error) {
 	// 1. 解析token
 	// ParseWithClaims会:
 	// - 解析token字符串
 	// - 使用keyFunc验证签名
 	// - 检查标准声明（过期时间、生效时间等）
 	// - 将载荷解析到Claims结构
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// 验证签名算法
-		// 防止攻击者使用其他算法（如none）绕过签名验证
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		// 返回密钥用于验证签名
-		return m.secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc, m.parserOptions()...)
 
 	// 2. 处理解析错误
 	if err != nil {
-		// 根据错误类型返回更具体的错误
-		if errors.Is(err, jwt.ErrTokenExpired) {
-			return nil, ErrExpiredToken
-		}
-		if errors.Is(err, jwt.ErrTokenNotValidYet) {
-			return nil, ErrTokenNotYetValid
-		}
-		if errors.Is(err, jwt.ErrTokenSignatureInvalid) {
-			return nil, ErrInvalidSignature
-		}
-		// 其他错误统一返回无效token
-		return nil, ErrInvalidToken
+		return nil, validationError(err)
 	}
 
 	// 3. 提取claims
@@ -228,3 +318,108 @@ func (m *jwtManager) RefreshToken(tokenString string) (string, error) {
 	// 使用相同的用户信息,但更新时间戳
 	return m.GenerateToken(claims.UserID, claims.Username)
 }
+
+// Introspect 内省令牌
+// 实现JWT接口的Introspect方法
+// 参数:
+//
+//	tokenString: JWT token字符串
+//
+// 返回:
+//
+//	*Introspection: 内省结果
+//	error: 格式无效或签名验证失败时的错误
+//
+// 实现步骤:
+//  1. 关闭声明校验解析token,只验证签名
+//  2. 使用与 ValidateToken 相同的规则单独校验时间和受众
+//  3. 校验结果写入 Active,不作为错误返回
+func (m *jwtManager) Introspect(tokenString string) (*Introspection, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// 1. 只验证签名,过期等声明错误留给下一步判断
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc, jwt.WithoutClaimsValidation())
+	if err != nil {
+		return nil, validationError(err)
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	// 2. 校验过期时间、生效时间和受众
+	active := jwt.NewValidator(m.parserOptions()...).Validate(claims) == nil
+
+	// 3. 填充内省结果
+	result := &Introspection{
+		Active:   active,
+		Sub:      claims.Subject,
+		Aud:      claims.Audience,
+		Iss:      claims.Issuer,
+		Jti:      claims.ID,
+		UserID:   claims.UserID,
+		Username: claims.Username,
+	}
+	if claims.ExpiresAt != nil {
+		result.Exp = claims.ExpiresAt.Unix()
+	}
+	if claims.IssuedAt != nil {
+		result.Iat = claims.IssuedAt.Unix()
+	}
+	if claims.NotBefore != nil {
+		result.Nbf = claims.NotBefore.Unix()
+	}
+
+	return result, nil
+}
+
+// validationError 将 jwt/v5 的解析错误映射为本包的错误
+// 调用方可以用 errors.Is 区分过期(提示刷新)和格式错误、签名无效(直接拒绝)
+// 参数:
+//
+//	err: jwt.ParseWithClaims 返回的错误
+//
+// 返回:
+//
+//	error: ErrTokenMalformed、ErrTokenSignatureInvalid、ErrTokenExpired、
+//	  ErrTokenNotValidYet、ErrInvalidAudience 之一,无法归类时返回 ErrInvalidToken
+func validationError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ErrTokenMalformed
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable):
+		// 不可验证的令牌(如 alg 不是 HMAC)与签名无效同样视为伪造
+		return ErrTokenSignatureInvalid
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet):
+		return ErrTokenNotValidYet
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrInvalidAudience
+	default:
+		// 其他错误统一返回无效token
+		return ErrInvalidToken
+	}
+}
+
+// keyFunc 返回签名验证密钥
+// 只接受 HMAC 算法,防止攻击者使用其他算法（如none）绕过签名验证
+// 调用方需持有读锁
+func (m *jwtManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return m.secret, nil
+}
+
+// parserOptions 返回令牌解析选项
+// 配置了期望受众时启用 aud 校验
+// 调用方需持有读锁
+func (m *jwtManager) parserOptions() []jwt.ParserOption {
+	if m.audience == "" {
+		return nil
+	}
+	return []jwt.ParserOption{jwt.WithAudience(m.audience)}
+}
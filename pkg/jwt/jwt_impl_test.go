@@ -0,0 +1,120 @@
+package jwt
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TestValidateTokenErrors 测试验证错误映射为可用 errors.Is 区分的类型
+func TestValidateTokenErrors(t *testing.T) {
+	j := newTestJWT(t, 0)
+
+	sign := func(method jwt.SigningMethod, key interface{}, claims *Claims) string {
+		token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		if err != nil {
+			t.Fatalf("sign token: %v", err)
+		}
+		return token
+	}
+	past := time.Now().Add(-time.Hour)
+	expired := sign(jwt.SigningMethodHS256, j.secret, &Claims{
+		UserID: 1,
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt:  jwt.NewNumericDate(past),
+			ExpiresAt: jwt.NewNumericDate(past.Add(time.Minute)),
+		},
+	})
+	unsigned := sign(jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, &Claims{UserID: 1})
+	future, _ := j.GenerateTokenWithOptions(1, "alice", WithNotBefore(time.Now().Add(time.Hour)))
+	valid, _ := j.GenerateToken(1, "alice")
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"malformed", "garbage", ErrTokenMalformed},
+		{"bad signature", valid[:len(valid)-2] + "AA", ErrTokenSignatureInvalid},
+		{"alg none", unsigned, ErrTokenSignatureInvalid},
+		{"expired", expired, ErrTokenExpired},
+		{"not valid yet", future, ErrTokenNotValidYet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := j.ValidateToken(tt.token); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateToken() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// 格式错误仍然满足 ErrInvalidToken,旧名称与新错误等价
+	if !errors.Is(ErrTokenMalformed, ErrInvalidToken) {
+		t.Error("ErrTokenMalformed should wrap ErrInvalidToken")
+	}
+	if !errors.Is(ErrExpiredToken, ErrTokenExpired) || !errors.Is(ErrInvalidSignature, ErrTokenSignatureInvalid) {
+		t.Error("deprecated errors should match the new ones")
+	}
+
+	// 中间件对客户端统一返回 401,具体原因附加在 c.Errors 上
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var reason error
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		if last := c.Errors.Last(); last != nil {
+			reason = last.Err
+		}
+	})
+	router.GET("/", MiddlewareWithExtractor(j, nil), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(AuthorizationHeader, BearerScheme+" "+expired)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnauthorized || !errors.Is(reason, ErrTokenExpired) {
+		t.Errorf("status = %d, reason = %v, want 401 and ErrTokenExpired", w.Code, reason)
+	}
+}
+
+// TestWithHKDF 测试从短口令派生签名密钥
+func TestWithHKDF(t *testing.T) {
+	cfg := &Config{Secret: "correct horse"}
+	if _, err := New(cfg); err == nil {
+		t.Fatal("New() with short secret should fail without WithHKDF")
+	}
+
+	salt := []byte("test-salt")
+	j1, err := New(cfg, WithHKDF(salt, "test/jwt/v1"))
+	if err != nil {
+		t.Fatalf("New() with WithHKDF error = %v", err)
+	}
+	if n := len(j1.(*jwtManager).secret); n != DerivedKeyLength {
+		t.Errorf("derived key length = %d, want %d", n, DerivedKeyLength)
+	}
+
+	// 相同参数派生相同密钥,不同实例签发的令牌可以互相验证
+	j2, _ := New(cfg, WithHKDF(salt, "test/jwt/v1"))
+	token, err := j1.GenerateToken(1, "alice")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if _, err := j2.ValidateToken(token); err != nil {
+		t.Errorf("ValidateToken() with same HKDF params error = %v", err)
+	}
+
+	// 修改 info 后已签发的令牌失效
+	j3, _ := New(cfg, WithHKDF(salt, "test/jwt/v2"))
+	if _, err := j3.ValidateToken(token); !errors.Is(err, ErrTokenSignatureInvalid) {
+		t.Errorf("ValidateToken() with different info error = %v, want ErrTokenSignatureInvalid", err)
+	}
+
+	if _, err := New(&Config{}, WithHKDF(salt, "")); !errors.Is(err, ErrMissingSecret) {
+		t.Errorf("New() with empty secret error = %v, want ErrMissingSecret", err)
+	}
+}
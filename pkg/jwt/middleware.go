@@ -0,0 +1,91 @@
+package jwt
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/types/result"
+)
+
+// MiddlewareWithExtractor 返回使用指定提取器认证请求的 Gin 中间件
+// 流程:
+//  1. 通过 extractor 提取令牌,没有令牌或格式错误时返回 401
+//  2. 调用 ValidateTokenBound 验证令牌,传入 c.ClientIP() 和 DeviceIDHeader 请求头,
+//     绑定了 IP/设备的令牌在其他 IP 或设备上使用时被拒绝;
+//     失败时返回 401,不向客户端泄露具体原因;
+//     具体错误通过 c.Error 附加到 c.Errors,可用 errors.Is 判断 ErrTokenExpired 等
+//  3. 将 Claims 存入上下文(ContextKeyClaims),通过 ClaimsFromContext 读取
+//  4. 设置响应头 X-Token-Expires-In(剩余有效期秒数),单页应用据此在过期前刷新,
+//     而不是等到 401 之后;跨域访问时需要在 CORS 的 ExposeHeaders 中加入该响应头
+//
+// 参数:
+//
+//	j: JWT 管理器
+//	extractor: 令牌提取器,为 nil 时使用 FromHeader()
+//
+// 返回:
+//
+//	gin.HandlerFunc: Gin 中间件
+//
+// 使用示例:
+//
+//	// 同时支持 API 客户端的请求头和浏览器的 httpOnly Cookie
+//	protected := router.Group("/api/v1")
+//	protected.Use(jwt.MiddlewareWithExtractor(jwtManager,
+//	    jwt.FromMultiple(jwt.FromHeader(), jwt.FromCookie("access_token"))))
+func MiddlewareWithExtractor(j JWT, extractor TokenExtractor) gin.HandlerFunc {
+	if extractor == nil {
+		extractor = FromHeader()
+	}
+
+	return func(c *gin.Context) {
+		token, err := extractor.ExtractToken(c.Request)
+		if err != nil {
+			if errors.Is(err, ErrTokenNotFound) {
+				result.Unauthorized(c, ErrMsgTokenNotFound)
+			} else {
+				result.Unauthorized(c, ErrMsgInvalidAuthHeader)
+			}
+			c.Abort()
+			return
+		}
+
+		claims, err := j.ValidateTokenBound(token, c.ClientIP(), c.GetHeader(DeviceIDHeader))
+		if err != nil {
+			// 具体原因(过期、格式错误、签名无效等)附加到上下文供日志中间件记录,
+			// 客户端只收到统一的 401
+			_ = c.Error(err)
+			result.Unauthorized(c, ErrMsgInvalidOrExpiredToken)
+			c.Abort()
+			return
+		}
+
+		if remaining, err := remainingValidity(claims, time.Now()); err == nil {
+			c.Header(TokenExpiresInHeader, strconv.FormatInt(int64(remaining/time.Second), 10))
+		}
+
+		c.Set(ContextKeyClaims, claims)
+		c.Next()
+	}
+}
+
+// ClaimsFromContext 从上下文获取 MiddlewareWithExtractor 存入的 Claims
+// 参数:
+//
+//	c: Gin 上下文
+//
+// 返回:
+//
+//	*Claims: 令牌载荷
+//	bool: 请求未经过认证中间件时返回 false
+func ClaimsFromContext(c *gin.Context) (*Claims, bool) {
+	v, exists := c.Get(ContextKeyClaims)
+	if !exists {
+		return nil, false
+	}
+	claims, ok := v.(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,74 @@
+package jwt
+
+import "time"
+
+// TokenOption 单个令牌的生成选项
+// 用于覆盖 Config 中的全局默认值,例如为邮箱确认令牌设置更短的有效期
+type TokenOption func(*tokenOptions)
+
+// tokenOptions 令牌生成选项
+type tokenOptions struct {
+	// ttl 有效期,<=0 时使用 Config.ExpiresIn
+	ttl time.Duration
+
+	// audience 受众 (aud)
+	audience []string
+
+	// subject 主题 (sub)
+	subject string
+
+	// notBefore 生效时间 (nbf),零值表示立即生效
+	notBefore time.Time
+
+	// bindIP 绑定的客户端 IP,为空时不绑定
+	bindIP string
+
+	// bindDevice 绑定的设备 ID,为空时不绑定
+	bindDevice string
+}
+
+// WithTTL 设置令牌有效期
+// 参数:
+//
+//	ttl: 有效期,<=0 时使用 Config.ExpiresIn
+//
+// 使用示例:
+//
+//	token, err := j.GenerateTokenWithOptions(userID, username, jwt.WithTTL(15*time.Minute))
+func WithTTL(ttl time.Duration) TokenOption {
+	return func(o *tokenOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithAudience 设置令牌受众 (aud)
+// 验证方配置了 Config.Audience 时,只接受包含该受众的令牌
+// 参数:
+//
+//	aud: 一个或多个受众标识
+func WithAudience(aud ...string) TokenOption {
+	return func(o *tokenOptions) {
+		o.audience = aud
+	}
+}
+
+// WithSubject 设置令牌主题 (sub)
+// 参数:
+//
+//	sub: 主题,通常用于区分令牌用途 (如 "email-confirm")
+func WithSubject(sub string) TokenOption {
+	return func(o *tokenOptions) {
+		o.subject = sub
+	}
+}
+
+// WithNotBefore 设置令牌生效时间 (nbf)
+// 在该时间之前验证令牌将返回 ErrTokenNotValidYet
+// 参数:
+//
+//	t: 生效时间
+func WithNotBefore(t time.Time) TokenOption {
+	return func(o *tokenOptions) {
+		o.notBefore = t
+	}
+}
@@ -0,0 +1,265 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RefreshTokenStore 刷新令牌状态存储
+// 用于实现刷新令牌的一次性使用和重放检测
+// 为什么需要:
+//   - JWT 本身无状态,无法得知某个刷新令牌是否已经用过
+//   - 已轮换的旧令牌再次出现,说明令牌可能被窃取,应撤销整个令牌家族
+//
+// 单实例部署可使用 NewMemoryRefreshStore,多实例部署应基于 Redis 等共享存储实现
+type RefreshTokenStore interface {
+	// MarkUsed 将刷新令牌标记为已使用
+	// 参数:
+	//   jti: 刷新令牌的唯一标识
+	//   expiresAt: 令牌过期时间,之后可以清理该记录
+	// 返回:
+	//   bool: 首次使用返回 true,已经使用过返回 false
+	//   error: 存储访问失败时的错误
+	MarkUsed(jti string, expiresAt time.Time) (bool, error)
+
+	// RevokeFamily 撤销令牌家族
+	// 撤销后该家族的所有刷新令牌都无法再轮换
+	// 参数:
+	//   family: 令牌家族标识
+	//   expiresAt: 家族中最新令牌的最晚过期时间,之后可以清理该记录
+	// 返回:
+	//   error: 存储访问失败时的错误
+	RevokeFamily(family string, expiresAt time.Time) error
+
+	// IsFamilyRevoked 判断令牌家族是否已被撤销
+	// 参数:
+	//   family: 令牌家族标识
+	// 返回:
+	//   bool: 已撤销返回 true
+	//   error: 存储访问失败时的错误
+	IsFamilyRevoked(family string) (bool, error)
+}
+
+// memoryRefreshStore 基于内存的刷新令牌存储
+// 过期记录在访问时按 refreshStoreSweepInterval 周期清理
+type memoryRefreshStore struct {
+	mu        sync.Mutex
+	used      map[string]time.Time
+	revoked   map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryRefreshStore 创建基于内存的刷新令牌存储
+// 注意: 状态只保存在当前进程,重启后丢失,多实例部署时无法共享
+// 返回:
+//
+//	RefreshTokenStore: 刷新令牌存储
+//
+// 使用示例:
+//
+//	j, err := jwt.New(&jwt.Config{
+//	    Secret:       secret,
+//	    RefreshStore: jwt.NewMemoryRefreshStore(),
+//	})
+func NewMemoryRefreshStore() RefreshTokenStore {
+	return &memoryRefreshStore{
+		used:      make(map[string]time.Time),
+		revoked:   make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+// MarkUsed 实现 RefreshTokenStore 接口
+func (s *memoryRefreshStore) MarkUsed(jti string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+
+	if _, ok := s.used[jti]; ok {
+		return false, nil
+	}
+	s.used[jti] = expiresAt
+	return true, nil
+}
+
+// RevokeFamily 实现 RefreshTokenStore 接口
+func (s *memoryRefreshStore) RevokeFamily(family string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sweep()
+
+	s.revoked[family] = expiresAt
+	return nil
+}
+
+// IsFamilyRevoked 实现 RefreshTokenStore 接口
+func (s *memoryRefreshStore) IsFamilyRevoked(family string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.revoked[family]
+	return ok, nil
+}
+
+// sweep 清理已过期的记录
+// 调用方需持有锁
+func (s *memoryRefreshStore) sweep() {
+	now := time.Now()
+	if now.Sub(s.lastSweep) < refreshStoreSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for jti, exp := range s.used {
+		if now.After(exp) {
+			delete(s.used, jti)
+		}
+	}
+	for family, exp := range s.revoked {
+		if now.After(exp) {
+			delete(s.revoked, family)
+		}
+	}
+}
+
+// GenerateRefreshToken 生成刷新令牌
+// 实现JWT接口的GenerateRefreshToken方法
+// 每次调用开启一个新的令牌家族,后续轮换产生的令牌属于同一家族
+// 参数:
+//
+//	userID: 用户ID
+//	username: 用户名
+//
+// 返回:
+//
+//	string: 刷新令牌
+//	error: 生成失败时的错误
+func (m *jwtManager) GenerateRefreshToken(userID int64, username string) (string, error) {
+	family, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.signRefreshToken(userID, username, family)
+}
+
+// RotateRefreshToken 轮换刷新令牌
+// 实现JWT接口的RotateRefreshToken方法
+// 参数:
+//
+//	oldRefresh: 当前持有的刷新令牌
+//
+// 返回:
+//
+//	access: 新的访问令牌
+//	newRefresh: 新的刷新令牌,与旧令牌属于同一家族
+//	err: 轮换失败时的错误
+//
+// 实现步骤:
+//  1. 验证旧令牌的签名、有效期,并确认是刷新令牌
+//  2. 配置了 RefreshStore 时检查家族是否已撤销,并将旧令牌标记为已使用
+//     旧令牌已经用过说明发生了重放,撤销整个家族
+//  3. 签发新的访问令牌和刷新令牌
+func (m *jwtManager) RotateRefreshToken(oldRefresh string) (string, string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// 1. 验证旧令牌
+	claims, err := m.parseClaims(oldRefresh)
+	if err != nil {
+		return "", "", err
+	}
+	if claims.TokenType != TokenTypeRefresh || claims.Family == "" || claims.ID == "" {
+		return "", "", ErrInvalidTokenType
+	}
+
+	// 2. 重放检测
+	if m.refreshStore != nil {
+		revoked, err := m.refreshStore.IsFamilyRevoked(claims.Family)
+		if err != nil {
+			return "", "", fmt.Errorf(ErrMsgRefreshStoreFailed, err)
+		}
+		if revoked {
+			return "", "", ErrRefreshTokenReused
+		}
+
+		first, err := m.refreshStore.MarkUsed(claims.ID, claims.ExpiresAt.Time)
+		if err != nil {
+			return "", "", fmt.Errorf(ErrMsgRefreshStoreFailed, err)
+		}
+		if !first {
+			// 家族中最新的令牌最晚在 now + refreshExpiresIn 过期
+			if err := m.refreshStore.RevokeFamily(claims.Family, time.Now().Add(m.refreshExpiresIn)); err != nil {
+				return "", "", fmt.Errorf(ErrMsgRefreshStoreFailed, err)
+			}
+			return "", "", ErrRefreshTokenReused
+		}
+	}
+
+	// 3. 签发新令牌对
+	access, err := m.signToken(m.newClaims(claims.UserID, claims.Username, m.ownAudience(&tokenOptions{})))
+	if err != nil {
+		return "", "", err
+	}
+	newRefresh, err := m.signRefreshToken(claims.UserID, claims.Username, claims.Family)
+	if err != nil {
+		return "", "", err
+	}
+
+	return access, newRefresh, nil
+}
+
+// signRefreshToken 签发属于指定家族的刷新令牌
+// 调用方需持有读锁
+func (m *jwtManager) signRefreshToken(userID int64, username, family string) (string, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	claims := m.newClaims(userID, username, m.ownAudience(&tokenOptions{ttl: m.refreshExpiresIn}))
+	claims.TokenType = TokenTypeRefresh
+	claims.Family = family
+	claims.ID = jti
+
+	return m.signToken(claims)
+}
+
+// ownAudience 配置了期望受众时,将其写入令牌
+// 保证本管理器签发的令牌能通过自身的受众校验
+// 调用方需持有读锁
+func (m *jwtManager) ownAudience(o *tokenOptions) *tokenOptions {
+	if m.audience != "" {
+		o.audience = []string{m.audience}
+	}
+	return o
+}
+
+// newTokenID 生成随机的令牌标识
+// 用作 jti 和令牌家族标识
+func newTokenID() (string, error) {
+	b := make([]byte, tokenIDBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// signToken 使用HMAC-SHA256签名claims
+// 调用方需持有读锁
+func (m *jwtManager) signToken(claims *Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(m.secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return tokenString, nil
+}
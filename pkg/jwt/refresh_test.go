@@ -0,0 +1,129 @@
+package jwt
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTestRefreshJWT 创建配置了内存刷新令牌存储的 JWT 管理器
+func newTestRefreshJWT(t *testing.T) *jwtManager {
+	t.Helper()
+	j, err := New(&Config{
+		Secret:       "0123456789abcdef0123456789abcdef",
+		RefreshStore: NewMemoryRefreshStore(),
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return j.(*jwtManager)
+}
+
+// TestRotateRefreshToken 测试刷新令牌轮换和重放检测
+func TestRotateRefreshToken(t *testing.T) {
+	j := newTestRefreshJWT(t)
+
+	first, err := j.GenerateRefreshToken(1, "alice")
+	if err != nil {
+		t.Fatalf("GenerateRefreshToken() error = %v", err)
+	}
+
+	// 轮换返回新的令牌对,新刷新令牌与旧令牌属于同一家族
+	access, second, err := j.RotateRefreshToken(first)
+	if err != nil {
+		t.Fatalf("RotateRefreshToken() error = %v", err)
+	}
+	if second == first {
+		t.Error("RotateRefreshToken() returned the old refresh token")
+	}
+	claims, err := j.ValidateToken(access)
+	if err != nil || claims.UserID != 1 || claims.Username != "alice" {
+		t.Fatalf("ValidateToken(access) = %+v, %v", claims, err)
+	}
+	oldClaims, _ := j.parseClaims(first)
+	newClaims, _ := j.parseClaims(second)
+	if newClaims.Family != oldClaims.Family || newClaims.ID == oldClaims.ID {
+		t.Errorf("family = %q/%q, jti = %q/%q, want same family and new jti",
+			oldClaims.Family, newClaims.Family, oldClaims.ID, newClaims.ID)
+	}
+
+	// 刷新令牌不能当作访问令牌使用
+	if _, err := j.ValidateToken(second); !errors.Is(err, ErrInvalidTokenType) {
+		t.Errorf("ValidateToken(refresh) error = %v, want ErrInvalidTokenType", err)
+	}
+
+	// 重放旧令牌:返回 ErrRefreshTokenReused 并撤销整个家族
+	if _, _, err := j.RotateRefreshToken(first); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("RotateRefreshToken(replayed) error = %v, want ErrRefreshTokenReused", err)
+	}
+	if revoked, _ := j.refreshStore.IsFamilyRevoked(oldClaims.Family); !revoked {
+		t.Error("family should be revoked after replay")
+	}
+
+	// 家族中尚未使用的最新令牌同样被拒绝
+	if _, _, err := j.RotateRefreshToken(second); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Errorf("RotateRefreshToken(newest) error = %v, want ErrRefreshTokenReused", err)
+	}
+
+	// 其他家族不受影响
+	other, _ := j.GenerateRefreshToken(1, "alice")
+	if _, _, err := j.RotateRefreshToken(other); err != nil {
+		t.Errorf("RotateRefreshToken(other family) error = %v", err)
+	}
+}
+
+// TestRotateRefreshTokenRejectsAccessToken 测试访问令牌不能用于轮换
+func TestRotateRefreshTokenRejectsAccessToken(t *testing.T) {
+	j := newTestRefreshJWT(t)
+
+	access, _ := j.GenerateToken(1, "alice")
+	if _, _, err := j.RotateRefreshToken(access); !errors.Is(err, ErrInvalidTokenType) {
+		t.Errorf("RotateRefreshToken(access) error = %v, want ErrInvalidTokenType", err)
+	}
+	if _, _, err := j.RotateRefreshToken("garbage"); !errors.Is(err, ErrTokenMalformed) {
+		t.Errorf("RotateRefreshToken(garbage) error = %v, want ErrTokenMalformed", err)
+	}
+}
+
+// TestMemoryRefreshStore 测试内存存储的一次性标记、家族撤销和过期清理
+func TestMemoryRefreshStore(t *testing.T) {
+	s := NewMemoryRefreshStore().(*memoryRefreshStore)
+	exp := time.Now().Add(time.Hour)
+
+	if first, err := s.MarkUsed("a", exp); err != nil || !first {
+		t.Errorf("MarkUsed() first = %v, %v, want true", first, err)
+	}
+	if first, err := s.MarkUsed("a", exp); err != nil || first {
+		t.Errorf("MarkUsed() second = %v, %v, want false", first, err)
+	}
+
+	if revoked, _ := s.IsFamilyRevoked("f"); revoked {
+		t.Error("IsFamilyRevoked() = true before RevokeFamily")
+	}
+	if err := s.RevokeFamily("f", exp); err != nil {
+		t.Fatalf("RevokeFamily() error = %v", err)
+	}
+	if revoked, _ := s.IsFamilyRevoked("f"); !revoked {
+		t.Error("IsFamilyRevoked() = false after RevokeFamily")
+	}
+
+	// 过期记录在下一次清理时删除,未过期的保留
+	past := time.Now().Add(-time.Minute)
+	_, _ = s.MarkUsed("old", past)
+	_ = s.RevokeFamily("old", past)
+	s.lastSweep = time.Now().Add(-2 * refreshStoreSweepInterval)
+	_, _ = s.MarkUsed("b", exp)
+
+	if _, ok := s.used["old"]; ok {
+		t.Error("expired jti was not swept")
+	}
+	if _, ok := s.revoked["old"]; ok {
+		t.Error("expired family was not swept")
+	}
+	if _, ok := s.used["a"]; !ok {
+		t.Error("unexpired jti was swept")
+	}
+	if revoked, _ := s.IsFamilyRevoked("f"); !revoked {
+		t.Error("unexpired family was swept")
+	}
+}
@@ -0,0 +1,182 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// asyncWriter 异步缓冲写入器,实现 zapcore.WriteSyncer
+// 设计要点:
+// - Write 只把编码好的日志复制到有界队列,磁盘/网络 I/O 由后台 goroutine 完成
+// - 单队列单消费者,日志按写入顺序输出
+// - 后台 goroutine 合并队列中已有的条目批量写入,减少系统调用
+// - Sync 通过同一队列投递刷新请求,返回时之前写入的日志都已落盘
+// - 队列满时按配置阻塞等待或丢弃(丢弃数量在下次刷新时报告到 stderr)
+type asyncWriter struct {
+	// out 实际的输出目标
+	out zapcore.WriteSyncer
+
+	// queue 待写入的日志和刷新请求
+	queue chan asyncItem
+
+	// dropWhenFull 队列满时是否丢弃日志
+	dropWhenFull bool
+
+	// dropped 因队列满被丢弃的日志条数
+	dropped atomic.Int64
+
+	// mu 保护 closed,发送方持有读锁,Close 持有写锁
+	// 保证关闭队列时没有正在进行的发送
+	mu     sync.RWMutex
+	closed bool
+
+	// done 后台 goroutine 退出时关闭
+	done chan struct{}
+}
+
+// asyncItem 队列元素,flush 非 nil 时表示刷新请求
+type asyncItem struct {
+	data  []byte
+	flush chan error
+}
+
+// newAsyncWriter 创建异步写入器并启动后台写入 goroutine
+// 参数:
+//
+//	out: 实际的输出目标
+//	queueSize: 队列容量(条),<= 0 时使用 DefaultAsyncQueueSize
+//	dropWhenFull: 队列满时丢弃日志(true)还是阻塞等待(false)
+func newAsyncWriter(out zapcore.WriteSyncer, queueSize int, dropWhenFull bool) *asyncWriter {
+	if queueSize <= 0 {
+		queueSize = DefaultAsyncQueueSize
+	}
+
+	w := &asyncWriter{
+		out:          out,
+		queue:        make(chan asyncItem, queueSize),
+		dropWhenFull: dropWhenFull,
+		done:         make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write 将日志放入队列
+// zap 在 Write 返回后会复用 p 的底层缓冲,因此需要复制
+// 写入器关闭后直接同步写入 out,Reload 前派生的子 logger 仍然可用
+func (w *asyncWriter) Write(p []byte) (int, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.closed {
+		return w.out.Write(p)
+	}
+
+	item := asyncItem{data: append([]byte(nil), p...)}
+	if w.dropWhenFull {
+		select {
+		case w.queue <- item:
+		default:
+			w.dropped.Add(1)
+		}
+		return len(p), nil
+	}
+
+	w.queue <- item
+	return len(p), nil
+}
+
+// Sync 等待队列中已有的日志写入完成,然后刷新 out
+// 刷新请求总是阻塞投递,即使配置了 dropWhenFull 也不会被丢弃
+func (w *asyncWriter) Sync() error {
+	w.mu.RLock()
+	if w.closed {
+		w.mu.RUnlock()
+		return w.out.Sync()
+	}
+
+	done := make(chan error, 1)
+	w.queue <- asyncItem{flush: done}
+	w.mu.RUnlock()
+
+	return <-done
+}
+
+// Close 写完队列中剩余的日志后停止后台 goroutine
+// 重复调用是安全的
+func (w *asyncWriter) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.queue)
+	w.mu.Unlock()
+
+	<-w.done
+	w.reportDropped()
+	return w.out.Sync()
+}
+
+// run 后台写入循环
+// 每次取出一条后继续合并队列中已有的条目,达到 maxAsyncBatchSize 或队列为空时写入
+func (w *asyncWriter) run() {
+	defer close(w.done)
+
+	batch := make([]byte, 0, maxAsyncBatchSize)
+	for item := range w.queue {
+		batch = w.handle(batch, item)
+		for len(w.queue) > 0 && len(batch) < maxAsyncBatchSize {
+			batch = w.handle(batch, <-w.queue)
+		}
+		batch = w.write(batch)
+	}
+}
+
+// handle 处理单个队列元素
+// 日志追加到 batch;刷新请求先写出 batch 再刷新 out
+func (w *asyncWriter) handle(batch []byte, item asyncItem) []byte {
+	if item.flush == nil {
+		return append(batch, item.data...)
+	}
+
+	batch = w.write(batch)
+	w.reportDropped()
+	item.flush <- w.out.Sync()
+	return batch
+}
+
+// write 写出 batch 并返回清空后的 batch
+// 写入错误输出到 stderr,避免递归写日志
+func (w *asyncWriter) write(batch []byte) []byte {
+	if len(batch) == 0 {
+		return batch
+	}
+	if _, err := w.out.Write(batch); err != nil {
+		fmt.Fprintf(os.Stderr, "logger async write error: %v\n", err)
+	}
+	return batch[:0]
+}
+
+// reportDropped 将自上次报告以来丢弃的日志数量输出到 stderr
+func (w *asyncWriter) reportDropped() {
+	if n := w.dropped.Swap(0); n > 0 {
+		fmt.Fprintf(os.Stderr, MsgAsyncDropped, n)
+	}
+}
+
+// closeAsyncWriters 关闭所有异步写入器,返回第一个错误
+func closeAsyncWriters(writers []*asyncWriter) error {
+	var firstErr error
+	for _, w := range writers {
+		if err := w.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
@@ -1,5 +1,7 @@
 package logger
 
+import "time"
+
 type Level int8
 
 // Level 定义日志级别
@@ -8,6 +10,7 @@ const (
 	LevelInfo
 	LevelWarn
 	LevelError
+	LevelPanic
 	LevelFatal
 )
 
@@ -38,12 +41,43 @@ const (
 	OutputFile   = "file"   // 仅输出到文件
 	OutputBoth   = "both"   // 同时输出到文件和控制台
 
+	// FieldTraceID OpenTelemetry trace ID 的日志字段名
+	FieldTraceID = "trace_id"
+
+	// FieldSpanID OpenTelemetry span ID 的日志字段名
+	FieldSpanID = "span_id"
+
+	// RedactedValue 脱敏字段的替换值
+	RedactedValue = "***"
+
+	// DefaultRateLimitInterval 错误日志限流的默认时间窗口
+	DefaultRateLimitInterval = time.Minute
+
+	// maxRateLimitKeys 错误日志限流跟踪的最大消息数
+	// 超出时清空计数,防止消息中拼接了动态内容导致内存增长
+	maxRateLimitKeys = 10000
+
+	// DefaultAsyncQueueSize 异步写入队列的默认容量(条)
+	DefaultAsyncQueueSize = 4096
+
+	// maxAsyncBatchSize 异步写入时单次合并写入的最大字节数
+	maxAsyncBatchSize = 256 << 10
+
+	// MsgAsyncDropped 异步队列满时丢弃日志的报告消息(输出到 stderr)
+	MsgAsyncDropped = "logger: dropped %d log entries because the async queue was full\n"
+
+	// MsgErrorsSuppressed 错误日志限流的汇总消息
+	MsgErrorsSuppressed = "suppressed %d identical errors"
+
 	// MsgLoggerReloading 日志重载中消息
 	MsgLoggerReloading = "reloading logger configuration"
 
 	// MsgLoggerReloaded 日志重载成功消息
 	MsgLoggerReloaded = "logger configuration reloaded successfully"
 
+	// MsgInvalidFields StrictFields 校验键值对失败时的日志消息
+	MsgInvalidFields = "logger: invalid keysAndValues"
+
 	// ErrMsgReloadFailed 重载失败的错误消息
 	ErrMsgReloadFailed = "failed to reload logger: %w"
 )
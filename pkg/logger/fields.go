@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
+
+// sugarFor 读取当前的 SugaredLogger
+// 启用 Config.StrictFields 时先校验键值对,所有级别的日志方法统一经过这里
+func (l *zapLogger) sugarFor(keysAndValues []interface{}) *zap.SugaredLogger {
+	l.mu.RLock()
+	sugar := l.sugar
+	strict := l.config != nil && l.config.StrictFields
+	l.mu.RUnlock()
+
+	if strict {
+		checkFields(sugar, 2, keysAndValues)
+	}
+	return sugar
+}
+
+// checkFields 校验键值对是否成对出现且键为字符串
+// 与 SugaredLogger 的解析规则一致: 直接传入的 zap.Field 单独占一个位置
+// 发现问题时以 DPanic 级别输出 MsgInvalidFields,
+// 启用 Config.Development 时 DPanic 会 panic(见 New),其他情况只记录日志
+// 参数:
+//
+//	sugar: 输出问题的 logger
+//	skip: 业务代码与 checkFields 之间的调用层数,用于 caller 定位
+//	keysAndValues: 待校验的键值对
+func checkFields(sugar *zap.SugaredLogger, skip int, keysAndValues []interface{}) {
+	for i := 0; i < len(keysAndValues); {
+		if _, ok := keysAndValues[i].(zap.Field); ok {
+			i++
+			continue
+		}
+
+		var problem string
+		key := keysAndValues[i]
+		if i == len(keysAndValues)-1 {
+			problem = fmt.Sprintf("key %v has no value", key)
+		} else if _, ok := key.(string); !ok {
+			problem = fmt.Sprintf("key %v at index %d is %T, not string", key, i, key)
+		}
+		if problem != "" {
+			sugar.Desugar().WithOptions(zap.AddCallerSkip(skip)).DPanic(MsgInvalidFields, zap.String("problem", problem))
+			return
+		}
+		i += 2
+	}
+}
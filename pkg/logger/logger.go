@@ -6,7 +6,12 @@
 // - 便于切换日志实现,无需修改业务代码
 package logger
 
-import "github.com/rei0721/go-scaffold/pkg/executor"
+import (
+	"context"
+	"log"
+
+	"github.com/rei0721/go-scaffold/pkg/executor"
+)
 
 // Logger 定义统一的日志接口
 // 这是一个抽象接口,具体实现在 zap.go 中
@@ -70,6 +75,19 @@ type Logger interface {
 	//   log.Error("external API error", "service", "payment", "statusCode", 500)
 	Error(msg string, keysAndValues ...interface{})
 
+	// Panic 记录错误日志后 panic
+	// 与 Fatal 的区别:
+	//   panic 可以被上层的 recover 捕获(如 HTTP 恢复中间件、协程池的 PanicHandler),
+	//   进程不会退出;Fatal 直接调用 os.Exit(1),defer 不会执行
+	// 用途:
+	// - 违反程序不变量、需要中止当前请求或任务的错误
+	// 参数:
+	//   msg: 日志消息,同时作为 panic 的值
+	//   keysAndValues: 可选的键值对
+	// 示例:
+	//   log.Panic("unexpected state", "orderId", id, "state", state)
+	Panic(msg string, keysAndValues ...interface{})
+
 	// Fatal 记录致命错误并退出程序
 	// 用途:
 	// - 无法恢复的严重错误
@@ -103,6 +121,31 @@ type Logger interface {
 	//   - 便于日志检索和分析
 	With(keysAndValues ...interface{}) Logger
 
+	// WithContext 返回绑定了请求上下文的 Logger
+	// 启用 Config.OTelCorrelation 且 ctx 中携带有效的 OpenTelemetry span 时,
+	// 返回的 Logger 自动附加 trace_id 和 span_id 字段,将日志与链路追踪关联
+	// 未启用、ctx 为 nil 或没有 span 时直接返回当前 Logger,不产生额外开销
+	// 参数:
+	//   ctx: 请求上下文,通常来自 c.Request.Context()
+	// 返回:
+	//   Logger: 带追踪字段的 Logger
+	// 使用示例:
+	//   log.WithContext(ctx).Info("order created", "orderId", id)
+	//   // {"msg":"order created","orderId":1,"trace_id":"4bf9...","span_id":"00f0..."}
+	WithContext(ctx context.Context) Logger
+
+	// StdLogger 返回标准库 *log.Logger 适配器
+	// 写入的每一行都以给定级别记录到当前 Logger,
+	// 用于只接受 *log.Logger 的第三方库(如 http.Server.ErrorLog)
+	// 参数:
+	//   level: 日志级别(debug/info/warn/error),无法识别时使用 info;
+	//          panic/fatal 按 error 记录,标准库日志不会导致 panic 或退出进程
+	// 返回:
+	//   *log.Logger: 无前缀、无标志位的标准库 logger,时间和调用者由当前 Logger 记录
+	// 使用示例:
+	//   srv.ErrorLog = log.StdLogger("error")
+	StdLogger(level string) *log.Logger
+
 	// Sync 刷新缓冲的日志条目
 	// 用途:
 	// - 确保所有日志都写入磁盘
@@ -228,4 +271,61 @@ type Config struct {
 	// - 磁盘空间
 	// - 问题排查需求
 	MaxAge int
+
+	// ErrorFilePath 错误日志文件路径(可选)
+	// 设置后 Error 及以上级别的日志额外写入该文件,与 Output 的输出目标同时生效
+	// 便于告警系统只监控错误,不被大量 info 日志淹没
+	// 使用 FileFormat 格式,与 FilePath 分别按 MaxSize、MaxBackups、MaxAge 轮转
+	// 例如: /var/log/app/error.log
+	// 为空时不单独输出错误日志
+	ErrorFilePath string
+
+	// RedactKeys 需要脱敏的字段键(不区分大小写)
+	// 匹配的字段值在编码前替换为 RedactedValue("***"),对所有输出生效
+	// 例如: []string{"password", "token", "authorization"}
+	// 注意: 只匹配顶层字段,不检查嵌套对象内部
+	RedactKeys []string
+
+	// ErrorRateLimit 错误日志限流(可选)
+	// 只作用于 Error/Fatal 级别,按消息内容计数:
+	// 同一消息在 Interval 内超过 MaxPerInterval 条后被抑制,
+	// 窗口结束后输出一条 "suppressed N identical errors" 汇总
+	// 用途: 依赖故障时热点错误路径不会写出海量相同的堆栈
+	// nil 表示不限流
+	ErrorRateLimit *RateLimitConfig
+
+	// Async 是否异步写入日志
+	// 启用后日志先进入有界内存队列,由后台 goroutine 按顺序批量写入输出目标,
+	// 高频的 Info 等调用不再阻塞在磁盘或网络 I/O 上
+	// Sync 会等待队列中的日志写完,Panic/Fatal 级别的日志写入后自动刷新
+	// 注意: 进程退出前必须调用 Sync(通常 defer log.Sync()),否则队列中的日志会丢失
+	Async bool
+
+	// AsyncQueueSize 异步队列容量(条)
+	// 仅当 Async=true 时有效,<= 0 时使用 DefaultAsyncQueueSize
+	AsyncQueueSize int
+
+	// AsyncDropWhenFull 异步队列满时的处理策略
+	// - false: 阻塞等待队列有空位,不丢日志(默认)
+	// - true: 丢弃新日志,调用方永不阻塞,丢弃数量在下次 Sync 时输出到 stderr
+	AsyncDropWhenFull bool
+
+	// OTelCorrelation 是否从上下文注入 OpenTelemetry 追踪字段
+	// 启用后 WithContext 从 trace.SpanContextFromContext(ctx) 读取
+	// trace_id 和 span_id 附加到日志,便于在追踪系统和日志系统之间跳转
+	// 上下文中没有 span 时不附加任何字段
+	OTelCorrelation bool
+
+	// StrictFields 是否校验结构化日志的键值对(开发模式)
+	// 启用后所有级别的日志方法和 With 都会检查 keysAndValues:
+	// 数量为奇数(缺少值)或键不是字符串时,以 DPanic 级别输出 MsgInvalidFields,
+	// 并在 problem 字段中说明问题,caller 指向出错的调用处
+	// 同时启用 Development 时 DPanic 直接 panic,让测试立即失败
+	// 生产环境建议关闭,避免每条日志的额外检查
+	StrictFields bool
+
+	// Development 是否启用 zap 开发模式(默认false)
+	// 启用后 DPanic 级别的日志写入后直接 panic,用于测试和本地开发
+	// 生产环境应关闭,否则 StrictFields 发现的键值对错误会导致进程 panic
+	Development bool
 }
@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WithContext 返回绑定了请求上下文的 Logger
+// 实现 Logger 接口
+// 启用 OTelCorrelation 时从 ctx 读取 OpenTelemetry 的 span 上下文,
+// 附加 trace_id 和 span_id 字段;只读取 span 上下文,不依赖 OTel SDK 的初始化
+// 参数:
+//
+//	ctx: 请求上下文
+//
+// 返回:
+//
+//	Logger: 带追踪字段的子 Logger,无需附加字段时返回当前 Logger
+func (l *zapLogger) WithContext(ctx context.Context) Logger {
+	l.mu.RLock()
+	enabled := l.config != nil && l.config.OTelCorrelation
+	l.mu.RUnlock()
+
+	if !enabled || ctx == nil {
+		return l
+	}
+
+	// 没有 span 或 span 无效(如未初始化 TracerProvider 时的 noop span)时不附加字段
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+
+	return l.With(FieldTraceID, sc.TraceID().String(), FieldSpanID, sc.SpanID().String())
+}
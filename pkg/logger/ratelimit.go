@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RateLimitConfig 错误日志限流配置
+type RateLimitConfig struct {
+	// MaxPerInterval 每个时间窗口内同一消息最多输出的条数
+	// 超出的部分被抑制,只计数
+	MaxPerInterval int
+
+	// Interval 时间窗口长度
+	// 未设置时使用 DefaultRateLimitInterval
+	Interval time.Duration
+}
+
+// rateLimitCore 按消息内容对 Error 及以上级别日志限流的 zapcore.Core 包装器
+// 与 zap 的 sampler 的区别:
+// - 只作用于 Error/Panic/Fatal,Debug/Info/Warn 不受影响
+// - 按消息内容计数,同一故障的重复错误被合并
+// - 窗口结束后输出一条 "suppressed N identical errors" 汇总,保留被抑制的数量
+// 汇总在同一消息的下一条日志到达或 Sync 时输出,不启动额外的 goroutine
+type rateLimitCore struct {
+	zapcore.Core
+
+	// limiter 所有 With 派生的 core 共享同一个限流器
+	limiter *errorLimiter
+}
+
+// errorLimiter 按消息计数的限流状态
+type errorLimiter struct {
+	mu       sync.Mutex
+	max      int
+	interval time.Duration
+	buckets  map[string]*rateBucket
+
+	// out 汇总写入的 core(未附加 With 字段的原始 core)
+	out zapcore.Core
+
+	// now 当前时间,测试时可替换
+	now func() time.Time
+}
+
+// rateBucket 单个消息在当前窗口的计数
+type rateBucket struct {
+	start      time.Time
+	count      int
+	suppressed int
+	level      zapcore.Level
+	logger     string
+}
+
+// newRateLimitCore 使用限流配置包装 core
+// cfg 为 nil 或 MaxPerInterval <= 0 时直接返回原 core
+func newRateLimitCore(core zapcore.Core, cfg *RateLimitConfig) zapcore.Core {
+	if cfg == nil || cfg.MaxPerInterval <= 0 {
+		return core
+	}
+
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = DefaultRateLimitInterval
+	}
+
+	return &rateLimitCore{
+		Core: core,
+		limiter: &errorLimiter{
+			max:      cfg.MaxPerInterval,
+			interval: interval,
+			buckets:  make(map[string]*rateBucket),
+			out:      core,
+			now:      time.Now,
+		},
+	}
+}
+
+// With 添加上下文字段,派生的 core 共享限流状态
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), limiter: c.limiter}
+}
+
+// Check 判断是否记录该日志
+// 限流在 Write 中判断,外层包装器(如脱敏)直接调用 Write 时同样生效
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 限流后写入内部 core
+func (c *rateLimitCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if ent.Level < zapcore.ErrorLevel {
+		return c.Core.Write(ent, fields)
+	}
+
+	allowed, summary := c.limiter.allow(ent)
+	if summary != nil {
+		_ = c.limiter.out.Write(summary.entry, summary.fields)
+	}
+	if !allowed {
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// Sync 输出所有待汇总的抑制计数后刷新内部 core
+func (c *rateLimitCore) Sync() error {
+	for _, s := range c.limiter.flush() {
+		_ = c.limiter.out.Write(s.entry, s.fields)
+	}
+	return c.Core.Sync()
+}
+
+// suppressedSummary 抑制汇总日志
+type suppressedSummary struct {
+	entry  zapcore.Entry
+	fields []zapcore.Field
+}
+
+// allow 判断该条错误是否可以输出
+// 返回:
+//
+//	bool: 当前窗口未超出 MaxPerInterval 时为 true
+//	*suppressedSummary: 上一个窗口有被抑制的日志时返回汇总,否则为 nil
+func (l *errorLimiter) allow(ent zapcore.Entry) (bool, *suppressedSummary) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[ent.Message]
+	if !ok {
+		// 消息种类过多时清空,防止内存无限增长
+		if len(l.buckets) >= maxRateLimitKeys {
+			l.buckets = make(map[string]*rateBucket)
+		}
+		b = &rateBucket{start: now}
+		l.buckets[ent.Message] = b
+	}
+
+	var summary *suppressedSummary
+	if now.Sub(b.start) >= l.interval {
+		summary = l.summary(ent.Message, b)
+		b.start = now
+		b.count = 0
+		b.suppressed = 0
+	}
+
+	b.level = ent.Level
+	b.logger = ent.LoggerName
+	if b.count < l.max {
+		b.count++
+		return true, summary
+	}
+	b.suppressed++
+	return false, summary
+}
+
+// flush 返回所有消息的抑制汇总并重置状态
+func (l *errorLimiter) flush() []*suppressedSummary {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var out []*suppressedSummary
+	for msg, b := range l.buckets {
+		if s := l.summary(msg, b); s != nil {
+			out = append(out, s)
+		}
+	}
+	l.buckets = make(map[string]*rateBucket)
+	return out
+}
+
+// summary 构建单个消息的抑制汇总,没有被抑制的日志时返回 nil
+// 调用方需持有锁
+func (l *errorLimiter) summary(msg string, b *rateBucket) *suppressedSummary {
+	if b.suppressed == 0 {
+		return nil
+	}
+	return &suppressedSummary{
+		entry: zapcore.Entry{
+			Level:      b.level,
+			Time:       l.now(),
+			LoggerName: b.logger,
+			Message:    fmt.Sprintf(MsgErrorsSuppressed, b.suppressed),
+		},
+		fields: []zapcore.Field{
+			zap.String("suppressedMessage", msg),
+			zap.Int("suppressed", b.suppressed),
+			zap.Duration("interval", l.interval),
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactCore 在编码前脱敏敏感字段的 zapcore.Core 包装器
+// 为什么在 Core 层实现:
+// - 对所有输出(控制台/文件、JSON/Console 格式)统一生效
+// - 覆盖 With 添加的上下文字段和每条日志的字段
+// - 业务代码无需关心哪些字段需要脱敏
+// 限制:
+// - 只匹配顶层字段的键,不检查嵌套对象(如 zap.Object、map)内部
+type redactCore struct {
+	zapcore.Core
+
+	// keys 需要脱敏的键(小写),用于不区分大小写匹配
+	keys map[string]struct{}
+}
+
+// newRedactCore 使用脱敏键包装 core
+// keys 为空时直接返回原 core,不引入额外开销
+func newRedactCore(core zapcore.Core, keys []string) zapcore.Core {
+	if len(keys) == 0 {
+		return core
+	}
+
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	return &redactCore{Core: core, keys: set}
+}
+
+// With 添加上下文字段,字段在加入前脱敏
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), keys: c.keys}
+}
+
+// Check 判断是否记录该日志
+// 必须将自身加入 CheckedEntry,否则 Write 会绕过脱敏直接调用内部 core
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 脱敏字段后写入内部 core
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redact(fields))
+}
+
+// redact 返回脱敏后的字段
+// 没有命中的字段时返回原切片,命中时复制,避免修改调用方的数据
+func (c *redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, f := range fields {
+		if _, ok := c.keys[strings.ToLower(f.Key)]; !ok {
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, len(fields))
+			copy(out, fields)
+		}
+		out[i] = zap.String(f.Key, RedactedValue)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
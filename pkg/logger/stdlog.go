@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"log"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// StdLogger 返回标准库 *log.Logger 适配器
+// 实现 Logger 接口
+// 每次写入时读取当前的 sugar,Reload 之后输出自动切换到新配置
+// 参数:
+//
+//	level: 日志级别,无法识别时使用 info,panic/fatal 按 error 记录
+//
+// 返回:
+//
+//	*log.Logger: 输出到当前 Logger 的标准库 logger
+//
+// 使用示例:
+//
+//	server, _ := httpserver.New(router, cfg, log,
+//	    httpserver.WithServerOptions(func(srv *http.Server) {
+//	        srv.ErrorLog = log.StdLogger("error")
+//	    }),
+//	)
+func (l *zapLogger) StdLogger(level string) *log.Logger {
+	level = strings.ToLower(level)
+	lvl := parseLevel(level)
+	// 标准库日志不应导致 panic 或退出进程,降级为 error
+	if lvl > LevelError || level == "panic" {
+		lvl = LevelError
+	}
+	return log.New(&stdLogWriter{logger: l, level: zapParseLevel(lvl)}, "", 0)
+}
+
+// stdLogWriter 将标准库 log 的输出写入 zapLogger
+// log.Logger 保证每次 Write 是一条完整的日志,末尾带换行符
+type stdLogWriter struct {
+	logger *zapLogger
+	level  zapcore.Level
+}
+
+// Write 实现 io.Writer 接口
+// 去掉末尾换行后作为日志消息,以固定级别记录
+// 调用链为 调用方 -> log.Logger.Printf -> log.Logger.output -> Write,
+// 额外跳过两层调用栈,使 caller 指向调用 log.Logger 的位置
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	w.logger.mu.RLock()
+	sugar := w.logger.sugar
+	w.logger.mu.RUnlock()
+
+	msg := string(bytes.TrimRight(p, "\r\n"))
+	sugar.WithOptions(zap.AddCallerSkip(2)).Logw(w.level, msg)
+	return len(p), nil
+}
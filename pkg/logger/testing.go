@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// LogEntry 表示一条被捕获的日志
+type LogEntry struct {
+	// Level 日志级别
+	Level Level
+
+	// Message 日志消息
+	Message string
+
+	// Fields 结构化字段(包括 With 附加的字段)
+	Fields map[string]interface{}
+}
+
+// LogSink 捕获日志条目的接收器
+// 由 NewTestLogger 创建,用于在单元测试中断言日志输出
+// 线程安全,可在并发测试中使用
+type LogSink struct {
+	logs *observer.ObservedLogs
+}
+
+// NewTestLogger 创建一个用于测试的 Logger
+// 所有级别的日志都会被记录到返回的 LogSink 中,不会输出到控制台或文件
+// Fatal 不会退出进程,而是在记录后 panic,便于测试捕获
+//
+// 返回:
+//
+//	Logger: 日志接口
+//	*LogSink: 日志接收器,用于断言
+//
+// 使用示例:
+//
+//	log, sink := logger.NewTestLogger()
+//	svc.SetLogger(log)
+//	svc.Login(ctx, req)
+//	if !sink.Contains(logger.LevelWarn, "invalid password") {
+//	    t.Error("expected warn log")
+//	}
+//
+// 注意:
+//
+//	对测试 Logger 调用 Reload 会切换为按配置输出的真实 Logger,之后的日志不再被捕获
+func NewTestLogger() (Logger, *LogSink) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	zapLog := zap.New(core, zap.WithFatalHook(zapcore.WriteThenPanic))
+
+	return &zapLogger{
+		sugar:  zapLog.Sugar(),
+		config: &Config{Level: "debug"},
+	}, &LogSink{logs: logs}
+}
+
+// Entries 返回已捕获的所有日志条目(按记录顺序)
+func (s *LogSink) Entries() []LogEntry {
+	observed := s.logs.All()
+	entries := make([]LogEntry, 0, len(observed))
+	for _, e := range observed {
+		entries = append(entries, LogEntry{
+			Level:   fromZapLevel(e.Level),
+			Message: e.Message,
+			Fields:  e.ContextMap(),
+		})
+	}
+	return entries
+}
+
+// Contains 判断是否存在指定级别且消息包含 substr 的日志
+// 参数:
+//
+//	level: 日志级别
+//	substr: 消息子串
+func (s *LogSink) Contains(level Level, substr string) bool {
+	for _, e := range s.logs.All() {
+		if fromZapLevel(e.Level) == level && strings.Contains(e.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reset 清空已捕获的日志
+func (s *LogSink) Reset() {
+	s.logs.TakeAll()
+}
+
+// fromZapLevel 将 zap 日志级别转换为 Level
+func fromZapLevel(level zapcore.Level) Level {
+	switch level {
+	case zapcore.DebugLevel:
+		return LevelDebug
+	case zapcore.InfoLevel:
+		return LevelInfo
+	case zapcore.WarnLevel:
+		return LevelWarn
+	case zapcore.ErrorLevel:
+		return LevelError
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return LevelPanic
+	default:
+		return LevelFatal
+	}
+}
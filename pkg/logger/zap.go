@@ -46,6 +46,10 @@ type zapLogger struct {
 	// 也用于确保重载时使用正确的配置
 	config *Config
 
+	// async 异步写入器,Config.Async=false 时为空
+	// Reload 替换 logger 后关闭旧的写入器
+	async []*asyncWriter
+
 	// executor 协程池管理器（可选）
 	// 使用 atomic.Value 实现无锁读取
 	// 用于异步日志操作（如Sync刷新）
@@ -69,6 +73,9 @@ type zapLogger struct {
 //     - stdout: 使用控制台格式
 //     - file: 使用文件格式
 //     - both: 分别为控制台和文件创建 Core,然后合并
+//     配置了 Async 时每个输出目标包装为异步写入器
+//     配置了 ErrorRateLimit 时包装错误限流 Core
+//     配置了 RedactKeys 时在最外层包装脱敏 Core
 //  3. 创建 zap Logger
 //  4. 包装为 SugaredLogger
 func New(cfg *Config) (Logger, error) {
@@ -77,60 +84,68 @@ func New(cfg *Config) (Logger, error) {
 
 	output := strings.ToLower(cfg.Output)
 
+	// 异步模式下每个输出目标使用独立的异步写入器
+	var async []*asyncWriter
+	wrap := func(ws zapcore.WriteSyncer) zapcore.WriteSyncer {
+		if !cfg.Async {
+			return ws
+		}
+		w := newAsyncWriter(ws, cfg.AsyncQueueSize, cfg.AsyncDropWhenFull)
+		async = append(async, w)
+		return w
+	}
+
 	// 2. 根据输出模式构建 Core
 	var core zapcore.Core
 
 	switch output {
-	case OutputStdout:
-		// 仅控制台输出
-		consoleFormat := getConsoleFormat(cfg)
-		encoder := buildEncoder(consoleFormat)
-		writer := zapcore.AddSync(os.Stdout)
-		core = zapcore.NewCore(encoder, writer, level)
-
 	case OutputFile:
 		// 仅文件输出
-		fileFormat := getFileFormat(cfg)
-		encoder := buildEncoder(fileFormat)
-		writer := buildFileWriter(cfg)
-		core = zapcore.NewCore(encoder, writer, level)
+		core = buildCore(getFileFormat(cfg), wrap(buildFileWriter(cfg)), level)
 
 	case OutputBoth:
 		// 同时输出到控制台和文件,可以使用不同格式
-		consoleFormat := getConsoleFormat(cfg)
-		fileFormat := getFileFormat(cfg)
-
-		// 为控制台创建 Core
-		consoleEncoder := buildEncoder(consoleFormat)
-		consoleWriter := zapcore.AddSync(os.Stdout)
-		consoleCore := zapcore.NewCore(consoleEncoder, consoleWriter, level)
-
-		// 为文件创建 Core
-		fileEncoder := buildEncoder(fileFormat)
-		fileWriter := buildFileWriter(cfg)
-		fileCore := zapcore.NewCore(fileEncoder, fileWriter, level)
-
 		// 使用 Tee 合并多个 Core
-		core = zapcore.NewTee(consoleCore, fileCore)
+		core = zapcore.NewTee(
+			buildCore(getConsoleFormat(cfg), wrap(zapcore.AddSync(os.Stdout)), level),
+			buildCore(getFileFormat(cfg), wrap(buildFileWriter(cfg)), level),
+		)
 
 	default:
-		// 未知模式,降级到控制台输出
-		consoleFormat := getConsoleFormat(cfg)
-		encoder := buildEncoder(consoleFormat)
-		writer := zapcore.AddSync(os.Stdout)
-		core = zapcore.NewCore(encoder, writer, level)
+		// stdout 或未知模式,使用控制台输出
+		core = buildCore(getConsoleFormat(cfg), wrap(zapcore.AddSync(os.Stdout)), level)
 	}
 
+	// 错误日志单独写入 ErrorFilePath,与上面的输出目标并存
+	if cfg.ErrorFilePath != "" {
+		core = zapcore.NewTee(core,
+			buildCore(getFileFormat(cfg), wrap(newRotatingWriter(cfg.ErrorFilePath, cfg)), errorLevelEnabler(level)),
+		)
+	}
+
+	// 错误日志限流,对所有输出统一计数
+	core = newRateLimitCore(core, cfg.ErrorRateLimit)
+
+	// 在最外层包装脱敏,对所有输出统一生效
+	core = newRedactCore(core, cfg.RedactKeys)
+
 	// 3. 创建 Logger
 	// zap.AddCaller(): 记录调用者信息(文件名和行号)
 	// zap.AddCallerSkip(1): 跳过 1 层调用栈
 	//   因为我们封装了一层,需要跳过才能显示真实调用者
-	zapLog := zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
+	opts := []zap.Option{zap.AddCaller(), zap.AddCallerSkip(1)}
+
+	// 开发模式下 DPanic 直接 panic,配合 StrictFields 时键值对错误无法被忽略
+	if cfg.Development {
+		opts = append(opts, zap.Development())
+	}
+	zapLog := zap.New(core, opts...)
 
 	// 4. 返回 SugaredLogger
 	return &zapLogger{
 		sugar:  zapLog.Sugar(),
 		config: cfg,
+		async:  async,
 	}, nil
 }
 
@@ -197,6 +212,35 @@ func zapParseLevel(level Level) zapcore.Level {
 	}
 }
 
+// buildCore 使用指定格式和写入器构建 Core
+// 参数:
+//
+//	format: 格式类型("json" 或 "console")
+//	writer: 输出写入器
+//	level: 最低日志级别
+//
+// 返回:
+//
+//	zapcore.Core: zap Core
+func buildCore(format string, writer zapcore.WriteSyncer, level zapcore.LevelEnabler) zapcore.Core {
+	return zapcore.NewCore(buildEncoder(format), writer, level)
+}
+
+// errorLevelEnabler 返回错误日志文件的级别过滤器
+// 只接受 Error 及以上级别,同时不低于全局的 Level
+// 参数:
+//
+//	level: 全局最低日志级别
+//
+// 返回:
+//
+//	zapcore.LevelEnabler: 级别过滤器
+func errorLevelEnabler(level zapcore.Level) zapcore.LevelEnabler {
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= zapcore.ErrorLevel && level.Enabled(l)
+	})
+}
+
 // buildEncoder 构建日志编码器
 // 编码器决定了日志的输出格式
 // 参数:
@@ -328,8 +372,22 @@ func buildFileWriter(cfg *Config) zapcore.WriteSyncer {
 		return zapcore.AddSync(os.Stdout)
 	}
 
+	return newRotatingWriter(cfg.FilePath, cfg)
+}
+
+// newRotatingWriter 构建带轮转的文件写入器
+// 每个文件使用独立的 lumberjack 实例,轮转参数取自 cfg
+// 参数:
+//
+//	path: 日志文件路径
+//	cfg: 日志配置
+//
+// 返回:
+//
+//	zapcore.WriteSyncer: 文件写入同步器
+func newRotatingWriter(path string, cfg *Config) zapcore.WriteSyncer {
 	lj := &lumberjack.Logger{
-		Filename:   cfg.FilePath,
+		Filename:   path,
 		MaxSize:    cfg.MaxSize,
 		MaxBackups: cfg.MaxBackups,
 		MaxAge:     cfg.MaxAge,
@@ -341,43 +399,40 @@ func buildFileWriter(cfg *Config) zapcore.WriteSyncer {
 // Debug 记录调试级别的日志
 // 实现 Logger 接口
 // 使用读锁保护,允许并发日志记录
+// 各级别方法都通过 sugarFor 获取 logger,启用 StrictFields 时统一校验键值对
 func (l *zapLogger) Debug(msg string, keysAndValues ...interface{}) {
 	// Debugw: "w" 表示 "with",支持键值对参数
 	// 例如: Debug("processing", "userId", 123, "action", "login")
-	l.mu.RLock()
-	sugar := l.sugar
-	l.mu.RUnlock()
-	sugar.Debugw(msg, keysAndValues...)
+	l.sugarFor(keysAndValues).Debugw(msg, keysAndValues...)
 }
 
 // Info 记录信息级别的日志
 // 实现 Logger 接口
 // 使用读锁保护,允许并发日志记录
 func (l *zapLogger) Info(msg string, keysAndValues ...interface{}) {
-	l.mu.RLock()
-	sugar := l.sugar
-	l.mu.RUnlock()
-	sugar.Infow(msg, keysAndValues...)
+	l.sugarFor(keysAndValues).Infow(msg, keysAndValues...)
 }
 
 // Warn 记录警告级别的日志
 // 实现 Logger 接口
 // 使用读锁保护,允许并发日志记录
 func (l *zapLogger) Warn(msg string, keysAndValues ...interface{}) {
-	l.mu.RLock()
-	sugar := l.sugar
-	l.mu.RUnlock()
-	sugar.Warnw(msg, keysAndValues...)
+	l.sugarFor(keysAndValues).Warnw(msg, keysAndValues...)
 }
 
 // Error 记录错误级别的日志
 // 实现 Logger 接口
 // 使用读锁保护,允许并发日志记录
 func (l *zapLogger) Error(msg string, keysAndValues ...interface{}) {
-	l.mu.RLock()
-	sugar := l.sugar
-	l.mu.RUnlock()
-	sugar.Errorw(msg, keysAndValues...)
+	l.sugarFor(keysAndValues).Errorw(msg, keysAndValues...)
+}
+
+// Panic 记录错误日志后 panic
+// 实现 Logger 接口
+// 使用读锁保护,允许并发日志记录
+func (l *zapLogger) Panic(msg string, keysAndValues ...interface{}) {
+	// Panicw 会先写入日志,然后以 msg 为值 panic
+	l.sugarFor(keysAndValues).Panicw(msg, keysAndValues...)
 }
 
 // Fatal 记录致命错误并退出程序
@@ -387,10 +442,7 @@ func (l *zapLogger) Error(msg string, keysAndValues ...interface{}) {
 func (l *zapLogger) Fatal(msg string, keysAndValues ...interface{}) {
 	// Fatalw 会先记录日志,然后调用 os.Exit(1)
 	// 使用场景: 无法恢复的严重错误
-	l.mu.RLock()
-	sugar := l.sugar
-	l.mu.RUnlock()
-	sugar.Fatalw(msg, keysAndValues...)
+	l.sugarFor(keysAndValues).Fatalw(msg, keysAndValues...)
 }
 
 // With 返回一个新的 Logger,添加了给定的键值对到上下文
@@ -420,16 +472,22 @@ func (l *zapLogger) With(keysAndValues ...interface{}) Logger {
 	l.mu.RLock()
 	sugar := l.sugar
 	config := l.config
+	async := l.async
 	l.mu.RUnlock()
+	if config != nil && config.StrictFields {
+		checkFields(sugar, 1, keysAndValues)
+	}
 	return &zapLogger{
 		sugar:  sugar.With(keysAndValues...),
 		config: config,
+		async:  async,
 	}
 }
 
 // Sync 刷新缓冲的日志条目
 // 实现 Logger 接口
 // 支持异步模式：如果设置了executor，使用协程池异步刷新
+// 启用 Config.Async 时总是同步等待队列写完,保证 defer log.Sync() 不丢日志
 // 为什么需要 Sync:
 // - zap 为了性能会缓冲日志
 // - 如果程序突然退出,缓冲的日志可能丢失
@@ -446,10 +504,12 @@ func (l *zapLogger) With(keysAndValues ...interface{}) Logger {
 func (l *zapLogger) Sync() error {
 	l.mu.RLock()
 	sugar := l.sugar
+	async := len(l.async) > 0
 	l.mu.RUnlock()
 
 	// 如果有executor，异步执行Sync
-	if exec := l.getExecutor(); exec != nil {
+	// 异步写入模式下 Sync 是唯一的刷新时机,不能再异步化
+	if exec := l.getExecutor(); exec != nil && !async {
 		_ = exec.Execute(constants.AppPoolLogger, func() {
 			if err := sugar.Sync(); err != nil {
 				// 异步模式下，错误输出到stderr避免递归
@@ -514,6 +574,7 @@ func (l *zapLogger) Reload(cfg *Config) error {
 
 	// 保存旧 sugar 的引用,用于后续同步
 	oldSugar := l.sugar
+	oldAsync := l.async
 
 	// 3. 原子地替换 logger 实例
 	// 将新 logger 的内部字段复制到当前实例
@@ -521,6 +582,7 @@ func (l *zapLogger) Reload(cfg *Config) error {
 	newZapLogger := newLogger.(*zapLogger)
 	l.sugar = newZapLogger.sugar
 	l.config = cfg
+	l.async = newZapLogger.async
 
 	// 4. 释放写锁
 	// 新 logger 已替换完成,其他 goroutine 可以使用新 logger
@@ -535,5 +597,10 @@ func (l *zapLogger) Reload(cfg *Config) error {
 		_ = oldSugar.Sync()
 	}
 
+	// 6. 关闭旧的异步写入器
+	// 队列中剩余的日志写完后停止后台 goroutine
+	// 之后通过旧 logger 派生的子 logger 写入的日志直接同步输出
+	_ = closeAsyncWriters(oldAsync)
+
 	return nil
 }
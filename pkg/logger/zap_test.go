@@ -1,8 +1,19 @@
 package logger
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // TestReload_Success 测试正常重载流程
@@ -157,3 +168,404 @@ func TestDefault(t *testing.T) {
 	log.Debug("debug from default logger")
 	log.Info("info from default logger")
 }
+
+// TestNewTestLogger 测试测试 Logger 捕获结构化日志
+func TestNewTestLogger(t *testing.T) {
+	log, sink := NewTestLogger()
+
+	log.With("requestId", "r-1").Warn("login failed: invalid password", "username", "alice")
+	log.Debug("debug message")
+
+	entries := sink.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+
+	e := entries[0]
+	if e.Level != LevelWarn || e.Message != "login failed: invalid password" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e.Fields["username"] != "alice" || e.Fields["requestId"] != "r-1" {
+		t.Errorf("unexpected fields: %v", e.Fields)
+	}
+
+	if !sink.Contains(LevelWarn, "invalid password") {
+		t.Error("expected warn entry to be found")
+	}
+	if sink.Contains(LevelError, "invalid password") {
+		t.Error("level should be matched exactly")
+	}
+
+	sink.Reset()
+	if len(sink.Entries()) != 0 {
+		t.Error("expected no entries after Reset")
+	}
+}
+
+// TestNewTestLogger_FatalPanics 测试 Fatal 不退出进程而是 panic
+func TestNewTestLogger_FatalPanics(t *testing.T) {
+	log, sink := NewTestLogger()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected Fatal to panic")
+		}
+		if !sink.Contains(LevelFatal, "fatal message") {
+			t.Error("expected fatal entry to be recorded")
+		}
+	}()
+
+	log.Fatal("fatal message")
+}
+
+// TestPanic 测试 Panic 记录日志后 panic,可以被 recover 捕获
+func TestPanic(t *testing.T) {
+	log, sink := NewTestLogger()
+
+	defer func() {
+		r := recover()
+		if r != "panic message" {
+			t.Fatalf("expected panic with message, got %v", r)
+		}
+		if !sink.Contains(LevelPanic, "panic message") {
+			t.Error("expected panic entry to be recorded")
+		}
+		if v := sink.Entries()[0].Fields["orderId"]; v != int64(42) {
+			t.Errorf("expected orderId field, got %v", v)
+		}
+	}()
+
+	log.Panic("panic message", "orderId", 42)
+}
+
+// TestRedactKeys 测试 JSON 和 Console 输出中的字段脱敏
+func TestRedactKeys(t *testing.T) {
+	for _, format := range []string{"json", "console"} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+			core := buildCore(format, zapcore.AddSync(&buf), zapcore.DebugLevel)
+			core = newRedactCore(core, []string{"password", "Authorization"})
+			sugar := zap.New(core).Sugar()
+
+			sugar.With("authorization", "Bearer abc.def").
+				Infow("login attempt", "username", "alice", "PASSWORD", "s3cret")
+
+			out := buf.String()
+			if strings.Contains(out, "s3cret") || strings.Contains(out, "Bearer abc.def") {
+				t.Errorf("secret leaked in %s output: %s", format, out)
+			}
+			if strings.Count(out, RedactedValue) != 2 {
+				t.Errorf("expected 2 redacted values in %s output: %s", format, out)
+			}
+			if !strings.Contains(out, "alice") {
+				t.Errorf("non-sensitive field should be kept in %s output: %s", format, out)
+			}
+		})
+	}
+}
+
+// TestRedactKeys_Empty 测试未配置脱敏键时不包装 Core
+func TestRedactKeys_Empty(t *testing.T) {
+	core := buildCore("json", zapcore.AddSync(&bytes.Buffer{}), zapcore.InfoLevel)
+	if newRedactCore(core, nil) != core {
+		t.Error("expected original core when no redact keys configured")
+	}
+}
+
+// TestErrorRateLimit 测试同一错误消息超出限额后被抑制并输出汇总
+func TestErrorRateLimit(t *testing.T) {
+	var buf bytes.Buffer
+	core := buildCore("json", zapcore.AddSync(&buf), zapcore.DebugLevel)
+	core = newRateLimitCore(core, &RateLimitConfig{MaxPerInterval: 2, Interval: time.Minute})
+	core = newRedactCore(core, []string{"password"})
+
+	now := time.Now()
+	core.(*redactCore).Core.(*rateLimitCore).limiter.now = func() time.Time { return now }
+	sugar := zap.New(core).Sugar()
+
+	for i := 0; i < 5; i++ {
+		sugar.Errorw("db unavailable", "attempt", i)
+		sugar.Infow("retrying", "attempt", i)
+	}
+	sugar.Errorw("cache unavailable")
+
+	out := buf.String()
+	if n := strings.Count(out, `"message":"db unavailable"`); n != 2 {
+		t.Errorf("expected 2 db errors, got %d: %s", n, out)
+	}
+	if n := strings.Count(out, `"message":"retrying"`); n != 5 {
+		t.Errorf("info logs should not be limited, got %d", n)
+	}
+	if !strings.Contains(out, "cache unavailable") {
+		t.Errorf("different message should not be limited: %s", out)
+	}
+
+	// 窗口结束后,下一条同消息日志前输出汇总
+	now = now.Add(time.Minute)
+	sugar.Errorw("db unavailable", "attempt", 5)
+	out = buf.String()
+	if !strings.Contains(out, fmt.Sprintf(MsgErrorsSuppressed, 3)) || !strings.Contains(out, `"suppressed":3`) {
+		t.Errorf("expected suppressed summary: %s", out)
+	}
+	if n := strings.Count(out, `"message":"db unavailable"`); n != 3 {
+		t.Errorf("expected new window to allow errors again, got %d", n)
+	}
+
+	// Sync 输出未结束窗口的汇总
+	buf.Reset()
+	sugar.Errorw("db unavailable")
+	sugar.Errorw("db unavailable")
+	_ = sugar.Sync()
+	if !strings.Contains(buf.String(), fmt.Sprintf(MsgErrorsSuppressed, 1)) {
+		t.Errorf("expected summary on Sync: %s", buf.String())
+	}
+}
+
+// blockingWriter 在 release 关闭前阻塞所有写入,用于模拟缓慢的输出目标
+type blockingWriter struct {
+	bytes.Buffer
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return w.Buffer.Write(p)
+}
+
+func (w *blockingWriter) Sync() error { return nil }
+
+// TestAsyncWriter_Order 测试异步写入保持顺序且 Sync 后日志全部落盘
+func TestAsyncWriter_Order(t *testing.T) {
+	var buf bytes.Buffer
+	w := newAsyncWriter(zapcore.AddSync(&buf), 16, false)
+	sugar := zap.New(buildCore("json", w, zapcore.InfoLevel)).Sugar()
+
+	for i := 0; i < 1000; i++ {
+		sugar.Infow("entry", "seq", i)
+	}
+	if err := sugar.Sync(); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1000 {
+		t.Fatalf("expected 1000 lines after Sync, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, fmt.Sprintf(`"seq":%d}`, i)) {
+			t.Fatalf("line %d out of order: %s", i, line)
+		}
+	}
+
+	// 关闭后写入直接同步输出
+	_ = w.Close()
+	sugar.Infow("after close")
+	if !strings.Contains(buf.String(), "after close") {
+		t.Error("writes after Close should go directly to the output")
+	}
+}
+
+// TestAsyncWriter_DropWhenFull 测试队列满时丢弃日志而不阻塞调用方
+func TestAsyncWriter_DropWhenFull(t *testing.T) {
+	out := &blockingWriter{release: make(chan struct{})}
+	w := newAsyncWriter(out, 2, true)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 100; i++ {
+			_, _ = w.Write([]byte("x\n"))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Write should not block when AsyncDropWhenFull is set")
+	}
+	if w.dropped.Load() == 0 {
+		t.Error("expected dropped entries when queue is full")
+	}
+
+	close(out.release)
+	_ = w.Close()
+	if n := strings.Count(out.String(), "x\n"); n == 0 || n >= 100 {
+		t.Errorf("expected some but not all entries written, got %d", n)
+	}
+}
+
+// TestNew_Async 测试 Config.Async 的 Logger 可以正常记录、刷新和重载
+func TestNew_Async(t *testing.T) {
+	log, err := New(&Config{Level: "info", Format: "json", Output: "stdout", Async: true})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	zl := log.(*zapLogger)
+	if len(zl.async) != 1 {
+		t.Fatalf("expected 1 async writer, got %d", len(zl.async))
+	}
+
+	child := log.With("component", "test")
+	log.Info("async message")
+	_ = log.Sync()
+
+	old := zl.async[0]
+	if err := log.Reload(&Config{Level: "info", Format: "json", Output: "stdout"}); err != nil {
+		t.Fatalf("failed to reload logger: %v", err)
+	}
+	if len(zl.async) != 0 || !old.closed {
+		t.Error("old async writer should be closed after reload")
+	}
+	child.Info("child logger still usable after reload")
+}
+
+// slowWriter 每次写入有固定延迟,模拟磁盘或网络 I/O
+type slowWriter struct{}
+
+func (slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(10 * time.Microsecond)
+	return len(p), nil
+}
+
+func (slowWriter) Sync() error { return nil }
+
+// BenchmarkLogger_SyncVsAsync 比较同步写入与异步写入的吞吐量
+func BenchmarkLogger_SyncVsAsync(b *testing.B) {
+	run := func(b *testing.B, ws zapcore.WriteSyncer) {
+		sugar := zap.New(buildCore("json", ws, zapcore.InfoLevel)).Sugar()
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				sugar.Infow("request completed", "status", 200, "path", "/api/users")
+			}
+		})
+		_ = sugar.Sync()
+	}
+
+	b.Run("sync", func(b *testing.B) {
+		run(b, zapcore.Lock(zapcore.AddSync(slowWriter{})))
+	})
+	b.Run("async", func(b *testing.B) {
+		w := newAsyncWriter(slowWriter{}, DefaultAsyncQueueSize, false)
+		defer w.Close()
+		run(b, w)
+	})
+}
+
+// TestWithContext_OTelCorrelation 测试从上下文注入追踪字段
+func TestWithContext_OTelCorrelation(t *testing.T) {
+	log, sink := NewTestLogger()
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	// 未启用时不附加字段
+	log.WithContext(ctx).Info("disabled")
+
+	log.(*zapLogger).config.OTelCorrelation = true
+	log.WithContext(ctx).Info("enabled")
+	log.WithContext(context.Background()).Info("no span")
+
+	entries := sink.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if _, ok := entries[0].Fields[FieldTraceID]; ok {
+		t.Errorf("unexpected trace field when disabled: %v", entries[0].Fields)
+	}
+	if entries[1].Fields[FieldTraceID] != sc.TraceID().String() || entries[1].Fields[FieldSpanID] != sc.SpanID().String() {
+		t.Errorf("unexpected fields: %v", entries[1].Fields)
+	}
+	if len(entries[2].Fields) != 0 {
+		t.Errorf("unexpected fields without span: %v", entries[2].Fields)
+	}
+}
+
+// TestStrictFields 测试严格模式下键值对错误在开发模式中 panic
+func TestStrictFields(t *testing.T) {
+	log, err := New(&Config{Level: "debug", Format: "json", Output: "stdout", StrictFields: true, Development: true})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+
+	mustPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: expected panic", name)
+			}
+		}()
+		fn()
+	}
+
+	// 合法的键值对,包括直接传入的 zap.Field
+	log.Info("ok", "userId", 1, zap.String("path", "/"), "method", "GET")
+
+	mustPanic("odd", func() { log.Debug("odd", "userId") })
+	mustPanic("non-string key", func() { log.Warn("bad key", 1, "value") })
+	mustPanic("with", func() { log.With("component") })
+
+	// 未启用时保持宽松
+	lenient, _ := New(&Config{Level: "debug", Format: "json", Output: "stdout"})
+	lenient.Info("odd", "userId", 1, "dangling")
+
+	// 非开发模式下只记录 DPanic 日志,不 panic
+	prod, _ := New(&Config{Level: "debug", Format: "json", Output: "stdout", StrictFields: true})
+	prod.Info("odd", "userId")
+}
+
+// TestStdLogger 测试标准库 logger 适配器
+func TestStdLogger(t *testing.T) {
+	log, sink := NewTestLogger()
+
+	log.StdLogger("warn").Printf("http: TLS handshake error from %s", "10.0.0.1:5000")
+	log.With("component", "http").StdLogger("fatal").Println("accept failed")
+	log.StdLogger("unknown").Print("multi\nline")
+
+	entries := sink.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+	if e := entries[0]; e.Level != LevelWarn || e.Message != "http: TLS handshake error from 10.0.0.1:5000" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	// fatal 降级为 error,不退出进程
+	if e := entries[1]; e.Level != LevelError || e.Message != "accept failed" || e.Fields["component"] != "http" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	if e := entries[2]; e.Level != LevelInfo || e.Message != "multi\nline" {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+}
+
+// TestErrorFilePath 测试错误日志单独写入错误文件,主文件仍包含全部日志
+func TestErrorFilePath(t *testing.T) {
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "app.log")
+	errorPath := filepath.Join(dir, "error.log")
+
+	log, err := New(&Config{Level: "info", Format: "json", Output: "file", FilePath: mainPath, ErrorFilePath: errorPath})
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	log.Info("info message")
+	log.Warn("warn message")
+	log.Error("error message", "code", 500)
+	_ = log.Sync()
+
+	mainLog, _ := os.ReadFile(mainPath)
+	errorLog, _ := os.ReadFile(errorPath)
+	for _, msg := range []string{"info message", "warn message", "error message"} {
+		if !strings.Contains(string(mainLog), msg) {
+			t.Errorf("main log missing %q", msg)
+		}
+	}
+	if lines := strings.Count(string(errorLog), "\n"); lines != 1 || !strings.Contains(string(errorLog), `"message":"error message"`) {
+		t.Errorf("error log should only contain the error entry, got:\n%s", errorLog)
+	}
+}
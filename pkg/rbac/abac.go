@@ -0,0 +1,55 @@
+package rbac
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/casbin/casbin/v3/model"
+	"github.com/casbin/casbin/v3/util"
+)
+
+// missingAttributeError 条件表达式访问请求中不存在的属性时 govaluate 返回的错误前缀
+// 例如条件 r.attrs.Age > 18 而请求属性中没有 Age
+const missingAttributeError = "No method or field"
+
+// unconditionalMatcher 根据模型匹配器生成只匹配无条件策略的匹配器
+// 将 eval(p.cond) 替换为 p.cond == DefaultCondition,条件不是 DefaultCondition 的策略不会匹配
+// 匹配器中没有 eval 时返回空字符串
+func unconditionalMatcher(m model.Model) string {
+	matcher := m["m"]["m"].Value
+	rules := util.GetEvalValue(matcher)
+	if len(rules) == 0 {
+		return ""
+	}
+
+	sets := make(map[string]string, len(rules))
+	for _, rule := range rules {
+		sets[rule] = fmt.Sprintf("(%s == '%s')", rule, DefaultCondition)
+	}
+	return util.ReplaceEvalWithMap(matcher, sets)
+}
+
+// isMissingAttribute 判断错误是否由条件访问不存在的属性引起
+func isMissingAttribute(err error) bool {
+	return err != nil && strings.Contains(err.Error(), missingAttributeError)
+}
+
+// enforceEx 执行一次 Casbin 检查,返回结果和命中的策略
+// ABAC模型下条件引用了请求未提供的属性时,Casbin 会中止整个检查并返回错误;
+// 此时视为这些条件不满足,改用 unconditionalMatcher 只按无条件策略检查,
+// 避免缺少属性变成 500,也不影响主体通过无条件策略获得的权限
+// 模型匹配器中没有 eval 时无法区分条件策略,直接拒绝
+func (s rbacState) enforceEx(sub, dom, obj, act string, attrs map[string]interface{}) (bool, []string, error) {
+	if !s.abac {
+		return s.enforcer.EnforceEx(sub, dom, obj, act)
+	}
+
+	ok, matched, err := s.enforcer.EnforceEx(sub, dom, obj, act, attrs)
+	if !isMissingAttribute(err) {
+		return ok, matched, err
+	}
+	if s.plainMatcher == "" {
+		return false, nil, nil
+	}
+	return s.enforcer.EnforceExWithMatcher(s.plainMatcher, sub, dom, obj, act, attrs)
+}
@@ -0,0 +1,83 @@
+package rbac
+
+import "testing"
+
+// TestEnforceWithContext 测试 ABAC 条件的允许、拒绝和缺少属性
+func TestEnforceWithContext(t *testing.T) {
+	r := setupTestRBAC(t, func(cfg *Config) { cfg.EnableABAC = true })
+
+	if err := r.AddRoleForUser("alice", "staff"); err != nil {
+		t.Fatalf("AddRoleForUser() error = %v", err)
+	}
+	if err := r.AddConditionalPolicy("staff", "", "docs", "write", "r.attrs.Age >= 18"); err != nil {
+		t.Fatalf("AddConditionalPolicy() error = %v", err)
+	}
+	if err := r.AddPolicy("alice", "docs", "read"); err != nil {
+		t.Fatalf("AddPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		act   string
+		attrs map[string]interface{}
+		want  bool
+	}{
+		{"condition met", "write", map[string]interface{}{"Age": 20}, true},
+		{"condition not met", "write", map[string]interface{}{"Age": 10}, false},
+		{"missing attribute", "write", map[string]interface{}{"Dept": "ops"}, false},
+		{"nil attrs", "write", nil, false},
+		{"unconditional policy", "read", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.EnforceWithContext("alice", "docs", tt.act, tt.attrs)
+			if err != nil || got != tt.want {
+				t.Errorf("EnforceWithContext() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+
+	// 非 ABAC 模型不支持属性
+	plain := setupTestRBAC(t, nil)
+	if _, err := plain.EnforceWithContext("alice", "docs", "read", nil); err != ErrABACNotEnabled {
+		t.Errorf("EnforceWithContext() on RBAC model error = %v, want ErrABACNotEnabled", err)
+	}
+}
+
+// TestEnforceABACWithoutAttrs 测试 ABAC 模型下不带属性的检查
+// 条件策略视为不满足,不返回错误,也不影响无条件策略
+func TestEnforceABACWithoutAttrs(t *testing.T) {
+	r := setupTestRBAC(t, func(cfg *Config) { cfg.EnableABAC = true })
+
+	_ = r.AddRoleForUser("alice", "staff")
+	_ = r.AddRoleForUser("bob", "staff")
+	// 条件策略排在无条件策略之前,Casbin 会先对它求值
+	_ = r.AddConditionalPolicy("staff", "", "docs", "write", "r.attrs.Age >= 18")
+	_ = r.AddPolicy("alice", "docs", "write")
+
+	tests := []struct {
+		sub  string
+		want bool
+	}{
+		{"alice", true},
+		{"bob", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sub, func(t *testing.T) {
+			got, err := r.Enforce(tt.sub, "docs", "write")
+			if err != nil || got != tt.want {
+				t.Errorf("Enforce() = %v, %v, want %v", got, err, tt.want)
+			}
+
+			allowed, _, _, err := r.Explain(tt.sub, "", "docs", "write")
+			if err != nil || allowed != tt.want {
+				t.Errorf("Explain() = %v, %v, want %v", allowed, err, tt.want)
+			}
+		})
+	}
+
+	// 有属性时条件策略照常生效
+	if ok, err := r.EnforceWithContext("bob", "docs", "write", map[string]interface{}{"Age": 30}); err != nil || !ok {
+		t.Errorf("EnforceWithContext() = %v, %v, want true", ok, err)
+	}
+}
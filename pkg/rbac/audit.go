@@ -0,0 +1,126 @@
+package rbac
+
+import (
+	"context"
+	"time"
+)
+
+// AuditAction 审计事件的变更类型
+type AuditAction string
+
+const (
+	// AuditActionAddPolicy 添加策略
+	AuditActionAddPolicy AuditAction = "add_policy"
+
+	// AuditActionRemovePolicy 删除策略
+	AuditActionRemovePolicy AuditAction = "remove_policy"
+
+	// AuditActionAddRole 为用户分配角色
+	AuditActionAddRole AuditAction = "add_role"
+
+	// AuditActionDeleteRole 撤销用户的角色
+	AuditActionDeleteRole AuditAction = "delete_role"
+)
+
+// AuditEvent 权限变更审计事件
+// 由 Config.AuditLog 接收,每条实际生效的策略或角色变更产生一个事件
+// 批量操作按规则逐条产生事件;策略已存在或不存在导致未生效的变更不产生事件
+type AuditEvent struct {
+	// Action 变更类型
+	Action AuditAction
+
+	// Subject 主体
+	// 策略变更时为策略主体（通常是角色），角色变更时为用户
+	Subject string
+
+	// Object 对象
+	// 策略变更时为资源，角色变更时为角色名称
+	Object string
+
+	// Act 操作，角色变更时为空
+	Act string
+
+	// Domain 域，无域时为空
+	Domain string
+
+	// Condition ABAC条件表达式，非ABAC策略为空
+	Condition string
+
+	// Timestamp 变更时间
+	Timestamp time.Time
+
+	// Actor 执行变更的操作者
+	// 来自 Ctx 系列方法传入的上下文（见 WithActor），未设置时为空
+	Actor string
+}
+
+// actorKey 上下文中操作者的键
+type actorKey struct{}
+
+// WithActor 在上下文中设置操作者
+// 配合 AddPolicyCtx 等 Ctx 系列方法使用，操作者会写入审计事件的 Actor 字段
+//
+// 参数:
+//
+//	ctx: 父上下文
+//	actor: 操作者标识，通常是当前登录用户ID
+//
+// 返回:
+//
+//	context.Context: 携带操作者的上下文
+//
+// 示例:
+//
+//	ctx := rbac.WithActor(c.Request.Context(), currentUserID)
+//	err := enforcer.AddRoleForUserCtx(ctx, "alice", "admin")
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext 获取上下文中的操作者
+//
+// 返回:
+//
+//	string: 操作者标识，未设置时为空
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// audit 向 Config.AuditLog 发送审计事件
+// 未配置 AuditLog 时直接返回
+func (r *rbacImpl) audit(ctx context.Context, event AuditEvent) {
+	auditLog := r.state().config.AuditLog
+	if auditLog == nil {
+		return
+	}
+	event.Timestamp = time.Now()
+	event.Actor = ActorFromContext(ctx)
+	auditLog(event)
+}
+
+// auditRules 为批量操作中的每条规则发送审计事件
+// 规则格式为 [sub, obj, act] 或 [sub, dom, obj, act, (cond)]
+func (r *rbacImpl) auditRules(ctx context.Context, action AuditAction, rules [][]string) {
+	if r.state().config.AuditLog == nil {
+		return
+	}
+	for _, rule := range rules {
+		event := AuditEvent{Action: action}
+		switch {
+		case len(rule) == 3:
+			event.Subject, event.Object, event.Act = rule[0], rule[1], rule[2]
+		case len(rule) >= 4:
+			event.Subject, event.Domain, event.Object, event.Act = rule[0], rule[1], rule[2], rule[3]
+			if len(rule) > 4 {
+				event.Condition = rule[4]
+			}
+		default:
+			continue
+		}
+		r.audit(ctx, event)
+	}
+}
@@ -0,0 +1,78 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+)
+
+// TestAuditLog 测试策略和角色变更产生审计事件
+func TestAuditLog(t *testing.T) {
+	var events []AuditEvent
+	r := setupTestRBAC(t, func(cfg *Config) {
+		cfg.AuditLog = func(e AuditEvent) { events = append(events, e) }
+	})
+	ctx := WithActor(context.Background(), "admin-1")
+
+	_ = r.AddPolicyCtx(ctx, "editor", "posts", "write")
+	_ = r.AddPolicyCtx(ctx, "editor", "posts", "write") // 已存在,不产生事件
+	_ = r.AddRoleForUserInDomainCtx(ctx, "alice", "editor", "tenant1")
+	_ = r.DeleteRoleForUserInDomain("alice", "editor", "tenant1")
+	_ = r.RemovePolicy("editor", "posts", "write")
+	_ = r.RemovePolicy("editor", "posts", "write") // 不存在,不产生事件
+	_ = r.AddPoliciesCtx(ctx, [][]string{{"viewer", "", "posts", "read"}, {"viewer", "", "users", "read"}})
+
+	want := []AuditEvent{
+		{Action: AuditActionAddPolicy, Subject: "editor", Object: "posts", Act: "write", Actor: "admin-1"},
+		{Action: AuditActionAddRole, Subject: "alice", Object: "editor", Domain: "tenant1", Actor: "admin-1"},
+		{Action: AuditActionDeleteRole, Subject: "alice", Object: "editor", Domain: "tenant1"},
+		{Action: AuditActionRemovePolicy, Subject: "editor", Object: "posts", Act: "write"},
+		{Action: AuditActionAddPolicy, Subject: "viewer", Object: "posts", Act: "read", Actor: "admin-1"},
+		{Action: AuditActionAddPolicy, Subject: "viewer", Object: "users", Act: "read", Actor: "admin-1"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, e := range events {
+		if e.Timestamp.IsZero() {
+			t.Errorf("event %d has no timestamp", i)
+		}
+		e.Timestamp = want[i].Timestamp
+		if e != want[i] {
+			t.Errorf("event %d = %+v, want %+v", i, e, want[i])
+		}
+	}
+}
+
+// TestAuditLogCondition 测试 ABAC 条件写入审计事件
+func TestAuditLogCondition(t *testing.T) {
+	var events []AuditEvent
+	r := setupTestRBAC(t, func(cfg *Config) {
+		cfg.EnableABAC = true
+		cfg.AuditLog = func(e AuditEvent) { events = append(events, e) }
+	})
+
+	_ = r.AddConditionalPolicy("editor", "", "posts", "edit", "r.attrs.owner == r.sub")
+	_ = r.AddPolicy("viewer", "posts", "read")
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0].Condition != "r.attrs.owner == r.sub" || events[1].Condition != "" {
+		t.Errorf("conditions = %q, %q", events[0].Condition, events[1].Condition)
+	}
+}
+
+// TestActorFromContext 测试操作者的读写
+func TestActorFromContext(t *testing.T) {
+	// nil 上下文不会 panic
+	var nilCtx context.Context
+	if got := ActorFromContext(nilCtx); got != "" {
+		t.Errorf("ActorFromContext(nil) = %q, want empty", got)
+	}
+	if got := ActorFromContext(context.Background()); got != "" {
+		t.Errorf("ActorFromContext(background) = %q, want empty", got)
+	}
+	if got := ActorFromContext(WithActor(context.Background(), "42")); got != "42" {
+		t.Errorf("ActorFromContext() = %q, want 42", got)
+	}
+}
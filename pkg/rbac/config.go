@@ -6,6 +6,12 @@ import (
 	"gorm.io/gorm"
 )
 
+// DefaultCondition ABAC模型下未指定条件的策略使用的条件表达式（恒为真）
+const DefaultCondition = "true"
+
+// abacRequestSize ABAC模型的请求字段数: sub, dom, obj, act, attrs
+const abacRequestSize = 5
+
 // Config RBAC配置
 type Config struct {
 	// 数据库连接（使用Gorm Adapter）
@@ -16,6 +22,12 @@ type Config struct {
 	// 如果为空，将使用pkg/rbac目录下的内置model.conf
 	ModelPath string
 
+	// 是否使用内置ABAC模型（默认false）
+	// 仅在ModelPath为空时生效，启用后使用model_abac.conf：
+	// 请求携带属性 r.attrs，策略携带条件表达式 p.cond
+	// 自定义模型的请求定义包含第5个字段时同样视为ABAC模型
+	EnableABAC bool
+
 	// 是否启用缓存（默认true）
 	// 缓存可以显著提升权限检查性能
 	EnableCache bool
@@ -32,6 +44,35 @@ type Config struct {
 	// 表名前缀（可选）
 	// 用于Casbin策略表的前缀，默认为空
 	TablePrefix string
+
+	// 审计回调（可选）
+	// 每条实际生效的策略或角色变更都会同步调用一次，用于记录权限变更审计日志
+	// 回调在变更方法返回前执行，应尽快返回，耗时操作请自行异步处理
+	// 操作者通过 WithActor 写入上下文，并使用 AddPolicyCtx 等 Ctx 系列方法传入
+	AuditLog func(event AuditEvent)
+
+	// 超级管理员（可选）
+	// 列出的主体在所有域中跳过权限检查，Enforce 系列方法直接返回 true
+	// 比授予 "*" 通配策略更安全（不会被误匹配到其他规则）也更快（不执行匹配器）
+	SuperAdmins []string
+
+	// 超级管理员角色（可选）
+	// 直接或通过角色继承拥有该角色的主体，在拥有该角色的域内跳过权限检查
+	// 例如 tenant1 的超级管理员只在 tenant1 中跳过检查，不影响其他租户
+	// 为空时不按角色判断
+	SuperAdminRole string
+
+	// 策略过滤条件（可选）
+	// 非空时创建、Reload 和 LoadPolicy 只加载匹配任一条件的策略，而不是整张 casbin_rule 表
+	// 用于多租户部署中每个实例只服务部分租户的场景，按域过滤可使用 DomainFilters
+	// 注意: 过滤加载后 SavePolicy 会失败（避免用部分策略覆盖整张表），应启用 AutoSave
+	PolicyFilters []Filter
+
+	// 操作层级（可选）
+	// 键为操作，值为它蕴含的操作，检查某个操作时拥有蕴含它的操作同样通过
+	// 例如 {"write": {"read"}, "admin": {"*"}}: 拥有 write 的主体可以 read，拥有 admin 的主体可以执行任意操作
+	// 蕴含关系可传递，值为 ActionAll（"*"）时蕴含所有操作；无需为每个操作重复添加策略
+	ActionHierarchy map[string][]string
 }
 
 // DefaultConfig 返回默认配置
@@ -57,6 +57,10 @@ RBAC模型包含以下核心概念：
 	// 检查：alice在tenant1域中能否读取data？
 	ok, err := rbac.EnforceWithDomain("alice", "tenant1", "data", "read")
 
+说明检查结果（返回命中的策略和原因，用于排查权限问题）：
+
+	ok, policy, reason, err := rbac.Explain("alice", "tenant1", "data", "read")
+
 批量操作：
 
 	// 批量添加策略
@@ -67,6 +71,47 @@ RBAC模型包含以下核心概念：
 	}
 	rbac.AddPolicies(rules)
 
+权限变更审计（配置 Config.AuditLog，通过上下文传入操作者）：
+
+	cfg.AuditLog = func(e rbac.AuditEvent) { auditLogger.Info(e.Action, e.Subject, e.Object, e.Actor) }
+	ctx := rbac.WithActor(ctx, operatorID)
+	rbac.AddRoleForUserCtx(ctx, "alice", "admin")
+
+Gin 权限中间件（注册在认证中间件之后）：
+
+	api.Use(rbac.Middleware(rbacManager, func(c *gin.Context) (string, string) {
+	    return c.FullPath(), c.Request.Method
+	}))
+	api.DELETE("/users/:id", rbac.RequirePermission(rbacManager, "users", "delete"), h.DeleteUser)
+
+运行时切换模型（构建失败时保持原 Enforcer）：
+
+	newCfg := *cfg
+	newCfg.ModelPath = "configs/rbac_model_v2.conf"
+	err := rbac.Reload(&newCfg)
+
+超级管理员（跳过权限检查，结果照常缓存）：
+
+	cfg.SuperAdmins = []string{"root"}        // 所有域
+	cfg.SuperAdminRole = "superadmin"         // 仅在拥有该角色的域
+	ok, err := rbac.IsSuperAdmin("alice", "tenant1")
+
+操作层级（write 蕴含 read，admin 蕴含所有操作）：
+
+	cfg.ActionHierarchy = map[string][]string{"write": {"read"}, "admin": {rbac.ActionAll}}
+	ok, err := rbac.Enforce("alice", "data", "read") // alice 只有 write 策略时同样返回 true
+
+只加载部分策略（大规模多租户，每个实例只负责部分域）：
+
+	cfg.PolicyFilters = rbac.DomainFilters("tenant1", "tenant2")
+	err := rbac.LoadFilteredPolicy(rbac.Filter{Ptype: []string{"p"}, V1: []string{"tenant3"}})
+	err = rbac.LoadIncrementalFilteredPolicy(rbac.Filter{Ptype: []string{"g"}, V2: []string{"tenant3"}})
+
+条件策略（ABAC，需 Config.EnableABAC=true）：
+
+	rbac.AddConditionalPolicy("editor", "", "posts", "edit", "r.attrs.owner == r.sub")
+	ok, err := rbac.EnforceWithContext("alice", "posts", "edit", map[string]interface{}{"owner": "alice"})
+
 # 最佳实践
 
 1. 角色命名：使用小写和下划线，如 "super_admin", "content_editor"
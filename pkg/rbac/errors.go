@@ -22,6 +22,9 @@ var (
 
 	// ErrSavePolicy 保存策略失败
 	ErrSavePolicy = errors.New("failed to save policy")
+
+	// ErrABACNotEnabled 当前模型不支持属性（ABAC）
+	ErrABACNotEnabled = errors.New("abac model not enabled")
 )
 
 // 错误消息模板常量
@@ -32,4 +35,23 @@ const (
 	ErrMsgRemovePolicyFailed = "remove policy failed: %w"
 	ErrMsgAddRoleFailed      = "add role failed: %w"
 	ErrMsgRemoveRoleFailed   = "remove role failed: %w"
+	ErrMsgReloadFailed       = "reload rbac failed: %w"
+)
+
+// Explain 原因模板常量
+const (
+	// ExplainNoMatchingPolicy 没有命中任何策略
+	ExplainNoMatchingPolicy = "no matching policy"
+
+	// ExplainMatchedDirectly 命中主体自身的策略
+	ExplainMatchedDirectly = "matched directly via policy %v"
+
+	// ExplainMatchedByRole 命中主体所属角色的策略
+	ExplainMatchedByRole = "matched by role %s via policy %v"
+
+	// ExplainDeniedByPolicy 命中拒绝策略
+	ExplainDeniedByPolicy = "denied by policy %v"
+
+	// ExplainSuperAdmin 主体为超级管理员，跳过权限检查
+	ExplainSuperAdmin = "allowed as super admin"
 )
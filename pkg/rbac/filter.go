@@ -0,0 +1,129 @@
+package rbac
+
+import (
+	"fmt"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+)
+
+// Filter 策略过滤条件
+// 与 gormadapter.Filter 一一对应,按 casbin_rule 表的列过滤:
+// 同一字段内的多个值为 OR(IN 查询),不同字段之间为 AND,空字段不过滤
+//
+// 内置模型中各列的含义:
+//
+//	p 策略:    V0=sub  V1=dom   V2=obj  V3=act (ABAC 模型 V4=cond)
+//	g 角色分配: V0=user V1=role  V2=dom
+//
+// 注意 p 与 g 的域不在同一列,按域过滤时使用 DomainFilters
+type Filter struct {
+	// Ptype 策略类型,如 "p"、"g"
+	Ptype []string
+
+	// V0-V5 对应 casbin_rule 表的 v0-v5 列
+	V0 []string
+	V1 []string
+	V2 []string
+	V3 []string
+	V4 []string
+	V5 []string
+}
+
+// DomainFilters 返回只加载指定域的过滤条件
+// 包含两个条件: 域内的 p 策略(V1)和域内的 g 角色分配(V2)
+// 参数:
+//
+//	domains: 域列表
+//
+// 返回:
+//
+//	[]Filter: 可以直接赋给 Config.PolicyFilters
+//
+// 使用示例:
+//
+//	cfg := rbac.DefaultConfig(db)
+//	cfg.PolicyFilters = rbac.DomainFilters("tenant1", "tenant2")
+func DomainFilters(domains ...string) []Filter {
+	return []Filter{
+		{Ptype: []string{"p"}, V1: domains},
+		{Ptype: []string{"g"}, V2: domains},
+	}
+}
+
+// adapterFilter 转换为 gormadapter.Filter
+func (f Filter) adapterFilter() gormadapter.Filter {
+	return gormadapter.Filter{
+		Ptype: f.Ptype,
+		V0:    f.V0,
+		V1:    f.V1,
+		V2:    f.V2,
+		V3:    f.V3,
+		V4:    f.V4,
+		V5:    f.V5,
+	}
+}
+
+// adapterFilters 批量转换为 gormadapter.Filter
+// gormadapter 依次执行每个条件的查询,结果合并加载(条件之间为 OR)
+func adapterFilters(filters []Filter) []gormadapter.Filter {
+	out := make([]gormadapter.Filter, len(filters))
+	for i, f := range filters {
+		out[i] = f.adapterFilter()
+	}
+	return out
+}
+
+// loadPolicy 按配置加载策略
+// 配置了 PolicyFilters 时只加载匹配的策略,否则加载全部策略
+func (s rbacState) loadPolicy() error {
+	if len(s.config.PolicyFilters) > 0 {
+		return s.enforcer.LoadFilteredPolicy(adapterFilters(s.config.PolicyFilters))
+	}
+	return s.enforcer.LoadPolicy()
+}
+
+// LoadFilteredPolicy 清空内存中的策略,只加载匹配过滤条件的策略
+func (r *rbacImpl) LoadFilteredPolicy(filter Filter) error {
+	s := r.state()
+	if s.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	if err := s.enforcer.LoadFilteredPolicy(filter.adapterFilter()); err != nil {
+		return fmt.Errorf("%w: %v", ErrLoadPolicy, err)
+	}
+
+	return r.clearCacheIfEnabled(s)
+}
+
+// LoadIncrementalFilteredPolicy 在内存中已有策略的基础上追加匹配过滤条件的策略
+func (r *rbacImpl) LoadIncrementalFilteredPolicy(filter Filter) error {
+	s := r.state()
+	if s.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	if err := s.enforcer.LoadIncrementalFilteredPolicy(filter.adapterFilter()); err != nil {
+		return fmt.Errorf("%w: %v", ErrLoadPolicy, err)
+	}
+
+	return r.clearCacheIfEnabled(s)
+}
+
+// IsFiltered 内存中的策略是否为过滤加载的部分策略
+func (r *rbacImpl) IsFiltered() bool {
+	s := r.state()
+	if s.enforcer == nil {
+		return false
+	}
+	return s.enforcer.IsFiltered()
+}
+
+// clearCacheIfEnabled 重新加载策略后清除缓存
+// ClearCache 同时清除角色继承缓存
+func (r *rbacImpl) clearCacheIfEnabled(s rbacState) error {
+	if s.config.EnableCache {
+		return r.ClearCache()
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package rbac
+
+import (
+	"slices"
+	"testing"
+)
+
+// seedTenants 写入两个租户的角色分配和策略
+func seedTenants(t *testing.T, r RBAC) {
+	t.Helper()
+	for _, tenant := range []string{"tenant1", "tenant2"} {
+		if err := r.AddRoleForUserInDomain("alice", "admin", tenant); err != nil {
+			t.Fatalf("AddRoleForUserInDomain() error = %v", err)
+		}
+		if err := r.AddPolicyWithDomain("admin", tenant, "users", "delete"); err != nil {
+			t.Fatalf("AddPolicyWithDomain() error = %v", err)
+		}
+	}
+}
+
+// TestDomainFilters 测试 PolicyFilters 只加载指定域的策略
+func TestDomainFilters(t *testing.T) {
+	db := setupTestDB(t)
+	seedTenants(t, newTestRBAC(t, db, nil))
+
+	r := newTestRBAC(t, db, func(cfg *Config) { cfg.PolicyFilters = DomainFilters("tenant1") })
+	if !r.IsFiltered() {
+		t.Error("IsFiltered() = false with PolicyFilters")
+	}
+	mustEnforceInDomain(t, r, "alice", "tenant1", "users", "delete", true)
+	mustEnforceInDomain(t, r, "alice", "tenant2", "users", "delete", false)
+	if got := r.GetPolicy(); len(got) != 1 || got[0][1] != "tenant1" {
+		t.Errorf("GetPolicy() = %v, want only tenant1", got)
+	}
+
+	// 过滤加载后不能用部分策略覆盖整张表
+	if err := r.SavePolicy(); err == nil {
+		t.Error("SavePolicy() after filtered load should fail")
+	}
+
+	// LoadPolicy 同样按过滤条件重新加载
+	if err := r.LoadPolicy(); err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	mustEnforceInDomain(t, r, "alice", "tenant2", "users", "delete", false)
+}
+
+// TestLoadFilteredPolicy 测试运行时按条件加载和追加策略
+func TestLoadFilteredPolicy(t *testing.T) {
+	db := setupTestDB(t)
+	r := newTestRBAC(t, db, nil)
+	seedTenants(t, r)
+	if r.IsFiltered() {
+		t.Error("IsFiltered() = true without filters")
+	}
+
+	// 写入缓存后按 tenant1 重新加载,缓存被清除
+	mustEnforceInDomain(t, r, "alice", "tenant2", "users", "delete", true)
+	if err := r.LoadFilteredPolicy(Filter{Ptype: []string{"p"}, V1: []string{"tenant1"}}); err != nil {
+		t.Fatalf("LoadFilteredPolicy() error = %v", err)
+	}
+	if err := r.LoadIncrementalFilteredPolicy(Filter{Ptype: []string{"g"}, V2: []string{"tenant1"}}); err != nil {
+		t.Fatalf("LoadIncrementalFilteredPolicy() error = %v", err)
+	}
+	if !r.IsFiltered() {
+		t.Error("IsFiltered() = false after LoadFilteredPolicy")
+	}
+	mustEnforceInDomain(t, r, "alice", "tenant1", "users", "delete", true)
+	mustEnforceInDomain(t, r, "alice", "tenant2", "users", "delete", false)
+
+	// 追加 tenant2
+	for _, f := range DomainFilters("tenant2") {
+		if err := r.LoadIncrementalFilteredPolicy(f); err != nil {
+			t.Fatalf("LoadIncrementalFilteredPolicy() error = %v", err)
+		}
+	}
+	mustEnforceInDomain(t, r, "alice", "tenant2", "users", "delete", true)
+
+	domains := []string{}
+	for _, rule := range r.GetPolicy() {
+		domains = append(domains, rule[1])
+	}
+	slices.Sort(domains)
+	if !slices.Equal(domains, []string{"tenant1", "tenant2"}) {
+		t.Errorf("policy domains = %v, want [tenant1 tenant2]", domains)
+	}
+}
@@ -0,0 +1,81 @@
+package rbac
+
+import "slices"
+
+// ActionAll ActionHierarchy 中表示蕴含所有操作的通配值
+// 例如 {"admin": {"*"}} 表示拥有 admin 的主体可以执行任意操作
+const ActionAll = "*"
+
+// buildActionImplications 根据操作层级计算每个操作可由哪些操作满足
+// 返回值的键为被蕴含的操作，值为直接或间接蕴含它的操作（不含自身）
+// 层级中存在环时不会死循环，环上的操作互相蕴含
+func buildActionImplications(hierarchy map[string][]string) (impliedBy map[string][]string, wildcard []string) {
+	if len(hierarchy) == 0 {
+		return nil, nil
+	}
+
+	impliedBy = make(map[string][]string)
+	for act := range hierarchy {
+		// 深度优先遍历 act 直接或间接蕴含的所有操作
+		visited := map[string]bool{act: true}
+		stack := slices.Clone(hierarchy[act])
+		for len(stack) > 0 {
+			implied := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if visited[implied] {
+				continue
+			}
+			visited[implied] = true
+
+			if implied == ActionAll {
+				wildcard = append(wildcard, act)
+				continue
+			}
+			impliedBy[implied] = append(impliedBy[implied], act)
+			stack = append(stack, hierarchy[implied]...)
+		}
+	}
+
+	// 排序保证检查顺序稳定
+	for implied := range impliedBy {
+		slices.Sort(impliedBy[implied])
+	}
+	slices.Sort(wildcard)
+	return impliedBy, wildcard
+}
+
+// candidateActions 返回满足 act 的所有操作，act 自身排在第一位
+// 未配置 ActionHierarchy 时只返回 act
+func (s rbacState) candidateActions(act string) []string {
+	if len(s.impliedBy[act]) == 0 && len(s.wildcard) == 0 {
+		return []string{act}
+	}
+
+	acts := []string{act}
+	for _, a := range s.impliedBy[act] {
+		if a != act {
+			acts = append(acts, a)
+		}
+	}
+	for _, a := range s.wildcard {
+		if !slices.Contains(acts, a) {
+			acts = append(acts, a)
+		}
+	}
+	return acts
+}
+
+// enforce 依次检查 act 及蕴含它的操作，任一操作通过即返回 true
+// attrs 仅在ABAC模型下传入匹配器，缺少条件引用的属性时该条件视为不满足（见 enforceEx）
+func (s rbacState) enforce(sub, dom, obj, act string, attrs map[string]interface{}) (bool, error) {
+	for _, a := range s.candidateActions(act) {
+		ok, _, err := s.enforceEx(sub, dom, obj, a, attrs)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
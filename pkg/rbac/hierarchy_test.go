@@ -0,0 +1,72 @@
+package rbac
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestBuildActionImplications 测试操作蕴含关系的计算
+func TestBuildActionImplications(t *testing.T) {
+	impliedBy, wildcard := buildActionImplications(map[string][]string{
+		"admin": {ActionAll},
+		"write": {"read"},
+		"owner": {"write", "delete"},
+		// 环上的操作互相蕴含,不会死循环
+		"a": {"b"},
+		"b": {"a"},
+	})
+
+	tests := map[string][]string{
+		"read":   {"owner", "write"},
+		"write":  {"owner"},
+		"delete": {"owner"},
+		"a":      {"b"},
+		"b":      {"a"},
+	}
+	for act, want := range tests {
+		if got := impliedBy[act]; !slices.Equal(got, want) {
+			t.Errorf("impliedBy[%s] = %v, want %v", act, got, want)
+		}
+	}
+	if !slices.Equal(wildcard, []string{"admin"}) {
+		t.Errorf("wildcard = %v, want [admin]", wildcard)
+	}
+
+	if impliedBy, wildcard := buildActionImplications(nil); impliedBy != nil || wildcard != nil {
+		t.Errorf("buildActionImplications(nil) = %v, %v, want nil", impliedBy, wildcard)
+	}
+}
+
+// TestActionHierarchy 测试蕴含的操作满足权限检查
+func TestActionHierarchy(t *testing.T) {
+	r := setupTestRBAC(t, func(cfg *Config) {
+		cfg.ActionHierarchy = map[string][]string{
+			"write": {"read"},
+			"admin": {ActionAll},
+		}
+	})
+	_ = r.AddPolicy("editor", "posts", "write")
+	_ = r.AddPolicy("root", "posts", "admin")
+
+	mustEnforce(t, r, "editor", "posts", "read", true)
+	mustEnforce(t, r, "editor", "posts", "write", true)
+	mustEnforce(t, r, "editor", "posts", "delete", false)
+	mustEnforce(t, r, "editor", "users", "read", false)
+	mustEnforce(t, r, "root", "posts", "delete", true)
+
+	// act 自身排在第一位,其后是蕴含它的操作
+	if got := r.state().candidateActions("read"); !slices.Equal(got, []string{"read", "write", "admin"}) {
+		t.Errorf("candidateActions(read) = %v", got)
+	}
+
+	// Explain 返回实际命中的策略
+	allowed, matched, _, err := r.Explain("editor", "", "posts", "read")
+	if err != nil || !allowed || !slices.Equal(matched, []string{"editor", "", "posts", "write"}) {
+		t.Errorf("Explain() = %v, %v, %v", allowed, matched, err)
+	}
+
+	// 未配置层级时只检查 act 自身
+	plain := setupTestRBAC(t, nil)
+	_ = plain.AddPolicy("editor", "posts", "write")
+	mustEnforce(t, plain, "editor", "posts", "read", false)
+}
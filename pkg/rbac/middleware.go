@@ -0,0 +1,146 @@
+package rbac
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rei0721/go-scaffold/types/result"
+)
+
+// 中间件上下文键
+const (
+	// ContextKeyUserID 认证中间件写入用户ID的上下文键
+	// 与 internal/middleware.ContextKeyUserID 保持一致
+	ContextKeyUserID = "user_id"
+
+	// ContextKeyDomain 域（租户）在上下文中的键
+	// 由上游中间件写入,未设置时使用空域
+	ContextKeyDomain = "rbac_domain"
+)
+
+// ObjectFunc 从请求中推导对象和操作
+// 例如 obj = c.FullPath(), act = c.Request.Method
+type ObjectFunc func(c *gin.Context) (obj, act string)
+
+// MiddlewareOption 中间件配置选项
+type MiddlewareOption func(*middlewareConfig)
+
+// middlewareConfig 中间件配置
+type middlewareConfig struct {
+	// subjectFunc 提取主体
+	subjectFunc func(c *gin.Context) (string, bool)
+
+	// domainFunc 提取域
+	domainFunc func(c *gin.Context) string
+}
+
+// WithSubjectFunc 自定义主体提取方式
+// 默认从 ContextKeyUserID 读取认证中间件写入的用户ID
+func WithSubjectFunc(fn func(c *gin.Context) (string, bool)) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.subjectFunc = fn
+	}
+}
+
+// WithDomainFunc 自定义域提取方式
+// 默认从 ContextKeyDomain 读取,未设置时为空域
+func WithDomainFunc(fn func(c *gin.Context) string) MiddlewareOption {
+	return func(cfg *middlewareConfig) {
+		cfg.domainFunc = fn
+	}
+}
+
+// Middleware 创建 Gin 权限检查中间件
+// JWT 负责认证（谁在访问）,RBAC 负责授权（能否访问）:
+// 该中间件需注册在认证中间件之后,从上下文读取主体,
+// 通过 objectFunc 推导对象和操作,再调用 EnforceWithDomain 检查权限
+//
+// 参数:
+//
+//	r: RBAC 实例
+//	objectFunc: 从请求推导对象和操作
+//	opts: 可选配置（主体、域的提取方式）
+//
+// 返回:
+//
+//	gin.HandlerFunc: 未认证返回 401,无权限返回 403,检查出错返回 500
+//
+// 使用示例:
+//
+//	api := router.Group("/api/v1")
+//	api.Use(middleware.AuthMiddleware(jwtManager))
+//	api.Use(rbac.Middleware(rbacManager, func(c *gin.Context) (string, string) {
+//	    return c.FullPath(), c.Request.Method
+//	}))
+func Middleware(r RBAC, objectFunc ObjectFunc, opts ...MiddlewareOption) gin.HandlerFunc {
+	cfg := &middlewareConfig{
+		subjectFunc: subjectFromContext,
+		domainFunc:  domainFromContext,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(c *gin.Context) {
+		// 1. 提取主体
+		sub, ok := cfg.subjectFunc(c)
+		if !ok || sub == "" {
+			result.Unauthorized(c, "Missing authenticated subject")
+			c.Abort()
+			return
+		}
+
+		// 2. 推导对象和操作
+		obj, act := objectFunc(c)
+
+		// 3. 检查权限
+		allowed, err := r.EnforceWithDomain(sub, cfg.domainFunc(c), obj, act)
+		if err != nil {
+			result.InternalError(c, "Permission check failed")
+			c.Abort()
+			return
+		}
+		if !allowed {
+			result.Forbidden(c, "Permission denied")
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission 创建检查固定权限的中间件
+// 适用于对象和操作在路由注册时即可确定的场景
+//
+// 使用示例:
+//
+//	admin.DELETE("/users/:id", rbac.RequirePermission(rbacManager, "users", "delete"), h.DeleteUser)
+func RequirePermission(r RBAC, obj, act string, opts ...MiddlewareOption) gin.HandlerFunc {
+	return Middleware(r, func(*gin.Context) (string, string) {
+		return obj, act
+	}, opts...)
+}
+
+// subjectFromContext 从上下文读取认证中间件写入的用户ID
+func subjectFromContext(c *gin.Context) (string, bool) {
+	v, exists := c.Get(ContextKeyUserID)
+	if !exists {
+		return "", false
+	}
+	switch id := v.(type) {
+	case int64:
+		return strconv.FormatInt(id, 10), true
+	case string:
+		return id, true
+	default:
+		return fmt.Sprint(id), true
+	}
+}
+
+// domainFromContext 从上下文读取域
+func domainFromContext(c *gin.Context) string {
+	return c.GetString(ContextKeyDomain)
+}
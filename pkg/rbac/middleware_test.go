@@ -0,0 +1,111 @@
+package rbac
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestRouter 创建模拟认证后经过权限中间件的路由
+// userID 非 nil 时写入 ContextKeyUserID,domain 非空时写入 ContextKeyDomain
+func newTestRouter(userID interface{}, domain string, guard gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != nil {
+			c.Set(ContextKeyUserID, userID)
+		}
+		if domain != "" {
+			c.Set(ContextKeyDomain, domain)
+		}
+	})
+	router.Any("/posts", guard, func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+// serve 发送请求并返回状态码
+func serve(router *gin.Engine, method string) int {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/posts", nil))
+	return w.Code
+}
+
+// TestMiddleware 测试中间件的认证、授权和错误响应
+func TestMiddleware(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+	_ = r.AddPolicy("1", "/posts", http.MethodGet)
+	_ = r.AddPolicyWithDomain("2", "tenant1", "/posts", http.MethodPost)
+
+	byRoute := Middleware(r, func(c *gin.Context) (string, string) {
+		return c.FullPath(), c.Request.Method
+	})
+
+	tests := []struct {
+		name   string
+		userID interface{}
+		domain string
+		method string
+		want   int
+	}{
+		{"allowed", int64(1), "", http.MethodGet, http.StatusOK},
+		{"denied", int64(1), "", http.MethodDelete, http.StatusForbidden},
+		{"string subject", "1", "", http.MethodGet, http.StatusOK},
+		{"unauthenticated", nil, "", http.MethodGet, http.StatusUnauthorized},
+		{"domain", int64(2), "tenant1", http.MethodPost, http.StatusOK},
+		{"other domain", int64(2), "tenant2", http.MethodPost, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serve(newTestRouter(tt.userID, tt.domain, byRoute), tt.method); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// 检查出错时返回 500
+	closed := setupTestRBAC(t, nil)
+	_ = closed.Close()
+	router := newTestRouter(int64(1), "", RequirePermission(closed, "/posts", http.MethodGet))
+	if got := serve(router, http.MethodGet); got != http.StatusInternalServerError {
+		t.Errorf("status with closed enforcer = %d, want 500", got)
+	}
+}
+
+// TestMiddlewareOptions 测试自定义主体和域的提取方式
+func TestMiddlewareOptions(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+	_ = r.AddPolicyWithDomain("alice", "tenant1", "posts", "read")
+
+	guard := RequirePermission(r, "posts", "read",
+		WithSubjectFunc(func(c *gin.Context) (string, bool) {
+			return c.GetHeader("X-User"), c.GetHeader("X-User") != ""
+		}),
+		WithDomainFunc(func(c *gin.Context) string { return c.GetHeader("X-Tenant") }),
+	)
+	router := newTestRouter(nil, "", guard)
+
+	tests := []struct {
+		user, tenant string
+		want         int
+	}{
+		{"alice", "tenant1", http.StatusOK},
+		{"alice", "tenant2", http.StatusForbidden},
+		{"bob", "tenant1", http.StatusForbidden},
+		{"", "tenant1", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/posts", nil)
+		req.Header.Set("X-User", tt.user)
+		req.Header.Set("X-Tenant", tt.tenant)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("user=%q tenant=%q status = %d, want %d", tt.user, tt.tenant, w.Code, tt.want)
+		}
+	}
+}
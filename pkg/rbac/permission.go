@@ -0,0 +1,115 @@
+package rbac
+
+import "strconv"
+
+// Subject 权限检查的主体（用户ID或角色）
+// 与 Object、Action、Domain 是不同的命名类型，传入这些类型的变量时参数顺序写错会编译失败
+// 注意: 四者的底层类型都是 string，无类型的字符串字面量可以赋给任意一个，
+// 例如 NewPermission(sub, "edit", "posts") 交换了对象和操作仍能通过编译
+type Subject string
+
+// Object 权限检查的对象（资源），如 "/api/v1/users" 或 "posts"
+type Object string
+
+// Action 权限检查的操作，如 "read"、"write" 或 HTTP 方法
+type Action string
+
+// Domain 权限检查的域（租户ID），空字符串表示不使用域
+type Domain string
+
+// UserSubject 返回用户的主体
+// 与角色分配和 Middleware 使用相同的格式（十进制用户ID）
+// 参数:
+//
+//	userID: 用户ID
+//
+// 返回:
+//
+//	Subject: 用户主体
+func UserSubject(userID int64) Subject {
+	return Subject(strconv.FormatInt(userID, 10))
+}
+
+// RoleSubject 返回角色的主体，用于直接检查角色拥有的权限
+func RoleSubject(role string) Subject {
+	return Subject(role)
+}
+
+// String 返回主体的字符串形式
+func (s Subject) String() string {
+	return string(s)
+}
+
+// String 返回对象的字符串形式
+func (o Object) String() string {
+	return string(o)
+}
+
+// String 返回操作的字符串形式
+func (a Action) String() string {
+	return string(a)
+}
+
+// String 返回域的字符串形式
+func (d Domain) String() string {
+	return string(d)
+}
+
+// Permission 一条权限：主体在域中对对象执行操作
+// 既可以用于检查（EnforcePermission），也可以转换为策略规则（Rule）
+//
+// 使用示例:
+//
+//	p := rbac.NewPermission(rbac.UserSubject(user.ID), "posts", "edit").InDomain("tenant1")
+//	ok, err := r.EnforcePermission(p)
+type Permission struct {
+	// Subject 主体
+	Subject Subject
+
+	// Domain 域，为空时不使用域
+	Domain Domain
+
+	// Object 对象
+	Object Object
+
+	// Action 操作
+	Action Action
+}
+
+// NewPermission 创建不带域的权限
+// 参数:
+//
+//	sub: 主体
+//	obj: 对象
+//	act: 操作
+//
+// 返回:
+//
+//	Permission: 权限，通过 InDomain 指定域
+func NewPermission(sub Subject, obj Object, act Action) Permission {
+	return Permission{Subject: sub, Object: obj, Action: act}
+}
+
+// InDomain 返回指定域的权限副本
+func (p Permission) InDomain(dom Domain) Permission {
+	p.Domain = dom
+	return p
+}
+
+// Rule 返回对应的策略规则 [sub, dom, obj, act]
+// 列顺序与内置模型的 p 策略一致，可以直接传给 AddPolicies、RemovePolicies
+func (p Permission) Rule() []string {
+	return []string{string(p.Subject), string(p.Domain), string(p.Object), string(p.Action)}
+}
+
+// EnforceP 使用类型化参数检查权限（无域）
+// 实现 RBAC 接口
+func (r *rbacImpl) EnforceP(sub Subject, obj Object, act Action) (bool, error) {
+	return r.EnforceWithDomain(string(sub), "", string(obj), string(act))
+}
+
+// EnforcePermission 检查权限，包含权限中的域
+// 实现 RBAC 接口
+func (r *rbacImpl) EnforcePermission(p Permission) (bool, error) {
+	return r.EnforceWithDomain(string(p.Subject), string(p.Domain), string(p.Object), string(p.Action))
+}
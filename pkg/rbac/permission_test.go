@@ -0,0 +1,53 @@
+package rbac
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestPermission 测试类型化参数的构造和转换
+func TestPermission(t *testing.T) {
+	if got := UserSubject(42); got != "42" {
+		t.Errorf("UserSubject(42) = %q, want 42", got)
+	}
+	if got := RoleSubject("admin").String(); got != "admin" {
+		t.Errorf("RoleSubject(admin) = %q", got)
+	}
+
+	p := NewPermission(UserSubject(1), "posts", "edit")
+	scoped := p.InDomain("tenant1")
+	if p.Domain != "" {
+		t.Error("InDomain modified the original permission")
+	}
+	if got := scoped.Rule(); !slices.Equal(got, []string{"1", "tenant1", "posts", "edit"}) {
+		t.Errorf("Rule() = %v", got)
+	}
+}
+
+// TestEnforcePermission 测试类型化参数的权限检查
+func TestEnforcePermission(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	p := NewPermission(UserSubject(1), "posts", "edit")
+	scoped := p.InDomain("tenant1")
+	if err := r.AddPolicies([][]string{p.Rule(), scoped.Rule()}); err != nil {
+		t.Fatalf("AddPolicies() error = %v", err)
+	}
+
+	var (
+		obj Object = "posts"
+		act Action = "edit"
+	)
+	if ok, err := r.EnforceP(UserSubject(1), obj, act); err != nil || !ok {
+		t.Errorf("EnforceP() = %v, %v, want true", ok, err)
+	}
+	if ok, err := r.EnforceP(UserSubject(2), obj, act); err != nil || ok {
+		t.Errorf("EnforceP(other user) = %v, %v, want false", ok, err)
+	}
+	if ok, err := r.EnforcePermission(scoped); err != nil || !ok {
+		t.Errorf("EnforcePermission(tenant1) = %v, %v, want true", ok, err)
+	}
+	if ok, err := r.EnforcePermission(p.InDomain("tenant2")); err != nil || ok {
+		t.Errorf("EnforcePermission(tenant2) = %v, %v, want false", ok, err)
+	}
+}
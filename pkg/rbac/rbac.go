@@ -1,5 +1,7 @@
 package rbac
 
+import "context"
+
 // RBAC 权限控制接口
 //
 // 基于Casbin实现的RBAC（基于角色的访问控制）
@@ -30,6 +32,71 @@ type RBAC interface {
 	//   ok, err := rbac.EnforceWithDomain("alice", "tenant1", "data1", "read")
 	EnforceWithDomain(sub, dom, obj, act string) (bool, error)
 
+	// EnforceP 使用类型化参数检查权限（无域）
+	// 与 Enforce 行为一致，Subject、Object、Action 是不同的命名类型，
+	// 传入这些类型的变量时交换 obj 和 act 在编译时即可发现（字符串字面量不受检查）
+	// 参数:
+	//   sub: 主体，如 rbac.UserSubject(userID)
+	//   obj: 对象
+	//   act: 操作
+	// 示例:
+	//   ok, err := rbac.EnforceP(rbac.UserSubject(1), "data1", "read")
+	EnforceP(sub Subject, obj Object, act Action) (bool, error)
+
+	// EnforcePermission 检查权限，域取自 Permission.Domain
+	// 与 EnforceWithDomain 行为一致
+	// 示例:
+	//   p := rbac.NewPermission("alice", "data1", "read").InDomain("tenant1")
+	//   ok, err := rbac.EnforcePermission(p)
+	EnforcePermission(p Permission) (bool, error)
+
+	// EnforceWithContext 带属性的权限检查（ABAC）
+	// 属性以 r.attrs 传入模型，可在策略条件中引用，如 "r.attrs.owner == r.sub"
+	// 结果依赖属性值，因此不使用缓存
+	// 参数:
+	//   sub: 主体（用户ID或角色）
+	//   obj: 对象（资源）
+	//   act: 操作
+	//   attrs: 请求属性，条件中引用但未提供的属性视为条件不满足，该条件策略不生效
+	// 返回:
+	//   bool: 是否有权限
+	//   error: 未启用ABAC模型时返回 ErrABACNotEnabled
+	// 示例:
+	//   ok, err := rbac.EnforceWithContext("alice", "posts", "edit",
+	//       map[string]interface{}{"owner": post.AuthorID})
+	EnforceWithContext(sub, obj, act string, attrs map[string]interface{}) (bool, error)
+
+	// Explain 检查权限并说明原因
+	// 用于排查权限问题和管理后台的"测试权限"功能，不读写缓存
+	// 参数:
+	//   sub: 主体（用户ID或角色）
+	//   dom: 域（租户ID），不使用域时传空字符串
+	//   obj: 对象（资源）
+	//   act: 操作
+	// 返回:
+	//   allowed: 是否有权限
+	//   matchedPolicy: 命中的策略规则，没有命中时为空
+	//   reason: 可读的原因，如 "no matching policy" 或 "matched by role admin via policy [admin  data1 read]"
+	//   err: 检查过程中的错误
+	// 示例:
+	//   ok, policy, reason, err := rbac.Explain("alice", "", "data1", "read")
+	Explain(sub, dom, obj, act string) (allowed bool, matchedPolicy []string, reason string, err error)
+
+	// IsSuperAdmin 判断主体在指定域中是否为超级管理员
+	// 超级管理员跳过所有权限检查，Enforce、EnforceWithDomain、EnforceWithContext 直接返回 true
+	// 参数:
+	//   sub: 主体（用户ID或角色）
+	//   dom: 域（租户ID），不使用域时传空字符串
+	// 返回:
+	//   bool: sub 在 Config.SuperAdmins 中，或在 dom 中直接或间接拥有 Config.SuperAdminRole 时为 true
+	//   error: 查询角色继承失败时的错误
+	// 示例:
+	//   // Config.SuperAdminRole = "superadmin"
+	//   rbac.AddRoleForUserInDomain("alice", "superadmin", "tenant1")
+	//   ok, _ := rbac.IsSuperAdmin("alice", "tenant1") // true
+	//   ok, _ = rbac.IsSuperAdmin("alice", "tenant2")  // false
+	IsSuperAdmin(sub, dom string) (bool, error)
+
 	// ========== 角色管理 ==========
 
 	// AddRoleForUser 为用户分配角色
@@ -73,6 +140,31 @@ type RBAC interface {
 	//   []string: 用户ID列表
 	GetUsersForRole(role string) ([]string, error)
 
+	// GetImplicitUsersForRole 获取直接或通过角色继承拥有指定角色的所有主体
+	// GetUsersForRole 只返回直接授予的用户,访问审查需要知道"谁实际上拥有 admin"
+	// 参数:
+	//   role: 角色名称
+	// 返回:
+	//   []string: 用户及继承了该角色的子角色
+	// 示例:
+	//   // g, alice, editor  g, editor, admin
+	//   users, _ := rbac.GetImplicitUsersForRole("admin") // [editor alice]
+	GetImplicitUsersForRole(role string) ([]string, error)
+
+	// GetImplicitUsersForRoleInDomain 获取在指定域中直接或通过角色继承拥有指定角色的所有主体
+	GetImplicitUsersForRoleInDomain(role, domain string) ([]string, error)
+
+	// GetRoleTree 获取完整的角色继承图
+	// 返回:
+	//   map[string][]string: key 为用户或子角色,value 为其直接父角色
+	// 示例:
+	//   tree, _ := rbac.GetRoleTree()
+	//   // map[alice:[editor] editor:[admin]]
+	GetRoleTree() (map[string][]string, error)
+
+	// GetRoleTreeInDomain 获取指定域中的角色继承图
+	GetRoleTreeInDomain(domain string) (map[string][]string, error)
+
 	// ========== 策略管理 ==========
 
 	// AddPolicy 添加策略
@@ -87,6 +179,19 @@ type RBAC interface {
 	// AddPolicyWithDomain 添加带域的策略
 	AddPolicyWithDomain(sub, domain, obj, act string) error
 
+	// AddConditionalPolicy 添加带条件的策略（ABAC）
+	// 参数:
+	//   sub: 主体（通常是角色）
+	//   domain: 域，无域时传空字符串
+	//   obj: 对象
+	//   act: 操作
+	//   cond: 条件表达式，可引用 r.sub/r.obj/r.act/r.attrs，如 "r.attrs.hour >= 9 && r.attrs.hour < 18"
+	// 返回:
+	//   error: 未启用ABAC模型时返回 ErrABACNotEnabled
+	// 说明:
+	//   ABAC模型下 AddPolicy 等方法添加的策略条件为 DefaultCondition（恒为真）
+	AddConditionalPolicy(sub, domain, obj, act, cond string) error
+
 	// RemovePolicy 删除策略
 	RemovePolicy(sub, obj, act string) error
 
@@ -120,12 +225,78 @@ type RBAC interface {
 	// RemovePolicies 批量删除策略
 	RemovePolicies(rules [][]string) error
 
+	// ========== 带操作者的变更 ==========
+	//
+	// 以下方法与同名的非 Ctx 方法行为一致，额外从上下文中读取操作者（见 WithActor），
+	// 写入 Config.AuditLog 收到的审计事件的 Actor 字段
+	// 示例:
+	//   ctx := rbac.WithActor(ctx, operatorID)
+	//   rbac.AddRoleForUserCtx(ctx, "alice", "admin")
+
+	// AddRoleForUserCtx 为用户分配角色
+	AddRoleForUserCtx(ctx context.Context, user, role string) error
+
+	// AddRoleForUserInDomainCtx 在指定域中为用户分配角色
+	AddRoleForUserInDomainCtx(ctx context.Context, user, role, domain string) error
+
+	// DeleteRoleForUserCtx 撤销用户的角色
+	DeleteRoleForUserCtx(ctx context.Context, user, role string) error
+
+	// DeleteRoleForUserInDomainCtx 在指定域中撤销用户的角色
+	DeleteRoleForUserInDomainCtx(ctx context.Context, user, role, domain string) error
+
+	// AddPolicyCtx 添加策略
+	AddPolicyCtx(ctx context.Context, sub, obj, act string) error
+
+	// AddPolicyWithDomainCtx 添加带域的策略
+	AddPolicyWithDomainCtx(ctx context.Context, sub, domain, obj, act string) error
+
+	// RemovePolicyCtx 删除策略
+	RemovePolicyCtx(ctx context.Context, sub, obj, act string) error
+
+	// RemovePolicyWithDomainCtx 删除带域的策略
+	RemovePolicyWithDomainCtx(ctx context.Context, sub, domain, obj, act string) error
+
+	// AddPoliciesCtx 批量添加策略
+	AddPoliciesCtx(ctx context.Context, rules [][]string) error
+
+	// RemovePoliciesCtx 批量删除策略
+	RemovePoliciesCtx(ctx context.Context, rules [][]string) error
+
 	// ========== 工具方法 ==========
 
 	// LoadPolicy 从存储加载策略
 	// 通常在Enforcer初始化时自动调用
 	LoadPolicy() error
 
+	// LoadFilteredPolicy 清空内存中的策略，只加载匹配过滤条件的策略
+	// 用于多租户部署中只加载本实例负责的域或主体，避免加载整张 casbin_rule 表
+	// 参数:
+	//   filter: 过滤条件，字段含义见 Filter
+	// 返回:
+	//   error: 加载失败时返回包装的 ErrLoadPolicy
+	// 注意:
+	//   过滤加载后 IsFiltered 返回 true，SavePolicy 会失败，策略变更依赖 AutoSave 持久化
+	// 使用示例:
+	//   // 只加载 tenant1 的策略和角色分配
+	//   filters := rbac.DomainFilters("tenant1")
+	//   err := r.LoadFilteredPolicy(filters[0])
+	//   err = r.LoadIncrementalFilteredPolicy(filters[1])
+	LoadFilteredPolicy(filter Filter) error
+
+	// LoadIncrementalFilteredPolicy 在内存中已有策略的基础上追加匹配过滤条件的策略
+	// 用于组合多个过滤条件，或在实例接管新租户时增量加载
+	// 参数:
+	//   filter: 过滤条件
+	// 返回:
+	//   error: 加载失败时返回包装的 ErrLoadPolicy
+	// 注意:
+	//   已加载的策略再次匹配时会重复加入内存，条件之间应互不重叠
+	LoadIncrementalFilteredPolicy(filter Filter) error
+
+	// IsFiltered 内存中的策略是否为过滤加载的部分策略
+	IsFiltered() bool
+
 	// SavePolicy 保存策略到存储
 	// 如果AutoSave=true，策略变更会自动保存，无需手动调用
 	SavePolicy() error
@@ -137,4 +308,10 @@ type RBAC interface {
 	// Close 关闭RBAC实例
 	// 释放资源
 	Close() error
+
+	// Reload 使用新配置重建Enforcer并原子替换
+	// 用于运行时切换模型（如 RBAC 迁移到带域的 RBAC）或数据库，无需重建RBAC实例
+	// 新的模型和策略全部加载成功后才替换，失败时保持原有Enforcer不变
+	// 替换后清除权限检查和角色继承缓存
+	Reload(cfg *Config) error
 }
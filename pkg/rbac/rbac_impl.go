@@ -1,9 +1,12 @@
 package rbac
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,15 +15,33 @@ import (
 	gormadapter "github.com/casbin/gorm-adapter/v3"
 )
 
-//go:embed model.conf
+//go:embed model.conf model_abac.conf
 var modelFS embed.FS
 
 // rbacImpl Casbin RBAC实现
 type rbacImpl struct {
+	rbacState
+	cache sync.Map // 权限检查结果缓存 map[string]cacheEntry
+	roles sync.Map // 角色继承查询缓存 map[string]roleCacheEntry
+	mu    sync.RWMutex
+}
+
+// rbacState 由配置构建的 enforcer 及相关状态
+// Reload 在写锁下整体替换,各方法开始时通过 state() 取得快照,
+// 保证一次调用内使用的 enforcer、配置和模型类型来自同一份配置
+type rbacState struct {
 	enforcer *casbin.Enforcer
 	config   *Config
-	cache    sync.Map // 权限检查结果缓存 map[string]cacheEntry
-	mu       sync.RWMutex
+
+	// abac 模型是否为ABAC模型（请求携带 attrs，策略携带 cond）
+	abac bool
+	// plainMatcher ABAC模型下只匹配无条件策略的匹配器，请求缺少条件引用的属性时使用
+	plainMatcher string
+
+	// impliedBy 由 Config.ActionHierarchy 计算，键为操作，值为蕴含它的其他操作
+	impliedBy map[string][]string
+	// wildcard 蕴含所有操作（ActionAll）的操作
+	wildcard []string
 }
 
 // cacheEntry 缓存条目
@@ -29,6 +50,13 @@ type cacheEntry struct {
 	expiresAt time.Time
 }
 
+// roleCacheEntry 角色继承查询缓存条目
+// value 为 []string 或 map[string][]string
+type roleCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
 // New 创建新的RBAC实例
 //
 // 参数:
@@ -51,9 +79,20 @@ func New(cfg *Config) (RBAC, error) {
 		cfg = &Config{}
 	}
 
+	state, err := newState(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rbacImpl{rbacState: state}, nil
+}
+
+// newState 根据配置创建 Adapter、加载模型并构建 Enforcer
+// New 和 Reload 共用,失败时不影响已有实例
+func newState(cfg *Config) (rbacState, error) {
 	// 验证配置
 	if err := cfg.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid config: %w", err)
+		return rbacState{}, fmt.Errorf("invalid config: %w", err)
 	}
 
 	// 创建Gorm Adapter
@@ -67,7 +106,7 @@ func New(cfg *Config) (RBAC, error) {
 		adapter, err = gormadapter.NewAdapterByDB(cfg.DB)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create gorm adapter: %w", err)
+		return rbacState{}, fmt.Errorf("failed to create gorm adapter: %w", err)
 	}
 
 	// 加载模型
@@ -76,38 +115,51 @@ func New(cfg *Config) (RBAC, error) {
 		// 使用外部模型文件
 		m, err = model.NewModelFromFile(cfg.ModelPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load model from file: %w", err)
+			return rbacState{}, fmt.Errorf("failed to load model from file: %w", err)
 		}
 	} else {
 		// 使用内置模型
-		modelContent, err := modelFS.ReadFile("model.conf")
+		modelFile := "model.conf"
+		if cfg.EnableABAC {
+			modelFile = "model_abac.conf"
+		}
+		modelContent, err := modelFS.ReadFile(modelFile)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read embedded model: %w", err)
+			return rbacState{}, fmt.Errorf("failed to read embedded model: %w", err)
 		}
 		m, err = model.NewModelFromString(string(modelContent))
 		if err != nil {
-			return nil, fmt.Errorf("failed to load embedded model: %w", err)
+			return rbacState{}, fmt.Errorf("failed to load embedded model: %w", err)
 		}
 	}
 
 	// 创建Enforcer
-	enforcer, err := casbin.NewEnforcer(m, adapter)
+	// 先不传入 Adapter,否则 NewEnforcer 会立即加载全部策略,PolicyFilters 失去意义
+	enforcer, err := casbin.NewEnforcer(m)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create enforcer: %w", err)
+		return rbacState{}, fmt.Errorf("failed to create enforcer: %w", err)
 	}
+	enforcer.SetAdapter(adapter)
 
 	// 设置自动保存
 	enforcer.EnableAutoSave(cfg.AutoSave)
 
+	state := rbacState{
+		enforcer: enforcer,
+		config:   cfg,
+		abac:     len(m["r"]["r"].Tokens) == abacRequestSize,
+	}
+	if state.abac {
+		state.plainMatcher = unconditionalMatcher(m)
+	}
+	state.impliedBy, state.wildcard = buildActionImplications(cfg.ActionHierarchy)
+
 	// 加载策略
-	if err := enforcer.LoadPolicy(); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrLoadPolicy, err)
+	if err := state.loadPolicy(); err != nil {
+		return rbacState{}, fmt.Errorf("%w: %v", ErrLoadPolicy, err)
 	}
 
-	return &rbacImpl{
-		enforcer: enforcer,
-		config:   cfg,
-	}, nil
+	return state, nil
 }
 
 // ========== 权限检查 ==========
@@ -119,52 +171,188 @@ func (r *rbacImpl) Enforce(sub, obj, act string) (bool, error) {
 
 // EnforceWithDomain 带域的权限检查
 func (r *rbacImpl) EnforceWithDomain(sub, dom, obj, act string) (bool, error) {
-	if r.enforcer == nil {
+	s := r.state()
+	if s.enforcer == nil {
 		return false, ErrEnforcerNotInitialized
 	}
 
 	// 检查缓存
-	if r.config.EnableCache {
+	if s.config.EnableCache {
 		if result, ok := r.getCached(sub, dom, obj, act); ok {
 			return result, nil
 		}
 	}
 
-	// 执行权限检查
-	result, err := r.enforcer.Enforce(sub, dom, obj, act)
+	// 超级管理员跳过匹配器，结果同样写入缓存
+	result, err := s.isSuperAdmin(sub, dom)
 	if err != nil {
 		return false, fmt.Errorf(ErrMsgEnforceFailed, err)
 	}
 
+	// 执行权限检查，配置 ActionHierarchy 时蕴含 act 的操作同样满足
+	// ABAC模型下以空属性检查，条件为 DefaultCondition 的策略照常生效，
+	// 引用属性的条件视为不满足
+	if !result {
+		result, err = s.enforce(sub, dom, obj, act, map[string]interface{}{})
+		if err != nil {
+			return false, fmt.Errorf(ErrMsgEnforceFailed, err)
+		}
+	}
+
 	// 缓存结果
-	if r.config.EnableCache {
-		r.setCache(sub, dom, obj, act, result)
+	if s.config.EnableCache {
+		r.setCache(s.enforcer, sub, dom, obj, act, result)
 	}
 
 	return result, nil
 }
 
+// EnforceWithContext 带属性的权限检查（ABAC）
+func (r *rbacImpl) EnforceWithContext(sub, obj, act string, attrs map[string]interface{}) (bool, error) {
+	s := r.state()
+	if s.enforcer == nil {
+		return false, ErrEnforcerNotInitialized
+	}
+	if !s.abac {
+		return false, ErrABACNotEnabled
+	}
+	if attrs == nil {
+		attrs = map[string]interface{}{}
+	}
+
+	// 超级管理员不受属性条件限制
+	if super, err := s.isSuperAdmin(sub, ""); err != nil || super {
+		if err != nil {
+			return false, fmt.Errorf(ErrMsgEnforceFailed, err)
+		}
+		return true, nil
+	}
+
+	// 结果依赖属性值，不读写缓存
+	result, err := s.enforce(sub, "", obj, act, attrs)
+	if err != nil {
+		return false, fmt.Errorf(ErrMsgEnforceFailed, err)
+	}
+
+	return result, nil
+}
+
+// Explain 检查权限并说明原因
+// 基于 Casbin 的 EnforceEx，它会返回决定结果的策略规则
+// 策略主体与请求主体不同时，说明权限来自主体直接或间接拥有的角色
+func (r *rbacImpl) Explain(sub, dom, obj, act string) (bool, []string, string, error) {
+	s := r.state()
+	if s.enforcer == nil {
+		return false, nil, "", ErrEnforcerNotInitialized
+	}
+
+	super, err := s.isSuperAdmin(sub, dom)
+	if err != nil {
+		return false, nil, "", fmt.Errorf(ErrMsgEnforceFailed, err)
+	}
+	if super {
+		return true, nil, ExplainSuperAdmin, nil
+	}
+
+	// 依次检查 act 及蕴含它的操作，返回第一个通过的操作命中的策略
+	// 都不通过时返回 act 自身的检查结果
+	var (
+		allowed bool
+		matched []string
+	)
+	for i, a := range s.candidateActions(act) {
+		ok, m, err := s.enforceEx(sub, dom, obj, a, map[string]interface{}{})
+		if err != nil {
+			return false, nil, "", fmt.Errorf(ErrMsgEnforceFailed, err)
+		}
+		if i == 0 || ok {
+			allowed, matched = ok, m
+		}
+		if ok {
+			break
+		}
+	}
+
+	return allowed, matched, explainReason(sub, allowed, matched), nil
+}
+
+// IsSuperAdmin 判断主体在指定域中是否为超级管理员
+func (r *rbacImpl) IsSuperAdmin(sub, dom string) (bool, error) {
+	s := r.state()
+	if s.enforcer == nil {
+		return false, ErrEnforcerNotInitialized
+	}
+	return s.isSuperAdmin(sub, dom)
+}
+
+// isSuperAdmin 判断主体在指定域中是否为超级管理员
+// Config.SuperAdmins 中的主体在所有域中生效；
+// 拥有 Config.SuperAdminRole 的主体只在拥有该角色的域中生效
+// 角色继承由 Casbin 的角色管理器在内存中计算，无需查询数据库
+func (s rbacState) isSuperAdmin(sub, dom string) (bool, error) {
+	if slices.Contains(s.config.SuperAdmins, sub) {
+		return true, nil
+	}
+	if s.config.SuperAdminRole == "" {
+		return false, nil
+	}
+
+	roles, err := s.enforcer.GetImplicitRolesForUser(sub, dom)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(roles, s.config.SuperAdminRole), nil
+}
+
+// explainReason 根据命中的策略生成可读的原因
+func explainReason(sub string, allowed bool, matched []string) string {
+	if len(matched) == 0 {
+		return ExplainNoMatchingPolicy
+	}
+	if !allowed {
+		return fmt.Sprintf(ExplainDeniedByPolicy, matched)
+	}
+	if matched[0] == sub {
+		return fmt.Sprintf(ExplainMatchedDirectly, matched)
+	}
+	return fmt.Sprintf(ExplainMatchedByRole, matched[0], matched)
+}
+
 // ========== 角色管理 ==========
 
 // AddRoleForUser 为用户分配角色（无域）
 func (r *rbacImpl) AddRoleForUser(user, role string) error {
-	return r.AddRoleForUserInDomain(user, role, "")
+	return r.AddRoleForUserInDomainCtx(context.Background(), user, role, "")
+}
+
+// AddRoleForUserCtx 为用户分配角色（无域），操作者取自上下文
+func (r *rbacImpl) AddRoleForUserCtx(ctx context.Context, user, role string) error {
+	return r.AddRoleForUserInDomainCtx(ctx, user, role, "")
 }
 
 // AddRoleForUserInDomain 在指定域中为用户分配角色
 func (r *rbacImpl) AddRoleForUserInDomain(user, role, domain string) error {
-	if r.enforcer == nil {
+	return r.AddRoleForUserInDomainCtx(context.Background(), user, role, domain)
+}
+
+// AddRoleForUserInDomainCtx 在指定域中为用户分配角色，操作者取自上下文
+func (r *rbacImpl) AddRoleForUserInDomainCtx(ctx context.Context, user, role, domain string) error {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
 
-	_, err := r.enforcer.AddRoleForUser(user, role, domain)
+	added, err := s.enforcer.AddRoleForUser(user, role, domain)
 	if err != nil {
 		return fmt.Errorf(ErrMsgAddRoleFailed, err)
 	}
+	if added {
+		r.audit(ctx, AuditEvent{Action: AuditActionAddRole, Subject: user, Object: role, Domain: domain})
+	}
 
 	// 清除缓存
-	if r.config.EnableCache {
-		r.clearUserCache(user)
+	if s.config.EnableCache {
+		r.clearRoleChangeCache(s, user, role, domain)
 	}
 
 	return nil
@@ -172,23 +360,37 @@ func (r *rbacImpl) AddRoleForUserInDomain(user, role, domain string) error {
 
 // DeleteRoleForUser 撤销用户的角色（无域）
 func (r *rbacImpl) DeleteRoleForUser(user, role string) error {
-	return r.DeleteRoleForUserInDomain(user, role, "")
+	return r.DeleteRoleForUserInDomainCtx(context.Background(), user, role, "")
+}
+
+// DeleteRoleForUserCtx 撤销用户的角色（无域），操作者取自上下文
+func (r *rbacImpl) DeleteRoleForUserCtx(ctx context.Context, user, role string) error {
+	return r.DeleteRoleForUserInDomainCtx(ctx, user, role, "")
 }
 
 // DeleteRoleForUserInDomain 在指定域中撤销用户的角色
 func (r *rbacImpl) DeleteRoleForUserInDomain(user, role, domain string) error {
-	if r.enforcer == nil {
+	return r.DeleteRoleForUserInDomainCtx(context.Background(), user, role, domain)
+}
+
+// DeleteRoleForUserInDomainCtx 在指定域中撤销用户的角色，操作者取自上下文
+func (r *rbacImpl) DeleteRoleForUserInDomainCtx(ctx context.Context, user, role, domain string) error {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
 
-	_, err := r.enforcer.DeleteRoleForUser(user, role, domain)
+	deleted, err := s.enforcer.DeleteRoleForUser(user, role, domain)
 	if err != nil {
 		return fmt.Errorf(ErrMsgRemoveRoleFailed, err)
 	}
+	if deleted {
+		r.audit(ctx, AuditEvent{Action: AuditActionDeleteRole, Subject: user, Object: role, Domain: domain})
+	}
 
 	// 清除缓存
-	if r.config.EnableCache {
-		r.clearUserCache(user)
+	if s.config.EnableCache {
+		r.clearRoleChangeCache(s, user, role, domain)
 	}
 
 	return nil
@@ -201,11 +403,12 @@ func (r *rbacImpl) GetRolesForUser(user string) ([]string, error) {
 
 // GetRolesForUserInDomain 获取用户在指定域中的角色
 func (r *rbacImpl) GetRolesForUserInDomain(user, domain string) ([]string, error) {
-	if r.enforcer == nil {
+	s := r.state()
+	if s.enforcer == nil {
 		return nil, ErrEnforcerNotInitialized
 	}
 
-	roles, err := r.enforcer.GetRolesForUser(user, domain)
+	roles, err := s.enforcer.GetRolesForUser(user, domain)
 	if err != nil {
 		return nil, err
 	}
@@ -215,11 +418,12 @@ func (r *rbacImpl) GetRolesForUserInDomain(user, domain string) ([]string, error
 
 // GetUsersForRole 获取拥有指定角色的所有用户
 func (r *rbacImpl) GetUsersForRole(role string) ([]string, error) {
-	if r.enforcer == nil {
+	s := r.state()
+	if s.enforcer == nil {
 		return nil, ErrEnforcerNotInitialized
 	}
 
-	users, err := r.enforcer.GetUsersForRole(role, "")
+	users, err := s.enforcer.GetUsersForRole(role, "")
 	if err != nil {
 		return nil, err
 	}
@@ -227,26 +431,142 @@ func (r *rbacImpl) GetUsersForRole(role string) ([]string, error) {
 	return users, nil
 }
 
+// GetImplicitUsersForRole 获取直接或通过角色继承拥有指定角色的所有主体（无域）
+func (r *rbacImpl) GetImplicitUsersForRole(role string) ([]string, error) {
+	return r.GetImplicitUsersForRoleInDomain(role, "")
+}
+
+// GetImplicitUsersForRoleInDomain 获取在指定域中直接或通过角色继承拥有指定角色的所有主体
+func (r *rbacImpl) GetImplicitUsersForRoleInDomain(role, domain string) ([]string, error) {
+	s := r.state()
+	if s.enforcer == nil {
+		return nil, ErrEnforcerNotInitialized
+	}
+
+	key := "users:" + domain + ":" + role
+	if cached, ok := r.getRoleCached(key); ok {
+		return append([]string(nil), cached.([]string)...), nil
+	}
+
+	users, err := s.enforcer.GetImplicitUsersForRole(role, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	r.setRoleCache(s.enforcer, key, users)
+	return append([]string(nil), users...), nil
+}
+
+// GetRoleTree 获取角色继承图（无域）
+func (r *rbacImpl) GetRoleTree() (map[string][]string, error) {
+	return r.GetRoleTreeInDomain("")
+}
+
+// GetRoleTreeInDomain 获取指定域中的角色继承图
+// 由分组策略 g = child, parent, domain 构建,key 为子角色（或用户），value 为直接父角色
+func (r *rbacImpl) GetRoleTreeInDomain(domain string) (map[string][]string, error) {
+	s := r.state()
+	if s.enforcer == nil {
+		return nil, ErrEnforcerNotInitialized
+	}
+
+	key := "tree:" + domain
+	if cached, ok := r.getRoleCached(key); ok {
+		return copyRoleTree(cached.(map[string][]string)), nil
+	}
+
+	// 不使用 GetFilteredGroupingPolicy: 过滤值为空字符串时 Casbin 视为匹配任意域
+	rules, err := s.enforcer.GetGroupingPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	tree := make(map[string][]string)
+	for _, rule := range rules {
+		if len(rule) < 2 {
+			continue
+		}
+		ruleDomain := ""
+		if len(rule) > 2 {
+			ruleDomain = rule[2]
+		}
+		if ruleDomain != domain {
+			continue
+		}
+		tree[rule[0]] = append(tree[rule[0]], rule[1])
+	}
+
+	r.setRoleCache(s.enforcer, key, tree)
+	return copyRoleTree(tree), nil
+}
+
 // ========== 策略管理 ==========
 
 // AddPolicy 添加策略（无域）
 func (r *rbacImpl) AddPolicy(sub, obj, act string) error {
-	return r.AddPolicyWithDomain(sub, "", obj, act)
+	return r.AddPolicyWithDomainCtx(context.Background(), sub, "", obj, act)
+}
+
+// AddPolicyCtx 添加策略（无域），操作者取自上下文
+func (r *rbacImpl) AddPolicyCtx(ctx context.Context, sub, obj, act string) error {
+	return r.AddPolicyWithDomainCtx(ctx, sub, "", obj, act)
 }
 
 // AddPolicyWithDomain 添加带域的策略
 func (r *rbacImpl) AddPolicyWithDomain(sub, domain, obj, act string) error {
-	if r.enforcer == nil {
+	return r.AddPolicyWithDomainCtx(context.Background(), sub, domain, obj, act)
+}
+
+// AddPolicyWithDomainCtx 添加带域的策略，操作者取自上下文
+func (r *rbacImpl) AddPolicyWithDomainCtx(ctx context.Context, sub, domain, obj, act string) error {
+	s := r.state()
+	if s.enforcer == nil {
+		return ErrEnforcerNotInitialized
+	}
+
+	added, err := s.enforcer.AddPolicy(s.policyRule([]string{sub, domain, obj, act}))
+	if err != nil {
+		return fmt.Errorf(ErrMsgAddPolicyFailed, err)
+	}
+	if added {
+		r.audit(ctx, AuditEvent{Action: AuditActionAddPolicy, Subject: sub, Domain: domain, Object: obj, Act: act})
+	}
+
+	// 清除缓存
+	if s.config.EnableCache {
+		if err := r.ClearCache(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddConditionalPolicy 添加带条件的策略（ABAC）
+func (r *rbacImpl) AddConditionalPolicy(sub, domain, obj, act, cond string) error {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
+	if !s.abac {
+		return ErrABACNotEnabled
+	}
+	if cond == "" {
+		cond = DefaultCondition
+	}
 
-	_, err := r.enforcer.AddPolicy(sub, domain, obj, act)
+	added, err := s.enforcer.AddPolicy(sub, domain, obj, act, cond)
 	if err != nil {
 		return fmt.Errorf(ErrMsgAddPolicyFailed, err)
 	}
+	if added {
+		r.audit(context.Background(), AuditEvent{
+			Action: AuditActionAddPolicy, Subject: sub, Domain: domain, Object: obj, Act: act, Condition: cond,
+		})
+	}
 
 	// 清除缓存
-	if r.config.EnableCache {
+	if s.config.EnableCache {
 		if err := r.ClearCache(); err != nil {
 			return err
 		}
@@ -257,22 +577,45 @@ func (r *rbacImpl) AddPolicyWithDomain(sub, domain, obj, act string) error {
 
 // RemovePolicy 删除策略（无域）
 func (r *rbacImpl) RemovePolicy(sub, obj, act string) error {
-	return r.RemovePolicyWithDomain(sub, "", obj, act)
+	return r.RemovePolicyWithDomainCtx(context.Background(), sub, "", obj, act)
+}
+
+// RemovePolicyCtx 删除策略（无域），操作者取自上下文
+func (r *rbacImpl) RemovePolicyCtx(ctx context.Context, sub, obj, act string) error {
+	return r.RemovePolicyWithDomainCtx(ctx, sub, "", obj, act)
 }
 
 // RemovePolicyWithDomain 删除带域的策略
 func (r *rbacImpl) RemovePolicyWithDomain(sub, domain, obj, act string) error {
-	if r.enforcer == nil {
+	return r.RemovePolicyWithDomainCtx(context.Background(), sub, domain, obj, act)
+}
+
+// RemovePolicyWithDomainCtx 删除带域的策略，操作者取自上下文
+func (r *rbacImpl) RemovePolicyWithDomainCtx(ctx context.Context, sub, domain, obj, act string) error {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
 
-	_, err := r.enforcer.RemovePolicy(sub, domain, obj, act)
+	// ABAC模型下删除该 sub/domain/obj/act 的所有策略，不区分条件
+	var (
+		removed bool
+		err     error
+	)
+	if s.abac {
+		removed, err = s.enforcer.RemoveFilteredPolicy(0, sub, domain, obj, act)
+	} else {
+		removed, err = s.enforcer.RemovePolicy(sub, domain, obj, act)
+	}
 	if err != nil {
 		return fmt.Errorf(ErrMsgRemovePolicyFailed, err)
 	}
+	if removed {
+		r.audit(ctx, AuditEvent{Action: AuditActionRemovePolicy, Subject: sub, Domain: domain, Object: obj, Act: act})
+	}
 
 	// 清除缓存
-	if r.config.EnableCache {
+	if s.config.EnableCache {
 		if err := r.ClearCache(); err != nil {
 			return err
 		}
@@ -283,19 +626,21 @@ func (r *rbacImpl) RemovePolicyWithDomain(sub, domain, obj, act string) error {
 
 // GetPolicy 获取所有策略
 func (r *rbacImpl) GetPolicy() [][]string {
-	if r.enforcer == nil {
+	s := r.state()
+	if s.enforcer == nil {
 		return nil
 	}
-	policies, _ := r.enforcer.GetPolicy()
+	policies, _ := s.enforcer.GetPolicy()
 	return policies
 }
 
 // GetFilteredPolicy 获取过滤后的策略
 func (r *rbacImpl) GetFilteredPolicy(fieldIndex int, fieldValues ...string) [][]string {
-	if r.enforcer == nil {
+	s := r.state()
+	if s.enforcer == nil {
 		return nil
 	}
-	policies, _ := r.enforcer.GetFilteredPolicy(fieldIndex, fieldValues...)
+	policies, _ := s.enforcer.GetFilteredPolicy(fieldIndex, fieldValues...)
 	return policies
 }
 
@@ -303,17 +648,28 @@ func (r *rbacImpl) GetFilteredPolicy(fieldIndex int, fieldValues ...string) [][]
 
 // AddPolicies 批量添加策略
 func (r *rbacImpl) AddPolicies(rules [][]string) error {
-	if r.enforcer == nil {
+	return r.AddPoliciesCtx(context.Background(), rules)
+}
+
+// AddPoliciesCtx 批量添加策略，操作者取自上下文
+func (r *rbacImpl) AddPoliciesCtx(ctx context.Context, rules [][]string) error {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
 
-	_, err := r.enforcer.AddPolicies(rules)
+	// Casbin 批量操作是全有或全无的：任意一条已存在时整体不生效
+	rules = s.policyRules(rules)
+	added, err := s.enforcer.AddPolicies(rules)
 	if err != nil {
 		return fmt.Errorf(ErrMsgAddPolicyFailed, err)
 	}
+	if added {
+		r.auditRules(ctx, AuditActionAddPolicy, rules)
+	}
 
 	// 清除缓存
-	if r.config.EnableCache {
+	if s.config.EnableCache {
 		if err := r.ClearCache(); err != nil {
 			return err
 		}
@@ -324,17 +680,27 @@ func (r *rbacImpl) AddPolicies(rules [][]string) error {
 
 // RemovePolicies 批量删除策略
 func (r *rbacImpl) RemovePolicies(rules [][]string) error {
-	if r.enforcer == nil {
+	return r.RemovePoliciesCtx(context.Background(), rules)
+}
+
+// RemovePoliciesCtx 批量删除策略，操作者取自上下文
+func (r *rbacImpl) RemovePoliciesCtx(ctx context.Context, rules [][]string) error {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
 
-	_, err := r.enforcer.RemovePolicies(rules)
+	rules = s.policyRules(rules)
+	removed, err := s.enforcer.RemovePolicies(rules)
 	if err != nil {
 		return fmt.Errorf(ErrMsgRemovePolicyFailed, err)
 	}
+	if removed {
+		r.auditRules(ctx, AuditActionRemovePolicy, rules)
+	}
 
 	// 清除缓存
-	if r.config.EnableCache {
+	if s.config.EnableCache {
 		if err := r.ClearCache(); err != nil {
 			return err
 		}
@@ -346,32 +712,28 @@ func (r *rbacImpl) RemovePolicies(rules [][]string) error {
 // ========== 工具方法 ==========
 
 // LoadPolicy 从存储加载策略
+// 配置了 PolicyFilters 时按过滤条件重新加载
 func (r *rbacImpl) LoadPolicy() error {
-	if r.enforcer == nil {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
 
-	if err := r.enforcer.LoadPolicy(); err != nil {
+	if err := s.loadPolicy(); err != nil {
 		return fmt.Errorf("%w: %v", ErrLoadPolicy, err)
 	}
 
-	// 清除缓存
-	if r.config.EnableCache {
-		if err := r.ClearCache(); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return r.clearCacheIfEnabled(s)
 }
 
 // SavePolicy 保存策略到存储
 func (r *rbacImpl) SavePolicy() error {
-	if r.enforcer == nil {
+	s := r.state()
+	if s.enforcer == nil {
 		return ErrEnforcerNotInitialized
 	}
 
-	if err := r.enforcer.SavePolicy(); err != nil {
+	if err := s.enforcer.SavePolicy(); err != nil {
 		return fmt.Errorf("%w: %v", ErrSavePolicy, err)
 	}
 
@@ -379,22 +741,57 @@ func (r *rbacImpl) SavePolicy() error {
 }
 
 // ClearCache 清除所有缓存
+// 包括权限检查结果和角色继承查询结果
 func (r *rbacImpl) ClearCache() error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	r.cache = sync.Map{}
+	r.roles = sync.Map{}
 	return nil
 }
 
 // Close 关闭RBAC实例
 func (r *rbacImpl) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	// Casbin enforcer 没有Close方法，只需清理资源
 	r.cache = sync.Map{}
+	r.roles = sync.Map{}
 	r.enforcer = nil
 	return nil
 }
 
+// Reload 使用新配置重建 Enforcer 并原子替换
+// 新的 Adapter、模型和策略全部加载成功后才在写锁下替换，失败时保持原状态
+// 替换时清除权限检查和角色继承缓存，旧缓存基于旧模型计算
+func (r *rbacImpl) Reload(cfg *Config) error {
+	if cfg == nil {
+		return fmt.Errorf(ErrMsgReloadFailed, ErrInvalidPolicy)
+	}
+
+	state, err := newState(cfg)
+	if err != nil {
+		return fmt.Errorf(ErrMsgReloadFailed, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rbacState = state
+	r.cache = sync.Map{}
+	r.roles = sync.Map{}
+	return nil
+}
+
+// state 返回当前状态的快照
+func (r *rbacImpl) state() rbacState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.rbacState
+}
+
 // ========== 内部辅助方法 ==========
 
 // getCached 从缓存获取权限检查结果
@@ -415,10 +812,15 @@ func (r *rbacImpl) getCached(sub, dom, obj, act string) (bool, bool) {
 }
 
 // setCache 设置缓存
-func (r *rbacImpl) setCache(sub, dom, obj, act string, result bool) {
+// enforcer 为计算结果时使用的 enforcer,期间发生 Reload 时丢弃结果
+func (r *rbacImpl) setCache(enforcer *casbin.Enforcer, sub, dom, obj, act string, result bool) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.enforcer != enforcer {
+		return
+	}
+
 	key := r.cacheKey(sub, dom, obj, act)
 	r.cache.Store(key, cacheEntry{
 		result:    result,
@@ -426,21 +828,126 @@ func (r *rbacImpl) setCache(sub, dom, obj, act string, result bool) {
 	})
 }
 
+// clearRoleChangeCache 角色分配变更后清除缓存
+// 变更只影响 user 自身时只清除 user 的权限检查缓存;
+// user 本身是被其他主体继承的角色(角色之间的继承),或变更涉及 SuperAdminRole 时,
+// 继承 user 的所有主体的结果和超级管理员判断都可能改变,清除全部权限检查缓存
+// 角色继承查询缓存总是整体清除
+func (r *rbacImpl) clearRoleChangeCache(s rbacState, user, role, domain string) {
+	if s.affectsOtherSubjects(user, role, domain) {
+		_ = r.ClearCache()
+		return
+	}
+	r.clearUserCache(user)
+	r.clearRoleCache()
+}
+
+// affectsOtherSubjects 判断 user 与 role 之间的分配变更是否影响 user 以外的主体
+// 查询失败时按影响处理,宁可多清缓存
+func (s rbacState) affectsOtherSubjects(user, role, domain string) bool {
+	if s.config.SuperAdminRole != "" {
+		if role == s.config.SuperAdminRole {
+			return true
+		}
+		roles, err := s.enforcer.GetImplicitRolesForUser(role, domain)
+		if err != nil || slices.Contains(roles, s.config.SuperAdminRole) {
+			return true
+		}
+	}
+
+	inheritors, err := s.enforcer.GetUsersForRole(user, domain)
+	return err != nil || len(inheritors) > 0
+}
+
 // clearUserCache 清除用户相关的缓存
 func (r *rbacImpl) clearUserCache(user string) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	// 遍历删除所有包含该用户的缓存
+	// 缓存键以 "主体:" 开头,带上分隔符避免误删名称前缀相同的其他主体
+	prefix := user + ":"
 	r.cache.Range(func(key, value interface{}) bool {
-		keyStr := key.(string)
-		if len(keyStr) > len(user) && keyStr[:len(user)] == user {
+		if strings.HasPrefix(key.(string), prefix) {
 			r.cache.Delete(key)
 		}
 		return true
 	})
 }
 
+// getRoleCached 从缓存获取角色继承查询结果
+func (r *rbacImpl) getRoleCached(key string) (interface{}, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.config.EnableCache {
+		return nil, false
+	}
+
+	if val, ok := r.roles.Load(key); ok {
+		entry := val.(roleCacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			return entry.value, true
+		}
+		r.roles.Delete(key)
+	}
+	return nil, false
+}
+
+// setRoleCache 缓存角色继承查询结果
+// enforcer 为查询时使用的 enforcer,期间发生 Reload 时丢弃结果
+func (r *rbacImpl) setRoleCache(enforcer *casbin.Enforcer, key string, value interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.config.EnableCache || r.enforcer != enforcer {
+		return
+	}
+
+	r.roles.Store(key, roleCacheEntry{
+		value:     value,
+		expiresAt: time.Now().Add(r.config.CacheTTL),
+	})
+}
+
+// clearRoleCache 清除角色继承查询缓存
+// 任何角色分配变更都可能改变继承关系,因此整体清除
+func (r *rbacImpl) clearRoleCache() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.roles = sync.Map{}
+}
+
+// copyRoleTree 深拷贝角色继承图,避免调用方修改缓存内容
+func copyRoleTree(tree map[string][]string) map[string][]string {
+	out := make(map[string][]string, len(tree))
+	for child, parents := range tree {
+		out[child] = append([]string(nil), parents...)
+	}
+	return out
+}
+
+// policyRule 将 [sub, dom, obj, act] 规则转换为当前模型的策略
+// ABAC模型下补充 DefaultCondition 作为条件字段，其他情况原样返回
+func (s rbacState) policyRule(rule []string) []string {
+	if s.abac && len(rule) == abacRequestSize-1 {
+		return append(rule, DefaultCondition)
+	}
+	return rule
+}
+
+// policyRules 批量转换规则，见 policyRule
+func (s rbacState) policyRules(rules [][]string) [][]string {
+	if !s.abac {
+		return rules
+	}
+	out := make([][]string, len(rules))
+	for i, rule := range rules {
+		out[i] = s.policyRule(append([]string(nil), rule...))
+	}
+	return out
+}
+
 // cacheKey 生成缓存键
 func (r *rbacImpl) cacheKey(sub, dom, obj, act string) string {
 	if dom == "" {
@@ -0,0 +1,395 @@
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupTestDB 创建内存数据库
+// 内存数据库按连接隔离,限制为单连接保证同一个 *gorm.DB 上的所有操作看到同一份数据
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql.DB: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	return db
+}
+
+// newTestRBAC 在指定数据库上创建 RBAC 实例
+// configure 可修改默认配置,如启用 ABAC、设置超级管理员
+func newTestRBAC(t *testing.T, db *gorm.DB, configure func(cfg *Config)) *rbacImpl {
+	t.Helper()
+
+	cfg := DefaultConfig(db)
+	if configure != nil {
+		configure(cfg)
+	}
+	r, err := New(cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return r.(*rbacImpl)
+}
+
+// setupTestRBAC 创建使用独立内存数据库的 RBAC 实例
+func setupTestRBAC(t *testing.T, configure func(cfg *Config)) *rbacImpl {
+	t.Helper()
+	return newTestRBAC(t, setupTestDB(t), configure)
+}
+
+// cacheLen 返回权限检查缓存的条目数
+func cacheLen(r *rbacImpl) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n := 0
+	r.cache.Range(func(key, value interface{}) bool {
+		n++
+		return true
+	})
+	return n
+}
+
+// mustEnforce 检查权限并断言结果
+func mustEnforce(t *testing.T, r RBAC, sub, obj, act string, want bool) {
+	t.Helper()
+	got, err := r.Enforce(sub, obj, act)
+	if err != nil || got != want {
+		t.Errorf("Enforce(%s, %s, %s) = %v, %v, want %v", sub, obj, act, got, err, want)
+	}
+}
+
+// mustEnforceInDomain 在指定域中检查权限并断言结果
+func mustEnforceInDomain(t *testing.T, r RBAC, sub, dom, obj, act string, want bool) {
+	t.Helper()
+	got, err := r.EnforceWithDomain(sub, dom, obj, act)
+	if err != nil || got != want {
+		t.Errorf("EnforceWithDomain(%s, %s, %s, %s) = %v, %v, want %v", sub, dom, obj, act, got, err, want)
+	}
+}
+
+// TestNew 测试配置校验
+func TestNew(t *testing.T) {
+	if _, err := New(nil); !errors.Is(err, ErrInvalidPolicy) {
+		t.Errorf("New(nil) error = %v, want ErrInvalidPolicy", err)
+	}
+	if _, err := New(&Config{}); !errors.Is(err, ErrInvalidPolicy) {
+		t.Errorf("New() without DB error = %v, want ErrInvalidPolicy", err)
+	}
+
+	r := setupTestRBAC(t, nil)
+	if r.abac {
+		t.Error("default model should not be ABAC")
+	}
+}
+
+// TestPolicyManagement 测试策略的增删查和缓存失效
+func TestPolicyManagement(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	if err := r.AddPolicy("alice", "posts", "read"); err != nil {
+		t.Fatalf("AddPolicy() error = %v", err)
+	}
+	mustEnforce(t, r, "alice", "posts", "read", true)
+	mustEnforce(t, r, "alice", "posts", "write", false)
+	if n := cacheLen(r); n != 2 {
+		t.Errorf("cache entries = %d, want 2", n)
+	}
+
+	// 策略变更清除缓存
+	if err := r.AddPolicy("alice", "posts", "write"); err != nil {
+		t.Fatalf("AddPolicy() error = %v", err)
+	}
+	if n := cacheLen(r); n != 0 {
+		t.Errorf("cache entries after AddPolicy = %d, want 0", n)
+	}
+	mustEnforce(t, r, "alice", "posts", "write", true)
+
+	if err := r.RemovePolicy("alice", "posts", "write"); err != nil {
+		t.Fatalf("RemovePolicy() error = %v", err)
+	}
+	mustEnforce(t, r, "alice", "posts", "write", false)
+
+	// 批量操作
+	rules := [][]string{{"bob", "", "posts", "read"}, {"bob", "", "users", "read"}}
+	if err := r.AddPolicies(rules); err != nil {
+		t.Fatalf("AddPolicies() error = %v", err)
+	}
+	if got := r.GetFilteredPolicy(0, "bob"); len(got) != 2 {
+		t.Errorf("GetFilteredPolicy(bob) = %v, want 2 rules", got)
+	}
+	if got := r.GetPolicy(); len(got) != 3 {
+		t.Errorf("GetPolicy() = %v, want 3 rules", got)
+	}
+	if err := r.RemovePolicies(rules); err != nil {
+		t.Fatalf("RemovePolicies() error = %v", err)
+	}
+	mustEnforce(t, r, "bob", "posts", "read", false)
+
+	// 关闭后所有方法返回 ErrEnforcerNotInitialized
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := r.Enforce("alice", "posts", "read"); !errors.Is(err, ErrEnforcerNotInitialized) {
+		t.Errorf("Enforce() after Close error = %v, want ErrEnforcerNotInitialized", err)
+	}
+	if err := r.AddPolicy("alice", "posts", "read"); !errors.Is(err, ErrEnforcerNotInitialized) {
+		t.Errorf("AddPolicy() after Close error = %v, want ErrEnforcerNotInitialized", err)
+	}
+}
+
+// TestRoleHierarchy 测试角色继承、隐式用户和角色继承图
+func TestRoleHierarchy(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	// alice -> editor -> viewer
+	_ = r.AddRoleForUser("alice", "editor")
+	_ = r.AddRoleForUser("editor", "viewer")
+	_ = r.AddPolicy("viewer", "posts", "read")
+	_ = r.AddPolicy("editor", "posts", "write")
+
+	mustEnforce(t, r, "alice", "posts", "read", true)
+	mustEnforce(t, r, "alice", "posts", "write", true)
+	mustEnforce(t, r, "editor", "posts", "read", true)
+	mustEnforce(t, r, "viewer", "posts", "write", false)
+
+	roles, err := r.GetRolesForUser("alice")
+	if err != nil || !slices.Equal(roles, []string{"editor"}) {
+		t.Errorf("GetRolesForUser(alice) = %v, %v, want [editor]", roles, err)
+	}
+	users, err := r.GetUsersForRole("viewer")
+	if err != nil || !slices.Equal(users, []string{"editor"}) {
+		t.Errorf("GetUsersForRole(viewer) = %v, %v, want [editor]", users, err)
+	}
+
+	implicit, err := r.GetImplicitUsersForRole("viewer")
+	slices.Sort(implicit)
+	if err != nil || !slices.Equal(implicit, []string{"alice", "editor"}) {
+		t.Errorf("GetImplicitUsersForRole(viewer) = %v, %v, want [alice editor]", implicit, err)
+	}
+
+	tree, err := r.GetRoleTree()
+	want := map[string][]string{"alice": {"editor"}, "editor": {"viewer"}}
+	if err != nil || fmt.Sprint(tree) != fmt.Sprint(want) {
+		t.Errorf("GetRoleTree() = %v, %v, want %v", tree, err, want)
+	}
+
+	// 修改返回值不影响缓存
+	tree["alice"][0] = "mallory"
+	implicit[0] = "mallory"
+	if again, _ := r.GetRoleTree(); again["alice"][0] != "editor" {
+		t.Errorf("cached role tree was mutated: %v", again)
+	}
+	if again, _ := r.GetImplicitUsersForRole("viewer"); slices.Contains(again, "mallory") {
+		t.Errorf("cached implicit users were mutated: %v", again)
+	}
+
+	// 撤销角色后继承关系和查询缓存同步更新
+	if err := r.DeleteRoleForUser("alice", "editor"); err != nil {
+		t.Fatalf("DeleteRoleForUser() error = %v", err)
+	}
+	mustEnforce(t, r, "alice", "posts", "read", false)
+	if again, _ := r.GetImplicitUsersForRole("viewer"); slices.Contains(again, "alice") {
+		t.Errorf("GetImplicitUsersForRole(viewer) after delete = %v", again)
+	}
+}
+
+// TestDomains 测试域之间的隔离
+func TestDomains(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+
+	_ = r.AddRoleForUserInDomain("alice", "admin", "tenant1")
+	_ = r.AddPolicyWithDomain("admin", "tenant1", "users", "delete")
+	_ = r.AddPolicyWithDomain("admin", "tenant2", "users", "delete")
+
+	mustEnforceInDomain(t, r, "alice", "tenant1", "users", "delete", true)
+	mustEnforceInDomain(t, r, "alice", "tenant2", "users", "delete", false)
+
+	roles, _ := r.GetRolesForUserInDomain("alice", "tenant2")
+	if len(roles) != 0 {
+		t.Errorf("GetRolesForUserInDomain(tenant2) = %v, want none", roles)
+	}
+	if tree, _ := r.GetRoleTreeInDomain("tenant1"); !slices.Equal(tree["alice"], []string{"admin"}) {
+		t.Errorf("GetRoleTreeInDomain(tenant1) = %v", tree)
+	}
+	if tree, _ := r.GetRoleTree(); len(tree) != 0 {
+		t.Errorf("GetRoleTree() without domain = %v, want empty", tree)
+	}
+}
+
+// TestSuperAdmin 测试超级管理员主体和超级管理员角色
+func TestSuperAdmin(t *testing.T) {
+	r := setupTestRBAC(t, func(cfg *Config) {
+		cfg.SuperAdmins = []string{"root"}
+		cfg.SuperAdminRole = "owner"
+	})
+	_ = r.AddRoleForUserInDomain("alice", "owner", "tenant1")
+
+	// SuperAdmins 在所有域中生效
+	mustEnforce(t, r, "root", "anything", "delete", true)
+	mustEnforceInDomain(t, r, "root", "tenant2", "anything", "delete", true)
+
+	// SuperAdminRole 只在拥有该角色的域中生效
+	mustEnforceInDomain(t, r, "alice", "tenant1", "anything", "delete", true)
+	mustEnforceInDomain(t, r, "alice", "tenant2", "anything", "delete", false)
+
+	tests := []struct {
+		sub, dom string
+		want     bool
+	}{
+		{"root", "", true},
+		{"alice", "tenant1", true},
+		{"alice", "tenant2", false},
+		{"bob", "tenant1", false},
+	}
+	for _, tt := range tests {
+		if got, err := r.IsSuperAdmin(tt.sub, tt.dom); err != nil || got != tt.want {
+			t.Errorf("IsSuperAdmin(%s, %s) = %v, %v, want %v", tt.sub, tt.dom, got, err, tt.want)
+		}
+	}
+
+	allowed, matched, reason, err := r.Explain("root", "", "anything", "delete")
+	if err != nil || !allowed || matched != nil || reason != ExplainSuperAdmin {
+		t.Errorf("Explain(root) = %v, %v, %q, %v", allowed, matched, reason, err)
+	}
+}
+
+// TestExplain 测试权限检查的原因说明
+func TestExplain(t *testing.T) {
+	r := setupTestRBAC(t, nil)
+	_ = r.AddRoleForUser("alice", "editor")
+	_ = r.AddPolicy("alice", "posts", "read")
+	_ = r.AddPolicy("editor", "posts", "write")
+
+	tests := []struct {
+		name        string
+		act         string
+		wantAllowed bool
+		wantMatched []string
+		wantReason  string
+	}{
+		{
+			"direct", "read", true, []string{"alice", "", "posts", "read"},
+			fmt.Sprintf(ExplainMatchedDirectly, []string{"alice", "", "posts", "read"}),
+		},
+		{
+			"by role", "write", true, []string{"editor", "", "posts", "write"},
+			fmt.Sprintf(ExplainMatchedByRole, "editor", []string{"editor", "", "posts", "write"}),
+		},
+		{"no match", "delete", false, nil, ExplainNoMatchingPolicy},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, matched, reason, err := r.Explain("alice", "", "posts", tt.act)
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+			if allowed != tt.wantAllowed || !slices.Equal(matched, tt.wantMatched) || reason != tt.wantReason {
+				t.Errorf("Explain() = %v, %v, %q, want %v, %v, %q",
+					allowed, matched, reason, tt.wantAllowed, tt.wantMatched, tt.wantReason)
+			}
+		})
+	}
+
+	// Explain 不读写缓存
+	if n := cacheLen(r); n != 0 {
+		t.Errorf("cache entries after Explain = %d, want 0", n)
+	}
+}
+
+// TestReload 测试运行时替换配置
+func TestReload(t *testing.T) {
+	db := setupTestDB(t)
+	r := newTestRBAC(t, db, nil)
+	_ = r.AddPolicy("alice", "posts", "write")
+	mustEnforce(t, r, "alice", "posts", "read", false)
+
+	// 新配置加载同一张表中的策略,缓存被清除
+	cfg := DefaultConfig(db)
+	cfg.ActionHierarchy = map[string][]string{"write": {"read"}}
+	if err := r.Reload(cfg); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if n := cacheLen(r); n != 0 {
+		t.Errorf("cache entries after Reload = %d, want 0", n)
+	}
+	mustEnforce(t, r, "alice", "posts", "read", true)
+
+	// 失败时保持原状态
+	if err := r.Reload(nil); !errors.Is(err, ErrInvalidPolicy) {
+		t.Errorf("Reload(nil) error = %v, want ErrInvalidPolicy", err)
+	}
+	if err := r.Reload(&Config{ModelPath: "does-not-exist.conf", DB: db}); err == nil {
+		t.Error("Reload() with missing model file should fail")
+	}
+	if r.state().config != cfg {
+		t.Error("failed Reload replaced the config")
+	}
+	mustEnforce(t, r, "alice", "posts", "read", true)
+}
+
+// TestRoleChangeClearsCache 测试角色之间的继承变更使继承者的缓存失效
+func TestRoleChangeClearsCache(t *testing.T) {
+	t.Run("role to role", func(t *testing.T) {
+		r := setupTestRBAC(t, nil)
+		_ = r.AddRoleForUser("alice", "editor")
+		_ = r.AddPolicy("admin", "posts", "publish")
+
+		// 结果写入缓存后再让 editor 继承 admin
+		mustEnforce(t, r, "alice", "posts", "publish", false)
+		if err := r.AddRoleForUser("editor", "admin"); err != nil {
+			t.Fatalf("AddRoleForUser() error = %v", err)
+		}
+		mustEnforce(t, r, "alice", "posts", "publish", true)
+
+		if err := r.DeleteRoleForUser("editor", "admin"); err != nil {
+			t.Fatalf("DeleteRoleForUser() error = %v", err)
+		}
+		mustEnforce(t, r, "alice", "posts", "publish", false)
+	})
+
+	t.Run("super admin role", func(t *testing.T) {
+		r := setupTestRBAC(t, func(cfg *Config) { cfg.SuperAdminRole = "root" })
+		_ = r.AddRoleForUser("alice", "ops")
+		_ = r.AddRoleForUser("admins", "root")
+
+		mustEnforce(t, r, "alice", "servers", "reboot", false)
+		if err := r.AddRoleForUser("ops", "admins"); err != nil {
+			t.Fatalf("AddRoleForUser() error = %v", err)
+		}
+		mustEnforce(t, r, "alice", "servers", "reboot", true)
+
+		if err := r.DeleteRoleForUser("ops", "admins"); err != nil {
+			t.Fatalf("DeleteRoleForUser() error = %v", err)
+		}
+		mustEnforce(t, r, "alice", "servers", "reboot", false)
+	})
+
+	t.Run("user only", func(t *testing.T) {
+		r := setupTestRBAC(t, nil)
+		_ = r.AddPolicy("editor", "posts", "edit")
+		mustEnforce(t, r, "al", "posts", "edit", false)
+		mustEnforce(t, r, "alice", "posts", "edit", false)
+
+		// 只清除 al 自身的缓存,名称前缀相同的 alice 不受影响
+		_ = r.AddRoleForUser("al", "editor")
+		if n := cacheLen(r); n != 1 {
+			t.Errorf("cache entries = %d, want 1", n)
+		}
+		mustEnforce(t, r, "al", "posts", "edit", true)
+	})
+}
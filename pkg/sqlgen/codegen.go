@@ -2,6 +2,7 @@ package sqlgen
 
 import (
 	"fmt"
+	"go/format"
 	"strings"
 )
 
@@ -46,7 +47,12 @@ func (c *CodeGenerator) Generate(schema *Schema) string {
 	sb.WriteString(fmt.Sprintf("type %s struct {\n", schema.Name))
 
 	// 字段
+	var enums []Field
 	for _, field := range schema.Fields {
+		if typeName := c.enumTypeName(schema, field); typeName != "" {
+			enums = append(enums, field)
+			field.Type = strings.Replace(field.Type, "string", typeName, 1)
+		}
 		c.writeField(&sb, field)
 	}
 
@@ -56,14 +62,39 @@ func (c *CodeGenerator) Generate(schema *Schema) string {
 	if c.options.WithTableName {
 		sb.WriteString("\n")
 		sb.WriteString("// TableName overrides the table name\n")
-		sb.WriteString(fmt.Sprintf("func (%s) TableName() string {\n", schema.Name))
+		sb.WriteString(fmt.Sprintf("func (%s) TableName() string {\n", c.receiver(schema.Name)))
 		sb.WriteString(fmt.Sprintf("\treturn \"%s\"\n", schema.TableName))
 		sb.WriteString("}\n")
 	}
 
+	// 枚举类型和常量
+	for _, field := range enums {
+		c.writeEnum(&sb, c.enumTypeName(schema, field), field)
+	}
+
 	return sb.String()
 }
 
+// receiver 返回模型方法的接收者声明
+// 配置了 ReceiverName 时为 "u User",否则只有类型名
+func (c *CodeGenerator) receiver(typeName string) string {
+	if c.options.ReceiverName == "" {
+		return typeName
+	}
+	return c.options.ReceiverName + " " + typeName
+}
+
+// formatCode 使用 gofmt 规则格式化生成的代码
+// 包括缩进、导入排序以及结构体字段/tag 列对齐
+// 生成的代码无法解析时返回 ErrCodeGenerateFailed,便于尽早发现生成器的缺陷
+func formatCode(code string) (string, error) {
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		return "", WrapError(ErrCodeGenerateFailed, "generated code is not valid Go", err)
+	}
+	return string(formatted), nil
+}
+
 // writeField 写入字段定义
 func (c *CodeGenerator) writeField(sb *strings.Builder, field Field) {
 	// 字段注释
@@ -85,13 +116,18 @@ func (c *CodeGenerator) writeField(sb *strings.Builder, field Field) {
 
 // buildTags 构建 struct tags
 func (c *CodeGenerator) buildTags(field Field) string {
+	if field.Association != nil {
+		return c.buildAssociationTags(field)
+	}
+
 	var tags []string
 
 	// GORM Tag
+	// 值中的双引号 (如 PostgreSQL 带引号的标识符) 需要转义,保证 tag 可以被 reflect 解析
 	if c.options.Tags&TagGorm != 0 {
 		gormTag := c.buildGormTag(field)
 		if gormTag != "" {
-			tags = append(tags, fmt.Sprintf("gorm:\"%s\"", gormTag))
+			tags = append(tags, fmt.Sprintf("gorm:\"%s\"", strings.ReplaceAll(gormTag, `"`, `\"`)))
 		}
 	}
 
@@ -123,6 +159,29 @@ func (c *CodeGenerator) buildTags(field Field) string {
 	return strings.Join(tags, " ")
 }
 
+// buildAssociationTags 构建关联字段的 struct tags
+// gorm tag 只包含 foreignKey/references,序列化 tag 带 omitempty,未 Preload 时不输出
+func (c *CodeGenerator) buildAssociationTags(field Field) string {
+	var tags []string
+	name := toSnakeCase(field.Name)
+
+	if c.options.Tags&TagGorm != 0 {
+		tags = append(tags, fmt.Sprintf("gorm:\"foreignKey:%s;references:%s\"",
+			field.Association.ForeignKey, field.Association.References))
+	}
+	if c.options.Tags&TagJson != 0 {
+		tags = append(tags, fmt.Sprintf("json:\"%s,omitempty\"", convertNaming(name, c.options.JSONNaming)))
+	}
+	if c.options.Tags&TagXml != 0 {
+		tags = append(tags, fmt.Sprintf("xml:\"%s,omitempty\"", convertNaming(name, c.options.JSONNaming)))
+	}
+	if c.options.Tags&TagYaml != 0 {
+		tags = append(tags, fmt.Sprintf("yaml:\"%s,omitempty\"", convertNaming(name, c.options.JSONNaming)))
+	}
+
+	return strings.Join(tags, " ")
+}
+
 // buildGormTag 构建 GORM tag
 func (c *CodeGenerator) buildGormTag(field Field) string {
 	var parts []string
@@ -155,6 +214,16 @@ func (c *CodeGenerator) buildGormTag(field Field) string {
 		parts = append(parts, fmt.Sprintf("default:%s", field.Column.Default))
 	}
 
+	// onUpdate
+	if field.Column.OnUpdate != "" {
+		parts = append(parts, fmt.Sprintf("onUpdate:%s", field.Column.OnUpdate))
+	}
+
+	// check
+	if check := checkTag(field.Column.Check); check != "" {
+		parts = append(parts, fmt.Sprintf("check:%s", check))
+	}
+
 	// size
 	if field.Column.Size > 0 && strings.Contains(strings.ToUpper(field.Column.Type), "VARCHAR") {
 		parts = append(parts, fmt.Sprintf("size:%d", field.Column.Size))
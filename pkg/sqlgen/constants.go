@@ -43,6 +43,20 @@ const (
 	TagDefault = TagGorm | TagJson
 )
 
+// ============================================================================
+// 关联类型 (Association Kinds)
+// ============================================================================
+
+// AssociationKind 表示逆向生成的关联字段类型
+type AssociationKind int
+
+const (
+	// AssociationBelongsTo 属于关系 (Post.User *User)
+	AssociationBelongsTo AssociationKind = iota + 1
+	// AssociationHasMany 一对多关系 (User.Posts []Post)
+	AssociationHasMany
+)
+
 // ============================================================================
 // 命名策略 (Naming Strategies)
 // ============================================================================
@@ -61,6 +75,33 @@ const (
 	KebabCase
 )
 
+// ============================================================================
+// 逆向生成选项 (Reverse Options)
+// ============================================================================
+
+// NullableStyle 表示可空列在逆向生成时使用的 Go 类型风格
+type NullableStyle int
+
+const (
+	// NullablePointer 使用指针类型 (*string、*int64、*time.Time)
+	NullablePointer NullableStyle = iota
+	// NullableSQLNull 使用 database/sql 的 Null 类型 (sql.NullString、sql.NullInt64、sql.NullTime),
+	// 没有对应类型的使用泛型 sql.Null[T]
+	NullableSQLNull
+)
+
+// FieldOrder 表示逆向生成的字段排列顺序
+type FieldOrder int
+
+const (
+	// FieldOrderPreserve 保持 DDL 中的列顺序 (默认)
+	FieldOrderPreserve FieldOrder = iota
+	// FieldOrderAlphabetical 按 Go 字段名字母顺序排列
+	FieldOrderAlphabetical
+	// FieldOrderPrimaryKeyFirst 主键字段在前,其余字段保持 DDL 顺序
+	FieldOrderPrimaryKeyFirst
+)
+
 // ============================================================================
 // SQL 操作类型 (SQL Operation Types)
 // ============================================================================
@@ -121,6 +162,8 @@ const (
 	GormTagNotNull = "not null"
 	// GormTagDefault 默认值
 	GormTagDefault = "default"
+	// GormTagOnUpdate 更新时自动赋值 (sqlgen 扩展,GORM 忽略此键)
+	GormTagOnUpdate = "onUpdate"
 	// GormTagSize 大小
 	GormTagSize = "size"
 	// GormTagType 类型
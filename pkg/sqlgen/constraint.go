@@ -0,0 +1,233 @@
+package sqlgen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ============================================================================
+// ENUM / CHECK 约束
+// ============================================================================
+
+var (
+	// 匹配表级 CHECK 约束头: [CONSTRAINT name] CHECK (
+	checkConstraintRegex = regexp.MustCompile(`(?i)^(?:CONSTRAINT\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+)?CHECK\s*\(`)
+
+	// 匹配列定义中的内联 CHECK 约束头
+	inlineCheckRegex = regexp.MustCompile(`(?i)\bCHECK\s*\(`)
+
+	// 匹配 col IN (...) 形式的约束表达式
+	enumCheckRegex = regexp.MustCompile(`(?is)^\s*[` + "`" + `"\[]?(\w+)[` + "`" + `"\]]?\s+IN\s*\((.*)\)\s*$`)
+
+	// 匹配 ENUM(...) 类型
+	enumTypeRegex = regexp.MustCompile(`(?is)^\s*ENUM\s*\((.*)\)\s*$`)
+
+	// 匹配 gorm check tag 中的约束名
+	checkNameRegex = regexp.MustCompile(`^[\w-]+$`)
+)
+
+// parseCheckConstraint 解析表级 CHECK 约束
+// 返回:
+//
+//	CheckConstraint: 解析结果,表达式为 col IN (...) 形式时 Column 为该列
+//	bool: def 不是 CHECK 约束时返回 false
+func parseCheckConstraint(def string) (CheckConstraint, bool) {
+	loc := checkConstraintRegex.FindStringSubmatchIndex(def)
+	if loc == nil {
+		return CheckConstraint{}, false
+	}
+	end := matchParen(def, loc[1]-1)
+	if end < 0 {
+		return CheckConstraint{}, false
+	}
+
+	check := CheckConstraint{Expression: strings.TrimSpace(def[loc[1]:end])}
+	if loc[2] >= 0 {
+		check.Name = def[loc[2]:loc[3]]
+	}
+	if column, _, ok := parseEnumCheck(check.Expression); ok {
+		check.Column = column
+	}
+	return check, true
+}
+
+// parseInlineCheck 解析列定义中的内联 CHECK 约束
+// 返回:
+//
+//	string: 约束表达式
+//	bool: 列定义不含 CHECK 约束时返回 false
+func parseInlineCheck(def string) (string, bool) {
+	loc := inlineCheckRegex.FindStringIndex(def)
+	if loc == nil {
+		return "", false
+	}
+	end := matchParen(def, loc[1]-1)
+	if end < 0 {
+		return "", false
+	}
+	return strings.TrimSpace(def[loc[1]:end]), true
+}
+
+// parseEnumCheck 解析 col IN ('a','b') 形式的约束表达式
+// 只有列表全部是字符串字面量时才视为枚举
+func parseEnumCheck(expr string) (column string, values []string, ok bool) {
+	m := enumCheckRegex.FindStringSubmatch(expr)
+	if m == nil {
+		return "", nil, false
+	}
+	values, ok = parseQuotedList(m[2])
+	if !ok {
+		return "", nil, false
+	}
+	return m[1], values, true
+}
+
+// parseEnumType 解析 ENUM('a','b') 类型的可选值
+// 不是 ENUM 类型时返回 nil
+func parseEnumType(sqlType string) []string {
+	m := enumTypeRegex.FindStringSubmatch(sqlType)
+	if m == nil {
+		return nil
+	}
+	values, ok := parseQuotedList(m[1])
+	if !ok {
+		return nil
+	}
+	return values
+}
+
+// parseQuotedList 解析逗号分隔的单引号字符串列表,如 'a','b'
+// 支持两个连续单引号的转义;出现非字符串字面量时返回 false
+func parseQuotedList(list string) ([]string, bool) {
+	var values []string
+	s := strings.TrimSpace(list)
+	for s != "" {
+		if s[0] != '\'' {
+			return nil, false
+		}
+
+		var sb strings.Builder
+		i := 1
+		for ; i < len(s); i++ {
+			if s[i] != '\'' {
+				sb.WriteByte(s[i])
+				continue
+			}
+			if i+1 < len(s) && s[i+1] == '\'' {
+				sb.WriteByte('\'')
+				i++
+				continue
+			}
+			break
+		}
+		if i >= len(s) {
+			return nil, false
+		}
+		values = append(values, sb.String())
+
+		s = strings.TrimSpace(s[i+1:])
+		if s == "" {
+			break
+		}
+		if s[0] != ',' {
+			return nil, false
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+	return values, len(values) > 0
+}
+
+// quoteList 将可选值格式化为 SQL 字符串列表,如 'a', 'b'
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = "'" + escapeString(v) + "'"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// enumFallbackType 返回不支持 ENUM 的方言中代替 ENUM 的字符串类型
+// 长度取最长的可选值
+func (g *Generator) enumFallbackType(values []string) string {
+	size := 1
+	for _, v := range values {
+		size = max(size, len(v))
+	}
+	return g.dialect.TypeMapping("string", size)
+}
+
+// splitCheckTag 拆分 gorm check tag
+// 与 GORM 一致:逗号前是字母、数字、下划线或短横线组成的名称时视为命名约束
+// 如 "chk_status,age >= 18" 返回 ("chk_status", "age >= 18")
+func splitCheckTag(tag string) (name, expr string) {
+	if idx := strings.Index(tag, ","); idx > 0 && checkNameRegex.MatchString(tag[:idx]) {
+		return tag[:idx], strings.TrimSpace(tag[idx+1:])
+	}
+	return "", strings.TrimSpace(tag)
+}
+
+// checkTag 将 CHECK 约束转换为 gorm check tag 的值
+// 去除 MySQL 反引号,避免破坏生成的 struct tag 字面量
+func checkTag(check *CheckConstraint) string {
+	if check == nil {
+		return ""
+	}
+	expr := strings.ReplaceAll(check.Expression, "`", "")
+	if check.Name != "" {
+		return check.Name + "," + expr
+	}
+	return expr
+}
+
+// ============================================================================
+// 枚举常量生成
+// ============================================================================
+
+// enumTypeName 返回字段生成的枚举类型名
+// 未启用 WithEnumConstants、字段没有可选值或不是字符串类型时返回空
+func (c *CodeGenerator) enumTypeName(schema *Schema, field Field) string {
+	if !c.options.WithEnumConstants || field.Association != nil || len(field.Column.EnumValues) == 0 {
+		return ""
+	}
+	if strings.TrimPrefix(field.Type, "*") != "string" {
+		return ""
+	}
+	return schema.Name + field.Name
+}
+
+// writeEnum 写入枚举类型和可选值常量
+// 常量名为类型名加上值的 PascalCase 形式,如 UserStatusActive
+func (c *CodeGenerator) writeEnum(sb *strings.Builder, typeName string, field Field) {
+	sb.WriteString(fmt.Sprintf("\n// %s %s 列的可选值\n", typeName, field.Column.Name))
+	sb.WriteString(fmt.Sprintf("type %s string\n\n", typeName))
+	sb.WriteString("const (\n")
+
+	used := make(map[string]bool, len(field.Column.EnumValues))
+	for i, value := range field.Column.EnumValues {
+		name := typeName + enumIdent(value)
+		if name == typeName || used[name] {
+			name = fmt.Sprintf("%s%d", name, i)
+		}
+		used[name] = true
+		sb.WriteString(fmt.Sprintf("\t%s %s = %s\n", name, typeName, strconv.Quote(value)))
+	}
+
+	sb.WriteString(")\n")
+}
+
+// enumIdent 将枚举值转换为标识符片段
+// 按非字母数字字符分词后转为 PascalCase,如 "in-progress" -> "InProgress"
+func enumIdent(value string) string {
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		runes := []rune(strings.ToLower(w))
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, "")
+}
@@ -209,6 +209,7 @@ type ConflictBuilder struct {
 	columns       []string
 	updateColumns []string
 	doNothing     bool
+	returning     []string
 }
 
 // DoNothing 忽略冲突
@@ -223,8 +224,31 @@ func (c *ConflictBuilder) DoUpdate(columns ...string) *ConflictBuilder {
 	return c
 }
 
+// Returning 返回插入或更新后的列
+// PostgreSQL/SQLite 生成 RETURNING 子句，SQL Server 生成 OUTPUT INSERTED 子句，
+// MySQL 不支持，Create 返回 ErrReturningNotSupported
+// 参数:
+//
+//	columns: 列名 (字段名自动转换为蛇形)，"*" 表示所有列
+//
+// 使用示例:
+//
+//	sql, err := gen.OnConflict("email").DoUpdate("username").Returning("id").Create(&user)
+//	// INSERT INTO "users" (...) VALUES (...) ON CONFLICT ("email") DO UPDATE SET ... RETURNING "id";
+//
+// 注意: PostgreSQL/SQLite 的 DO NOTHING 在冲突时不返回任何行
+func (c *ConflictBuilder) Returning(columns ...string) *ConflictBuilder {
+	c.returning = columns
+	return c
+}
+
 // Create 生成带冲突处理的 INSERT 语句
 func (c *ConflictBuilder) Create(value interface{}) (string, error) {
+	dialect := c.generator.dialect.Name()
+	if len(c.returning) > 0 && dialect != PostgreSQL && dialect != SQLite && dialect != SQLServer {
+		return "", ErrReturningNotSupported
+	}
+
 	sql, err := c.generator.Create(value)
 	if err != nil {
 		return "", err
@@ -233,7 +257,7 @@ func (c *ConflictBuilder) Create(value interface{}) (string, error) {
 	// 移除末尾的分号
 	sql = strings.TrimSuffix(sql, ";")
 
-	switch c.generator.dialect.Name() {
+	switch dialect {
 	case MySQL:
 		if c.doNothing {
 			sql += " ON DUPLICATE KEY UPDATE " + c.generator.dialect.Quote(c.columns[0]) + " = " + c.generator.dialect.Quote(c.columns[0])
@@ -281,5 +305,33 @@ func (c *ConflictBuilder) Create(value interface{}) (string, error) {
 		}
 	}
 
+	if len(c.returning) > 0 {
+		sql = c.appendReturning(sql)
+	}
+
 	return sql + ";", nil
 }
+
+// appendReturning 添加返回列子句
+// SQL Server 的 OUTPUT 子句位于列列表和 VALUES 之间，其他方言的 RETURNING 位于语句末尾
+func (c *ConflictBuilder) appendReturning(sql string) string {
+	if c.generator.dialect.Name() == SQLServer {
+		cols := c.returningColumns("INSERTED.")
+		// 第一个 ") VALUES " 紧跟在列列表之后，值列表不会出现在它之前
+		return strings.Replace(sql, ") VALUES ", ") OUTPUT "+cols+" VALUES ", 1)
+	}
+	return sql + " RETURNING " + c.returningColumns("")
+}
+
+// returningColumns 返回以逗号分隔的返回列，"*" 不加引号
+func (c *ConflictBuilder) returningColumns(prefix string) string {
+	cols := make([]string, len(c.returning))
+	for i, col := range c.returning {
+		if col == "*" {
+			cols[i] = prefix + col
+			continue
+		}
+		cols[i] = prefix + c.generator.dialect.Quote(toSnakeCase(col))
+	}
+	return strings.Join(cols, ", ")
+}
@@ -59,6 +59,7 @@ func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) strin
 	var columnDefs []string
 	var primaryKeys []string
 	var indexes []string
+	var checks []string
 
 	for _, field := range fields {
 		colDef := g.buildColumnDef(field)
@@ -85,6 +86,11 @@ func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) strin
 			indexes = append(indexes, fmt.Sprintf("UNIQUE INDEX %s (%s)",
 				g.dialect.Quote(indexName), g.dialect.Quote(field.ColumnName)))
 		}
+
+		// 命名 CHECK 约束作为表级约束输出,未命名的在 buildColumnDef 中内联
+		if name, expr := splitCheckTag(field.Tag.Check); name != "" && expr != "" {
+			checks = append(checks, fmt.Sprintf("CONSTRAINT %s CHECK (%s)", g.dialect.Quote(name), expr))
+		}
 	}
 
 	sb.WriteString(strings.Join(columnDefs, ",\n"))
@@ -102,6 +108,12 @@ func (g *Generator) buildCreateTable(tableName string, fields []FieldInfo) strin
 		sb.WriteString(idx)
 	}
 
+	// 添加 CHECK 约束
+	for _, check := range checks {
+		sb.WriteString(",\n  ")
+		sb.WriteString(check)
+	}
+
 	sb.WriteString("\n)")
 
 	// 添加引擎子句 (MySQL)
@@ -123,7 +135,13 @@ func (g *Generator) buildColumnDef(field FieldInfo) string {
 	parts = append(parts, g.dialect.Quote(field.ColumnName))
 
 	// 类型
-	parts = append(parts, field.SQLType)
+	// 只有 MySQL 支持 ENUM,其他方言改用字符串类型加 CHECK (col IN (...)) 约束
+	sqlType := field.SQLType
+	enumValues := parseEnumType(sqlType)
+	if enumValues != nil && g.dialect.Name() != MySQL {
+		sqlType = g.enumFallbackType(enumValues)
+	}
+	parts = append(parts, sqlType)
 
 	// NOT NULL
 	if field.Tag.NotNull || field.Tag.PrimaryKey {
@@ -143,6 +161,22 @@ func (g *Generator) buildColumnDef(field FieldInfo) string {
 		parts = append(parts, fmt.Sprintf("DEFAULT %s", field.Tag.Default))
 	}
 
+	// ON UPDATE (MySQL 特有,如 ON UPDATE CURRENT_TIMESTAMP)
+	if field.Tag.OnUpdate != "" {
+		if clause := g.dialect.OnUpdateClause(field.Tag.OnUpdate); clause != "" {
+			parts = append(parts, clause)
+		}
+	}
+
+	// CHECK
+	if enumValues != nil && g.dialect.Name() != MySQL {
+		parts = append(parts, fmt.Sprintf("CHECK (%s IN (%s))",
+			g.dialect.Quote(field.ColumnName), quoteList(enumValues)))
+	}
+	if name, expr := splitCheckTag(field.Tag.Check); name == "" && expr != "" {
+		parts = append(parts, fmt.Sprintf("CHECK (%s)", expr))
+	}
+
 	// COMMENT (MySQL 特有)
 	if field.Tag.Comment != "" && g.dialect.Name() == MySQL {
 		parts = append(parts, fmt.Sprintf("COMMENT '%s'", escapeString(field.Tag.Comment)))
@@ -92,11 +92,8 @@ func (g *Generator) buildDeleteWhereClause() string {
 	var conditions []string
 
 	// 处理用户条件
-	for _, cond := range g.ctx.WhereConditions {
-		condStr := g.buildCondition(cond)
-		if condStr != "" {
-			conditions = append(conditions, condStr)
-		}
+	if userConds := g.joinConditions(); userConds != "" {
+		conditions = append(conditions, userConds)
 	}
 
 	// 从模型主键获取条件
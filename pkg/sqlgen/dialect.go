@@ -38,6 +38,10 @@ type DialectHandler interface {
 
 	// EngineClause 返回引擎子句 (MySQL 专用)
 	EngineClause() string
+
+	// OnUpdateClause 返回列更新时自动赋值的子句 (如 ON UPDATE CURRENT_TIMESTAMP)
+	// 不支持的方言返回空字符串
+	OnUpdateClause(value string) string
 }
 
 // ============================================================================
@@ -162,6 +166,10 @@ func (d *mysqlDialect) EngineClause() string {
 	return "ENGINE=InnoDB DEFAULT CHARSET=utf8mb4"
 }
 
+func (d *mysqlDialect) OnUpdateClause(value string) string {
+	return "ON UPDATE " + value
+}
+
 // ============================================================================
 // PostgreSQL 方言
 // ============================================================================
@@ -268,6 +276,10 @@ func (d *postgresDialect) EngineClause() string {
 	return "" // PostgreSQL 不需要
 }
 
+func (d *postgresDialect) OnUpdateClause(value string) string {
+	return "" // PostgreSQL 需要触发器实现
+}
+
 // ============================================================================
 // SQLite 方言
 // ============================================================================
@@ -342,6 +354,10 @@ func (d *sqliteDialect) EngineClause() string {
 	return "" // SQLite 不需要
 }
 
+func (d *sqliteDialect) OnUpdateClause(value string) string {
+	return "" // SQLite 需要触发器实现
+}
+
 // ============================================================================
 // SQL Server 方言
 // ============================================================================
@@ -448,6 +464,10 @@ func (d *sqlserverDialect) EngineClause() string {
 	return "" // SQL Server 不需要
 }
 
+func (d *sqlserverDialect) OnUpdateClause(value string) string {
+	return "" // SQL Server 需要触发器实现
+}
+
 // ============================================================================
 // 方言注册表
 // ============================================================================
@@ -0,0 +1,406 @@
+package sqlgen
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// 离线迁移 (结构体 vs 现有 DDL)
+// ============================================================================
+
+// AutoMigrateDiff 比较结构体与现有表结构,生成迁移 SQL
+// 不需要数据库连接,适合在 CI 中生成迁移文件 (离线版 AutoMigrate)
+//
+// 比较内容:
+//   - 列: 新增、删除、类型/NOT NULL/DEFAULT 变化
+//   - 索引: 按名称比较 gorm index/uniqueIndex 标签与 DDL 中的索引,列或唯一性变化时先删后建
+//
+// 限制:
+//   - 类型按文本比较 (忽略大小写、整数显示宽度和 UNSIGNED 等修饰),
+//     现有 DDL 最好使用与 Table() 相同的类型名
+//   - 不处理主键、外键和列顺序的变化
+//   - SQLite 不支持修改列,需要修改时生成注释提示手动重建表
+//
+// 参数:
+//
+//	current: 目标结构体 (指针)
+//	existingDDL: 现有表的 CREATE TABLE 语句 (可包含其他表和 CREATE INDEX 语句),
+//	             找不到对应的表时生成 CREATE TABLE
+//
+// 返回:
+//
+//	upSQL: 升级 SQL,每条语句一行,无变化时为空
+//	downSQL: 回滚 SQL,按升级的逆序撤销每一步
+//	err: 模型无效时的错误
+//
+// 使用示例:
+//
+//	existing, _ := os.ReadFile("schema/users.sql")
+//	up, down, err := gen.AutoMigrateDiff(&User{}, string(existing))
+func (g *Generator) AutoMigrateDiff(current interface{}, existingDDL string) (upSQL, downSQL string, err error) {
+	ng := g.clone()
+	if err := ng.parseModel(current); err != nil {
+		return "", "", err
+	}
+
+	fields := parseStructFields(ng.ctx.ModelType, ng.ctx.ModelValue, ng.dialect)
+	if len(fields) == 0 {
+		return "", "", ErrInvalidModel
+	}
+	tableName := ng.ctx.TableName
+
+	// 现有 DDL 中没有这张表,整表新建
+	existing := findSchema(NewParser(ng.config.Dialect), existingDDL, tableName)
+	if existing == nil {
+		return ng.buildCreateTable(tableName, fields), ng.buildDropTable(tableName), nil
+	}
+
+	d := &migrationDiff{g: ng, table: tableName}
+	d.diffIndexesDrop(existing.Indexes, targetIndexes(tableName, fields))
+	d.diffColumns(existing.Fields, fields)
+	d.diffIndexesAdd(existing.Indexes, targetIndexes(tableName, fields))
+
+	return d.build()
+}
+
+// findSchema 从 DDL 中查找指定表
+func findSchema(parser *Parser, ddl, tableName string) *Schema {
+	schemas, _ := parser.Parse(ddl)
+	for _, s := range schemas {
+		if strings.EqualFold(s.TableName, tableName) {
+			return s
+		}
+	}
+	return nil
+}
+
+// migrationDiff 收集迁移步骤
+// 每一步同时记录升级语句和对应的回滚语句
+type migrationDiff struct {
+	g     *Generator
+	table string
+	up    []string
+	down  []string
+}
+
+// add 记录一步迁移
+func (d *migrationDiff) add(up, down []string) {
+	d.up = append(d.up, up...)
+	// 回滚时逆序执行
+	d.down = append(append([]string(nil), down...), d.down...)
+}
+
+// build 生成最终的 SQL
+func (d *migrationDiff) build() (string, string, error) {
+	if len(d.up) == 0 {
+		return "", "", nil
+	}
+	return strings.Join(d.up, "\n"), strings.Join(d.down, "\n"), nil
+}
+
+// ============================================================================
+// 列比较
+// ============================================================================
+
+// diffColumns 比较列定义
+// 先按结构体顺序处理新增和修改,再处理结构体中已删除的列
+func (d *migrationDiff) diffColumns(existing []Field, target []FieldInfo) {
+	byName := make(map[string]Field, len(existing))
+	for _, f := range existing {
+		byName[strings.ToLower(f.Column.Name)] = f
+	}
+
+	seen := make(map[string]bool, len(target))
+	for _, t := range target {
+		name := strings.ToLower(t.ColumnName)
+		seen[name] = true
+
+		old, ok := byName[name]
+		if !ok {
+			d.add([]string{d.alter(d.addColumnKeyword() + d.g.buildColumnDef(t))},
+				[]string{d.alter("DROP COLUMN " + d.g.dialect.Quote(t.ColumnName))})
+			continue
+		}
+
+		oldInfo := fieldInfoFromColumn(old.Column)
+		if !columnChanged(oldInfo, t) {
+			continue
+		}
+		d.add(d.modifyColumn(oldInfo, t), d.modifyColumn(t, oldInfo))
+	}
+
+	for _, f := range existing {
+		if seen[strings.ToLower(f.Column.Name)] {
+			continue
+		}
+		d.add([]string{d.alter("DROP COLUMN " + d.g.dialect.Quote(f.Column.Name))},
+			[]string{d.alter(d.addColumnKeyword() + d.g.buildColumnDef(fieldInfoFromColumn(f.Column)))})
+	}
+}
+
+// modifyColumn 生成将列从 from 修改为 to 的语句
+func (d *migrationDiff) modifyColumn(from, to FieldInfo) []string {
+	column := d.g.dialect.Quote(to.ColumnName)
+
+	switch d.g.dialect.Name() {
+	case PostgreSQL:
+		var stmts []string
+		if normalizeSQLType(from.SQLType) != normalizeSQLType(to.SQLType) {
+			stmts = append(stmts, d.alter(fmt.Sprintf("ALTER COLUMN %s TYPE %s", column, to.SQLType)))
+		}
+		if columnNotNull(from) != columnNotNull(to) {
+			action := "DROP NOT NULL"
+			if columnNotNull(to) {
+				action = "SET NOT NULL"
+			}
+			stmts = append(stmts, d.alter(fmt.Sprintf("ALTER COLUMN %s %s", column, action)))
+		}
+		if normalizeDefault(from.Tag.Default) != normalizeDefault(to.Tag.Default) {
+			action := "DROP DEFAULT"
+			if to.Tag.Default != "" {
+				action = "SET DEFAULT " + to.Tag.Default
+			}
+			stmts = append(stmts, d.alter(fmt.Sprintf("ALTER COLUMN %s %s", column, action)))
+		}
+		return stmts
+	case SQLServer:
+		// SQL Server 的默认值是独立的约束,名称无法从 DDL 推断
+		var stmts []string
+		if normalizeSQLType(from.SQLType) != normalizeSQLType(to.SQLType) || columnNotNull(from) != columnNotNull(to) {
+			null := "NULL"
+			if columnNotNull(to) {
+				null = "NOT NULL"
+			}
+			stmts = append(stmts, d.alter(fmt.Sprintf("ALTER COLUMN %s %s %s", column, to.SQLType, null)))
+		}
+		if normalizeDefault(from.Tag.Default) != normalizeDefault(to.Tag.Default) {
+			stmts = append(stmts, fmt.Sprintf("-- SQL Server: change the default constraint of column %s of %s to %q manually",
+				column, d.g.dialect.Quote(d.table), to.Tag.Default))
+		}
+		return stmts
+	case SQLite:
+		return []string{fmt.Sprintf("-- SQLite does not support modifying column %s of %s, recreate the table manually",
+			column, d.g.dialect.Quote(d.table))}
+	default:
+		return []string{d.alter("MODIFY COLUMN " + d.g.buildColumnDef(to))}
+	}
+}
+
+// addColumnKeyword 返回新增列的关键字
+// SQL Server 使用 ADD 而不是 ADD COLUMN
+func (d *migrationDiff) addColumnKeyword() string {
+	if d.g.dialect.Name() == SQLServer {
+		return "ADD "
+	}
+	return "ADD COLUMN "
+}
+
+// alter 生成 ALTER TABLE 语句
+func (d *migrationDiff) alter(operation string) string {
+	return fmt.Sprintf("ALTER TABLE %s %s;", d.g.dialect.Quote(d.table), operation)
+}
+
+// fieldInfoFromColumn 将解析出的列转换为 FieldInfo,以便复用 buildColumnDef
+func fieldInfoFromColumn(col Column) FieldInfo {
+	return FieldInfo{
+		Name:       col.Name,
+		ColumnName: col.Name,
+		SQLType:    col.Type,
+		Tag: &ParsedTag{
+			Column:        col.Name,
+			Type:          col.Type,
+			PrimaryKey:    col.PrimaryKey,
+			AutoIncrement: col.AutoIncrement,
+			NotNull:       col.NotNull,
+			Default:       quoteDefault(col.Default),
+			OnUpdate:      col.OnUpdate,
+			Comment:       col.Comment,
+			Check:         checkTag(col.Check),
+		},
+	}
+}
+
+// columnChanged 判断列的类型、NOT NULL 或默认值是否变化
+func columnChanged(old, target FieldInfo) bool {
+	return normalizeSQLType(old.SQLType) != normalizeSQLType(target.SQLType) ||
+		columnNotNull(old) != columnNotNull(target) ||
+		normalizeDefault(old.Tag.Default) != normalizeDefault(target.Tag.Default)
+}
+
+// columnNotNull 判断列是否非空 (主键隐含 NOT NULL)
+func columnNotNull(f FieldInfo) bool {
+	return f.Tag.NotNull || f.Tag.PrimaryKey
+}
+
+// intDisplayWidthRegex 匹配整数类型的显示宽度,如 INT(11)
+var intDisplayWidthRegex = regexp.MustCompile(`^(SMALLINT|MEDIUMINT|INT|INTEGER|BIGINT)\(\d+\)$`)
+
+// sqlTypeAliases 同义类型
+var sqlTypeAliases = map[string]string{
+	"INTEGER": "INT",
+	"INT4":    "INT",
+	"INT8":    "BIGINT",
+	"BOOL":    "BOOLEAN",
+}
+
+// sqlTypeSpaceReplacer 去掉类型参数中的空格,如 DECIMAL(10, 2)
+var sqlTypeSpaceReplacer = strings.NewReplacer(", ", ",", " ,", ",", "( ", "(", " )", ")", " (", "(")
+
+// normalizeSQLType 规范化 SQL 类型以便比较
+// 只取第一个单词 (忽略 UNSIGNED、PRECISION 等修饰),去掉整数显示宽度并统一同义类型
+func normalizeSQLType(sqlType string) string {
+	fields := strings.Fields(sqlTypeSpaceReplacer.Replace(strings.ToUpper(sqlType)))
+	if len(fields) == 0 {
+		return ""
+	}
+	t := fields[0]
+	if m := intDisplayWidthRegex.FindStringSubmatch(t); m != nil {
+		t = m[1]
+	}
+	if alias, ok := sqlTypeAliases[t]; ok {
+		t = alias
+	}
+	return t
+}
+
+// normalizeDefault 规范化默认值以便比较
+// DEFAULT NULL 与未设置默认值等价
+func normalizeDefault(value string) string {
+	v := strings.ToUpper(strings.Trim(strings.TrimSpace(value), `'"`))
+	if v == "NULL" {
+		return ""
+	}
+	return v
+}
+
+// quoteDefault 为解析出的默认值补回引号
+// 解析 DDL 时会去掉默认值的引号,数字、NULL、布尔值和函数调用保持原样
+func quoteDefault(value string) string {
+	if value == "" {
+		return ""
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	switch strings.ToUpper(value) {
+	case "NULL", "TRUE", "FALSE", "CURRENT_TIMESTAMP", "CURRENT_DATE", "CURRENT_TIME", "LOCALTIMESTAMP":
+		return value
+	}
+	if strings.Contains(value, "(") {
+		return value
+	}
+	return "'" + escapeString(value) + "'"
+}
+
+// ============================================================================
+// 索引比较
+// ============================================================================
+
+// targetIndexes 从结构体标签收集索引
+// 使用相同索引名的字段组成联合索引,未命名时与 Table() 的命名规则一致
+func targetIndexes(tableName string, fields []FieldInfo) []Index {
+	var indexes []Index
+	positions := make(map[string]int)
+
+	addColumn := func(name, prefix, column string, unique bool) {
+		if name == "" || name == "true" {
+			name = prefix + tableName + "_" + column
+		}
+		key := strings.ToLower(name)
+		if i, ok := positions[key]; ok {
+			indexes[i].Columns = append(indexes[i].Columns, column)
+			return
+		}
+		positions[key] = len(indexes)
+		indexes = append(indexes, Index{Name: name, Columns: []string{column}, Unique: unique})
+	}
+
+	for _, f := range fields {
+		if f.Tag.Index != "" {
+			addColumn(f.Tag.Index, "idx_", f.ColumnName, false)
+		}
+		if f.Tag.UniqueIndex != "" {
+			addColumn(f.Tag.UniqueIndex, "uk_", f.ColumnName, true)
+		}
+	}
+	return indexes
+}
+
+// diffIndexesDrop 删除结构体中不存在或定义变化的索引
+// 在修改列之前执行,避免删除列时索引仍引用该列
+func (d *migrationDiff) diffIndexesDrop(existing, target []Index) {
+	for _, old := range existing {
+		if t := findIndex(target, old.Name); t != nil && indexEqual(old, *t) {
+			continue
+		}
+		d.add([]string{d.dropIndex(old)}, []string{d.createIndex(old)})
+	}
+}
+
+// diffIndexesAdd 创建现有 DDL 中不存在或定义变化的索引
+// 在修改列之后执行,保证索引引用的列已经存在
+func (d *migrationDiff) diffIndexesAdd(existing, target []Index) {
+	for _, t := range target {
+		if old := findIndex(existing, t.Name); old != nil && indexEqual(*old, t) {
+			continue
+		}
+		d.add([]string{d.createIndex(t)}, []string{d.dropIndex(t)})
+	}
+}
+
+// createIndex 生成创建索引的语句
+func (d *migrationDiff) createIndex(idx Index) string {
+	columns := make([]string, len(idx.Columns))
+	for i, c := range idx.Columns {
+		columns[i] = d.g.dialect.Quote(c)
+	}
+
+	kind := "INDEX"
+	if idx.Unique {
+		kind = "UNIQUE INDEX"
+	}
+
+	if d.g.dialect.Name() == MySQL {
+		return d.alter(fmt.Sprintf("ADD %s %s (%s)", kind, d.g.dialect.Quote(idx.Name), strings.Join(columns, ", ")))
+	}
+	return fmt.Sprintf("CREATE %s %s ON %s (%s);", kind, d.g.dialect.Quote(idx.Name),
+		d.g.dialect.Quote(d.table), strings.Join(columns, ", "))
+}
+
+// dropIndex 生成删除索引的语句
+func (d *migrationDiff) dropIndex(idx Index) string {
+	switch d.g.dialect.Name() {
+	case MySQL:
+		return d.alter("DROP INDEX " + d.g.dialect.Quote(idx.Name))
+	case SQLServer:
+		return fmt.Sprintf("DROP INDEX %s ON %s;", d.g.dialect.Quote(idx.Name), d.g.dialect.Quote(d.table))
+	default:
+		return fmt.Sprintf("DROP INDEX %s;", d.g.dialect.Quote(idx.Name))
+	}
+}
+
+// findIndex 按名称查找索引 (忽略大小写)
+func findIndex(indexes []Index, name string) *Index {
+	for i := range indexes {
+		if strings.EqualFold(indexes[i].Name, name) {
+			return &indexes[i]
+		}
+	}
+	return nil
+}
+
+// indexEqual 判断两个索引的列和唯一性是否相同
+func indexEqual(a, b Index) bool {
+	if a.Unique != b.Unique || len(a.Columns) != len(b.Columns) {
+		return false
+	}
+	for i := range a.Columns {
+		if !strings.EqualFold(a.Columns[i], b.Columns[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -5,6 +5,7 @@
 // 1. 正向生成 (Forward): 接收 Go Struct 和链式条件，返回 SQL 字符串
 //   - 完全兼容 GORM API 风格
 //   - 支持 CRUD、DDL、事务、迁移脚本生成
+//   - AutoMigrateDiff 比较结构体与现有 DDL,离线生成 ALTER 迁移 (升级和回滚)
 //
 // 2. 逆向生成 (Reverse): 接收 SQL DDL 脚本，返回 Go Struct 代码
 //   - 支持 CREATE TABLE 解析
@@ -25,11 +26,20 @@
 //	// 生成 SELECT 语句
 //	sql, _ := gen.Where("status = ?", 1).Find(&users)
 //
+//	// 生成 UPSERT 并取回 ID (PostgreSQL/SQLite RETURNING,SQL Server OUTPUT)
+//	sql, _ := gen.OnConflict("email").DoUpdate("username").Returning("id").Create(&user)
+//
 // 逆向生成示例:
 //
 //	ddl := "CREATE TABLE users (id INT PRIMARY KEY, name VARCHAR(64));"
 //	goCode, _ := gen.ParseSQL(ddl).Generate()
 //
+//	// 结构体名默认单数化 (users -> User),TableName() 仍返回 users
+//	goCode, _ = gen.ParseSQL(ddl).Singularize(false).ReceiverName("u").Generate()
+//
+//	// 可空列生成 *T (或 sql.NullXxx),主键字段排在最前
+//	goCode, _ = gen.ParseSQL(ddl).NullableAsPointer(true).OrderFields(sqlgen.FieldOrderPrimaryKeyFirst).Generate()
+//
 // # 设计哲学
 //
 //   - 纯文本工具: 不依赖数据库连接，可在任何环境运行
@@ -97,6 +97,12 @@ var (
 		Message: "data is empty",
 	}
 
+	// ErrReturningNotSupported 当前方言不支持返回插入的行 (MySQL)
+	ErrReturningNotSupported = &Error{
+		Code:    ErrCodeInvalidDialect,
+		Message: "RETURNING is not supported by this dialect",
+	}
+
 	// ErrNoTableName 无法获取表名
 	ErrNoTableName = &Error{
 		Code:    ErrCodeReflectFailed,
@@ -4,6 +4,8 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+
+	"github.com/jinzhu/inflection"
 )
 
 // ============================================================================
@@ -133,13 +135,12 @@ func (g *Generator) Omit(columns ...string) *Generator {
 }
 
 // Where 添加 WHERE 条件
+// 切片参数会展开为多个占位符,如 Where("id IN ?", []int{1, 2})
 func (g *Generator) Where(query interface{}, args ...interface{}) *Generator {
-	ng := g.clone()
-	ng.ctx.WhereConditions = append(ng.ctx.WhereConditions, WhereCondition{
+	return g.addCondition(WhereCondition{
 		Query: query,
 		Args:  args,
 	})
-	return ng
 }
 
 // Order 设置排序
@@ -242,28 +243,26 @@ func toPascalCase(s string) string {
 	return strings.Join(parts, "")
 }
 
+// toSingular 将英文复数单词转换为单数 (users -> user, categories -> category)
+// 使用 inflection 库处理不规则变化 (people -> person) 和不可数名词
 func toSingular(word string) string {
-	lower := strings.ToLower(word)
-	if strings.HasSuffix(lower, "ies") && len(word) > 3 {
-		return word[:len(word)-3] + "y"
-	}
-	if strings.HasSuffix(lower, "sses") || strings.HasSuffix(lower, "ches") || strings.HasSuffix(lower, "shes") || strings.HasSuffix(lower, "xes") || strings.HasSuffix(lower, "zes") {
-		if len(word) > 2 {
-			return word[:len(word)-2]
-		}
-		return word
-	}
-	if strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss") && !strings.HasSuffix(lower, "us") {
-		return word[:len(word)-1]
-	}
-	return word
+	return inflection.Singular(word)
 }
 
-func toStructNameFromTable(tableName string) string {
-	parts := strings.Split(tableName, "_")
-	if len(parts) == 0 {
+// toStructNameFromTable 根据表名推导结构体名称
+// 参数:
+//
+//	tableName: 表名,如 user_profiles
+//	singularize: 是否将最后一个单词单数化
+//
+// 返回:
+//
+//	string: 结构体名称,如 UserProfile (不单数化时为 UserProfiles)
+func toStructNameFromTable(tableName string, singularize bool) string {
+	if !singularize {
 		return toPascalCase(tableName)
 	}
+	parts := strings.Split(tableName, "_")
 	parts[len(parts)-1] = toSingular(parts[len(parts)-1])
 	return toPascalCase(strings.Join(parts, "_"))
 }
@@ -0,0 +1,101 @@
+package sqlgen
+
+import (
+	"sort"
+	"strings"
+)
+
+// sqlNullTypes 基础类型到 database/sql Null 类型的映射
+// 不在表中的类型使用泛型 sql.Null[T]
+var sqlNullTypes = map[string]string{
+	"string":    "sql.NullString",
+	"int64":     "sql.NullInt64",
+	"int32":     "sql.NullInt32",
+	"int16":     "sql.NullInt16",
+	"uint8":     "sql.NullByte",
+	"byte":      "sql.NullByte",
+	"float64":   "sql.NullFloat64",
+	"bool":      "sql.NullBool",
+	"time.Time": "sql.NullTime",
+}
+
+// isNullableColumn 判断列是否可空
+// 没有 NOT NULL 的列可空;主键和自增列即使没有显式 NOT NULL 也不会为 NULL
+func isNullableColumn(col Column) bool {
+	return !col.NotNull && !col.PrimaryKey && !col.AutoIncrement
+}
+
+// nullableType 返回可空列使用的 Go 类型
+// 指针、切片、map 等本身可以表示 nil 的类型,以及已经是 sql.Null 系列的类型原样返回,
+// 因此对同一字段重复调用结果不变
+func nullableType(goType string, style NullableStyle) string {
+	switch {
+	case goType == "",
+		strings.HasPrefix(goType, "*"),
+		strings.HasPrefix(goType, "[]"),
+		strings.HasPrefix(goType, "map["),
+		strings.HasPrefix(goType, "sql.Null"),
+		goType == "interface{}", goType == "any", goType == "json.RawMessage":
+		return goType
+	}
+
+	if style == NullableSQLNull {
+		if nullType, ok := sqlNullTypes[goType]; ok {
+			return nullType
+		}
+		return "sql.Null[" + goType + "]"
+	}
+	return "*" + goType
+}
+
+// applyNullable 将可空列的字段类型转换为可空类型
+// 关联字段 (没有对应的列) 不处理
+func applyNullable(fields []Field, style NullableStyle) {
+	for i := range fields {
+		if fields[i].Association != nil || !isNullableColumn(fields[i].Column) {
+			continue
+		}
+		fields[i].Type = nullableType(fields[i].Type, style)
+	}
+}
+
+// typeImports 根据字段类型同步标准库导入
+// 可空类型转换可能新增 database/sql、移除 time (time.Time 变为 sql.NullTime),
+// 因此先去掉由字段类型决定的导入,再按当前字段类型重新添加
+func typeImports(imports []string, fields []Field) []string {
+	typePackages := map[string]string{
+		"time":          "time.",
+		"encoding/json": "json.",
+		"database/sql":  "sql.",
+	}
+
+	var out []string
+	for _, imp := range imports {
+		if _, ok := typePackages[imp]; !ok {
+			out = append(out, imp)
+		}
+	}
+	for imp, prefix := range typePackages {
+		for _, f := range fields {
+			if strings.Contains(f.Type, prefix) {
+				out = append(out, imp)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// orderFields 按排列策略返回排序后的字段副本,不修改原切片
+func orderFields(fields []Field, order FieldOrder) []Field {
+	out := append([]Field(nil), fields...)
+	switch order {
+	case FieldOrderAlphabetical:
+		sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	case FieldOrderPrimaryKeyFirst:
+		sort.SliceStable(out, func(i, j int) bool {
+			return out[i].Column.PrimaryKey && !out[j].Column.PrimaryKey
+		})
+	}
+	return out
+}
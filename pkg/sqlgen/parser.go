@@ -43,6 +43,20 @@ func (p *Parser) Parse(sql string) ([]*Schema, error) {
 		schemas = append(schemas, schema)
 	}
 
+	// 独立的 CREATE INDEX 语句 (PostgreSQL/SQLite 常见写法) 归入对应的表
+	for _, m := range createIndexRegex.FindAllStringSubmatch(p.input, -1) {
+		for _, schema := range schemas {
+			if strings.EqualFold(schema.TableName, m[3]) {
+				schema.Indexes = append(schema.Indexes, Index{
+					Name:    m[2],
+					Columns: splitIndexColumns(m[4]),
+					Unique:  m[1] != "",
+				})
+				break
+			}
+		}
+	}
+
 	return schemas, nil
 }
 
@@ -57,8 +71,8 @@ func (p *Parser) ParseSingle(sql string) (*Schema, error) {
 
 // 正则表达式
 var (
-	// 匹配 CREATE TABLE 语句
-	createTableRegex = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([\s\S]+?)\)`)
+	// 匹配 CREATE TABLE 语句头 (到左括号为止),表体由 matchParen 按括号配对截取
+	createTableRegex = regexp.MustCompile(`(?i)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(`)
 
 	// 匹配数据类型
 	dataTypeRegex = regexp.MustCompile(`(?i)^(\w+)(?:\(([^)]+)\))?`)
@@ -67,30 +81,85 @@ var (
 	commentRegex = regexp.MustCompile(`(?i)COMMENT\s+['"]([^'"]+)['"]`)
 
 	// 匹配 DEFAULT
-	defaultRegex = regexp.MustCompile(`(?i)DEFAULT\s+(['"]?[^,'"]+['"]?)`)
+	// 取值为引号字符串或不含空白的单个标记 (如 0、CURRENT_TIMESTAMP、CURRENT_TIMESTAMP(3)),
+	// 避免把其后的 ON UPDATE、COMMENT 等子句当作默认值
+	defaultRegex = regexp.MustCompile(`(?i)DEFAULT\s+('(?:[^']|'')*'|"[^"]*"|[^\s,]+)`)
+
+	// 匹配 ON UPDATE 时间戳子句 (MySQL)
+	// 只匹配时间戳函数,不与外键的 ON UPDATE CASCADE 混淆
+	onUpdateRegex = regexp.MustCompile(`(?i)ON\s+UPDATE\s+(CURRENT_TIMESTAMP(?:\(\d*\))?|NOW\(\d*\)|LOCALTIMESTAMP(?:\(\d*\))?)`)
 
 	// 匹配 PRIMARY KEY 约束
 	pkConstraintRegex = regexp.MustCompile(`(?i)(?:CONSTRAINT\s+\w+\s+)?PRIMARY\s+KEY\s*\(([^)]+)\)`)
+
+	// 匹配 FOREIGN KEY 约束
+	fkConstraintRegex = regexp.MustCompile(`(?i)^(?:CONSTRAINT\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+)?FOREIGN\s+KEY\s*\(([^)]+)\)\s*REFERENCES\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([^)]+)\)`)
+
+	// 匹配表定义中的索引: [UNIQUE] INDEX|KEY name (cols)、CONSTRAINT name UNIQUE (cols)
+	inlineIndexRegex = regexp.MustCompile(`(?i)^(?:(UNIQUE)\s+(?:INDEX|KEY)?|INDEX|KEY|CONSTRAINT)\s*[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*(UNIQUE\s*)?(?:USING\s+\w+\s*)?\(([^)]+)\)`)
+
+	// 匹配独立的 CREATE INDEX 语句
+	createIndexRegex = regexp.MustCompile(`(?i)CREATE\s+(UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s+ON\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*(?:USING\s+\w+\s*)?\(([^)]+)\)`)
+
+	// 匹配列定义中的内联 REFERENCES
+	referencesRegex = regexp.MustCompile(`(?i)REFERENCES\s+[` + "`" + `"'\[]?(\w+)[` + "`" + `"'\]]?\s*\(([^)]+)\)`)
 )
 
 func (p *Parser) findCreateTableStatements() []string {
 	var results []string
-	matches := createTableRegex.FindAllString(p.input, -1)
-	results = append(results, matches...)
+	for _, loc := range createTableRegex.FindAllStringIndex(p.input, -1) {
+		end := matchParen(p.input, loc[1]-1)
+		if end < 0 {
+			continue
+		}
+		results = append(results, p.input[loc[0]:end+1])
+	}
 	return results
 }
 
+// matchParen 返回与 open 位置左括号配对的右括号位置,未找到时返回 -1
+// 忽略引号内的括号,如 COMMENT '状态(0/1)'
+func matchParen(s string, open int) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		ch := s[i]
+		if quote != 0 {
+			if ch == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch ch {
+		case '\'', '"', '`':
+			quote = ch
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
 func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
-	matches := createTableRegex.FindStringSubmatch(sql)
-	if len(matches) < 3 {
+	loc := createTableRegex.FindStringSubmatchIndex(sql)
+	if loc == nil {
+		return nil, ErrParseFailed
+	}
+	end := matchParen(sql, loc[1]-1)
+	if end < 0 {
 		return nil, ErrParseFailed
 	}
 
-	tableName := matches[1]
-	columnsBody := matches[2]
+	tableName := sql[loc[2]:loc[3]]
+	columnsBody := sql[loc[1]:end]
 
 	schema := &Schema{
-		Name:      toStructNameFromTable(tableName),
+		Name:      toStructNameFromTable(tableName, true),
 		TableName: tableName,
 	}
 
@@ -105,15 +174,45 @@ func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
 			continue
 		}
 
+		// CHECK 约束
+		if check, ok := parseCheckConstraint(colDef); ok {
+			schema.Checks = append(schema.Checks, check)
+			continue
+		}
+
+		// 外键约束
+		if fkMatch := fkConstraintRegex.FindStringSubmatch(colDef); fkMatch != nil {
+			schema.ForeignKeys = append(schema.ForeignKeys, ForeignKey{
+				Name:       fkMatch[1],
+				Columns:    splitIdentifiers(fkMatch[2]),
+				RefTable:   fkMatch[3],
+				RefColumns: splitIdentifiers(fkMatch[4]),
+			})
+			continue
+		}
+
+		// 索引和唯一约束
+		if idxMatch := inlineIndexRegex.FindStringSubmatch(colDef); idxMatch != nil &&
+			!strings.EqualFold(idxMatch[2], "PRIMARY") {
+			upper := strings.ToUpper(colDef)
+			isConstraint := strings.HasPrefix(upper, "CONSTRAINT")
+			// CONSTRAINT 只处理 UNIQUE 约束,其他约束交给下面的分支
+			if !isConstraint || idxMatch[3] != "" {
+				schema.Indexes = append(schema.Indexes, Index{
+					Name:    idxMatch[2],
+					Columns: splitIndexColumns(idxMatch[4]),
+					Unique:  idxMatch[1] != "" || idxMatch[3] != "",
+				})
+				continue
+			}
+		}
+
 		// 检查是否是约束定义
 		if strings.HasPrefix(strings.ToUpper(colDef), "PRIMARY KEY") ||
 			strings.HasPrefix(strings.ToUpper(colDef), "CONSTRAINT") {
 			// 提取主键列
 			if pkMatch := pkConstraintRegex.FindStringSubmatch(colDef); len(pkMatch) > 1 {
-				pkCols := strings.Split(pkMatch[1], ",")
-				for _, pk := range pkCols {
-					primaryKeys = append(primaryKeys, strings.Trim(strings.TrimSpace(pk), "`\"'[]"))
-				}
+				primaryKeys = append(primaryKeys, splitIdentifiers(pkMatch[1])...)
 			}
 			continue
 		}
@@ -134,7 +233,24 @@ func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
 			continue
 		}
 
+		// 内联 CHECK: status VARCHAR(16) CHECK (status IN ('a','b'))
+		if expr, ok := parseInlineCheck(colDef); ok {
+			schema.Checks = append(schema.Checks, CheckConstraint{
+				Column:     col.Column.Name,
+				Expression: expr,
+			})
+		}
+
 		schema.Fields = append(schema.Fields, *col)
+
+		// 内联外键: user_id BIGINT REFERENCES users(id)
+		if refMatch := referencesRegex.FindStringSubmatch(colDef); refMatch != nil {
+			schema.ForeignKeys = append(schema.ForeignKeys, ForeignKey{
+				Columns:    []string{col.Column.Name},
+				RefTable:   refMatch[1],
+				RefColumns: splitIdentifiers(refMatch[2]),
+			})
+		}
 	}
 
 	// 标记主键
@@ -146,12 +262,53 @@ func (p *Parser) parseCreateTable(sql string) (*Schema, error) {
 		}
 	}
 
+	// 关联 CHECK 约束到列,col IN (...) 形式的约束同时作为列的可选值
+	for i := range schema.Checks {
+		check := &schema.Checks[i]
+		if check.Column == "" {
+			continue
+		}
+		for j := range schema.Fields {
+			col := &schema.Fields[j].Column
+			if !strings.EqualFold(col.Name, check.Column) {
+				continue
+			}
+			col.Check = check
+			if _, values, ok := parseEnumCheck(check.Expression); ok && len(col.EnumValues) == 0 {
+				col.EnumValues = values
+			}
+			break
+		}
+	}
+
 	// 检查需要导入的包
 	p.analyzeImports(schema)
 
 	return schema, nil
 }
 
+// splitIdentifiers 分割逗号分隔的列名列表并去除引号
+func splitIdentifiers(list string) []string {
+	parts := strings.Split(list, ",")
+	names := make([]string, 0, len(parts))
+	for _, part := range parts {
+		names = append(names, strings.Trim(strings.TrimSpace(part), "`\"'[]"))
+	}
+	return names
+}
+
+// splitIndexColumns 分割索引列列表
+// 去除引号和 ASC/DESC 等排序修饰
+func splitIndexColumns(list string) []string {
+	names := splitIdentifiers(list)
+	for i, name := range names {
+		if fields := strings.Fields(name); len(fields) > 0 {
+			names[i] = strings.Trim(fields[0], "`\"'[]")
+		}
+	}
+	return names
+}
+
 // splitColumns 分割列定义 (处理嵌套括号)
 func (p *Parser) splitColumns(body string) []string {
 	var result []string
@@ -204,7 +361,13 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 		return nil, fmt.Errorf("cannot parse data type: %s", def)
 	}
 
+	// 类型参数中可能有空格,如 ENUM('a', 'b')、DECIMAL(10, 2),按括号配对截取完整类型
 	sqlType := parts[1]
+	if open := len(typeMatch[1]); open < len(restDef) && restDef[open] == '(' {
+		if end := matchParen(restDef, open); end > 0 {
+			sqlType = restDef[:end+1]
+		}
+	}
 	baseType := strings.ToUpper(typeMatch[1])
 
 	// 解析类型参数
@@ -235,6 +398,12 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 		defaultValue = strings.Trim(match[1], "'\"")
 	}
 
+	// 解析 ON UPDATE
+	var onUpdate string
+	if match := onUpdateRegex.FindStringSubmatch(def); len(match) > 1 {
+		onUpdate = strings.ToUpper(match[1])
+	}
+
 	// 解析注释
 	var comment string
 	if match := commentRegex.FindStringSubmatch(def); len(match) > 1 {
@@ -272,10 +441,12 @@ func (p *Parser) parseColumnDef(def string) (*Field, error) {
 		AutoIncrement: isAutoIncrement,
 		NotNull:       isNotNull,
 		Default:       defaultValue,
+		OnUpdate:      onUpdate,
 		Comment:       comment,
 		Size:          size,
 		Precision:     precision,
 		Scale:         scale,
+		EnumValues:    parseEnumType(sqlType),
 	}
 
 	field := &Field{
@@ -48,17 +48,67 @@ func (g *Generator) Find(dest interface{}, conds ...interface{}) (string, error)
 }
 
 // Count 生成计数查询的 SQL
-func (g *Generator) Count(count *int64) (string, error) {
+// 使用已累积的 Where、Joins 条件和软删除过滤,忽略 Order、Limit、Offset,
+// 同一条链既能生成分页查询,也能生成总数查询:
+//
+//	q := gen.Where("status = ?", 1).Order("id DESC").Limit(20).Offset(40)
+//	page, _ := q.Find(&users)
+//	total, _ := q.Count(&User{})
+//	// SELECT COUNT(*) FROM `users` WHERE `users`.`deleted_at` IS NULL AND status = 1;
+//
+// 参数:
+//
+//	model: 模型实例,为 nil 或 *int64 时使用 Model 设置的模型
+func (g *Generator) Count(model interface{}) (string, error) {
 	ng := g.clone()
 
-	if ng.ctx.TableName == "" {
-		return "", ErrNoTableName
+	if err := ng.resolveModel(model); err != nil {
+		return "", err
 	}
 
 	ng.ctx.Operation = OpSelect
-	ng.ctx.SelectColumns = []string{"count(*)"}
 
-	return ng.buildSelect()
+	return "SELECT COUNT(*) FROM " + ng.buildFrom() + ";", nil
+}
+
+// Exists 生成判断记录是否存在的 SQL
+// 与 Count 使用相同的条件,数据库找到第一条匹配的记录即返回,不统计全部记录
+// 结果为单行单列,存在时为 1(PostgreSQL 为 true):
+//   - MySQL、PostgreSQL、SQLite: SELECT EXISTS(SELECT 1 FROM ...);
+//   - SQL Server: SELECT CASE WHEN EXISTS(SELECT 1 FROM ...) THEN 1 ELSE 0 END;
+//
+// 参数:
+//
+//	model: 模型实例,为 nil 或 *bool 时使用 Model 设置的模型
+func (g *Generator) Exists(model interface{}) (string, error) {
+	ng := g.clone()
+
+	if err := ng.resolveModel(model); err != nil {
+		return "", err
+	}
+
+	ng.ctx.Operation = OpSelect
+	subquery := "SELECT 1 FROM " + ng.buildFrom()
+
+	// SQL Server 的 EXISTS 只能出现在条件中,不能直接作为查询结果
+	if ng.dialect.Name() == SQLServer {
+		return "SELECT CASE WHEN EXISTS(" + subquery + ") THEN 1 ELSE 0 END;", nil
+	}
+	return "SELECT EXISTS(" + subquery + ");", nil
+}
+
+// resolveModel 解析 Count、Exists 的模型参数
+// nil 或结果指针 (*int64、*bool) 表示沿用 Model 设置的模型
+func (g *Generator) resolveModel(model interface{}) error {
+	switch model.(type) {
+	case nil, *int64, *bool:
+		if g.ctx.TableName == "" {
+			return ErrNoTableName
+		}
+		return nil
+	default:
+		return g.parseModel(model)
+	}
 }
 
 // Pluck 生成单列查询的 SQL
@@ -161,14 +211,7 @@ func (g *Generator) buildSelect() (string, error) {
 	}
 
 	sb.WriteString(" FROM ")
-	sb.WriteString(g.dialect.Quote(g.ctx.TableName))
-
-	// WHERE
-	whereClause := g.buildWhereClause()
-	if whereClause != "" {
-		sb.WriteString(" WHERE ")
-		sb.WriteString(whereClause)
-	}
+	sb.WriteString(g.buildFrom())
 
 	// ORDER BY
 	if g.ctx.OrderBy != "" {
@@ -191,6 +234,28 @@ func (g *Generator) buildSelect() (string, error) {
 	return sb.String(), nil
 }
 
+// buildFrom 构建 FROM 之后的表、JOIN 和 WHERE 子句
+// SELECT、Count 和 Exists 共用,保证条件一致
+func (g *Generator) buildFrom() string {
+	var sb strings.Builder
+
+	sb.WriteString(g.dialect.Quote(g.ctx.TableName))
+
+	// JOIN
+	for _, join := range g.ctx.Joins {
+		sb.WriteString(" ")
+		sb.WriteString(g.buildCondition(join))
+	}
+
+	// WHERE
+	if whereClause := g.buildWhereClause(); whereClause != "" {
+		sb.WriteString(" WHERE ")
+		sb.WriteString(whereClause)
+	}
+
+	return sb.String()
+}
+
 // buildWhereClause 构建 WHERE 子句
 func (g *Generator) buildWhereClause() string {
 	var conditions []string
@@ -203,11 +268,12 @@ func (g *Generator) buildWhereClause() string {
 	}
 
 	// 处理用户条件
-	for _, cond := range g.ctx.WhereConditions {
-		condStr := g.buildCondition(cond)
-		if condStr != "" {
-			conditions = append(conditions, condStr)
+	if userConds := g.joinConditions(); userConds != "" {
+		// 与软删除条件组合时,OR 条件整体加括号以保持优先级
+		if len(conditions) > 0 && g.hasOrCondition() {
+			userConds = "(" + userConds + ")"
 		}
+		conditions = append(conditions, userConds)
 	}
 
 	return strings.Join(conditions, " AND ")
@@ -217,8 +283,14 @@ func (g *Generator) buildWhereClause() string {
 func (g *Generator) buildCondition(cond WhereCondition) string {
 	switch query := cond.Query.(type) {
 	case string:
-		// 字符串条件，替换占位符
-		sql, _ := g.dialect.Interpolate(query, cond.Args...)
+		// 字符串条件，展开切片参数后替换占位符
+		query, args := expandSliceArgs(query, cond.Args)
+		if strings.Contains(query, "?") {
+			// ? 占位符与方言无关,统一按顺序替换
+			sql, _ := interpolateSQL(query, args, g.dialect.Quote)
+			return sql
+		}
+		sql, _ := g.dialect.Interpolate(query, args...)
 		return sql
 	default:
 		return ""
@@ -230,17 +302,20 @@ func (g *Generator) buildCondition(cond WhereCondition) string {
 // ============================================================================
 
 // Or 添加 OR 条件
+// 与前面的条件以 OR 连接,连续的 AND 条件会作为一组加括号:
+//
+//	gen.Where("a = ?", 1).Where("b = ?", 2).Or("c = ?", 3)
+//	// WHERE (a = 1 AND b = 2) OR c = 3
 func (g *Generator) Or(query interface{}, args ...interface{}) *Generator {
-	ng := g.clone()
-	// TODO: 实现 OR 条件支持
-	return ng
+	return g.addCondition(WhereCondition{Query: query, Args: args, Or: true})
 }
 
 // Not 添加 NOT 条件
+//
+//	gen.Not("status = ?", 0)
+//	// WHERE NOT (status = 0)
 func (g *Generator) Not(query interface{}, args ...interface{}) *Generator {
-	ng := g.clone()
-	// TODO: 实现 NOT 条件支持
-	return ng
+	return g.addCondition(WhereCondition{Query: query, Args: args, Not: true})
 }
 
 // Group 设置 GROUP BY
@@ -272,8 +347,14 @@ func (g *Generator) Distinct(columns ...string) *Generator {
 // ============================================================================
 
 // Joins 添加 JOIN
+// query 为完整的 JOIN 语句,参数按 Where 的规则替换占位符:
+//
+//	gen.Joins("JOIN orders ON orders.user_id = users.id AND orders.status = ?", 1)
+//	// SELECT * FROM `users` JOIN orders ON orders.user_id = users.id AND orders.status = 1
 func (g *Generator) Joins(query string, args ...interface{}) *Generator {
 	ng := g.clone()
-	// TODO: 实现 JOIN 支持
+	joins := make([]WhereCondition, len(ng.ctx.Joins), len(ng.ctx.Joins)+1)
+	copy(joins, ng.ctx.Joins)
+	ng.ctx.Joins = append(joins, WhereCondition{Query: query, Args: args})
 	return ng
 }
@@ -19,10 +19,12 @@ type ParsedTag struct {
 	AutoIncrement bool
 	NotNull       bool
 	Default       string
+	OnUpdate      string // onUpdate:CURRENT_TIMESTAMP
 	Index         string
 	UniqueIndex   string
 	Comment       string
-	Ignore        bool // gorm:"-"
+	Check         string // check:expr 或 check:name,expr
+	Ignore        bool   // gorm:"-"
 }
 
 // parseGormTag 解析 gorm struct tag
@@ -57,12 +59,16 @@ func parseGormTag(tag string) *ParsedTag {
 				}
 			case "default":
 				result.Default = value
+			case "onupdate":
+				result.OnUpdate = value
 			case "index":
 				result.Index = value
 			case "uniqueindex":
 				result.UniqueIndex = value
 			case "comment":
 				result.Comment = value
+			case "check":
+				result.Check = value
 			}
 		} else {
 			// 处理单独的标志
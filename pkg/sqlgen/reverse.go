@@ -1,8 +1,11 @@
 package sqlgen
 
 import (
+	"go/token"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 // ============================================================================
@@ -111,6 +114,84 @@ func (r *ReverseBuilder) WithSoftDelete(enabled bool) *ReverseBuilder {
 	return r
 }
 
+// WithAssociations 是否根据外键生成关联字段
+// 启用后,对解析出的单列外键:
+//   - 外键所在表生成 belongs-to 字段,如 Post.User *User
+//   - 被引用表生成 has-many 字段,如 User.Posts []Post
+//
+// 关联字段带有 gorm foreignKey/references tag,可直接用于 GORM Preload
+// 被引用表不在本次解析结果中时跳过该外键
+func (r *ReverseBuilder) WithAssociations(enabled bool) *ReverseBuilder {
+	r.options.WithAssociations = enabled
+	return r
+}
+
+// WithEnumConstants 是否为带可选值的字符串列生成枚举类型和常量
+// 可选值来自 MySQL ENUM 类型或 CHECK (col IN (...)) 约束,启用后:
+//   - 生成 type UserStatus string 以及 UserStatusActive 等常量
+//   - 字段类型改为该枚举类型,GORM 可以直接读写
+func (r *ReverseBuilder) WithEnumConstants(enabled bool) *ReverseBuilder {
+	r.options.WithEnumConstants = enabled
+	return r
+}
+
+// Singularize 是否将表名单数化作为结构体名称 (默认启用)
+// 启用时 users -> User、user_profiles -> UserProfile、people -> Person,
+// 禁用时直接使用表名的大驼峰形式 (users -> Users)
+// TableName() 方法始终返回原始表名,不受影响
+// 通过 Name 设置的自定义结构体名称优先
+func (r *ReverseBuilder) Singularize(enabled bool) *ReverseBuilder {
+	r.options.Singularize = enabled
+	for _, schema := range r.schemas {
+		schema.Name = toStructNameFromTable(schema.TableName, enabled)
+	}
+	return r
+}
+
+// ReceiverName 设置生成的模型方法的接收者变量名
+// 例如 ReceiverName("u") 生成 func (u User) TableName() string
+// 为空时省略接收者变量 (默认),名称不是合法的 Go 标识符时生成返回错误
+func (r *ReverseBuilder) ReceiverName(name string) *ReverseBuilder {
+	if name != "" && !token.IsIdentifier(name) {
+		r.err = NewError(ErrCodeGenerateFailed, "invalid receiver name: "+name)
+		return r
+	}
+	r.options.ReceiverName = name
+	return r
+}
+
+// NullableAsPointer 是否为可空列生成可空类型
+// 启用后没有 NOT NULL 的列 (主键和自增列除外) 生成可空类型,数据库中的 NULL 可以与零值区分:
+//   - email VARCHAR(128)        -> Email *string (默认 NullablePointer 风格)
+//   - email VARCHAR(128) NOT NULL -> Email string
+//
+// 类型风格通过 NullableStyle 选择;指针、切片等本身可以为 nil 的类型保持不变
+// 在 TypeMapping 之后、FieldConverter 之前应用
+func (r *ReverseBuilder) NullableAsPointer(enabled bool) *ReverseBuilder {
+	r.options.NullableAsPointer = enabled
+	return r
+}
+
+// NullableStyle 设置可空列的类型风格
+// NullablePointer (默认) 生成 *string、*int64;NullableSQLNull 生成 sql.NullString、sql.NullInt64,
+// 没有对应 Null 类型的基础类型使用 sql.Null[T],并自动导入 database/sql
+// 仅在 NullableAsPointer(true) 时生效
+func (r *ReverseBuilder) NullableStyle(style NullableStyle) *ReverseBuilder {
+	r.options.NullableStyle = style
+	return r
+}
+
+// OrderFields 设置字段排列顺序
+//   - FieldOrderPreserve: 保持 DDL 中的列顺序 (默认)
+//   - FieldOrderAlphabetical: 按 Go 字段名字母顺序
+//   - FieldOrderPrimaryKeyFirst: 主键字段在前,其余保持 DDL 顺序
+//
+// 关联字段始终排在列字段之后
+func (r *ReverseBuilder) OrderFields(strategy FieldOrder) *ReverseBuilder {
+	r.options.FieldOrder = strategy
+	return r
+}
+
 // Import 添加额外导入的包
 func (r *ReverseBuilder) Import(packages ...string) *ReverseBuilder {
 	r.options.Imports = append(r.options.Imports, packages...)
@@ -274,6 +355,11 @@ func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
 		}
 	}
 
+	// 可空列使用可空类型
+	if r.options.NullableAsPointer {
+		applyNullable(schema.Fields, r.options.NullableStyle)
+	}
+
 	// 应用字段转换器
 	if r.options.FieldConverter != nil {
 		for i := range schema.Fields {
@@ -286,9 +372,9 @@ func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
 		r.options.BeforeGenerate(schema)
 	}
 
-	// 合并导入
+	// 合并导入 (字段类型决定的标准库导入按最终类型重新计算)
 	allImports := make(map[string]bool)
-	for _, imp := range schema.Imports {
+	for _, imp := range typeImports(schema.Imports, schema.Fields) {
 		allImports[imp] = true
 	}
 	for _, imp := range r.options.Imports {
@@ -299,14 +385,25 @@ func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
 	for imp := range allImports {
 		imports = append(imports, imp)
 	}
+	sort.Strings(imports)
 	schema.Imports = imports
 
 	// 设置包名
 	schema.Package = r.options.Package
 
+	// 排列字段并追加关联字段 (使用副本,保留解析顺序,避免重复生成时字段累积)
+	target := *schema
+	target.Fields = orderFields(schema.Fields, r.options.FieldOrder)
+	if r.options.WithAssociations {
+		target.Fields = append(target.Fields, r.associationFields(schema)...)
+	}
+
 	// 生成代码
 	codegen := NewCodeGenerator(r.options)
-	code := codegen.Generate(schema)
+	code, err := formatCode(codegen.Generate(&target))
+	if err != nil {
+		return "", err
+	}
 
 	// 调用 AfterGenerate 钩子
 	if r.options.AfterGenerate != nil {
@@ -316,6 +413,87 @@ func (r *ReverseBuilder) generateCode(schema *Schema) (string, error) {
 	return code, nil
 }
 
+// associationFields 根据外键生成 schema 的关联字段
+// 关联的结构体与 schema 生成在同一个包中,不需要额外导入
+func (r *ReverseBuilder) associationFields(schema *Schema) []Field {
+	byTable := make(map[string]*Schema, len(r.schemas))
+	for _, s := range r.schemas {
+		byTable[strings.ToLower(s.TableName)] = s
+	}
+
+	existing := make(map[string]bool, len(schema.Fields))
+	for _, f := range schema.Fields {
+		existing[f.Name] = true
+	}
+
+	var fields []Field
+	add := func(f Field) {
+		// 与已有字段重名时跳过,避免生成无法编译的代码
+		if existing[f.Name] {
+			return
+		}
+		existing[f.Name] = true
+		fields = append(fields, f)
+	}
+
+	// belongs-to: 本表的外键
+	for _, fk := range schema.ForeignKeys {
+		ref, ok := byTable[strings.ToLower(fk.RefTable)]
+		if !ok || len(fk.Columns) != 1 || len(fk.RefColumns) != 1 {
+			continue
+		}
+		add(Field{
+			Name: belongsToFieldName(fk.Columns[0], ref),
+			Type: "*" + ref.Name,
+			Association: &Association{
+				Kind:       AssociationBelongsTo,
+				ForeignKey: goFieldName(schema, fk.Columns[0]),
+				References: goFieldName(ref, fk.RefColumns[0]),
+			},
+		})
+	}
+
+	// has-many: 其他表引用本表的外键
+	for _, other := range r.schemas {
+		for _, fk := range other.ForeignKeys {
+			if !strings.EqualFold(fk.RefTable, schema.TableName) || len(fk.Columns) != 1 || len(fk.RefColumns) != 1 {
+				continue
+			}
+			add(Field{
+				Name: toPascalCase(other.TableName),
+				Type: "[]" + other.Name,
+				Association: &Association{
+					Kind:       AssociationHasMany,
+					ForeignKey: goFieldName(other, fk.Columns[0]),
+					References: goFieldName(schema, fk.RefColumns[0]),
+				},
+			})
+		}
+	}
+
+	return fields
+}
+
+// belongsToFieldName 推导 belongs-to 字段名
+// 外键列以 _id 结尾时去掉后缀 (author_id -> Author),否则使用被引用的结构体名
+func belongsToFieldName(column string, ref *Schema) string {
+	lower := strings.ToLower(column)
+	if strings.HasSuffix(lower, "_id") && len(lower) > len("_id") {
+		return toPascalCase(column[:len(column)-len("_id")])
+	}
+	return ref.Name
+}
+
+// goFieldName 返回列在 schema 中对应的 Go 字段名
+func goFieldName(schema *Schema, column string) string {
+	for _, f := range schema.Fields {
+		if strings.EqualFold(f.Column.Name, column) {
+			return f.Name
+		}
+	}
+	return toPascalCase(column)
+}
+
 // ============================================================================
 // 数据库逆向 (可选功能)
 // ============================================================================
@@ -1,6 +1,9 @@
 package sqlgen
 
 import (
+	"errors"
+	"go/format"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -128,6 +131,34 @@ func TestCreateBatch(t *testing.T) {
 	}
 }
 
+func TestConflictReturning(t *testing.T) {
+	user := TestUser{Username: "admin", Email: "admin@test.com"}
+
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{PostgreSQL, `ON CONFLICT ("email") DO UPDATE SET "username" = EXCLUDED."username" RETURNING "id", "created_at";`},
+		{SQLite, `DO UPDATE SET "username" = excluded."username" RETURNING "id", "created_at";`},
+		{SQLServer, `) OUTPUT INSERTED.[id], INSERTED.[created_at] VALUES (`},
+	}
+	for _, tt := range tests {
+		sql, err := New(&Config{Dialect: tt.dialect}).
+			OnConflict("email").DoUpdate("username").Returning("id", "CreatedAt").Create(&user)
+		if err != nil {
+			t.Fatalf("%s: Create() failed: %v", tt.dialect, err)
+		}
+		if !strings.Contains(sql, tt.want) {
+			t.Errorf("%s: SQL = %s, want contains %s", tt.dialect, sql, tt.want)
+		}
+	}
+
+	_, err := New(&Config{Dialect: MySQL}).OnConflict("email").DoNothing().Returning("id").Create(&user)
+	if !errors.Is(err, ErrReturningNotSupported) {
+		t.Errorf("MySQL: err = %v, want ErrReturningNotSupported", err)
+	}
+}
+
 // ============================================================================
 // SELECT 测试
 // ============================================================================
@@ -184,6 +215,115 @@ func TestFind(t *testing.T) {
 	}
 }
 
+func TestFind_OrNotPrecedence(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL, SoftDelete: true})
+
+	var users []TestUser
+	sql, err := gen.Where("status = ?", 1).Where("age > ?", 18).Or("name = ?", "admin").Not("role = ?", "guest").Find(&users)
+	if err != nil {
+		t.Fatalf("Find() failed: %v", err)
+	}
+
+	want := "WHERE `test_users`.`deleted_at` IS NULL AND ((status = 1 AND age > 18) OR (name = 'admin' AND NOT (role = 'guest')));"
+	if !strings.HasSuffix(sql, want) {
+		t.Errorf("unexpected SQL:\n got: %s\nwant suffix: %s", sql, want)
+	}
+}
+
+func TestCount(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL, SoftDelete: true})
+
+	// 同一条链生成分页查询和总数查询,总数忽略排序和分页
+	q := gen.Joins("JOIN orders ON orders.user_id = users.id AND orders.status = ?", 2).
+		Where("status = ?", 1).Order("id DESC").Limit(10).Offset(20)
+	sql, err := q.Count(&TestUser{})
+	if err != nil {
+		t.Fatalf("Count() failed: %v", err)
+	}
+
+	want := "SELECT COUNT(*) FROM `users` JOIN orders ON orders.user_id = users.id AND orders.status = 2 WHERE `users`.`deleted_at` IS NULL AND status = 1;"
+	if sql != want {
+		t.Errorf("unexpected SQL:\n got: %s\nwant: %s", sql, want)
+	}
+
+	page, _ := q.Find(&TestUser{})
+	if !strings.Contains(page, "JOIN orders") || !strings.HasSuffix(page, "ORDER BY id DESC LIMIT 10 OFFSET 20;") {
+		t.Errorf("page query should keep joins and pagination: %s", page)
+	}
+
+	// 兼容 Model(...).Count(&total) 的用法
+	var total int64
+	if sql, err := gen.Model(&TestUser{}).Count(&total); err != nil || !strings.HasPrefix(sql, "SELECT COUNT(*) FROM `users`") {
+		t.Errorf("Model().Count(&total) = %q, %v", sql, err)
+	}
+	if _, err := gen.Count(nil); !errors.Is(err, ErrNoTableName) {
+		t.Errorf("Count(nil) without model error = %v, want ErrNoTableName", err)
+	}
+}
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{MySQL, "SELECT EXISTS(SELECT 1 FROM `users` WHERE `users`.`deleted_at` IS NULL AND email = 'a@b.c');"},
+		{PostgreSQL, `SELECT EXISTS(SELECT 1 FROM "users" WHERE "users"."deleted_at" IS NULL AND email = 'a@b.c');`},
+		{SQLServer, "SELECT CASE WHEN EXISTS(SELECT 1 FROM [users] WHERE [users].[deleted_at] IS NULL AND email = 'a@b.c') THEN 1 ELSE 0 END;"},
+	}
+
+	for _, tt := range tests {
+		gen := New(&Config{Dialect: tt.dialect, SoftDelete: true})
+		sql, err := gen.Where("email = ?", "a@b.c").Limit(1).Exists(&TestUser{})
+		if err != nil {
+			t.Fatalf("%s: Exists() failed: %v", tt.dialect, err)
+		}
+		if sql != tt.want {
+			t.Errorf("%s: unexpected SQL:\n got: %s\nwant: %s", tt.dialect, sql, tt.want)
+		}
+	}
+}
+
+func TestFind_WhereInBetween(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{MySQL, "WHERE `id` IN (1, 2, 3) AND `test_users`.`age` BETWEEN 18 AND 30 AND `name` IN (NULL);"},
+		{PostgreSQL, `WHERE "id" IN (1, 2, 3) AND "test_users"."age" BETWEEN 18 AND 30 AND "name" IN (NULL);`},
+		{SQLite, `WHERE "id" IN (1, 2, 3) AND "test_users"."age" BETWEEN 18 AND 30 AND "name" IN (NULL);`},
+	}
+
+	for _, tt := range tests {
+		gen := New(&Config{Dialect: tt.dialect, SoftDelete: false})
+
+		var users []TestUser
+		sql, err := gen.WhereIn("id", []int64{1, 2, 3}).
+			WhereBetween("test_users.age", 18, 30).
+			WhereIn("name").
+			Find(&users)
+		if err != nil {
+			t.Fatalf("%s: Find() failed: %v", tt.dialect, err)
+		}
+		if !strings.HasSuffix(sql, tt.want) {
+			t.Errorf("%s: unexpected SQL:\n got: %s\nwant suffix: %s", tt.dialect, sql, tt.want)
+		}
+	}
+}
+
+func TestWhere_SliceExpansion(t *testing.T) {
+	gen := New(&Config{Dialect: PostgreSQL, SoftDelete: false})
+
+	var users []TestUser
+	sql, err := gen.Where("status = ? AND id IN ?", 1, []int{4, 5}).Find(&users)
+	if err != nil {
+		t.Fatalf("Find() failed: %v", err)
+	}
+
+	if !strings.Contains(sql, "status = 1 AND id IN (4, 5)") {
+		t.Errorf("slice argument should be expanded, got: %s", sql)
+	}
+}
+
 // ============================================================================
 // UPDATE 测试
 // ============================================================================
@@ -343,3 +483,414 @@ func TestTransaction(t *testing.T) {
 		t.Error("Transaction should contain INSERT")
 	}
 }
+
+func TestParseSQL_GofmtOutput(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+
+	ddl := `
+	CREATE TABLE sys_users (
+		id bigint unsigned AUTO_INCREMENT PRIMARY KEY,
+		username varchar(64) NOT NULL COMMENT '用户名',
+		created_at datetime
+	);`
+
+	code, err := gen.ParseSQL(ddl).
+		Package("models").
+		Tags(TagGorm | TagJson).
+		Generate()
+	if err != nil {
+		t.Fatalf("ParseSQL().Generate() failed: %v", err)
+	}
+
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		t.Fatalf("generated code is not valid Go: %v", err)
+	}
+	if string(formatted) != code {
+		t.Errorf("generated code is not gofmt-formatted:\n%s", code)
+	}
+}
+
+func TestFormatCode_InvalidSource(t *testing.T) {
+	_, err := formatCode("package models\n\ntype User struct {\n\tName string `json:\"name\"\n}\n")
+	if err == nil {
+		t.Fatal("expected error for invalid generated code")
+	}
+
+	var sqlErr *Error
+	if !errors.As(err, &sqlErr) || sqlErr.Code != ErrCodeGenerateFailed {
+		t.Errorf("expected ErrCodeGenerateFailed, got %v", err)
+	}
+}
+
+func TestParseSQL_ForeignKeys(t *testing.T) {
+	schemas, err := NewParser(MySQL).Parse(`
+	CREATE TABLE posts (
+		id bigint PRIMARY KEY,
+		user_id bigint NOT NULL,
+		category_id bigint REFERENCES categories(id),
+		title varchar(100) COMMENT '标题(必填)',
+		CONSTRAINT fk_posts_user FOREIGN KEY (user_id) REFERENCES users(id)
+	);`)
+	if err != nil || len(schemas) != 1 {
+		t.Fatalf("Parse() = %d schemas, err %v", len(schemas), err)
+	}
+
+	schema := schemas[0]
+	if len(schema.Fields) != 4 {
+		t.Errorf("expected 4 fields, got %d", len(schema.Fields))
+	}
+	if len(schema.ForeignKeys) != 2 {
+		t.Fatalf("expected 2 foreign keys, got %+v", schema.ForeignKeys)
+	}
+
+	fk := schema.ForeignKeys[1]
+	if fk.Name != "fk_posts_user" || fk.RefTable != "users" || fk.Columns[0] != "user_id" || fk.RefColumns[0] != "id" {
+		t.Errorf("unexpected constraint foreign key: %+v", fk)
+	}
+	if inline := schema.ForeignKeys[0]; inline.RefTable != "categories" || inline.Columns[0] != "category_id" {
+		t.Errorf("unexpected inline foreign key: %+v", inline)
+	}
+}
+
+func TestParseSQL_WithAssociations(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+
+	ddl := `
+	CREATE TABLE users (
+		id bigint PRIMARY KEY,
+		name varchar(64)
+	);
+	CREATE TABLE posts (
+		id bigint PRIMARY KEY,
+		user_id bigint NOT NULL,
+		FOREIGN KEY (user_id) REFERENCES users(id)
+	);`
+
+	files, err := gen.ParseSQL(ddl).WithAssociations(true).GenerateAll()
+	if err != nil {
+		t.Fatalf("GenerateAll() failed: %v", err)
+	}
+
+	if !strings.Contains(files["users"], "Posts []Post `gorm:\"foreignKey:UserId;references:Id\" json:\"posts,omitempty\"`") {
+		t.Errorf("users should have has-many Posts field:\n%s", files["users"])
+	}
+	if !strings.Contains(files["posts"], "User   *User `gorm:\"foreignKey:UserId;references:Id\" json:\"user,omitempty\"`") {
+		t.Errorf("posts should have belongs-to User field:\n%s", files["posts"])
+	}
+
+	// 未启用时不生成关联字段
+	files, _ = gen.ParseSQL(ddl).GenerateAll()
+	if strings.Contains(files["users"], "Posts") {
+		t.Errorf("associations should be disabled by default:\n%s", files["users"])
+	}
+}
+
+type TestAudit struct {
+	ID        uint64    `gorm:"column:id;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;onUpdate:CURRENT_TIMESTAMP"`
+}
+
+func TestTable_TimestampDefaults(t *testing.T) {
+	sql, err := New(&Config{Dialect: MySQL}).Table(&TestAudit{})
+	if err != nil {
+		t.Fatalf("Table() error = %v", err)
+	}
+	if !strings.Contains(sql, "`updated_at` DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP") {
+		t.Errorf("missing ON UPDATE clause:\n%s", sql)
+	}
+
+	sql, err = New(&Config{Dialect: PostgreSQL}).Table(&TestAudit{})
+	if err != nil {
+		t.Fatalf("Table() error = %v", err)
+	}
+	if strings.Contains(sql, "ON UPDATE") || !strings.Contains(sql, "DEFAULT CURRENT_TIMESTAMP") {
+		t.Errorf("unexpected PostgreSQL DDL:\n%s", sql)
+	}
+}
+
+func TestParseSQL_TimestampDefaults(t *testing.T) {
+	schemas, err := NewParser(MySQL).Parse(`
+	CREATE TABLE audits (
+		id bigint PRIMARY KEY,
+		status int DEFAULT 1 COMMENT '状态',
+		created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at datetime(3) DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)
+	);`)
+	if err != nil || len(schemas) != 1 {
+		t.Fatalf("Parse() = %d schemas, err %v", len(schemas), err)
+	}
+
+	fields := schemas[0].Fields
+	if got := fields[1].Column.Default; got != "1" {
+		t.Errorf("status default = %q, want %q", got, "1")
+	}
+	if col := fields[2].Column; col.Default != "CURRENT_TIMESTAMP" || col.OnUpdate != "" {
+		t.Errorf("created_at = default %q, onUpdate %q", col.Default, col.OnUpdate)
+	}
+	if col := fields[3].Column; col.Default != "CURRENT_TIMESTAMP(3)" || col.OnUpdate != "CURRENT_TIMESTAMP(3)" {
+		t.Errorf("updated_at = default %q, onUpdate %q", col.Default, col.OnUpdate)
+	}
+
+	code, err := New(&Config{Dialect: MySQL}).ParseSQL(`CREATE TABLE audits (
+		id bigint PRIMARY KEY,
+		updated_at datetime DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+	);`).GenerateAll()
+	if err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	if !strings.Contains(code["audits"], "default:CURRENT_TIMESTAMP;onUpdate:CURRENT_TIMESTAMP") {
+		t.Errorf("generated tag missing onUpdate:\n%s", code["audits"])
+	}
+}
+
+// ============================================================================
+// 离线迁移测试
+// ============================================================================
+
+type DiffUser struct {
+	ID       uint64 `gorm:"column:id;primaryKey;autoIncrement"`
+	Username string `gorm:"column:username;size:64;not null;uniqueIndex:uk_users_username"`
+	Email    string `gorm:"column:email;size:255;index:idx_users_email"`
+	Status   int    `gorm:"column:status;default:2"`
+}
+
+func (DiffUser) TableName() string {
+	return "users"
+}
+
+func TestAutoMigrateDiff(t *testing.T) {
+	gen := New(&Config{Dialect: MySQL})
+
+	existing := "CREATE TABLE `users` (\n" +
+		"  `id` bigint unsigned NOT NULL AUTO_INCREMENT,\n" +
+		"  `username` varchar(32) NOT NULL,\n" +
+		"  `status` int(11) DEFAULT 1,\n" +
+		"  `nickname` varchar(64) DEFAULT 'anon',\n" +
+		"  PRIMARY KEY (`id`),\n" +
+		"  UNIQUE KEY `uk_users_username` (`username`),\n" +
+		"  KEY `idx_nick` (`nickname`)\n" +
+		") ENGINE=InnoDB;"
+
+	up, down, err := gen.AutoMigrateDiff(&DiffUser{}, existing)
+	if err != nil {
+		t.Fatalf("AutoMigrateDiff() error = %v", err)
+	}
+
+	wantUp := strings.Join([]string{
+		"ALTER TABLE `users` DROP INDEX `idx_nick`;",
+		"ALTER TABLE `users` MODIFY COLUMN `username` VARCHAR(64) NOT NULL;",
+		"ALTER TABLE `users` ADD COLUMN `email` VARCHAR(255);",
+		"ALTER TABLE `users` MODIFY COLUMN `status` INT DEFAULT 2;",
+		"ALTER TABLE `users` DROP COLUMN `nickname`;",
+		"ALTER TABLE `users` ADD INDEX `idx_users_email` (`email`);",
+	}, "\n")
+	if up != wantUp {
+		t.Errorf("up =\n%s\nwant\n%s", up, wantUp)
+	}
+
+	wantDown := strings.Join([]string{
+		"ALTER TABLE `users` DROP INDEX `idx_users_email`;",
+		"ALTER TABLE `users` ADD COLUMN `nickname` varchar(64) DEFAULT 'anon';",
+		"ALTER TABLE `users` MODIFY COLUMN `status` int(11) DEFAULT 1;",
+		"ALTER TABLE `users` DROP COLUMN `email`;",
+		"ALTER TABLE `users` MODIFY COLUMN `username` varchar(32) NOT NULL;",
+		"ALTER TABLE `users` ADD INDEX `idx_nick` (`nickname`);",
+	}, "\n")
+	if down != wantDown {
+		t.Errorf("down =\n%s\nwant\n%s", down, wantDown)
+	}
+
+	// 与 Table() 生成的 DDL 比较没有差异
+	ddl, err := gen.Table(&DiffUser{})
+	if err != nil {
+		t.Fatalf("Table() error = %v", err)
+	}
+	if up, down, err := gen.AutoMigrateDiff(&DiffUser{}, ddl); up != "" || down != "" || err != nil {
+		t.Errorf("AutoMigrateDiff(Table()) = %q, %q, %v; want no changes", up, down, err)
+	}
+
+	// 表不存在时整表新建
+	up, down, err = gen.AutoMigrateDiff(&DiffUser{}, "")
+	if err != nil || !strings.HasPrefix(up, "CREATE TABLE `users`") || down != "DROP TABLE IF EXISTS `users`;" {
+		t.Errorf("AutoMigrateDiff(\"\") = %q, %q, %v", up, down, err)
+	}
+}
+
+func TestAutoMigrateDiff_PostgresIndexes(t *testing.T) {
+	gen := New(&Config{Dialect: PostgreSQL})
+
+	existing := `
+	CREATE TABLE users (
+		id BIGINT PRIMARY KEY,
+		username VARCHAR(64) NOT NULL,
+		email VARCHAR(255),
+		status INTEGER DEFAULT 2
+	);
+	CREATE INDEX idx_users_email ON users (email);
+	CREATE INDEX uk_users_username ON users (username);`
+
+	up, down, err := gen.AutoMigrateDiff(&DiffUser{}, existing)
+	if err != nil {
+		t.Fatalf("AutoMigrateDiff() error = %v", err)
+	}
+
+	// 唯一性变化: 先删后建
+	wantUp := `DROP INDEX "uk_users_username";` + "\n" +
+		`CREATE UNIQUE INDEX "uk_users_username" ON "users" ("username");`
+	if up != wantUp {
+		t.Errorf("up =\n%s\nwant\n%s", up, wantUp)
+	}
+	wantDown := `DROP INDEX "uk_users_username";` + "\n" +
+		`CREATE INDEX "uk_users_username" ON "users" ("username");`
+	if down != wantDown {
+		t.Errorf("down =\n%s\nwant\n%s", down, wantDown)
+	}
+}
+
+type TestTicket struct {
+	ID       uint64 `gorm:"column:id;primaryKey"`
+	Status   string `gorm:"column:status;type:ENUM('open','closed');not null"`
+	Priority int    `gorm:"column:priority;check:chk_priority,priority > 0"`
+}
+
+func TestTable_EnumAndCheck(t *testing.T) {
+	sql, err := New(&Config{Dialect: MySQL}).Table(&TestTicket{})
+	if err != nil {
+		t.Fatalf("Table() error = %v", err)
+	}
+	if !strings.Contains(sql, "`status` ENUM('open','closed') NOT NULL") {
+		t.Errorf("MySQL should keep ENUM type:\n%s", sql)
+	}
+	if !strings.Contains(sql, "CONSTRAINT `chk_priority` CHECK (priority > 0)") {
+		t.Errorf("missing named CHECK constraint:\n%s", sql)
+	}
+
+	sql, err = New(&Config{Dialect: PostgreSQL}).Table(&TestTicket{})
+	if err != nil {
+		t.Fatalf("Table() error = %v", err)
+	}
+	if !strings.Contains(sql, `"status" VARCHAR(6) NOT NULL CHECK ("status" IN ('open', 'closed'))`) {
+		t.Errorf("PostgreSQL should fall back to VARCHAR + CHECK:\n%s", sql)
+	}
+}
+
+func TestParseSQL_EnumConstants(t *testing.T) {
+	ddl := `
+	CREATE TABLE orders (
+		id bigint PRIMARY KEY,
+		state ENUM('pending', 'in-progress', 'done') NOT NULL
+	);`
+
+	code, err := New(&Config{Dialect: MySQL}).ParseSQL(ddl).WithEnumConstants(true).Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	for _, want := range []string{
+		"State OrderState `gorm:\"column:state;type:ENUM('pending', 'in-progress', 'done');not null\"",
+		"type OrderState string",
+		"OrderStateInProgress OrderState = \"in-progress\"",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("missing %q:\n%s", want, code)
+		}
+	}
+
+	pg := `
+	CREATE TABLE orders (
+		id bigint PRIMARY KEY,
+		state varchar(16) NOT NULL,
+		CONSTRAINT chk_state CHECK (state IN ('pending','done'))
+	);`
+
+	schemas, err := NewParser(PostgreSQL).Parse(pg)
+	if err != nil || len(schemas) != 1 {
+		t.Fatalf("Parse() = %v, %v", schemas, err)
+	}
+	col := schemas[0].Fields[1].Column
+	if len(col.EnumValues) != 2 || col.EnumValues[1] != "done" {
+		t.Errorf("EnumValues = %v", col.EnumValues)
+	}
+	if col.Check == nil || col.Check.Name != "chk_state" {
+		t.Errorf("Check = %+v", col.Check)
+	}
+}
+
+func TestParseSQL_SingularizeAndReceiver(t *testing.T) {
+	ddl := `
+	CREATE TABLE people (
+		id bigint PRIMARY KEY
+	);`
+
+	code, err := New(&Config{Dialect: MySQL}).ParseSQL(ddl).ReceiverName("p").Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	for _, want := range []string{"type Person struct", "func (p Person) TableName() string", `return "people"`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("missing %q:\n%s", want, code)
+		}
+	}
+
+	code, err = New(&Config{Dialect: MySQL}).ParseSQL(ddl).Singularize(false).Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	for _, want := range []string{"type People struct", "func (People) TableName() string"} {
+		if !strings.Contains(code, want) {
+			t.Errorf("missing %q:\n%s", want, code)
+		}
+	}
+
+	if _, err := New(&Config{Dialect: MySQL}).ParseSQL(ddl).ReceiverName("1x").Generate(); err == nil {
+		t.Error("Generate() with invalid receiver name should fail")
+	}
+}
+
+func TestParseSQL_NullableAndOrder(t *testing.T) {
+	ddl := `
+	CREATE TABLE users (
+		name varchar(64) NOT NULL,
+		email varchar(128),
+		age int unsigned,
+		id bigint AUTO_INCREMENT PRIMARY KEY,
+		deleted_at datetime
+	);`
+
+	builder := New(&Config{Dialect: MySQL}).ParseSQL(ddl).NullableAsPointer(true).OrderFields(FieldOrderPrimaryKeyFirst)
+	code, err := builder.Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	for _, re := range []string{`Id\s+int64 `, `Name\s+string `, `Email\s+\*string `, `Age\s+\*uint32 `, `DeletedAt\s+\*time\.Time `} {
+		if !regexp.MustCompile(re).MatchString(code) {
+			t.Errorf("missing %s:\n%s", re, code)
+		}
+	}
+	if strings.Index(code, "Id ") > strings.Index(code, "Name ") {
+		t.Errorf("primary key should come first:\n%s", code)
+	}
+
+	// 重复生成不会叠加指针
+	if again, _ := builder.Generate(); strings.Contains(again, "**") {
+		t.Errorf("nullable applied twice:\n%s", again)
+	}
+
+	code, err = New(&Config{Dialect: MySQL}).ParseSQL(ddl).
+		NullableAsPointer(true).NullableStyle(NullableSQLNull).OrderFields(FieldOrderAlphabetical).Generate()
+	if err != nil {
+		t.Fatalf("Generate() failed: %v", err)
+	}
+	for _, re := range []string{`Email\s+sql\.NullString `, `Age\s+sql\.Null\[uint32\] `, `DeletedAt\s+sql\.NullTime `, `"database/sql"`} {
+		if !regexp.MustCompile(re).MatchString(code) {
+			t.Errorf("missing %s:\n%s", re, code)
+		}
+	}
+	if strings.Contains(code, `"time"`) {
+		t.Errorf("unused time import:\n%s", code)
+	}
+	if strings.Index(code, "Age ") > strings.Index(code, "Email ") || strings.Index(code, "Email ") > strings.Index(code, "Name ") {
+		t.Errorf("fields should be alphabetical:\n%s", code)
+	}
+}
@@ -23,7 +23,7 @@ import (
 {{end}}}
 {{if $.WithTableName}}
 // TableName overrides the table name
-func ({{.Name}}) TableName() string {
+func ({{if .ReceiverName}}{{.ReceiverName}} {{end}}{{.Name}}) TableName() string {
 	return "{{.TableName}}"
 }
 {{end}}`
@@ -117,7 +117,10 @@ func (r *ReverseBuilder) GenerateWithDAO() (structCode string, daoCode string, e
 
 	// 生成 DAO
 	codegen := NewCodeGenerator(r.options)
-	daoCode = codegen.GenerateDAO(schema, r.daoMethods)
+	daoCode, err = formatCode(codegen.GenerateDAO(schema, r.daoMethods))
+	if err != nil {
+		return "", "", err
+	}
 
 	return structCode, daoCode, nil
 }
@@ -59,6 +59,12 @@ type Schema struct {
 	// Indexes 索引列表
 	Indexes []Index
 
+	// ForeignKeys 外键列表
+	ForeignKeys []ForeignKey
+
+	// Checks CHECK 约束列表 (包括列级和表级约束)
+	Checks []CheckConstraint
+
 	// Package 包名 (用于代码生成)
 	Package string
 
@@ -82,6 +88,21 @@ type Field struct {
 
 	// Comment 字段注释
 	Comment string
+
+	// Association 关联信息,非 nil 时表示该字段是关联字段而非数据库列
+	Association *Association
+}
+
+// Association 表示根据外键生成的关联字段
+type Association struct {
+	// Kind 关联类型
+	Kind AssociationKind
+
+	// ForeignKey 外键所在结构体中的外键字段名 (如 UserId)
+	ForeignKey string
+
+	// References 被引用结构体中的字段名 (如 Id)
+	References string
 }
 
 // Column 表示数据库列定义
@@ -105,8 +126,13 @@ type Column struct {
 	NotNull bool
 
 	// Default 默认值
+	// 函数默认值原样保留,如 CURRENT_TIMESTAMP
 	Default string
 
+	// OnUpdate 行更新时自动赋值的表达式 (MySQL ON UPDATE 子句)
+	// 如 CURRENT_TIMESTAMP,未设置时为空
+	OnUpdate string
+
 	// Comment 列注释
 	Comment string
 
@@ -118,6 +144,13 @@ type Column struct {
 
 	// Scale 小数位数 (用于 DECIMAL 等)
 	Scale int
+
+	// EnumValues 列的可选值
+	// 来自 MySQL ENUM('a','b') 类型或 CHECK (col IN ('a','b')) 约束,未限定时为空
+	EnumValues []string
+
+	// Check 约束该列的 CHECK 约束,没有时为 nil
+	Check *CheckConstraint
 }
 
 // Index 表示数据库索引定义
@@ -135,6 +168,34 @@ type Index struct {
 	Type string
 }
 
+// ForeignKey 表示外键约束
+type ForeignKey struct {
+	// Name 约束名 (未命名时为空)
+	Name string
+
+	// Columns 本表的外键列
+	Columns []string
+
+	// RefTable 被引用的表
+	RefTable string
+
+	// RefColumns 被引用的列
+	RefColumns []string
+}
+
+// CheckConstraint 表示 CHECK 约束
+type CheckConstraint struct {
+	// Name 约束名 (未命名时为空)
+	Name string
+
+	// Column 约束的列
+	// 列级约束或 col IN (...) 形式的表级约束可以确定所属列,其他表级约束为空
+	Column string
+
+	// Expression 约束表达式,不含外层括号,如 status IN ('active','inactive')
+	Expression string
+}
+
 // ============================================================================
 // 查询上下文 (Query Context)
 // ============================================================================
@@ -165,6 +226,9 @@ type QueryContext struct {
 	// WhereConditions WHERE 条件
 	WhereConditions []WhereCondition
 
+	// Joins JOIN 子句,Query 为完整的 JOIN 语句
+	Joins []WhereCondition
+
 	// OrderBy ORDER BY 子句
 	OrderBy string
 
@@ -191,6 +255,12 @@ type WhereCondition struct {
 
 	// Args 参数列表
 	Args []interface{}
+
+	// Or 是否以 OR 与前面的条件连接
+	Or bool
+
+	// Not 是否对条件取反
+	Not bool
 }
 
 // ============================================================================
@@ -246,6 +316,30 @@ type ReverseOptions struct {
 
 	// Overwrite 是否覆盖已存在的文件
 	Overwrite bool
+
+	// WithAssociations 是否根据外键生成关联字段 (belongs-to / has-many)
+	WithAssociations bool
+
+	// WithEnumConstants 是否为带可选值的字符串列生成枚举类型和常量
+	WithEnumConstants bool
+
+	// Singularize 是否将表名单数化作为结构体名称 (users -> User)
+	// TableName() 始终返回原始表名
+	Singularize bool
+
+	// ReceiverName 生成的模型方法 (如 TableName) 的接收者变量名
+	// 为空时省略接收者变量: func (User) TableName() string
+	ReceiverName string
+
+	// NullableAsPointer 可空列 (没有 NOT NULL 且不是主键) 是否生成可空类型
+	// 类型风格由 NullableStyle 决定
+	NullableAsPointer bool
+
+	// NullableStyle 可空列的类型风格,仅在 NullableAsPointer 启用时生效
+	NullableStyle NullableStyle
+
+	// FieldOrder 字段排列顺序
+	FieldOrder FieldOrder
 }
 
 // DefaultReverseOptions 返回默认逆向生成选项
@@ -261,6 +355,7 @@ func DefaultReverseOptions() *ReverseOptions {
 		WithSoftDelete: true,
 		FileNaming:     SnakeCase,
 		Overwrite:      false,
+		Singularize:    true,
 	}
 }
 
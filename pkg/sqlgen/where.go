@@ -0,0 +1,173 @@
+package sqlgen
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ============================================================================
+// 组合条件 (In / Between) 与条件拼接
+// ============================================================================
+
+// WhereIn 添加 IN 条件
+// values 可以是多个值,也可以是单个切片 (会被展开为多个占位符)
+//
+//	gen.WhereIn("id", 1, 2, 3)
+//	gen.WhereIn("id", []int64{1, 2, 3})
+//	// WHERE `id` IN (1, 2, 3)
+//
+// values 为空时生成 IN (NULL),不匹配任何记录
+func (g *Generator) WhereIn(column string, values ...interface{}) *Generator {
+	if len(values) == 1 && isExpandable(values[0]) {
+		return g.addCondition(WhereCondition{
+			Query: g.quoteColumn(column) + " IN ?",
+			Args:  values,
+		})
+	}
+	return g.addCondition(WhereCondition{
+		Query: g.quoteColumn(column) + " IN ?",
+		Args:  []interface{}{values},
+	})
+}
+
+// WhereBetween 添加 BETWEEN 条件
+//
+//	gen.WhereBetween("age", 18, 30)
+//	// WHERE `age` BETWEEN 18 AND 30
+func (g *Generator) WhereBetween(column string, lo, hi interface{}) *Generator {
+	return g.addCondition(WhereCondition{
+		Query: g.quoteColumn(column) + " BETWEEN ? AND ?",
+		Args:  []interface{}{lo, hi},
+	})
+}
+
+// addCondition 在克隆的生成器上追加条件
+// 复制条件切片,避免从同一个生成器派生的多条链共享底层数组
+func (g *Generator) addCondition(cond WhereCondition) *Generator {
+	ng := g.clone()
+	conds := make([]WhereCondition, len(ng.ctx.WhereConditions), len(ng.ctx.WhereConditions)+1)
+	copy(conds, ng.ctx.WhereConditions)
+	ng.ctx.WhereConditions = append(conds, cond)
+	return ng
+}
+
+// quoteColumn 引用列名,支持 table.column 形式
+func (g *Generator) quoteColumn(column string) string {
+	parts := strings.Split(column, ".")
+	for i, p := range parts {
+		parts[i] = g.dialect.Quote(p)
+	}
+	return strings.Join(parts, ".")
+}
+
+// joinConditions 按 AND/OR 优先级拼接用户条件
+// 以 OR 条件为分界将条件分组,组内以 AND 连接,组间以 OR 连接;
+// 存在多个组时,包含多个条件的组会加括号
+func (g *Generator) joinConditions() string {
+	var groups [][]string
+	for _, cond := range g.ctx.WhereConditions {
+		condStr := g.buildCondition(cond)
+		if condStr == "" {
+			continue
+		}
+		if cond.Not {
+			condStr = "NOT (" + condStr + ")"
+		}
+		if cond.Or && len(groups) > 0 {
+			groups = append(groups, []string{condStr})
+			continue
+		}
+		if len(groups) == 0 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], condStr)
+	}
+
+	if len(groups) == 1 {
+		return strings.Join(groups[0], " AND ")
+	}
+
+	parts := make([]string, len(groups))
+	for i, group := range groups {
+		if len(group) > 1 {
+			parts[i] = "(" + strings.Join(group, " AND ") + ")"
+		} else {
+			parts[i] = group[0]
+		}
+	}
+	return strings.Join(parts, " OR ")
+}
+
+// expandSliceArgs 将切片参数展开为多个占位符
+// 例如 ("id IN ?", []int{1, 2, 3}) → ("id IN (?, ?, ?)", 1, 2, 3)
+// 与 GORM 的行为保持一致
+func expandSliceArgs(query string, args []interface{}) (string, []interface{}) {
+	hasSlice := false
+	for _, arg := range args {
+		if isExpandable(arg) {
+			hasSlice = true
+			break
+		}
+	}
+	if !hasSlice {
+		return query, args
+	}
+
+	var sb strings.Builder
+	expanded := make([]interface{}, 0, len(args))
+	argIndex := 0
+	for i := 0; i < len(query); i++ {
+		if query[i] != '?' || argIndex >= len(args) {
+			sb.WriteByte(query[i])
+			continue
+		}
+
+		arg := args[argIndex]
+		argIndex++
+		if !isExpandable(arg) {
+			sb.WriteByte('?')
+			expanded = append(expanded, arg)
+			continue
+		}
+
+		rv := reflect.ValueOf(arg)
+		if rv.Len() == 0 {
+			sb.WriteString("(NULL)")
+			continue
+		}
+		sb.WriteByte('(')
+		for j := 0; j < rv.Len(); j++ {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteByte('?')
+			expanded = append(expanded, rv.Index(j).Interface())
+		}
+		sb.WriteByte(')')
+	}
+	expanded = append(expanded, args[argIndex:]...)
+
+	return sb.String(), expanded
+}
+
+// isExpandable 判断参数是否为需要展开的切片 ([]byte 除外)
+func isExpandable(arg interface{}) bool {
+	if arg == nil {
+		return false
+	}
+	if _, ok := arg.([]byte); ok {
+		return false
+	}
+	kind := reflect.TypeOf(arg).Kind()
+	return kind == reflect.Slice || kind == reflect.Array
+}
+
+// hasOrCondition 判断是否包含 OR 条件
+func (g *Generator) hasOrCondition() bool {
+	for i, cond := range g.ctx.WhereConditions {
+		if cond.Or && i > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -178,6 +178,9 @@ Snowflake ID 结构（64位）:
 	index := extractIndex(podName)   // 1
 	nodeID := int64(index)
 
+	// 方式4: 无法分配稳定节点 ID 时自动推导（概率唯一，见 NewSnowflakeAutoNode）
+	gen, err := utils.NewSnowflakeAutoNode()
+
 ## 设备 ID 生成
 
 1. 固定应用盐值：
@@ -8,11 +8,88 @@
 package utils
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/bwmarrin/snowflake"
 )
 
+// Snowflake 位布局常量
+const (
+	// SnowflakeLayoutBits 节点位与序列号位共享的总位数
+	// 64 位 = 1 位符号 + 41 位时间戳 + 22 位(节点 + 序列号)
+	SnowflakeLayoutBits = 22
+
+	// DefaultSnowflakeNodeBits 默认节点位数(支持 1024 个节点)
+	DefaultSnowflakeNodeBits uint8 = 10
+
+	// DefaultSnowflakeStepBits 默认序列号位数(每毫秒 4096 个 ID)
+	DefaultSnowflakeStepBits uint8 = 12
+)
+
+// autoNodeSalt 自动节点 ID 使用的设备 ID 盐值
+const autoNodeSalt = "go-scaffold-snowflake-node"
+
+// ErrInvalidSnowflakeConfig Snowflake 配置无效
+var ErrInvalidSnowflakeConfig = errors.New("invalid snowflake config")
+
+// snowflakeLayoutMu 保护 snowflake 库的全局布局变量
+// 库通过包级变量 Epoch/NodeBits/StepBits 决定新建节点的布局,
+// 创建节点时需要临时修改这些变量,必须串行执行
+var snowflakeLayoutMu sync.Mutex
+
+// SnowflakeConfig 自定义 Snowflake 布局配置
+// 布局是部署时决策:
+//   - 一旦签发了 ID,修改 Epoch/NodeBits/StepBits 会导致已有 ID 无法正确解析
+//     (时间戳、节点号提取错误),新旧 ID 也可能失去有序性甚至冲突
+//   - 同一集群内所有实例必须使用相同的布局
+type SnowflakeConfig struct {
+	// NodeID 节点 ID,取值范围 0 ~ 2^NodeBits-1
+	NodeID int64
+
+	// Epoch 起始时间戳(Unix 毫秒)
+	// 为 0 时使用库默认值(2010-11-04 01:42:54 UTC)
+	// 使用更晚的 Epoch 可以延长可用年限
+	Epoch int64
+
+	// NodeBits 节点位数
+	// 更多的节点位支持更大的集群,但会减少序列号位
+	NodeBits uint8
+
+	// StepBits 序列号位数,决定每毫秒每节点可生成的 ID 数量
+	// NodeBits 与 StepBits 都为 0 时使用默认布局 10/12
+	StepBits uint8
+}
+
+// Validate 验证布局配置
+// 规则:
+//   - NodeBits + StepBits 不超过 22
+//   - Epoch 不能晚于当前时间
+//   - NodeID 在节点位可表示的范围内
+func (c *SnowflakeConfig) Validate() error {
+	if int(c.NodeBits)+int(c.StepBits) > SnowflakeLayoutBits {
+		return fmt.Errorf("%w: NodeBits+StepBits must be <= %d, got %d",
+			ErrInvalidSnowflakeConfig, SnowflakeLayoutBits, int(c.NodeBits)+int(c.StepBits))
+	}
+	if c.StepBits == 0 {
+		return fmt.Errorf("%w: StepBits must be greater than 0", ErrInvalidSnowflakeConfig)
+	}
+	if c.Epoch < 0 || c.Epoch > time.Now().UnixMilli() {
+		return fmt.Errorf("%w: Epoch must be between 0 and now, got %d", ErrInvalidSnowflakeConfig, c.Epoch)
+	}
+	if maxNode := int64(1)<<c.NodeBits - 1; c.NodeID < 0 || c.NodeID > maxNode {
+		return fmt.Errorf("%w: NodeID must be between 0 and %d, got %d", ErrInvalidSnowflakeConfig, maxNode, c.NodeID)
+	}
+	return nil
+}
+
 // IDGenerator 定义 ID 生成的接口
 // 提供两种格式的 ID 生成:int64 和 string
 // 为什么使用接口:
@@ -95,6 +172,10 @@ type snowflakeGenerator struct {
 //   - 时钟回拨会导致 ID 重复,生产环境需要注意
 //   - 确保系统时间同步(使用 NTP)
 func NewSnowflake(nodeID int64) (IDGenerator, error) {
+	// 持锁创建,避免读到 NewSnowflakeWithLayout 临时修改的布局
+	snowflakeLayoutMu.Lock()
+	defer snowflakeLayoutMu.Unlock()
+
 	// 创建 Snowflake 节点
 	node, err := snowflake.NewNode(nodeID)
 	if err != nil {
@@ -104,6 +185,61 @@ func NewSnowflake(nodeID int64) (IDGenerator, error) {
 	return &snowflakeGenerator{node: node}, nil
 }
 
+// NewSnowflakeWithLayout 使用自定义 Epoch 和位布局创建 ID 生成器
+// 参数:
+//
+//	cfg: 布局配置
+//
+// 返回:
+//
+//	IDGenerator: ID 生成器接口
+//	error: 配置无效时返回 ErrInvalidSnowflakeConfig
+//
+// 使用示例:
+//
+//	// 大规模集群: 12 位节点(4096 个节点),10 位序列号(每毫秒 1024 个 ID)
+//	gen, err := utils.NewSnowflakeWithLayout(utils.SnowflakeConfig{
+//	    NodeID:   nodeID,
+//	    Epoch:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli(),
+//	    NodeBits: 12,
+//	    StepBits: 10,
+//	})
+//
+// 注意事项:
+//   - 布局是部署时决策,签发 ID 后修改会导致已有 ID 无法正确解析
+//   - 生成器创建后布局固定,不受之后创建的其他生成器影响
+func NewSnowflakeWithLayout(cfg SnowflakeConfig) (IDGenerator, error) {
+	if cfg.NodeBits == 0 && cfg.StepBits == 0 {
+		cfg.NodeBits = DefaultSnowflakeNodeBits
+		cfg.StepBits = DefaultSnowflakeStepBits
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	snowflakeLayoutMu.Lock()
+	defer snowflakeLayoutMu.Unlock()
+
+	// 库在 NewNode 时读取全局布局并保存到节点中
+	// 临时修改全局变量,创建后恢复,不影响其他生成器
+	oldEpoch, oldNodeBits, oldStepBits := snowflake.Epoch, snowflake.NodeBits, snowflake.StepBits
+	defer func() {
+		snowflake.Epoch, snowflake.NodeBits, snowflake.StepBits = oldEpoch, oldNodeBits, oldStepBits
+	}()
+
+	if cfg.Epoch > 0 {
+		snowflake.Epoch = cfg.Epoch
+	}
+	snowflake.NodeBits = cfg.NodeBits
+	snowflake.StepBits = cfg.StepBits
+
+	node, err := snowflake.NewNode(cfg.NodeID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSnowflakeConfig, err)
+	}
+	return &snowflakeGenerator{node: node}, nil
+}
+
 // NextID 生成一个新的唯一 int64 ID
 // 实现 Generator 接口
 // 返回:
@@ -168,3 +304,72 @@ func DefaultSnowflake() IDGenerator {
 	}
 	return gen
 }
+
+// NewSnowflakeAutoNode 使用自动推导的节点 ID 创建默认布局的 ID 生成器
+// 适用于无法分配稳定节点 ID 的场景(Serverless、自动扩缩容的 Deployment 等)
+// 节点 ID 由 AutoNodeID(DefaultSnowflakeNodeBits) 推导
+// 返回:
+//
+//	IDGenerator: ID 生成器接口
+//	error: 创建失败时的错误
+//
+// 使用示例:
+//
+//	gen, err := utils.NewSnowflakeAutoNode()
+//
+// 注意事项:
+//   - 唯一性是概率性的: 节点空间只有 1024 个值,
+//     n 个实例同时运行时节点 ID 冲突的概率约为 1-exp(-n*(n-1)/2048)
+//     (10 个实例约 4%,50 个实例约 70%)
+//   - 节点 ID 冲突的实例在同一毫秒生成的 ID 可能重复
+//   - 能分配稳定节点 ID 时(StatefulSet 序号、配置中心)应优先使用 NewSnowflake
+//   - 实例较多时可用 AutoNodeID 配合 NewSnowflakeWithLayout 扩大节点位数
+func NewSnowflakeAutoNode() (IDGenerator, error) {
+	nodeID, err := AutoNodeID(DefaultSnowflakeNodeBits)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnowflake(nodeID)
+}
+
+// AutoNodeID 在不依赖外部协调的情况下推导节点 ID
+// 对 设备 ID(GenerateDeviceID) + 进程 ID + 进程级随机数 做 SHA-256,
+// 取结果对 2^nodeBits 取模:
+//   - 设备 ID 区分不同主机
+//   - 进程 ID 与随机数区分同一主机(或克隆出的相同容器)上的多个进程
+//
+// 参数:
+//
+//	nodeBits: 节点位数,取值 1 ~ 22
+//
+// 返回:
+//
+//	int64: 节点 ID,取值范围 0 ~ 2^nodeBits-1
+//	error: nodeBits 无效或读取随机数失败时的错误
+//
+// 使用示例:
+//
+//	// 16 位节点空间(65536 个节点),6 位序列号(每毫秒 64 个 ID)
+//	nodeID, err := utils.AutoNodeID(16)
+//	gen, err := utils.NewSnowflakeWithLayout(utils.SnowflakeConfig{
+//	    NodeID: nodeID, NodeBits: 16, StepBits: 6,
+//	})
+func AutoNodeID(nodeBits uint8) (int64, error) {
+	if nodeBits == 0 || nodeBits > SnowflakeLayoutBits {
+		return 0, fmt.Errorf("%w: nodeBits must be between 1 and %d, got %d",
+			ErrInvalidSnowflakeConfig, SnowflakeLayoutBits, nodeBits)
+	}
+
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return 0, fmt.Errorf("failed to read random node seed: %w", err)
+	}
+
+	h := sha256.New()
+	h.Write([]byte(GenerateDeviceID(autoNodeSalt)))
+	h.Write([]byte(strconv.Itoa(os.Getpid())))
+	h.Write(nonce)
+	sum := h.Sum(nil)
+
+	return int64(binary.BigEndian.Uint64(sum[:8]) % (uint64(1) << nodeBits)), nil
+}
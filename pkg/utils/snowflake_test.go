@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSnowflakeConfigValidate 测试布局配置校验
+func TestSnowflakeConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     SnowflakeConfig
+		wantErr bool
+	}{
+		{"default layout", SnowflakeConfig{NodeID: 1023, NodeBits: 10, StepBits: 12}, false},
+		{"large cluster", SnowflakeConfig{NodeID: 4095, NodeBits: 12, StepBits: 10}, false},
+		{"no node bits", SnowflakeConfig{NodeID: 0, NodeBits: 0, StepBits: 22}, false},
+		{"bits exceed 22", SnowflakeConfig{NodeBits: 12, StepBits: 11}, true},
+		{"no step bits", SnowflakeConfig{NodeBits: 10}, true},
+		{"node out of range", SnowflakeConfig{NodeID: 1024, NodeBits: 10, StepBits: 12}, true},
+		{"negative node", SnowflakeConfig{NodeID: -1, NodeBits: 10, StepBits: 12}, true},
+		{"future epoch", SnowflakeConfig{Epoch: time.Now().Add(time.Hour).UnixMilli(), NodeBits: 10, StepBits: 12}, true},
+		{"negative epoch", SnowflakeConfig{Epoch: -1, NodeBits: 10, StepBits: 12}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSnowflakeConfig) {
+				t.Errorf("Validate() error = %v, want ErrInvalidSnowflakeConfig", err)
+			}
+		})
+	}
+}
+
+// TestNewSnowflakeWithLayout 测试自定义布局写入 ID 的各个字段
+func TestNewSnowflakeWithLayout(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli()
+	cfg := SnowflakeConfig{NodeID: 3000, Epoch: epoch, NodeBits: 12, StepBits: 10}
+	gen, err := NewSnowflakeWithLayout(cfg)
+	if err != nil {
+		t.Fatalf("NewSnowflakeWithLayout() error = %v", err)
+	}
+
+	before := time.Now().UnixMilli()
+	id := gen.NextID()
+	after := time.Now().UnixMilli()
+
+	if node := (id >> cfg.StepBits) & (1<<cfg.NodeBits - 1); node != cfg.NodeID {
+		t.Errorf("node field = %d, want %d", node, cfg.NodeID)
+	}
+	ts := id>>(cfg.NodeBits+cfg.StepBits) + epoch
+	if ts < before || ts > after {
+		t.Errorf("timestamp field = %d, want between %d and %d", ts, before, after)
+	}
+
+	// 默认布局的生成器不受自定义布局影响
+	def, err := NewSnowflake(5)
+	if err != nil {
+		t.Fatalf("NewSnowflake() error = %v", err)
+	}
+	if node := (def.NextID() >> DefaultSnowflakeStepBits) & (1<<DefaultSnowflakeNodeBits - 1); node != 5 {
+		t.Errorf("default layout node field = %d, want 5", node)
+	}
+
+	// 零值布局使用默认值
+	if _, err := NewSnowflakeWithLayout(SnowflakeConfig{NodeID: 1023}); err != nil {
+		t.Errorf("NewSnowflakeWithLayout() with zero layout error = %v", err)
+	}
+	if _, err := NewSnowflakeWithLayout(SnowflakeConfig{NodeID: 1024}); !errors.Is(err, ErrInvalidSnowflakeConfig) {
+		t.Errorf("NewSnowflakeWithLayout() with node out of range error = %v, want ErrInvalidSnowflakeConfig", err)
+	}
+}
+
+// TestSnowflakeMonotonicUnique 测试并发生成的 ID 唯一,单个 goroutine 内递增
+func TestSnowflakeMonotonicUnique(t *testing.T) {
+	// 序列号只有 4 位,同一毫秒内很快耗尽,覆盖等待下一毫秒的路径
+	gen, err := NewSnowflakeWithLayout(SnowflakeConfig{NodeID: 1, NodeBits: 4, StepBits: 4})
+	if err != nil {
+		t.Fatalf("NewSnowflakeWithLayout() error = %v", err)
+	}
+
+	const workers, perWorker = 4, 500
+	var (
+		mu   sync.Mutex
+		seen = make(map[int64]struct{}, workers*perWorker)
+		wg   sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids := make([]int64, perWorker)
+			for i := range ids {
+				ids[i] = gen.NextID()
+				if i > 0 && ids[i] <= ids[i-1] {
+					t.Errorf("ID %d not greater than previous %d", ids[i], ids[i-1])
+					return
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range ids {
+				seen[id] = struct{}{}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(seen) != workers*perWorker {
+		t.Errorf("got %d unique IDs, want %d", len(seen), workers*perWorker)
+	}
+	if s := gen.NextIDString(); s == "" || s[0] == '-' {
+		t.Errorf("NextIDString() = %q", s)
+	}
+}
+
+// TestAutoNodeID 测试自动节点 ID 在节点位范围内
+func TestAutoNodeID(t *testing.T) {
+	for _, bits := range []uint8{1, 4, DefaultSnowflakeNodeBits, 16, SnowflakeLayoutBits} {
+		for i := 0; i < 20; i++ {
+			id, err := AutoNodeID(bits)
+			if err != nil {
+				t.Fatalf("AutoNodeID(%d) error = %v", bits, err)
+			}
+			if id < 0 || id >= int64(1)<<bits {
+				t.Fatalf("AutoNodeID(%d) = %d, out of range", bits, id)
+			}
+		}
+	}
+
+	for _, bits := range []uint8{0, SnowflakeLayoutBits + 1} {
+		if _, err := AutoNodeID(bits); !errors.Is(err, ErrInvalidSnowflakeConfig) {
+			t.Errorf("AutoNodeID(%d) error = %v, want ErrInvalidSnowflakeConfig", bits, err)
+		}
+	}
+
+	gen, err := NewSnowflakeAutoNode()
+	if err != nil {
+		t.Fatalf("NewSnowflakeAutoNode() error = %v", err)
+	}
+	if gen.NextID() <= 0 {
+		t.Error("NewSnowflakeAutoNode() generated a non-positive ID")
+	}
+}
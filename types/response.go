@@ -33,6 +33,10 @@ type LoginResponse struct {
 	// 并在后续请求中放在 Authorization header 中
 	Token string `json:"token"`
 
+	// RefreshToken 刷新令牌
+	// 访问令牌过期后用于换取新的令牌对,每次刷新后旧的刷新令牌作废
+	RefreshToken string `json:"refreshToken,omitempty"`
+
 	// ExpiresIn 令牌有效期(秒)
 	// 前端可以用来计算令牌过期时间
 	ExpiresIn int `json:"expiresIn"`